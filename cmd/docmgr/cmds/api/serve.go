@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -19,9 +20,11 @@ import (
 
 func newServeCommand() *cobra.Command {
 	var (
-		addr       string
-		root       string
-		corsOrigin string
+		addr              string
+		root              string
+		corsOrigin        string
+		watch             bool
+		watchPollInterval time.Duration
 	)
 
 	cmd := &cobra.Command{
@@ -38,6 +41,12 @@ func newServeCommand() *cobra.Command {
 				return fmt.Errorf("failed to build index on startup: %w", err)
 			}
 
+			if watch {
+				go func() {
+					_ = mgr.Watch(ctx, httpapi.WatchOptions{PollInterval: watchPollInterval})
+				}()
+			}
+
 			srv := &http.Server{
 				Addr: addr,
 				Handler: func() http.Handler {
@@ -53,6 +62,9 @@ func newServeCommand() *cobra.Command {
 
 					return mux
 				}(),
+				// Request contexts derive from ctx so long-lived /api/v1/events
+				// streams end when the server shuts down.
+				BaseContext: func(net.Listener) context.Context { return ctx },
 			}
 
 			go func() {
@@ -73,6 +85,8 @@ func newServeCommand() *cobra.Command {
 	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1:8787", "Bind address for the HTTP server")
 	cmd.Flags().StringVar(&root, "root", "ttmp", "Docs root directory")
 	cmd.Flags().StringVar(&corsOrigin, "cors-origin", "", "If set, add CORS headers for this origin (for browser-based UIs)")
+	cmd.Flags().BoolVar(&watch, "watch", true, "Watch the docs root and apply file changes to the index as they happen")
+	cmd.Flags().DurationVar(&watchPollInterval, "watch-poll-interval", 30*time.Second, "How often the docs root is rescanned when --watch is on but file notifications are unavailable")
 
 	return cmd
 }
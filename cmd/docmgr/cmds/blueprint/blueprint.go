@@ -0,0 +1,31 @@
+package blueprint
+
+import "github.com/spf13/cobra"
+
+// Attach registers ticket blueprint commands as docmgr blueprint ...
+func Attach(root *cobra.Command) error {
+	blueprintCmd := &cobra.Command{
+		Use:   "blueprint",
+		Short: "Inspect ticket blueprints",
+		Long: `Inspect ticket blueprints. A blueprint names the docs, topics, seed tasks,
+and first changelog entry that 'docmgr ticket create --blueprint NAME' adds
+to a new ticket. Blueprints live in <root>/_blueprints/<name>.yaml; bugfix,
+feature, and spike are built in.
+
+Examples:
+
+    docmgr blueprint list
+
+    docmgr ticket create --ticket MEN-4300 --title "Retry budgets" --blueprint feature
+`,
+	}
+
+	listCmd, err := newListCommand()
+	if err != nil {
+		return err
+	}
+
+	blueprintCmd.AddCommand(listCmd)
+	root.AddCommand(blueprintCmd)
+	return nil
+}
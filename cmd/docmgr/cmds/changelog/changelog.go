@@ -2,12 +2,12 @@ package changelog
 
 import "github.com/spf13/cobra"
 
-// Attach registers changelog update command under docmgr changelog update.
+// Attach registers changelog commands (update/rollup) as docmgr changelog ...
 func Attach(root *cobra.Command) error {
 	changelogCmd := &cobra.Command{
 		Use:   "changelog",
 		Short: "Manage changelog entries",
-		Long: `Append dated entries to a ticket changelog.md.
+		Long: `Append dated entries to a ticket changelog.md and aggregate entries across tickets.
 
 Examples:
   # Add a short entry
@@ -15,6 +15,12 @@ Examples:
 
   # Include file notes
   docmgr changelog update --ticket MEN-4242 --entry "Refactor" --file-note "pkg/foo.go:reason"
+
+  # Record a decision
+  docmgr changelog update --ticket MEN-4242 --kind decision --entry "Keep REST under /api/v1"
+
+  # Release notes across tickets
+  docmgr changelog rollup --since 2026-09-01 --topics api
 `,
 	}
 
@@ -22,7 +28,11 @@ Examples:
 	if err != nil {
 		return err
 	}
-	changelogCmd.AddCommand(updateCmd)
+	rollupCmd, err := newRollupCommand()
+	if err != nil {
+		return err
+	}
+	changelogCmd.AddCommand(updateCmd, rollupCmd)
 	root.AddCommand(changelogCmd)
 	return nil
 }
@@ -0,0 +1,32 @@
+package changelog
+
+import (
+	"github.com/carapace-sh/carapace"
+	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/common"
+	"github.com/go-go-golems/docmgr/pkg/commands"
+	"github.com/go-go-golems/docmgr/pkg/completion"
+	"github.com/go-go-golems/glazed/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+func newRollupCommand() (*cobra.Command, error) {
+	cmd, err := commands.NewChangelogRollupCommand()
+	if err != nil {
+		return nil, err
+	}
+	cobraCmd, err := common.BuildCommand(
+		cmd,
+		cli.WithDualMode(true),
+		cli.WithGlazeToggleFlag("with-glaze-output"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	carapace.Gen(cobraCmd).FlagCompletion(carapace.ActionMap{
+		"topics": completion.ActionTopics(),
+		"status": completion.ActionStatus(),
+		"kind":   carapace.ActionValues("added", "changed", "fixed", "removed", "decision"),
+		"root":   completion.ActionDirectories(),
+	})
+	return cobraCmd, nil
+}
@@ -30,6 +30,7 @@ func newUpdateCommand() (*cobra.Command, error) {
 		"changelog-file": completion.ActionFiles(),
 		"file-note":      completion.ActionFiles().MultiParts(":", ":"),
 		"topics":         completion.ActionTopics(),
+		"kind":           carapace.ActionValues("added", "changed", "fixed", "removed", "decision"),
 		"root":           completion.ActionDirectories(),
 	})
 	return cobraCmd, nil
@@ -22,6 +22,9 @@ func newAddCommand() (*cobra.Command, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := common.DisableFlagCommaSplitting(cmd2, "var"); err != nil {
+		return nil, err
+	}
 
 	// Register dynamic flag completions
 	carapace.Gen(cmd2).FlagCompletion(carapace.ActionMap{
@@ -0,0 +1,20 @@
+package doc
+
+import "testing"
+
+func TestAddVarKeepsCommaValuesIntact(t *testing.T) {
+	cmd, err := newAddCommand()
+	if err != nil {
+		t.Fatalf("newAddCommand: %v", err)
+	}
+	if err := cmd.ParseFlags([]string{"--var", "items=a,b", "--var", "owner=me"}); err != nil {
+		t.Fatalf("ParseFlags: %v", err)
+	}
+	got, err := cmd.Flags().GetStringSlice("var")
+	if err != nil {
+		t.Fatalf("GetStringSlice: %v", err)
+	}
+	if len(got) != 2 || got[0] != "items=a,b" || got[1] != "owner=me" {
+		t.Fatalf("--var = %#v, want [items=a,b owner=me]", got)
+	}
+}
@@ -29,6 +29,7 @@ func NewSearchCommand() (*cobra.Command, error) {
 	}
 	carapace.Gen(cobraCmd).FlagCompletion(carapace.ActionMap{
 		"root":     completion.ActionDirectories(),
+		"view":     completion.ActionViews(),
 		"ticket":   completion.ActionTickets(),
 		"topics":   completion.ActionTopics(),
 		"doc-type": completion.ActionDocTypes(),
@@ -2,7 +2,7 @@ package importcmd
 
 import "github.com/spf13/cobra"
 
-// Attach registers the import command tree (currently only file import).
+// Attach registers the import command tree (file and url import).
 func Attach(root *cobra.Command) error {
 	importCmd := &cobra.Command{
 		Use:   "import",
@@ -13,6 +13,12 @@ Examples:
   # Import a local file into sources/
   docmgr import file --ticket MEN-4242 --file /path/to/spec.pdf --name "API Spec"
 
+  # Saved HTML pages and PDFs also get their text extracted to markdown
+  docmgr import file --ticket MEN-4242 --file ~/Downloads/rfc.html --url https://example.com/rfc
+
+  # Fetch a URL into sources/url/
+  docmgr import url --ticket MEN-4242 --url https://example.com/rfc
+
   # Import and emit JSON (for scripts)
   docmgr import file --ticket MEN-4242 --file /path/to/spec.pdf --with-glaze-output --output json
 `,
@@ -22,7 +28,11 @@ Examples:
 	if err != nil {
 		return err
 	}
-	importCmd.AddCommand(fileCmd)
+	urlCmd, err := newURLCommand()
+	if err != nil {
+		return err
+	}
+	importCmd.AddCommand(fileCmd, urlCmd)
 	root.AddCommand(importCmd)
 	return nil
 }
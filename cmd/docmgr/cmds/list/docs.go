@@ -26,6 +26,7 @@ func newDocsCommand() (*cobra.Command, error) {
 	cobraCmd.Short = "List documents"
 	carapace.Gen(cobraCmd).FlagCompletion(carapace.ActionMap{
 		"root":     completion.ActionDirectories(),
+		"view":     completion.ActionViews(),
 		"ticket":   completion.ActionTickets(),
 		"status":   completion.ActionStatus(),
 		"doc-type": completion.ActionDocTypes(),
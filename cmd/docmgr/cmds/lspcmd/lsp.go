@@ -0,0 +1,55 @@
+//glazedclilint:file-ignore stdio language server speaks LSP on stdout; it has no Glazed output
+package lspcmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/go-go-golems/docmgr/internal/lsp"
+	"github.com/spf13/cobra"
+)
+
+// Attach registers `docmgr lsp` under the root.
+func Attach(root *cobra.Command) error {
+	var (
+		rootDir string
+		stdio   bool
+		chdir   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Run the docmgr language server (LSP over stdio)",
+		Long: `Run a Language Server Protocol server on stdin/stdout for editing docmgr docs.
+
+Inside a doc's frontmatter it:
+  - completes vocabulary slugs in Topics, DocType, Status and Intent
+  - completes RelatedFiles paths with anchors (repo://, ws://, docs://)
+  - publishes doctor diagnostics when a doc is opened or saved
+  - shows the related file's note (and other docs relating it) on hover
+  - jumps to the file of an anchored RelatedFiles path on go-to-definition
+
+Examples:
+  # Neovim (0.11+)
+  vim.lsp.config('docmgr', { cmd = { 'docmgr', 'lsp' }, filetypes = { 'markdown' }, root_markers = { '.ttmp.yaml' } })
+  vim.lsp.enable('docmgr')
+
+  # Any client: start 'docmgr lsp' for markdown files in the repository.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_ = stdio // stdio is the only transport; accepted because clients pass it
+			// No signal handling: the server blocks reading stdin, and editors
+			// stop it with shutdown/exit or by closing the stream.
+			server := lsp.NewServer(lsp.Options{Root: rootDir, Chdir: chdir})
+			return server.Serve(context.Background(), os.Stdin, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVar(&rootDir, "root", "", "Docs root directory (defaults to the configured root, usually ttmp)")
+	cmd.Flags().BoolVar(&stdio, "stdio", true, "Speak LSP over stdin/stdout (the only transport)")
+	cmd.Flags().BoolVar(&chdir, "chdir", true, "Change into the client's workspace folder on initialize")
+
+	root.AddCommand(cmd)
+	return nil
+}
@@ -2,20 +2,25 @@ package cmds
 
 import (
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/api"
+	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/blueprint"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/changelog"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/configcmd"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/doc"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/ignorecmd"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/importcmd"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/list"
+	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/lspcmd"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/meta"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/skill"
+	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/sources"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/tasks"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/template"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/ticket"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/validate"
+	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/view"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/vocab"
 	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/workspace"
+	"github.com/go-go-golems/docmgr/internal/hooks"
 	"github.com/go-go-golems/docmgr/pkg/commands"
 	"github.com/go-go-golems/docmgr/pkg/completion"
 	"github.com/go-go-golems/glazed/pkg/help"
@@ -40,15 +45,21 @@ Helpful docs (built-in):
 		// main.go already prints the error returned by Execute; silence cobra's
 		// own copy so errors are printed exactly once.
 		SilenceErrors: true,
-		// Propagate --verbose to bare-mode output (workspace banner, reminders).
+		// Propagate --verbose to bare-mode output (workspace banner, reminders)
+		// and --no-hooks to the lifecycle hooks configured in .ttmp.yaml.
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			if v, err := cmd.Flags().GetBool("verbose"); err == nil {
 				commands.SetVerbose(v)
 			}
+			if v, err := cmd.Flags().GetBool("no-hooks"); err == nil {
+				hooks.SetDisabled(v)
+			}
 		},
 	}
 	//glazedclilint:ignore global persistent flag on the plain Cobra root; not a Glazed command
 	rootCmd.PersistentFlags().Bool("verbose", false, "Show workspace banner and coaching output (off by default)")
+	//glazedclilint:ignore global persistent flag on the plain Cobra root; not a Glazed command
+	rootCmd.PersistentFlags().Bool("no-hooks", false, "Don't run the lifecycle hooks configured in .ttmp.yaml (also: DOCMGR_NO_HOOKS=1)")
 
 	help_cmd.SetupCobraRootCommand(helpSystem, rootCmd)
 
@@ -89,6 +100,9 @@ Helpful docs (built-in):
 	if err := importcmd.Attach(rootCmd); err != nil {
 		return nil, err
 	}
+	if err := sources.Attach(rootCmd); err != nil {
+		return nil, err
+	}
 	if err := ignorecmd.Attach(rootCmd); err != nil {
 		return nil, err
 	}
@@ -107,6 +121,15 @@ Helpful docs (built-in):
 	if err := validate.Attach(rootCmd); err != nil {
 		return nil, err
 	}
+	if err := view.Attach(rootCmd); err != nil {
+		return nil, err
+	}
+	if err := blueprint.Attach(rootCmd); err != nil {
+		return nil, err
+	}
+	if err := lspcmd.Attach(rootCmd); err != nil {
+		return nil, err
+	}
 
 	return rootCmd, nil
 }
@@ -0,0 +1,29 @@
+package sources
+
+import "github.com/spf13/cobra"
+
+// Attach registers the sources command tree (currently only check).
+func Attach(root *cobra.Command) error {
+	sourcesCmd := &cobra.Command{
+		Use:   "sources",
+		Short: "Inspect imported external sources",
+		Long: `Inspect the external sources recorded in ticket frontmatter (ExternalSources)
+and their local copies under sources/.
+
+Examples:
+  # Report local copies that no longer match their recorded SHA
+  docmgr sources check
+
+  # List every checked source for one ticket
+  docmgr sources check --ticket MEN-4242 --all
+`,
+	}
+
+	checkCmd, err := newCheckCommand()
+	if err != nil {
+		return err
+	}
+	sourcesCmd.AddCommand(checkCmd)
+	root.AddCommand(sourcesCmd)
+	return nil
+}
@@ -26,6 +26,7 @@ func newAddCommand() (*cobra.Command, error) {
 		"ticket":     completion.ActionTickets(),
 		"tasks-file": completion.ActionFiles(),
 		"after":      completion.ActionTaskIDs(),
+		"priority":   carapace.ActionValues("high", "medium", "low"),
 	})
 	return cobraCmd, nil
 }
@@ -18,6 +18,9 @@ Examples:
   docmgr task add --ticket MEN-4242 --text "Write design doc"
 
   docmgr task check --ticket MEN-4242 --id 1
+
+  # Open tasks across all api tickets
+  docmgr task query --unchecked --topics api
 `,
 	}
 
@@ -49,6 +52,10 @@ Examples:
 	if err != nil {
 		return err
 	}
+	queryCmd, err := newQueryCommand()
+	if err != nil {
+		return err
+	}
 
 	tasksCmd.AddCommand(
 		listCmd,
@@ -58,6 +65,7 @@ Examples:
 		editCmd,
 		removeCmd,
 		migrateCmd,
+		queryCmd,
 	)
 	root.AddCommand(tasksCmd)
 	return nil
@@ -7,10 +7,12 @@ func Attach(root *cobra.Command) error {
 	templateCmd := &cobra.Command{
 		Use:   "template",
 		Short: "Manage and validate templates",
-		Long: `Validate and debug docmgr output templates (used for rich human-mode rendering).
+		Long: `Validate and debug docmgr templates: document templates in <root>/_templates/
+(used by 'docmgr doc add') and output templates in <root>/templates/ (used for
+rich human-mode rendering).
 
 Examples:
-  # Validate all templates under <root>/templates/
+  # Validate all templates under <root>/_templates/ and <root>/templates/
   docmgr template validate
 
   # Validate one template file
@@ -26,8 +26,9 @@ func newCreateCommand() (*cobra.Command, error) {
 	cobraCmd.Use = "create"
 	cobraCmd.Aliases = append(cobraCmd.Aliases, "create-ticket")
 	carapace.Gen(cobraCmd).FlagCompletion(carapace.ActionMap{
-		"topics": completion.ActionTopics(),
-		"root":   completion.ActionDirectories(),
+		"topics":    completion.ActionTopics(),
+		"root":      completion.ActionDirectories(),
+		"blueprint": completion.ActionBlueprints(),
 	})
 	return cobraCmd, nil
 }
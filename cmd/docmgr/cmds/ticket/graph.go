@@ -25,6 +25,8 @@ func newGraphCommand() (*cobra.Command, error) {
 	carapace.Gen(cobraCmd).FlagCompletion(carapace.ActionMap{
 		"root":   completion.ActionDirectories(),
 		"ticket": completion.ActionTickets(),
+		"topics": completion.ActionTopics(),
+		"status": completion.ActionStatus(),
 	})
 	return cobraCmd, nil
 }
@@ -0,0 +1,37 @@
+package ticket
+
+import (
+	"github.com/carapace-sh/carapace"
+	"github.com/go-go-golems/docmgr/cmd/docmgr/cmds/common"
+	"github.com/go-go-golems/docmgr/pkg/commands"
+	"github.com/go-go-golems/docmgr/pkg/completion"
+	"github.com/go-go-golems/glazed/pkg/cli"
+	"github.com/spf13/cobra"
+)
+
+func newLinkCommand() (*cobra.Command, error) {
+	cmd, err := commands.NewTicketLinkCommand()
+	if err != nil {
+		return nil, err
+	}
+	cobraCmd, err := common.BuildCommand(
+		cmd,
+		cli.WithDualMode(true),
+		cli.WithGlazeToggleFlag("with-glaze-output"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	tickets := carapace.ActionMultiParts(",", func(c carapace.Context) carapace.Action {
+		return completion.ActionTickets()
+	})
+	carapace.Gen(cobraCmd).FlagCompletion(carapace.ActionMap{
+		"ticket":     completion.ActionTickets(),
+		"depends-on": tickets,
+		"blocks":     tickets,
+		"supersedes": tickets,
+		"relates-to": tickets,
+		"root":       completion.ActionDirectories(),
+	})
+	return cobraCmd, nil
+}
@@ -16,8 +16,14 @@ Examples:
   # Show one ticket's detail
   docmgr ticket show MEN-4242
 
+  # Move a ticket to review (enforces the workflow in .ttmp.yaml, if any)
+  docmgr ticket transition --ticket MEN-4242 --to review
+
   # Close a ticket and record a changelog entry
   docmgr ticket close --ticket MEN-4242 --changelog-entry "Implementation complete"
+
+  # Record that MEN-4300 depends on MEN-4242
+  docmgr ticket link --ticket MEN-4300 --depends-on MEN-4242
 `,
 	}
 
@@ -49,8 +55,16 @@ Examples:
 	if err != nil {
 		return err
 	}
+	linkCmd, err := newLinkCommand()
+	if err != nil {
+		return err
+	}
+	transitionCmd, err := newTransitionCommand()
+	if err != nil {
+		return err
+	}
 
-	ticketCmd.AddCommand(createCmd, listCmd, showCmd, renameCmd, closeCmd, moveCmd, graphCmd)
+	ticketCmd.AddCommand(createCmd, listCmd, showCmd, renameCmd, closeCmd, moveCmd, graphCmd, linkCmd, transitionCmd)
 	root.AddCommand(ticketCmd)
 	return nil
 }
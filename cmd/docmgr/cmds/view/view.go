@@ -0,0 +1,44 @@
+package view
+
+import "github.com/spf13/cobra"
+
+// Attach registers saved view commands (save/list/run/delete) as docmgr view ...
+func Attach(root *cobra.Command) error {
+	viewCmd := &cobra.Command{
+		Use:   "view",
+		Short: "Manage saved views (named searches)",
+		Long: `Manage saved views. A view is a named set of search filters stored in
+<root>/views.yaml; run it with 'docmgr view run', 'docmgr search --view'
+or 'docmgr list docs --view'.
+
+Examples:
+
+    docmgr view save my-active-api --topics api --status active --where 'NOT owner:bob'
+
+    docmgr view list
+
+    docmgr view run my-active-api
+`,
+	}
+
+	saveCmd, err := newSaveCommand()
+	if err != nil {
+		return err
+	}
+	listCmd, err := newListCommand()
+	if err != nil {
+		return err
+	}
+	runCmd, err := newRunCommand()
+	if err != nil {
+		return err
+	}
+	deleteCmd, err := newDeleteCommand()
+	if err != nil {
+		return err
+	}
+
+	viewCmd.AddCommand(saveCmd, listCmd, runCmd, deleteCmd)
+	root.AddCommand(viewCmd)
+	return nil
+}
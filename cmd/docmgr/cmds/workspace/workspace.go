@@ -2,13 +2,13 @@ package workspace
 
 import "github.com/spf13/cobra"
 
-// Attach registers workspace-wide commands (init/configure/status/doctor) both at the root level
+// Attach registers workspace-wide commands (init/configure/status/doctor/stale/diff/undo) both at the root level
 // and under a namespaced "workspace" command to match documentation references.
 func Attach(root *cobra.Command) error {
 	workspaceCmd := &cobra.Command{
 		Use:   "workspace",
 		Short: "Workspace initialization and configuration commands",
-		Long: `Workspace-wide commands, also available at the root for convenience (init/status/doctor/configure/export-sqlite).
+		Long: `Workspace-wide commands, also available at the root for convenience (init/status/doctor/stale/diff/undo/configure/export-sqlite).
 
 Examples:
   # Initialize the docs root
@@ -16,6 +16,15 @@ Examples:
 
   # Show workspace status (namespaced form)
   docmgr workspace status --summary-only
+
+  # Docs whose related code changed since they were last updated
+  docmgr stale
+
+  # Docs added/removed/changed since a git revision
+  docmgr diff --since HEAD~10
+
+  # Revert the last ticket rename/move, doc move, renumber or layout-fix
+  docmgr undo
 `,
 	}
 
@@ -24,6 +33,9 @@ Examples:
 		newConfigureCommand,
 		newStatusCommand,
 		newDoctorCommand,
+		newStaleCommand,
+		newDiffCommand,
+		newUndoCommand,
 		newExportSQLiteCommand,
 	}
 
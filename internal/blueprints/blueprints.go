@@ -0,0 +1,199 @@
+// Package blueprints loads named ticket blueprints: the docs, topics, seed
+// tasks, and first changelog entry that 'docmgr ticket create --blueprint'
+// scaffolds in addition to the standard ticket skeleton.
+//
+// Blueprints live in <root>/_blueprints/<name>.yaml. The built-in bugfix,
+// feature, and spike blueprints are used when the docs root does not define a
+// blueprint of the same name; 'docmgr init' copies them into _blueprints/ for
+// customization.
+package blueprints
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed embedded/*.yaml
+var embeddedFS embed.FS
+
+// DirName is the blueprint directory, stored directly in the docs root.
+const DirName = "_blueprints"
+
+// SourceBuiltin marks a blueprint that comes from the docmgr binary.
+const SourceBuiltin = "builtin"
+
+// Blueprint declares what a new ticket starts with. Titles, tasks, and the
+// changelog entry may use the {{TICKET}} and {{TITLE}} placeholders.
+type Blueprint struct {
+	Name        string `yaml:"-" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Topics are added to the ticket's --topics.
+	Topics []string `yaml:"topics,omitempty" json:"topics,omitempty"`
+	Docs   []Doc    `yaml:"docs,omitempty" json:"docs,omitempty"`
+	// Tasks seed the TODO section of tasks.md.
+	Tasks []string `yaml:"tasks,omitempty" json:"tasks,omitempty"`
+	// Changelog replaces the default "Initial workspace created" entry. It is
+	// markdown and may span several lines.
+	Changelog string `yaml:"changelog,omitempty" json:"changelog,omitempty"`
+	// Source is the blueprint file, or SourceBuiltin.
+	Source string `yaml:"-" json:"source"`
+}
+
+// Doc is one document created from the doc-type template, like 'docmgr doc add'.
+type Doc struct {
+	DocType string `yaml:"docType" json:"docType"`
+	Title   string `yaml:"title" json:"title"`
+	Summary string `yaml:"summary,omitempty" json:"summary,omitempty"`
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ErrNotFound is returned when no blueprint of the given name exists.
+var ErrNotFound = errors.New("blueprint not found")
+
+// Dir returns the blueprint directory for a docs root.
+func Dir(root string) string {
+	return filepath.Join(root, DirName)
+}
+
+// Validate checks the blueprint name and that every doc has a type and title.
+func (b Blueprint) Validate() error {
+	if !namePattern.MatchString(b.Name) {
+		return errors.Errorf("invalid blueprint name %q (letters, digits, '.', '_' and '-')", b.Name)
+	}
+	for i, d := range b.Docs {
+		if strings.TrimSpace(d.DocType) == "" || strings.TrimSpace(d.Title) == "" {
+			return errors.Errorf("blueprint %q: docs[%d] needs a docType and a title", b.Name, i)
+		}
+		if strings.ContainsAny(d.DocType, `/\`) {
+			return errors.Errorf("blueprint %q: invalid docType %q", b.Name, d.DocType)
+		}
+	}
+	return nil
+}
+
+// Expand returns a copy of b with {{TICKET}} and {{TITLE}} replaced.
+func (b Blueprint) Expand(ticket, title string) Blueprint {
+	r := strings.NewReplacer("{{TICKET}}", ticket, "{{TITLE}}", title)
+	out := b
+	out.Docs = make([]Doc, len(b.Docs))
+	for i, d := range b.Docs {
+		out.Docs[i] = Doc{DocType: d.DocType, Title: r.Replace(d.Title), Summary: r.Replace(d.Summary)}
+	}
+	out.Tasks = make([]string, len(b.Tasks))
+	for i, t := range b.Tasks {
+		out.Tasks[i] = r.Replace(t)
+	}
+	out.Changelog = r.Replace(b.Changelog)
+	return out
+}
+
+// Get returns the blueprint named name: <root>/_blueprints/<name>.yaml, else
+// the built-in blueprint of that name.
+func Get(root, name string) (Blueprint, error) {
+	name = strings.TrimSpace(name)
+	if !namePattern.MatchString(name) {
+		return Blueprint{}, errors.Errorf("invalid blueprint name %q", name)
+	}
+	path := filepath.Join(Dir(root), name+".yaml")
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		return parse(name, path, data)
+	case !os.IsNotExist(err):
+		return Blueprint{}, errors.Wrapf(err, "read %s", path)
+	}
+	data, err = embeddedFS.ReadFile("embedded/" + name + ".yaml")
+	if err != nil {
+		return Blueprint{}, errors.Wrapf(ErrNotFound, "%q (see 'docmgr blueprint list')", name)
+	}
+	return parse(name, SourceBuiltin, data)
+}
+
+// List returns the blueprints defined in root plus the built-in ones they
+// don't override, sorted by name.
+func List(root string) ([]Blueprint, error) {
+	byName := map[string]Blueprint{}
+	builtin, err := embeddedFS.ReadDir("embedded")
+	if err != nil {
+		return nil, errors.Wrap(err, "read built-in blueprints")
+	}
+	for _, e := range builtin {
+		name := strings.TrimSuffix(e.Name(), ".yaml")
+		data, err := embeddedFS.ReadFile("embedded/" + e.Name())
+		if err != nil {
+			return nil, errors.Wrapf(err, "read built-in blueprint %s", name)
+		}
+		b, err := parse(name, SourceBuiltin, data)
+		if err != nil {
+			return nil, err
+		}
+		byName[name] = b
+	}
+
+	entries, err := os.ReadDir(Dir(root))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "read blueprint directory")
+	}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".yaml" {
+			continue
+		}
+		name := strings.TrimSuffix(e.Name(), ".yaml")
+		path := filepath.Join(Dir(root), e.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read %s", path)
+		}
+		b, err := parse(name, path, data)
+		if err != nil {
+			return nil, err
+		}
+		byName[name] = b
+	}
+
+	out := make([]Blueprint, 0, len(byName))
+	for _, b := range byName {
+		out = append(out, b)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// Builtin returns the raw YAML of the built-in blueprints by name, for
+// scaffolding into a docs root.
+func Builtin() (map[string][]byte, error) {
+	entries, err := embeddedFS.ReadDir("embedded")
+	if err != nil {
+		return nil, errors.Wrap(err, "read built-in blueprints")
+	}
+	out := map[string][]byte{}
+	for _, e := range entries {
+		data, err := embeddedFS.ReadFile("embedded/" + e.Name())
+		if err != nil {
+			return nil, errors.Wrapf(err, "read built-in blueprint %s", e.Name())
+		}
+		out[strings.TrimSuffix(e.Name(), ".yaml")] = data
+	}
+	return out, nil
+}
+
+func parse(name, source string, data []byte) (Blueprint, error) {
+	var b Blueprint
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return Blueprint{}, errors.Wrapf(err, "parse blueprint %s", source)
+	}
+	b.Name = name
+	b.Source = source
+	if err := b.Validate(); err != nil {
+		return Blueprint{}, err
+	}
+	return b, nil
+}
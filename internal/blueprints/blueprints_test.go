@@ -0,0 +1,80 @@
+package blueprints
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetPrefersRootOverBuiltin(t *testing.T) {
+	root := t.TempDir()
+
+	b, err := Get(root, "feature")
+	if err != nil {
+		t.Fatalf("Get builtin: %v", err)
+	}
+	if b.Source != SourceBuiltin || len(b.Docs) == 0 || len(b.Tasks) == 0 {
+		t.Fatalf("unexpected builtin feature blueprint: %+v", b)
+	}
+
+	if err := os.MkdirAll(Dir(root), 0755); err != nil {
+		t.Fatal(err)
+	}
+	custom := "description: Ours\ntopics: [backend]\ndocs:\n  - docType: design-doc\n    title: \"{{TICKET}}: {{TITLE}}\"\n"
+	if err := os.WriteFile(filepath.Join(Dir(root), "feature.yaml"), []byte(custom), 0644); err != nil {
+		t.Fatal(err)
+	}
+	b, err = Get(root, "feature")
+	if err != nil {
+		t.Fatalf("Get override: %v", err)
+	}
+	if b.Description != "Ours" || b.Source == SourceBuiltin {
+		t.Fatalf("expected the root blueprint, got %+v", b)
+	}
+	if got := b.Expand("MEN-1", "Retry").Docs[0].Title; got != "MEN-1: Retry" {
+		t.Fatalf("Expand title = %q", got)
+	}
+
+	if _, err := Get(root, "nope"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := Get(root, "../feature"); err == nil {
+		t.Fatalf("expected an invalid name error")
+	}
+}
+
+func TestListMergesRootAndBuiltin(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(Dir(root), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(Dir(root), "incident.yaml"), []byte("description: Incident review\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := List(root)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	var names []string
+	for _, b := range all {
+		names = append(names, b.Name)
+	}
+	want := []string{"bugfix", "feature", "incident", "spike"}
+	if len(names) != len(want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("names = %v, want %v", names, want)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(Dir(root), "broken.yaml"), []byte("docs:\n  - title: No type\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := List(root); err == nil {
+		t.Fatalf("expected a validation error for a doc without docType")
+	}
+}
@@ -0,0 +1,219 @@
+// Package customrules loads team-defined doctor checks from
+// <docs-root>/.docmgr/rules.yaml and evaluates them against the workspace index.
+//
+// A rule selects the documents that violate it with a query expression (the
+// `--where` language of 'docmgr search'); every match becomes a finding:
+//
+//	rules:
+//	  - id: design-doc-owner
+//	    description: Design docs need an owner
+//	    where: doctype:design-doc AND NOT owner:*
+//	    severity: error
+//	    message: "{{.Title}} has no Owners"
+//	    hint: docmgr meta update --doc <path> --field Owners --value <name>
+package customrules
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgrctx"
+	"github.com/go-go-golems/docmgr/pkg/models"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the rules file, stored in the docs root's state directory.
+const FileName = "rules.yaml"
+
+// Rule is one declarative check.
+type Rule struct {
+	ID          string `yaml:"id" json:"id"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Where is a query expression selecting the offending documents.
+	Where string `yaml:"where" json:"where"`
+	// Severity is info, warning (default) or error.
+	Severity string `yaml:"severity,omitempty" json:"severity,omitempty"`
+	// Stage and Symptom are the taxonomy codes of the findings; they default
+	// to docmgr.custom and the rule ID.
+	Stage   string `yaml:"stage,omitempty" json:"stage,omitempty"`
+	Symptom string `yaml:"symptom,omitempty" json:"symptom,omitempty"`
+	// Message is a text/template rendered with the document's frontmatter
+	// fields (.Title, .Ticket, .DocType, .Owners, ...) and .Path.
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+	Hint    string `yaml:"hint,omitempty" json:"hint,omitempty"`
+}
+
+type rulesFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+var (
+	idPattern   = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	codePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+)
+
+// Path returns the rules file location for a docs root.
+func Path(root string) string {
+	return filepath.Join(root, workspace.StateDirName, FileName)
+}
+
+// SeverityOrDefault returns the rule severity, warning when unset.
+func (r Rule) SeverityOrDefault() core.Severity {
+	if strings.TrimSpace(r.Severity) == "" {
+		return core.SeverityWarning
+	}
+	return core.Severity(strings.ToLower(strings.TrimSpace(r.Severity)))
+}
+
+// Validate checks the rule ID, codes and severity and that its expression and
+// message template parse.
+func (r Rule) Validate() error {
+	if !idPattern.MatchString(r.ID) {
+		return errors.Errorf("invalid rule id %q (letters, digits, '.', '_' and '-')", r.ID)
+	}
+	if strings.TrimSpace(r.Where) == "" {
+		return errors.Errorf("rule %q: where is required", r.ID)
+	}
+	if _, err := workspace.ParseDocExpr(r.Where); err != nil {
+		return errors.Wrapf(err, "rule %q", r.ID)
+	}
+	sev := r.SeverityOrDefault()
+	if sev != core.SeverityInfo && sev != core.SeverityWarning && sev != core.SeverityError {
+		return errors.Errorf("rule %q: invalid severity %q (info|warning|error)", r.ID, r.Severity)
+	}
+	if r.Stage != "" && !codePattern.MatchString(r.Stage) {
+		return errors.Errorf("rule %q: invalid stage %q (lowercase letters, digits, '.', '_' and '-')", r.ID, r.Stage)
+	}
+	if r.Symptom != "" && !codePattern.MatchString(r.Symptom) {
+		return errors.Errorf("rule %q: invalid symptom %q (lowercase letters, digits, '.', '_' and '-')", r.ID, r.Symptom)
+	}
+	if _, err := r.messageTemplate(); err != nil {
+		return errors.Wrapf(err, "rule %q: message", r.ID)
+	}
+	return nil
+}
+
+func (r Rule) messageTemplate() (*template.Template, error) {
+	msg := r.Message
+	if strings.TrimSpace(msg) == "" {
+		msg = r.Description
+	}
+	if strings.TrimSpace(msg) == "" {
+		msg = "matches rule " + r.ID
+	}
+	return template.New(r.ID).Option("missingkey=zero").Parse(msg)
+}
+
+// Load reads and validates the rules defined in root, in file order. A missing
+// file yields no rules.
+func Load(root string) ([]Rule, error) {
+	data, err := os.ReadFile(Path(root))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Rule{}, nil
+		}
+		return nil, errors.Wrap(err, "read rules file")
+	}
+	var f rulesFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && err != io.EOF {
+		return nil, errors.Wrapf(err, "parse %s", Path(root))
+	}
+	seen := map[string]bool{}
+	for _, r := range f.Rules {
+		if err := r.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "%s", Path(root))
+		}
+		if seen[r.ID] {
+			return nil, errors.Errorf("%s: duplicate rule id %q", Path(root), r.ID)
+		}
+		seen[r.ID] = true
+	}
+	if f.Rules == nil {
+		f.Rules = []Rule{}
+	}
+	return f.Rules, nil
+}
+
+// Finding is one document matched by a rule.
+type Finding struct {
+	Rule Rule
+	// Path is the absolute document path.
+	Path    string
+	Ticket  string
+	Message string
+}
+
+// Taxonomy returns the diagnostics entry for the finding.
+func (f Finding) Taxonomy() *core.Taxonomy {
+	return docmgrctx.NewCustomRule(
+		f.Path,
+		f.Rule.ID,
+		core.StageCode(f.Rule.Stage),
+		core.SymptomCode(f.Rule.Symptom),
+		f.Rule.SeverityOrDefault(),
+		f.Message,
+		f.Rule.Hint,
+	)
+}
+
+// messageData is the template data of a rule message.
+type messageData struct {
+	*models.Document
+	// Path is the document path relative to the docs root.
+	Path string
+}
+
+// Evaluate runs every rule against the indexed documents selected by base
+// (scope and visibility options); the rule expression replaces
+// base.Filters.Where. Documents that fail to parse are never matched.
+func Evaluate(ctx context.Context, ws *workspace.Workspace, rules []Rule, base workspace.DocQuery) ([]Finding, error) {
+	if ws == nil {
+		return nil, errors.New("nil workspace")
+	}
+	root := ws.Context().Root
+	var out []Finding
+	for _, r := range rules {
+		expr, err := workspace.ParseDocExpr(r.Where)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rule %q", r.ID)
+		}
+		tmpl, err := r.messageTemplate()
+		if err != nil {
+			return nil, errors.Wrapf(err, "rule %q: message", r.ID)
+		}
+		q := base
+		q.Filters.Where = expr
+		q.Options.IncludeErrors = false
+		q.Options.IncludeDiagnostics = false
+		res, err := ws.QueryDocs(ctx, q)
+		if err != nil {
+			return nil, errors.Wrapf(err, "evaluate rule %q", r.ID)
+		}
+		for _, h := range res.Docs {
+			if h.Doc == nil {
+				continue
+			}
+			rel := h.Path
+			if rp, err := filepath.Rel(root, h.Path); err == nil {
+				rel = filepath.ToSlash(rp)
+			}
+			var msg bytes.Buffer
+			if err := tmpl.Execute(&msg, messageData{Document: h.Doc, Path: rel}); err != nil {
+				return nil, errors.Wrapf(err, "rule %q: render message for %s", r.ID, rel)
+			}
+			out = append(out, Finding{Rule: r, Path: h.Path, Ticket: h.Doc.Ticket, Message: strings.TrimSpace(msg.String())})
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,108 @@
+package customrules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgrctx"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+	if rules, err := Load(root); err != nil || len(rules) != 0 {
+		t.Fatalf("expected no rules without a rules file, got %v, %v", rules, err)
+	}
+
+	writeFile(t, Path(root), `rules:
+  - id: design-owner
+    where: doctype:design-doc AND NOT owner:*
+    severity: error
+  - id: no-status
+    where: NOT status:*
+`)
+	rules, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(rules) != 2 || rules[0].SeverityOrDefault() != core.SeverityError || rules[1].SeverityOrDefault() != core.SeverityWarning {
+		t.Fatalf("unexpected rules: %+v", rules)
+	}
+
+	bad := map[string]string{
+		"syntax":      "rules:\n  - id: x\n    where: status:(\n",
+		"no where":    "rules:\n  - id: x\n",
+		"severity":    "rules:\n  - id: x\n    where: status:draft\n    severity: fatal\n",
+		"symptom":     "rules:\n  - id: x\n    where: status:draft\n    symptom: Has Spaces\n",
+		"template":    "rules:\n  - id: x\n    where: status:draft\n    message: \"{{.Title\"\n",
+		"duplicate":   "rules:\n  - id: x\n    where: status:draft\n  - id: x\n    where: status:review\n",
+		"unknown key": "rules:\n  - id: x\n    when: status:draft\n",
+	}
+	for name, content := range bad {
+		writeFile(t, Path(root), content)
+		if _, err := Load(root); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	ctx := context.Background()
+	repoRoot := t.TempDir()
+	docsRoot := filepath.Join(repoRoot, "ttmp")
+	ticketDir := filepath.Join(docsRoot, "2026", "09", "01", "API-1--rest")
+	writeFile(t, filepath.Join(ticketDir, "index.md"), "---\nTitle: REST API\nTicket: API-1\nStatus: active\nDocType: index\nOwners: [alice]\n---\n")
+	writeFile(t, filepath.Join(ticketDir, "design-doc", "01-routes.md"), "---\nTitle: Routes\nTicket: API-1\nStatus: active\nDocType: design-doc\n---\n")
+	writeFile(t, filepath.Join(ticketDir, "design-doc", "02-auth.md"), "---\nTitle: Auth\nTicket: API-1\nStatus: active\nDocType: design-doc\nOwners: [bob]\n---\n")
+
+	ws, err := workspace.NewWorkspaceFromContext(workspace.WorkspaceContext{Root: docsRoot, ConfigDir: repoRoot, RepoRoot: repoRoot})
+	if err != nil {
+		t.Fatalf("NewWorkspaceFromContext: %v", err)
+	}
+	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{}); err != nil {
+		t.Fatalf("InitIndex: %v", err)
+	}
+
+	rules := []Rule{{
+		ID:       "design-owner",
+		Where:    "doctype:design-doc AND NOT owner:*",
+		Severity: "error",
+		Stage:    "team.review",
+		Message:  "{{.Title}} ({{.Path}}) has no Owners",
+		Hint:     "add Owners",
+	}}
+	findings, err := Evaluate(ctx, ws, rules, workspace.DocQuery{Scope: workspace.Scope{Kind: workspace.ScopeRepo}})
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %+v", findings)
+	}
+	f := findings[0]
+	if f.Ticket != "API-1" || !strings.HasSuffix(f.Path, "01-routes.md") ||
+		f.Message != "Routes (2026/09/01/API-1--rest/design-doc/01-routes.md) has no Owners" {
+		t.Fatalf("unexpected finding: %+v", f)
+	}
+
+	tax := f.Taxonomy()
+	if tax.Stage != "team.review" || tax.Symptom != "design-owner" || tax.Severity != core.SeverityError {
+		t.Fatalf("unexpected taxonomy: %+v", tax)
+	}
+	if payload, ok := tax.Context.(*docmgrctx.CustomRuleContext); !ok || payload.Hint != "add Owners" {
+		t.Fatalf("unexpected context: %+v", tax.Context)
+	}
+}
@@ -0,0 +1,182 @@
+// Package docdiff compares the documents of two workspace indexes, typically
+// built from different git revisions (see workspace.BuildIndexOptions.Revision).
+package docdiff
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/models"
+)
+
+// ChangeKind says how a document differs between the two sides.
+type ChangeKind string
+
+const (
+	Added    ChangeKind = "added"
+	Removed  ChangeKind = "removed"
+	Modified ChangeKind = "modified"
+)
+
+// FieldChange is one frontmatter field whose value differs. Lists are
+// rendered comma-separated; an empty Old/New means the field was unset.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// DocChange is one document that was added, removed, or modified.
+type DocChange struct {
+	Path   string        `json:"path"` // docs-root relative
+	Ticket string        `json:"ticket"`
+	Title  string        `json:"title"`
+	Kind   ChangeKind    `json:"kind"`
+	Fields []FieldChange `json:"fields,omitempty"` // Modified only, sorted by field
+}
+
+// Options filters Compare's result.
+type Options struct {
+	// Ticket keeps documents of this ticket (exact ID) on either side.
+	Ticket string
+	// Fields keeps modified documents where one of these fields changed
+	// (case-insensitive) and drops the other field changes. Added and
+	// removed documents are always reported.
+	Fields []string
+}
+
+// Compare reports documents added, removed or whose indexed frontmatter changed
+// between from and to. Documents are matched by their path relative to each
+// workspace's docs root. Body-only edits are not reported.
+func Compare(ctx context.Context, from, to *workspace.Workspace, opts Options) ([]DocChange, error) {
+	before, err := snapshot(ctx, from, opts.Ticket)
+	if err != nil {
+		return nil, err
+	}
+	after, err := snapshot(ctx, to, opts.Ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := map[string]bool{}
+	for _, f := range opts.Fields {
+		if f = strings.ToLower(strings.TrimSpace(f)); f != "" {
+			keep[f] = true
+		}
+	}
+
+	var out []DocChange
+	for rel, a := range after {
+		b, ok := before[rel]
+		if !ok {
+			out = append(out, DocChange{Path: rel, Ticket: a.Ticket, Title: a.Title, Kind: Added})
+			continue
+		}
+		var fields []FieldChange
+		for _, fc := range compareFields(docFields(b), docFields(a)) {
+			if len(keep) == 0 || keep[strings.ToLower(fc.Field)] {
+				fields = append(fields, fc)
+			}
+		}
+		if len(fields) > 0 {
+			out = append(out, DocChange{Path: rel, Ticket: a.Ticket, Title: a.Title, Kind: Modified, Fields: fields})
+		}
+	}
+	for rel, b := range before {
+		if _, ok := after[rel]; !ok {
+			out = append(out, DocChange{Path: rel, Ticket: b.Ticket, Title: b.Title, Kind: Removed})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Ticket != out[j].Ticket {
+			return out[i].Ticket < out[j].Ticket
+		}
+		return out[i].Path < out[j].Path
+	})
+	return out, nil
+}
+
+func snapshot(ctx context.Context, ws *workspace.Workspace, ticket string) (map[string]*models.Document, error) {
+	res, err := ws.QueryDocs(ctx, workspace.DocQuery{
+		Scope: workspace.Scope{Kind: workspace.ScopeRepo},
+		Options: workspace.DocQueryOptions{
+			IncludeControlDocs:  true,
+			IncludeArchivedPath: true,
+			IncludeScriptsPath:  true,
+			IncludeSourcesPath:  true,
+			OrderBy:             workspace.OrderByPath,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	root := ws.Context().Root
+	out := map[string]*models.Document{}
+	for _, h := range res.Docs {
+		if h.Doc == nil {
+			continue
+		}
+		if ticket != "" && !strings.EqualFold(strings.TrimSpace(h.Doc.Ticket), ticket) {
+			continue
+		}
+		rel, err := filepath.Rel(root, filepath.FromSlash(h.Path))
+		if err != nil {
+			rel = h.Path
+		}
+		out[filepath.ToSlash(rel)] = h.Doc
+	}
+	return out, nil
+}
+
+// docFields flattens the indexed frontmatter of doc into field -> value.
+func docFields(doc *models.Document) map[string]string {
+	list := func(values []string) string { return strings.Join(values, ", ") }
+	out := map[string]string{
+		"Title":     doc.Title,
+		"Ticket":    doc.Ticket,
+		"Status":    doc.Status,
+		"DocType":   doc.DocType,
+		"Intent":    doc.Intent,
+		"Topics":    list(doc.Topics),
+		"Owners":    list(doc.Owners),
+		"WhatFor":   doc.WhatFor,
+		"WhenToUse": doc.WhenToUse,
+	}
+	if !doc.LastUpdated.IsZero() {
+		out["LastUpdated"] = models.FormatFieldDate(doc.LastUpdated)
+	}
+	related := make([]string, 0, len(doc.RelatedFiles))
+	for _, rf := range doc.RelatedFiles {
+		related = append(related, rf.Path)
+	}
+	out["RelatedFiles"] = list(related)
+	for _, kind := range models.TicketLinkKinds {
+		out[string(kind)] = list(doc.TicketLinks(kind))
+	}
+	for _, name := range doc.ExtraFieldNames() {
+		values, _ := models.FieldValueStrings(doc.Extra[name])
+		out[name] = list(values)
+	}
+	return out
+}
+
+func compareFields(before, after map[string]string) []FieldChange {
+	names := map[string]struct{}{}
+	for k := range before {
+		names[k] = struct{}{}
+	}
+	for k := range after {
+		names[k] = struct{}{}
+	}
+	var out []FieldChange
+	for name := range names {
+		if before[name] != after[name] {
+			out = append(out, FieldChange{Field: name, Old: before[name], New: after[name]})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Field < out[j].Field })
+	return out
+}
@@ -0,0 +1,142 @@
+package docdiff
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+)
+
+func TestCompareRevisionAgainstWorkingTree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	ctx := context.Background()
+	repoRoot := t.TempDir()
+	docsRoot := filepath.Join(repoRoot, "ttmp")
+	ticketDir := filepath.Join(docsRoot, "2026", "01", "15", "DIFF-1--demo")
+
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		cmd.Dir = repoRoot
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return string(out)
+	}
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	git("init", "-q")
+	write(filepath.Join(ticketDir, "index.md"), `---
+Title: Diff Demo
+Ticket: DIFF-1
+Status: active
+DocType: index
+Topics: [backend]
+---
+`)
+	write(filepath.Join(ticketDir, "design", "01-old.md"), `---
+Title: Old Design
+Ticket: DIFF-1
+DocType: design-doc
+---
+`)
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+
+	// Working tree: status and topics change, one doc removed, one added, one body-only edit.
+	write(filepath.Join(ticketDir, "index.md"), `---
+Title: Diff Demo
+Ticket: DIFF-1
+Status: complete
+DocType: index
+Topics: [backend, api]
+---
+
+Body edits are not reported.
+`)
+	if err := os.Remove(filepath.Join(ticketDir, "design", "01-old.md")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	write(filepath.Join(ticketDir, "reference", "01-new.md"), `---
+Title: New Reference
+Ticket: DIFF-1
+DocType: reference
+---
+`)
+
+	open := func(rev string) *workspace.Workspace {
+		t.Helper()
+		ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: docsRoot, Revision: rev})
+		if err != nil {
+			t.Fatalf("DiscoverWorkspace(%q): %v", rev, err)
+		}
+		if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{}); err != nil {
+			t.Fatalf("InitIndex(%q): %v", rev, err)
+		}
+		return ws
+	}
+	from := open("HEAD")
+	to := open("")
+	if from.LastIndexStats().Revision == "" || to.LastIndexStats().Revision != "" {
+		t.Fatalf("unexpected revisions: from=%+v to=%+v", from.LastIndexStats(), to.LastIndexStats())
+	}
+
+	changes, err := Compare(ctx, from, to, Options{})
+	if err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+	got := map[string]DocChange{}
+	for _, ch := range changes {
+		got[ch.Path] = ch
+	}
+	prefix := "2026/01/15/DIFF-1--demo/"
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", changes)
+	}
+	if got[prefix+"reference/01-new.md"].Kind != Added {
+		t.Fatalf("expected new reference to be added: %+v", changes)
+	}
+	if got[prefix+"design/01-old.md"].Kind != Removed {
+		t.Fatalf("expected old design to be removed: %+v", changes)
+	}
+	idx := got[prefix+"index.md"]
+	if idx.Kind != Modified || len(idx.Fields) != 2 {
+		t.Fatalf("expected index.md modified in 2 fields, got %+v", idx)
+	}
+	if idx.Fields[0] != (FieldChange{Field: "Status", Old: "active", New: "complete"}) ||
+		idx.Fields[1] != (FieldChange{Field: "Topics", Old: "backend", New: "api, backend"}) {
+		t.Fatalf("unexpected field changes: %+v", idx.Fields)
+	}
+
+	changes, err = Compare(ctx, from, to, Options{Fields: []string{"status"}})
+	if err != nil {
+		t.Fatalf("Compare --field: %v", err)
+	}
+	for _, ch := range changes {
+		if ch.Kind == Modified && (len(ch.Fields) != 1 || ch.Fields[0].Field != "Status") {
+			t.Fatalf("field filter not applied: %+v", ch)
+		}
+	}
+
+	bad, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: docsRoot, Revision: "no-such-rev"})
+	if err != nil {
+		t.Fatalf("DiscoverWorkspace: %v", err)
+	}
+	if err := bad.InitIndex(ctx, workspace.BuildIndexOptions{}); err == nil {
+		t.Fatalf("expected unknown revision to fail")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
 	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgrctx"
@@ -41,6 +42,13 @@ func ReadDocumentWithFrontmatterFS(fsys fs.FS, path string) (*models.Document, s
 	return readDocumentWithFrontmatterBytes(path, raw)
 }
 
+// ParseDocumentWithFrontmatter parses already-read file contents. path is only used
+// for diagnostics. Callers that need the raw bytes (e.g. to hash them) use this to
+// avoid reading the file twice.
+func ParseDocumentWithFrontmatter(path string, raw []byte) (*models.Document, string, error) {
+	return readDocumentWithFrontmatterBytes(path, raw)
+}
+
 func readDocumentWithFrontmatterBytes(path string, raw []byte) (*models.Document, string, error) {
 	fm, body, fmStartLine, err := extractFrontmatter(raw)
 	if err != nil {
@@ -72,10 +80,58 @@ func readDocumentWithFrontmatterBytes(path string, raw []byte) (*models.Document
 		tax := docmgrctx.NewFrontmatterParse(path, line, col, snippet, problem, err)
 		return nil, "", core.WrapWithCause(err, tax)
 	}
+	normalizeExtraFields(doc.Extra)
+	doc.Positions = frontmatterPositions(&node, fmStartLine)
 
 	return &doc, string(body), nil
 }
 
+// frontmatterPositions records the file position of every top-level key, its
+// value, and each list element. Node lines count from the first frontmatter
+// line, so they are shifted by fmStartLine.
+func frontmatterPositions(node *yaml.Node, fmStartLine int) models.FrontmatterPositions {
+	root := node
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+	at := func(n *yaml.Node) models.Position {
+		return models.Position{Line: n.Line + fmStartLine - 1, Column: n.Column}
+	}
+	out := models.FrontmatterPositions{}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		fp := models.FieldPosition{Key: at(key), Value: at(value)}
+		if value.Kind == yaml.SequenceNode {
+			for _, item := range value.Content {
+				fp.Items = append(fp.Items, at(item))
+			}
+		}
+		out[key.Value] = fp
+	}
+	return out
+}
+
+// normalizeExtraFields turns YAML timestamps in custom fields back into the
+// strings they were written as, so `DueDate: 2026-03-01` survives a rewrite
+// instead of becoming a full RFC3339 timestamp.
+func normalizeExtraFields(extra map[string]any) {
+	for k, v := range extra {
+		switch x := v.(type) {
+		case time.Time:
+			extra[k] = models.FormatFieldDate(x)
+		case []any:
+			for i, item := range x {
+				if t, ok := item.(time.Time); ok {
+					x[i] = models.FormatFieldDate(t)
+				}
+			}
+		}
+	}
+}
+
 // WriteDocumentWithFrontmatter writes the provided document metadata and body
 // to the target markdown path using YAML frontmatter.
 func WriteDocumentWithFrontmatter(path string, doc *models.Document, body string, force bool) error {
@@ -97,31 +153,12 @@ func WriteDocumentWithFrontmatter(path string, doc *models.Document, body string
 		_ = os.Remove(tmp.Name())
 	}()
 
-	var fmBuf bytes.Buffer
-	enc := yaml.NewEncoder(&fmBuf)
-	if err := enc.Encode(doc); err != nil {
-		_ = tmp.Close()
-		return err
-	}
-	if err := enc.Close(); err != nil {
-		_ = tmp.Close()
-		return err
-	}
-	fmBytes := frontmatter.PreprocessYAML(fmBuf.Bytes())
-
-	if _, err := tmp.WriteString("---\n"); err != nil {
-		_ = tmp.Close()
-		return err
-	}
-	if _, err := tmp.Write(fmBytes); err != nil {
-		_ = tmp.Close()
-		return err
-	}
-	if _, err := tmp.WriteString("---\n\n"); err != nil {
+	content, err := RenderDocumentWithFrontmatter(doc, body)
+	if err != nil {
 		_ = tmp.Close()
 		return err
 	}
-	if _, err := tmp.WriteString(body); err != nil {
+	if _, err := tmp.Write(content); err != nil {
 		_ = tmp.Close()
 		return err
 	}
@@ -133,6 +170,27 @@ func WriteDocumentWithFrontmatter(path string, doc *models.Document, body string
 	return os.Rename(tmp.Name(), path)
 }
 
+// RenderDocumentWithFrontmatter returns the file content
+// WriteDocumentWithFrontmatter writes: the YAML frontmatter block followed by
+// the body.
+func RenderDocumentWithFrontmatter(doc *models.Document, body string) ([]byte, error) {
+	var fmBuf bytes.Buffer
+	enc := yaml.NewEncoder(&fmBuf)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteString("---\n")
+	out.Write(frontmatter.PreprocessYAML(fmBuf.Bytes()))
+	out.WriteString("---\n\n")
+	out.WriteString(body)
+	return out.Bytes(), nil
+}
+
 // extractFrontmatter returns the frontmatter bytes, body bytes, and the starting line number (1-based) of the YAML block.
 func extractFrontmatter(raw []byte) ([]byte, []byte, int, error) {
 	lines := bytes.Split(raw, []byte("\n"))
@@ -39,6 +39,52 @@ Body line
 	}
 }
 
+func TestReadDocumentWithFrontmatter_RecordsPositions(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "doc.md")
+	content := `---
+Title: Hello
+Topics:
+    - api
+    - chat
+Owners: [alice, bob]
+RelatedFiles:
+    - Path: pkg/a.go
+      Note: first
+    - Path: pkg/b.go
+      Note: second
+---
+Body line
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	doc, _, err := ReadDocumentWithFrontmatter(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checks := []struct {
+		name string
+		got  models.Position
+		want models.Position
+	}{
+		{"Title value", doc.Positions.Value("Title"), models.Position{Line: 2, Column: 8}},
+		{"Topics[1]", doc.Positions.Item("Topics", 1), models.Position{Line: 5, Column: 7}},
+		{"Owners[1]", doc.Positions.Item("owners", 1), models.Position{Line: 6, Column: 17}},
+		{"RelatedFiles[1]", doc.Positions.Item("RelatedFiles", 1), models.Position{Line: 10, Column: 7}},
+		{"missing field", doc.Positions.Value("Summary"), models.Position{}},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s: got %+v, want %+v", c.name, c.got, c.want)
+		}
+	}
+	if key, _ := doc.Positions.Field("RelatedFiles"); key.Key != (models.Position{Line: 7, Column: 1}) {
+		t.Errorf("RelatedFiles key: got %+v", key.Key)
+	}
+}
+
 func TestReadDocumentWithFrontmatter_InvalidReportsLine(t *testing.T) {
 	tmp := t.TempDir()
 	path := filepath.Join(tmp, "bad.md")
@@ -12,9 +12,13 @@ import (
 // doc and body are nil if readErr is non-nil.
 type WalkDocumentFunc func(path string, doc *models.Document, body string, readErr error) error
 
+// ReadDocumentFunc reads and parses a single markdown document.
+type ReadDocumentFunc func(path string) (*models.Document, string, error)
+
 type walkConfig struct {
 	skipDir  func(path string, d fs.DirEntry) bool
 	skipFile func(path string, d fs.DirEntry) bool
+	read     ReadDocumentFunc
 }
 
 // WalkOption customizes the behavior of WalkDocuments.
@@ -35,10 +39,20 @@ func WithSkipFile(skip func(path string, d fs.DirEntry) bool) WalkOption {
 	}
 }
 
+// WithReadDocument replaces the default reader (ReadDocumentWithFrontmatter), e.g. to
+// serve unchanged documents from a cache.
+func WithReadDocument(read ReadDocumentFunc) WalkOption {
+	return func(cfg *walkConfig) {
+		if read != nil {
+			cfg.read = read
+		}
+	}
+}
+
 // WalkDocuments walks the root directory, invoking fn for every markdown document encountered.
 // Directories beginning with "_" are skipped by default. Use WithSkipDir to customize.
 func WalkDocuments(root string, fn WalkDocumentFunc, opts ...WalkOption) error {
-	cfg := &walkConfig{}
+	cfg := &walkConfig{read: ReadDocumentWithFrontmatter}
 	for _, opt := range opts {
 		opt(cfg)
 	}
@@ -63,7 +77,7 @@ func WalkDocuments(root string, fn WalkDocumentFunc, opts ...WalkOption) error {
 			return nil
 		}
 
-		doc, body, readErr := ReadDocumentWithFrontmatter(path)
+		doc, body, readErr := cfg.read(path)
 		return fn(path, doc, body, readErr)
 	})
 }
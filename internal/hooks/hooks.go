@@ -0,0 +1,188 @@
+// Package hooks runs the user commands configured under hooks: in .ttmp.yaml
+// when docmgr writes to the workspace (ticket created, status changed, task
+// checked, changelog appended, ...).
+//
+// Each matching hook runs through the shell in the directory of .ttmp.yaml
+// with the JSON Payload on stdin and DOCMGR_EVENT / DOCMGR_TICKET /
+// DOCMGR_PATH in its environment. Hooks run after the write succeeded; a
+// failing or timed-out hook is reported on stderr and never fails the write.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/pkg/errors"
+)
+
+// Event names a workspace write that hooks can subscribe to.
+type Event string
+
+const (
+	EventTicketCreated     Event = "ticket.created"
+	EventDocAdded          Event = "doc.added"
+	EventDocUpdated        Event = "doc.updated"
+	EventStatusChanged     Event = "status.changed"
+	EventTaskAdded         Event = "task.added"
+	EventTaskChecked       Event = "task.checked"
+	EventTaskUnchecked     Event = "task.unchecked"
+	EventTaskEdited        Event = "task.edited"
+	EventTaskRemoved       Event = "task.removed"
+	EventChangelogAppended Event = "changelog.appended"
+)
+
+// DefaultTimeout bounds a hook run when its config sets no timeout.
+const DefaultTimeout = 10 * time.Second
+
+// Payload is the JSON document a hook receives on stdin.
+type Payload struct {
+	Event  Event          `json:"event"`
+	Time   time.Time      `json:"time"`
+	Ticket string         `json:"ticket,omitempty"`
+	Path   string         `json:"path,omitempty"`
+	Data   map[string]any `json:"data,omitempty"`
+}
+
+// Result is the outcome of one hook run.
+type Result struct {
+	Hook   string
+	Output []byte
+	Err    error
+}
+
+var disabled atomic.Bool
+
+// SetDisabled turns hook execution off for this process (--no-hooks).
+func SetDisabled(v bool) { disabled.Store(v) }
+
+// Disabled reports whether hooks are off, via SetDisabled or DOCMGR_NO_HOOKS.
+func Disabled() bool {
+	if disabled.Load() {
+		return true
+	}
+	v := strings.TrimSpace(os.Getenv("DOCMGR_NO_HOOKS"))
+	return v != "" && v != "0" && !strings.EqualFold(v, "false")
+}
+
+// Fire runs the hooks configured for p.Event and reports failures on stderr.
+func Fire(ctx context.Context, p Payload) {
+	if Disabled() {
+		return
+	}
+	cfgPath, err := workspace.FindTTMPConfigPath()
+	if err != nil {
+		return
+	}
+	cfg, err := workspace.LoadWorkspaceConfig()
+	if err != nil || cfg == nil || len(cfg.Hooks) == 0 {
+		return
+	}
+	for _, r := range Run(ctx, cfg.Hooks, filepath.Dir(cfgPath), p) {
+		if len(r.Output) > 0 {
+			_, _ = os.Stderr.Write(r.Output)
+		}
+		if r.Err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: hook %s failed on %s: %v\n", r.Hook, p.Event, r.Err)
+		}
+	}
+}
+
+// Run runs the hooks matching p.Event one after another in dir and returns
+// their results. Hooks with an invalid config are reported as failed.
+func Run(ctx context.Context, hooks []workspace.HookConfig, dir string, p Payload) []Result {
+	if p.Time.IsZero() {
+		p.Time = time.Now()
+	}
+	var out []Result
+	for _, h := range hooks {
+		if !Matches(h, p.Event) {
+			continue
+		}
+		out = append(out, runOne(ctx, h, dir, p))
+	}
+	return out
+}
+
+// Matches reports whether hook h subscribes to event e.
+func Matches(h workspace.HookConfig, e Event) bool {
+	if len(h.Events) == 0 {
+		return true
+	}
+	for _, pattern := range h.Events {
+		if ok, err := path.Match(strings.TrimSpace(pattern), string(e)); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func runOne(ctx context.Context, h workspace.HookConfig, dir string, p Payload) Result {
+	r := Result{Hook: hookName(h)}
+	if strings.TrimSpace(h.Command) == "" {
+		r.Err = errors.New("empty command")
+		return r
+	}
+	timeout := DefaultTimeout
+	if strings.TrimSpace(h.Timeout) != "" {
+		d, err := time.ParseDuration(strings.TrimSpace(h.Timeout))
+		if err != nil || d <= 0 {
+			r.Err = errors.Errorf("invalid timeout %q", h.Timeout)
+			return r
+		}
+		timeout = d
+	}
+	payload, err := json.Marshal(p)
+	if err != nil {
+		r.Err = errors.Wrap(err, "marshal payload")
+		return r
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := shellCommand(runCtx, h.Command)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"DOCMGR_EVENT="+string(p.Event),
+		"DOCMGR_TICKET="+p.Ticket,
+		"DOCMGR_PATH="+p.Path,
+	)
+	// Don't wait for grandchildren holding the output pipe after a timeout.
+	cmd.WaitDelay = time.Second
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err = cmd.Run()
+	r.Output = output.Bytes()
+	if runCtx.Err() == context.DeadlineExceeded {
+		r.Err = errors.Errorf("timed out after %s", timeout)
+	} else if err != nil {
+		r.Err = err
+	}
+	return r
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+func hookName(h workspace.HookConfig) string {
+	if n := strings.TrimSpace(h.Name); n != "" {
+		return n
+	}
+	return strings.TrimSpace(h.Command)
+}
@@ -0,0 +1,86 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+)
+
+func TestRunPassesPayloadAndFiltersEvents(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in this test use sh")
+	}
+	dir := t.TempDir()
+	hooks := []workspace.HookConfig{
+		{Name: "record", Command: `cat > "payload-$DOCMGR_EVENT.json"`, Events: []string{"task.*"}},
+		{Name: "changelog-only", Command: "touch changelog-ran", Events: []string{"changelog.appended"}},
+	}
+
+	results := Run(context.Background(), hooks, dir, Payload{
+		Event:  EventTaskChecked,
+		Ticket: "MEN-1",
+		Path:   "/tmp/tasks.md",
+		Data:   map[string]any{"ids": []string{"ab12"}},
+	})
+	if len(results) != 1 || results[0].Hook != "record" || results[0].Err != nil {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "changelog-ran")); err == nil {
+		t.Fatalf("hook filtered to changelog.appended ran on task.checked")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "payload-task.checked.json"))
+	if err != nil {
+		t.Fatalf("read payload: %v", err)
+	}
+	var got Payload
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if got.Event != EventTaskChecked || got.Ticket != "MEN-1" || got.Time.IsZero() {
+		t.Fatalf("unexpected payload: %+v", got)
+	}
+}
+
+func TestRunReportsFailuresAndTimeouts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in this test use sh")
+	}
+	hooks := []workspace.HookConfig{
+		{Name: "fails", Command: "echo boom >&2; exit 3"},
+		{Name: "slow", Command: "sleep 5", Timeout: "100ms"},
+		{Name: "bad-timeout", Command: "true", Timeout: "soon"},
+	}
+	results := Run(context.Background(), hooks, t.TempDir(), Payload{Event: EventDocUpdated})
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %+v", results)
+	}
+	if results[0].Err == nil || !strings.Contains(string(results[0].Output), "boom") {
+		t.Errorf("fails: err=%v output=%q", results[0].Err, results[0].Output)
+	}
+	if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "timed out") {
+		t.Errorf("slow: expected a timeout, got %v", results[1].Err)
+	}
+	if results[2].Err == nil || !strings.Contains(results[2].Err.Error(), "invalid timeout") {
+		t.Errorf("bad-timeout: expected an invalid timeout error, got %v", results[2].Err)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	t.Setenv("DOCMGR_NO_HOOKS", "1")
+	if !Disabled() {
+		t.Fatalf("DOCMGR_NO_HOOKS=1 should disable hooks")
+	}
+	t.Setenv("DOCMGR_NO_HOOKS", "")
+	SetDisabled(true)
+	defer SetDisabled(false)
+	if !Disabled() {
+		t.Fatalf("SetDisabled(true) should disable hooks")
+	}
+}
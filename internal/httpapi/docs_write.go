@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/go-go-golems/docmgr/internal/hooks"
+	"github.com/go-go-golems/docmgr/internal/workflow"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/docmgr/pkg/commands"
 	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
@@ -25,7 +27,7 @@ type docsMetaResponse struct {
 }
 
 // handleDocsMeta wraps the 'docmgr meta update' write primitive
-// (commands.UpdateDocumentField): POST {path, field, value} updates one
+// (commands.WriteDocumentField): POST {path, field, value} updates one
 // frontmatter field of a document under the docs root and refreshes the
 // in-memory index.
 func (s *Server) handleDocsMeta(w http.ResponseWriter, r *http.Request) error {
@@ -47,19 +49,31 @@ func (s *Server) handleDocsMeta(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	var resp docsMetaResponse
+	var ev Event
+	var fired []hooks.Payload
 	if err := s.mgr.WithWorkspace(func(ws *workspace.Workspace) error {
 		abs, rel, err := resolveDocWithin(ws.Context().Root, req.Path)
 		if err != nil {
 			return err
 		}
 
-		if err := commands.UpdateDocumentField(abs, req.Field, req.Value); err != nil {
-			if errors.Is(err, commands.ErrUnknownMetaField) {
+		payloads, err := commands.WriteDocumentField(abs, req.Field, req.Value)
+		if err != nil {
+			if errors.Is(err, commands.ErrUnknownMetaField) || errors.Is(err, commands.ErrInvalidMetaValue) {
 				return NewHTTPError(http.StatusBadRequest, "invalid_argument", err.Error(), map[string]any{
 					"field": "field",
 					"value": req.Field,
 				})
 			}
+			var te *workflow.TransitionError
+			if errors.As(err, &te) {
+				return NewHTTPError(http.StatusConflict, "transition_not_allowed", err.Error(), map[string]any{
+					"path":    rel,
+					"from":    te.From,
+					"to":      te.To,
+					"reasons": te.Reasons,
+				})
+			}
 			if t, ok := core.AsTaxonomy(err); ok {
 				return NewHTTPError(http.StatusUnprocessableEntity, "invalid_frontmatter", err.Error(), map[string]any{
 					"path":     rel,
@@ -69,15 +83,22 @@ func (s *Server) handleDocsMeta(w http.ResponseWriter, r *http.Request) error {
 			return err
 		}
 
+		fired = payloads
 		resp = docsMetaResponse{Path: rel, Field: req.Field, Value: req.Value, Status: "updated"}
+		ev = Event{Type: EventDocUpdated, Ticket: indexedDocTicket(r.Context(), ws, abs), Path: rel, Data: map[string]any{"field": req.Field}}
 		return nil
 	}); err != nil {
 		return err
 	}
+	// Hooks run after the workspace lock is released.
+	for _, p := range fired {
+		hooks.Fire(r.Context(), p)
+	}
 
 	if _, err := s.mgr.Refresh(r.Context()); err != nil {
 		return err
 	}
+	s.mgr.Publish(ev)
 
 	return writeJSON(w, http.StatusOK, resp)
 }
@@ -128,6 +149,8 @@ func (s *Server) handleDocsRelate(w http.ResponseWriter, r *http.Request) error
 	}
 
 	var resp docsRelateResponse
+	var ev Event
+	var fired []hooks.Payload
 	if err := s.mgr.WithWorkspace(func(ws *workspace.Workspace) error {
 		abs, rel, err := resolveDocWithin(ws.Context().Root, req.Path)
 		if err != nil {
@@ -139,7 +162,7 @@ func (s *Server) handleDocsRelate(w http.ResponseWriter, r *http.Request) error
 			add = append(add, commands.RelatedFileChange{Path: item.Path, Note: item.Note})
 		}
 
-		res, err := commands.ApplyRelatedFilesUpdate(ws, abs, add, req.Remove)
+		res, payloads, err := commands.ApplyRelatedFilesUpdate(ws, abs, add, req.Remove)
 		if err != nil {
 			if t, ok := core.AsTaxonomy(err); ok {
 				return NewHTTPError(http.StatusUnprocessableEntity, "invalid_frontmatter", err.Error(), map[string]any{
@@ -150,6 +173,7 @@ func (s *Server) handleDocsRelate(w http.ResponseWriter, r *http.Request) error
 			return err
 		}
 
+		fired = payloads
 		status := "updated"
 		if !res.Changed {
 			status = "noop"
@@ -162,14 +186,22 @@ func (s *Server) handleDocsRelate(w http.ResponseWriter, r *http.Request) error
 			Total:   res.Total,
 			Status:  status,
 		}
+		ev = Event{Type: EventDocUpdated, Ticket: indexedDocTicket(r.Context(), ws, abs), Path: rel}
 		return nil
 	}); err != nil {
 		return err
 	}
+	// Hooks run after the workspace lock is released.
+	for _, p := range fired {
+		hooks.Fire(r.Context(), p)
+	}
 
 	if _, err := s.mgr.Refresh(r.Context()); err != nil {
 		return err
 	}
+	if resp.Status != "noop" {
+		s.mgr.Publish(ev)
+	}
 
 	return writeJSON(w, http.StatusOK, resp)
 }
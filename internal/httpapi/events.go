@@ -0,0 +1,207 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+)
+
+// EventType names a workspace change published on /api/v1/events.
+type EventType string
+
+const (
+	EventDocUpdated        EventType = "doc.updated"
+	EventDocRemoved        EventType = "doc.removed"
+	EventTicketCreated     EventType = "ticket.created"
+	EventTaskChecked       EventType = "task.checked"
+	EventTasksUpdated      EventType = "tasks.updated"
+	EventChangelogAppended EventType = "changelog.appended"
+	EventIndexRefreshed    EventType = "index.refreshed"
+)
+
+// Event is one workspace change notification.
+//
+// Events are invalidation hints, not a change log: a single edit can produce
+// several events (e.g. task.checked from the write endpoint and doc.updated from
+// the watcher), and slow subscribers may miss events. Clients refetch what they
+// display instead of applying event payloads.
+type Event struct {
+	ID     int64          `json:"id"`
+	Type   EventType      `json:"type"`
+	Ticket string         `json:"ticket,omitempty"`
+	Path   string         `json:"path,omitempty"` // docs-root-relative, slash-separated
+	At     time.Time      `json:"at"`
+	Data   map[string]any `json:"data,omitempty"`
+}
+
+const (
+	eventSubscriberBuffer = 64
+	eventKeepAlive        = 25 * time.Second
+)
+
+// eventBroker fans out events to the connected SSE clients.
+type eventBroker struct {
+	mu     sync.Mutex
+	nextID int64
+	subs   map[chan Event]struct{}
+}
+
+func newEventBroker() *eventBroker {
+	return &eventBroker{subs: map[chan Event]struct{}{}}
+}
+
+// Publish stamps ev and delivers it to every subscriber without blocking;
+// subscribers whose buffer is full drop the event.
+func (b *eventBroker) Publish(ev Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	ev.ID = b.nextID
+	if ev.At.IsZero() {
+		ev.At = time.Now()
+	}
+	for ch := range b.subs {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a subscriber; the returned cancel func unregisters it and
+// closes the channel.
+func (b *eventBroker) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, eventSubscriberBuffer)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Subscribers returns the number of connected subscribers.
+func (b *eventBroker) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+// Publish sends a workspace change event to all /api/v1/events subscribers.
+func (m *IndexManager) Publish(ev Event) {
+	m.events.Publish(ev)
+}
+
+// Subscribe returns a channel of workspace change events and its cancel func.
+func (m *IndexManager) Subscribe() (<-chan Event, func()) {
+	return m.events.Subscribe()
+}
+
+// handleEvents streams workspace change events as Server-Sent Events.
+//
+// Each event is written as `id:`, `event: <type>` and a JSON `data:` line; a
+// comment line is sent periodically so proxies keep the connection open.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return NewHTTPError(http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed", nil)
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return NewHTTPError(http.StatusInternalServerError, "internal", "streaming not supported", nil)
+	}
+
+	events, cancel := s.mgr.Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprint(w, "retry: 3000\n: connected\n\n"); err != nil {
+		return nil
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return nil
+			}
+		case ev, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := writeSSEEvent(w, ev); err != nil {
+				return nil
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeSSEEvent(w http.ResponseWriter, ev Event) error {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, b)
+	return err
+}
+
+// docsRelPath returns path relative to the docs root, slash-separated.
+func docsRelPath(ws *workspace.Workspace, path string) string {
+	rel, err := filepath.Rel(ws.Context().Root, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// fileChangeEvents maps one applied watcher change to events. A newly created
+// index.md announces a ticket; tasks.md and changelog.md edits get their own
+// event types so ticket pages can refresh just those panels.
+func fileChangeEvents(ws *workspace.Workspace, c FileChange, ticket string) []Event {
+	rel := docsRelPath(ws, c.Path)
+	if c.Op == FileRemoved {
+		return []Event{{Type: EventDocRemoved, Ticket: ticket, Path: rel}}
+	}
+	out := []Event{{Type: EventDocUpdated, Ticket: ticket, Path: rel}}
+	switch strings.ToLower(filepath.Base(c.Path)) {
+	case "index.md":
+		if c.Created {
+			out = append(out, Event{Type: EventTicketCreated, Ticket: ticket, Path: rel})
+		}
+	case "tasks.md":
+		out = append(out, Event{Type: EventTasksUpdated, Ticket: ticket, Path: rel})
+	case "changelog.md":
+		out = append(out, Event{Type: EventChangelogAppended, Ticket: ticket, Path: rel})
+	}
+	return out
+}
+
+// indexedDocTicket returns the Ticket of an indexed document, or "".
+func indexedDocTicket(ctx context.Context, ws *workspace.Workspace, path string) string {
+	var ticket string
+	_ = ws.DB().QueryRowContext(ctx, `SELECT COALESCE(ticket_id,'') FROM docs WHERE path = ?`, filepath.Clean(path)).Scan(&ticket)
+	return ticket
+}
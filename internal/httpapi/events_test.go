@@ -0,0 +1,100 @@
+package httpapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvents_StreamsWriteAndWatcherChanges(t *testing.T) {
+	s := setupWriteTestServer(t)
+	root := s.mgr.Snapshot().Workspace.Context().Root
+
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/v1/events", nil)
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", ct)
+	}
+
+	events := make(chan Event, 16)
+	go func() {
+		defer close(events)
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			data, ok := strings.CutPrefix(sc.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			var ev Event
+			if err := json.Unmarshal([]byte(data), &ev); err == nil {
+				events <- ev
+			}
+		}
+	}()
+
+	next := func(want EventType) Event {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case ev, ok := <-events:
+				if !ok {
+					t.Fatalf("stream closed while waiting for %s", want)
+				}
+				if ev.Type == want {
+					return ev
+				}
+			case <-timeout:
+				t.Fatalf("timed out waiting for %s", want)
+			}
+		}
+	}
+
+	rr := doJSON(t, s, http.MethodPost, "/api/v1/tickets/changelog", map[string]any{
+		"ticket": "WRT-9",
+		"entry":  "Streamed entry",
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("changelog: %d %s", rr.Code, rr.Body.String())
+	}
+	if ev := next(EventChangelogAppended); ev.Ticket != "WRT-9" || ev.Path != "2026/01/03/WRT-9--writes/changelog.md" {
+		t.Fatalf("unexpected changelog event: %+v", ev)
+	}
+
+	newIndex := filepath.Join(root, "2026", "01", "04", "EVT-1--created", "index.md")
+	mustMkdirAll(t, filepath.Dir(newIndex))
+	mustWriteFile(t, newIndex, "---\nTitle: Created\nTicket: EVT-1\nDocType: index\n---\n")
+	if err := s.mgr.ApplyChanges(ctx, []FileChange{{Path: newIndex, Op: FileUpserted, Created: true}}); err != nil {
+		t.Fatalf("ApplyChanges: %v", err)
+	}
+	if ev := next(EventTicketCreated); ev.Ticket != "EVT-1" || ev.Path != "2026/01/04/EVT-1--created/index.md" {
+		t.Fatalf("unexpected ticket.created event: %+v", ev)
+	}
+
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for s.mgr.events.Subscribers() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("subscriber not released after disconnect")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -17,14 +17,20 @@ type IndexSnapshot struct {
 type IndexManager struct {
 	rootOverride string
 
+	// mu guards the fields below and serializes index access: readers hold the
+	// read lock for the duration of WithWorkspace, per-file updates (ApplyChanges)
+	// take the write lock.
 	mu        sync.RWMutex
 	workspace *workspace.Workspace
 	indexedAt time.Time
 	docsCount int
+	watch     WatchStats
+
+	events *eventBroker
 }
 
 func NewIndexManager(rootOverride string) *IndexManager {
-	return &IndexManager{rootOverride: rootOverride}
+	return &IndexManager{rootOverride: rootOverride, events: newEventBroker()}
 }
 
 func (m *IndexManager) Refresh(ctx context.Context) (IndexSnapshot, error) {
@@ -49,6 +55,8 @@ func (m *IndexManager) Refresh(ctx context.Context) (IndexSnapshot, error) {
 	m.docsCount = count
 	m.mu.Unlock()
 
+	m.Publish(Event{Type: EventIndexRefreshed, At: now, Data: map[string]any{"docsIndexed": count}})
+
 	return IndexSnapshot{Workspace: ws, IndexedAt: now, DocsIndexed: count}, nil
 }
 
@@ -62,15 +70,17 @@ func (m *IndexManager) Snapshot() IndexSnapshot {
 	}
 }
 
+// WithWorkspace runs fn against the current index while holding the read lock,
+// so per-file updates from the watcher never interleave with a query. fn must not
+// call back into the IndexManager.
 func (m *IndexManager) WithWorkspace(fn func(ws *workspace.Workspace) error) error {
 	m.mu.RLock()
-	ws := m.workspace
-	m.mu.RUnlock()
+	defer m.mu.RUnlock()
 
-	if ws == nil {
+	if m.workspace == nil {
 		return ErrIndexNotReady
 	}
-	return fn(ws)
+	return fn(m.workspace)
 }
 
 func countAllDocs(ctx context.Context, ws *workspace.Workspace) (int, error) {
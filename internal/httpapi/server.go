@@ -44,14 +44,17 @@ func NewServer(mgr *IndexManager, opts ServerOptions) *Server {
 	s.mux.HandleFunc("/api/v1/workspace/topics", s.wrap(s.handleWorkspaceTopics))
 	s.mux.HandleFunc("/api/v1/workspace/topics/get", s.wrap(s.handleWorkspaceTopicsGet))
 	s.mux.HandleFunc("/api/v1/index/refresh", s.wrap(s.handleIndexRefresh))
+	s.mux.HandleFunc("/api/v1/events", s.wrap(s.handleEvents))
 	s.mux.HandleFunc("/api/v1/search/docs", s.wrap(s.handleSearchDocs))
 	s.mux.HandleFunc("/api/v1/search/files", s.wrap(s.handleSearchFiles))
+	s.mux.HandleFunc("/api/v1/views", s.wrap(s.handleViews))
 	s.mux.HandleFunc("/api/v1/docs/get", s.wrap(s.handleDocsGet))
 	s.mux.HandleFunc("/api/v1/docs/meta", s.wrap(s.handleDocsMeta))
 	s.mux.HandleFunc("/api/v1/docs/relate", s.wrap(s.handleDocsRelate))
 	s.mux.HandleFunc("/api/v1/files/get", s.wrap(s.handleFilesGet))
 	s.mux.HandleFunc("/api/v1/files/raw", s.wrap(s.handleFilesRaw))
 	s.mux.HandleFunc("/api/v1/workspace/doctor", s.wrap(s.handleWorkspaceDoctor))
+	s.mux.HandleFunc("/api/v1/workspace/doctor/fix", s.wrap(s.handleWorkspaceDoctorFix))
 	s.mux.HandleFunc("/api/v1/tickets/get", s.wrap(s.handleTicketsGet))
 	s.mux.HandleFunc("/api/v1/tickets/changelog", s.wrap(s.handleTicketsChangelog))
 	s.mux.HandleFunc("/api/v1/tickets/docs", s.wrap(s.handleTicketsDocs))
@@ -59,6 +62,7 @@ func NewServer(mgr *IndexManager, opts ServerOptions) *Server {
 	s.mux.HandleFunc("/api/v1/tickets/tasks/check", s.wrap(s.handleTicketsTasksCheck))
 	s.mux.HandleFunc("/api/v1/tickets/tasks/add", s.wrap(s.handleTicketsTasksAdd))
 	s.mux.HandleFunc("/api/v1/tickets/graph", s.wrap(s.handleTicketsGraph))
+	s.mux.HandleFunc("/api/v1/tasks", s.wrap(s.handleTasks))
 
 	return s
 }
@@ -113,6 +117,7 @@ func (s *Server) handleWorkspaceStatus(w http.ResponseWriter, r *http.Request) e
 		"indexedAt":      snap.IndexedAt.Format(time.RFC3339Nano),
 		"docsIndexed":    snap.DocsIndexed,
 		"ftsAvailable":   snap.Workspace.FTSAvailable(),
+		"watch":          s.mgr.WatchStats(),
 	})
 }
 
@@ -213,6 +218,18 @@ func (s *Server) handleSearchDocs(w http.ResponseWriter, r *http.Request) error
 		return err
 	}
 
+	where := strings.TrimSpace(r.URL.Query().Get("q"))
+	if _, err := workspace.ParseDocExpr(where); err != nil {
+		var qerr *workspace.QueryExprError
+		if errors.As(err, &qerr) {
+			return NewHTTPError(http.StatusBadRequest, "invalid_query", qerr.Error(), map[string]any{
+				"field":    "q",
+				"position": qerr.Pos + 1,
+			})
+		}
+		return err
+	}
+
 	q := searchsvc.SearchQuery{
 		TextQuery:           strings.TrimSpace(r.URL.Query().Get("query")),
 		AllowEmpty:          true,
@@ -222,6 +239,7 @@ func (s *Server) handleSearchDocs(w http.ResponseWriter, r *http.Request) error
 		Status:              strings.TrimSpace(r.URL.Query().Get("status")),
 		File:                fileFilter,
 		Dir:                 dirFilter,
+		Where:               where,
 		ExternalSource:      strings.TrimSpace(r.URL.Query().Get("externalSource")),
 		Since:               strings.TrimSpace(r.URL.Query().Get("since")),
 		Until:               strings.TrimSpace(r.URL.Query().Get("until")),
@@ -293,6 +311,7 @@ func (s *Server) handleSearchDocs(w http.ResponseWriter, r *http.Request) error
 			"status":         q.Status,
 			"file":           q.File,
 			"dir":            q.Dir,
+			"q":              q.Where,
 			"externalSource": q.ExternalSource,
 			"since":          q.Since,
 			"until":          q.Until,
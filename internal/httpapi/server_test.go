@@ -3,6 +3,8 @@ package httpapi
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -58,3 +60,17 @@ func TestServer_SearchPathFiltersRejectUnsafeValuesBeforeIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestServer_SearchRejectsInvalidQueryExpressionBeforeIndex(t *testing.T) {
+	t.Parallel()
+
+	mgr := NewIndexManager("ttmp")
+	s := NewServer(mgr, ServerOptions{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search/docs?q="+url.QueryEscape("status:active AND (topic:api"), nil)
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "invalid_query") {
+		t.Fatalf("expected 400 invalid_query, got %d (%s)", rr.Code, rr.Body.String())
+	}
+}
@@ -0,0 +1,129 @@
+package httpapi
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/tasksmd"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+)
+
+type tasksQuery struct {
+	Ticket          string   `json:"ticket"`
+	Checked         string   `json:"checked"`
+	Status          string   `json:"status"`
+	Topics          []string `json:"topics"`
+	Owners          []string `json:"owners"`
+	Section         string   `json:"section"`
+	Q               string   `json:"q"`
+	Assignee        string   `json:"assignee"`
+	Priority        string   `json:"priority"`
+	IncludeArchived bool     `json:"includeArchived"`
+}
+
+type taskItem struct {
+	Ticket       string `json:"ticket"`
+	TicketTitle  string `json:"ticketTitle"`
+	TicketStatus string `json:"ticketStatus"`
+	// Ref addresses the task in POST /api/v1/tickets/tasks/check: the stable
+	// ID when present, else the 1-based position.
+	Ref      string `json:"ref"`
+	ID       int    `json:"id"`
+	StableID string `json:"stableId,omitempty"`
+	Section  string `json:"section"`
+	Checked  bool   `json:"checked"`
+	Text     string `json:"text"`
+	Line     int    `json:"line"`
+	Path     string `json:"path"`
+	tasksmd.Attrs
+}
+
+type tasksResponse struct {
+	Query tasksQuery       `json:"query"`
+	Stats ticketTasksStats `json:"stats"`
+	// Sections lists the section headings in order of first appearance
+	// (board columns).
+	Sections []string   `json:"sections"`
+	Results  []taskItem `json:"results"`
+}
+
+// handleTasks serves GET /api/v1/tasks: tasks of all tickets from the index.
+func (s *Server) handleTasks(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return NewHTTPError(http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed", nil)
+	}
+
+	q := tasksQuery{
+		Ticket:          strings.TrimSpace(r.URL.Query().Get("ticket")),
+		Checked:         strings.ToLower(strings.TrimSpace(r.URL.Query().Get("checked"))),
+		Status:          strings.TrimSpace(r.URL.Query().Get("status")),
+		Topics:          splitCSV(r.URL.Query().Get("topics")),
+		Owners:          splitCSV(r.URL.Query().Get("owners")),
+		Section:         strings.TrimSpace(r.URL.Query().Get("section")),
+		Q:               strings.TrimSpace(r.URL.Query().Get("q")),
+		Assignee:        strings.TrimSpace(r.URL.Query().Get("assignee")),
+		Priority:        strings.TrimSpace(r.URL.Query().Get("priority")),
+		IncludeArchived: parseBoolDefault(r.URL.Query().Get("includeArchived"), false),
+	}
+	f := workspace.TaskFilters{
+		Ticket:              q.Ticket,
+		Status:              q.Status,
+		TopicsAny:           q.Topics,
+		OwnersAny:           q.Owners,
+		Section:             q.Section,
+		TextContains:        q.Q,
+		Assignee:            q.Assignee,
+		Priority:            q.Priority,
+		IncludeArchivedPath: q.IncludeArchived,
+	}
+	switch q.Checked {
+	case "":
+	case "true", "false":
+		checked := q.Checked == "true"
+		f.Checked = &checked
+	default:
+		return NewHTTPError(http.StatusBadRequest, "invalid_argument", "checked must be true or false", map[string]any{
+			"field": "checked",
+			"value": q.Checked,
+		})
+	}
+
+	resp := tasksResponse{Query: q, Sections: []string{}, Results: []taskItem{}}
+	if err := s.mgr.WithWorkspace(func(ws *workspace.Workspace) error {
+		tasks, err := ws.QueryTasks(r.Context(), f)
+		if err != nil {
+			return err
+		}
+		seen := map[string]bool{}
+		for _, t := range tasks {
+			if !seen[t.Section] {
+				seen[t.Section] = true
+				resp.Sections = append(resp.Sections, t.Section)
+			}
+			resp.Stats.Total++
+			if t.Checked {
+				resp.Stats.Done++
+			}
+			resp.Results = append(resp.Results, taskItem{
+				Ticket:       t.Ticket,
+				TicketTitle:  t.TicketTitle,
+				TicketStatus: t.TicketStatus,
+				Ref:          t.DisplayID(),
+				ID:           t.Position,
+				StableID:     t.StableID,
+				Section:      t.Section,
+				Checked:      t.Checked,
+				Text:         t.Text,
+				Line:         t.Line,
+				Path:         relPath(ws, filepath.FromSlash(t.Path)),
+				Attrs:        t.Attrs,
+			})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return writeJSON(w, http.StatusOK, resp)
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-go-golems/docmgr/internal/hooks"
 	"github.com/go-go-golems/docmgr/internal/paths"
 	"github.com/go-go-golems/docmgr/internal/tasksmd"
 	"github.com/go-go-golems/docmgr/internal/ticketgraph"
@@ -381,6 +382,8 @@ func (s *Server) handleTicketsTasksCheck(w http.ResponseWriter, r *http.Request)
 		return NewHTTPError(http.StatusBadRequest, "invalid_argument", "missing refs", map[string]any{"field": "refs"})
 	}
 
+	var ev Event
+	var hook hooks.Payload
 	if err := s.mgr.WithWorkspace(func(ws *workspace.Workspace) error {
 		res, err := tickets.Resolve(r.Context(), ws, req.Ticket)
 		if err != nil {
@@ -409,10 +412,17 @@ func (s *Server) handleTicketsTasksCheck(w http.ResponseWriter, r *http.Request)
 		if err := tasksmd.WriteFile(abs, updated); err != nil {
 			return err
 		}
+		ev = Event{Type: EventTaskChecked, Ticket: res.TicketID, Path: rawPath, Data: map[string]any{"refs": refs, "checked": req.Checked}}
+		hook = hooks.Payload{Event: hooks.EventTaskUnchecked, Ticket: res.TicketID, Path: abs, Data: map[string]any{"refs": refs}}
+		if req.Checked {
+			hook.Event = hooks.EventTaskChecked
+		}
 		return nil
 	}); err != nil {
 		return err
 	}
+	hooks.Fire(r.Context(), hook)
+	s.mgr.Publish(ev)
 
 	return writeJSON(w, http.StatusOK, map[string]any{"ok": true})
 }
@@ -442,6 +452,8 @@ func (s *Server) handleTicketsTasksAdd(w http.ResponseWriter, r *http.Request) e
 		return NewHTTPError(http.StatusBadRequest, "invalid_argument", "missing text", map[string]any{"field": "text"})
 	}
 
+	var ev Event
+	var hook hooks.Payload
 	if err := s.mgr.WithWorkspace(func(ws *workspace.Workspace) error {
 		res, err := tickets.Resolve(r.Context(), ws, req.Ticket)
 		if err != nil {
@@ -470,10 +482,14 @@ func (s *Server) handleTicketsTasksAdd(w http.ResponseWriter, r *http.Request) e
 		if err := tasksmd.WriteFile(abs, updated); err != nil {
 			return err
 		}
+		ev = Event{Type: EventTasksUpdated, Ticket: res.TicketID, Path: rawPath, Data: map[string]any{"section": req.Section}}
+		hook = hooks.Payload{Event: hooks.EventTaskAdded, Ticket: res.TicketID, Path: abs, Data: map[string]any{"section": req.Section, "text": req.Text}}
 		return nil
 	}); err != nil {
 		return err
 	}
+	hooks.Fire(r.Context(), hook)
+	s.mgr.Publish(ev)
 
 	return writeJSON(w, http.StatusOK, map[string]any{"ok": true})
 }
@@ -490,6 +506,10 @@ func (s *Server) handleTicketsGraph(w http.ResponseWriter, r *http.Request) erro
 		return NewHTTPError(http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed", nil)
 	}
 
+	if parseBoolDefault(r.URL.Query().Get("all"), false) {
+		return s.handleWorkspaceGraph(w, r)
+	}
+
 	ticketID := strings.TrimSpace(r.URL.Query().Get("ticket"))
 	if ticketID == "" {
 		return NewHTTPError(http.StatusBadRequest, "invalid_argument", "missing ticket", map[string]any{"field": "ticket"})
@@ -535,6 +555,71 @@ func (s *Server) handleTicketsGraph(w http.ResponseWriter, r *http.Request) erro
 	return writeJSON(w, http.StatusOK, resp)
 }
 
+type workspaceGraphResponse struct {
+	Direction string                  `json:"direction"`
+	Format    string                  `json:"format"`
+	Mermaid   string                  `json:"mermaid,omitempty"`
+	DOT       string                  `json:"dot,omitempty"`
+	Nodes     []ticketgraph.GraphNode `json:"nodes"`
+	Edges     []ticketgraph.GraphEdge `json:"edges"`
+	Stats     ticketgraph.Stats       `json:"stats"`
+}
+
+// handleWorkspaceGraph serves /api/v1/tickets/graph?all=1: the graph across
+// all tickets, optionally filtered by topics/status. nodes/edges are always
+// returned; format selects which rendering (mermaid or dot) is added.
+func (s *Server) handleWorkspaceGraph(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	direction := strings.TrimSpace(q.Get("direction"))
+	if direction == "" {
+		direction = "TD"
+	}
+	format := strings.ToLower(strings.TrimSpace(q.Get("format")))
+	if format == "" {
+		format = "mermaid"
+	}
+	if format != "mermaid" && format != "dot" && format != "json" {
+		return NewHTTPError(http.StatusBadRequest, "invalid_argument", "invalid format", map[string]any{"field": "format", "value": format})
+	}
+	opts := ticketgraph.WorkspaceOptions{
+		Topics:             splitCSV(q.Get("topics")),
+		Statuses:           splitCSV(q.Get("status")),
+		IncludeDocs:        parseBoolDefault(q.Get("includeDocs"), false),
+		IncludeArchived:    parseBoolDefault(q.Get("includeArchived"), false),
+		IncludeScripts:     parseBoolDefault(q.Get("includeScripts"), false),
+		IncludeControlDocs: parseBoolDefault(q.Get("includeControlDocs"), true),
+	}
+
+	var resp workspaceGraphResponse
+	if err := s.mgr.WithWorkspace(func(ws *workspace.Workspace) error {
+		g, err := ticketgraph.BuildWorkspace(r.Context(), ws, opts)
+		if err != nil {
+			return err
+		}
+		resp = workspaceGraphResponse{
+			Direction: direction,
+			Format:    format,
+			Nodes:     g.Nodes,
+			Edges:     g.Edges,
+			Stats:     g.Stats,
+		}
+		switch format {
+		case "mermaid":
+			resp.Mermaid, err = g.RenderMermaid(direction)
+		case "dot":
+			resp.DOT, err = g.RenderDOT(direction)
+		}
+		if err != nil {
+			return NewHTTPError(http.StatusBadRequest, "invalid_argument", err.Error(), nil)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return writeJSON(w, http.StatusOK, resp)
+}
+
 func ticketTaskCounts(ws *workspace.Workspace, ticketDirRel string) (int, int, error) {
 	if ws == nil {
 		return 0, 0, errors.New("nil workspace")
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/go-go-golems/docmgr/internal/hooks"
 	"github.com/go-go-golems/docmgr/internal/tickets"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/docmgr/pkg/commands"
@@ -85,6 +86,7 @@ type ticketChangelogAppendRequest struct {
 	Ticket string `json:"ticket"`
 	Title  string `json:"title"`
 	Entry  string `json:"entry"`
+	Kind   string `json:"kind"`
 }
 
 func (s *Server) handleTicketsChangelogPost(w http.ResponseWriter, r *http.Request) error {
@@ -99,8 +101,14 @@ func (s *Server) handleTicketsChangelogPost(w http.ResponseWriter, r *http.Reque
 	if strings.TrimSpace(req.Entry) == "" {
 		return NewHTTPError(http.StatusBadRequest, "invalid_argument", "missing entry", map[string]any{"field": "entry"})
 	}
+	kind, err := commands.ParseChangelogKind(req.Kind)
+	if err != nil {
+		return NewHTTPError(http.StatusBadRequest, "invalid_argument", err.Error(), map[string]any{"field": "kind", "value": req.Kind})
+	}
 
 	var resp map[string]any
+	var ev Event
+	var hook hooks.Payload
 	if err := s.mgr.WithWorkspace(func(ws *workspace.Workspace) error {
 		res, err := resolveTicketOrHTTPError(r, ws, req.Ticket)
 		if err != nil {
@@ -109,7 +117,7 @@ func (s *Server) handleTicketsChangelogPost(w http.ResponseWriter, r *http.Reque
 
 		relPath := filepath.ToSlash(filepath.Join(res.TicketDirRel, "changelog.md"))
 		absPath := filepath.Join(ws.Context().Root, filepath.FromSlash(res.TicketDirRel), "changelog.md")
-		date, err := commands.AppendChangelogEntry(absPath, req.Title, req.Entry, nil)
+		date, payload, err := commands.WriteChangelogEntry(absPath, commands.NewChangelogEntry{Ticket: res.TicketID, Title: req.Title, Entry: req.Entry, Kind: kind})
 		if err != nil {
 			return err
 		}
@@ -118,15 +126,20 @@ func (s *Server) handleTicketsChangelogPost(w http.ResponseWriter, r *http.Reque
 			"ticket": req.Ticket,
 			"path":   relPath,
 			"date":   date,
+			"kind":   string(kind),
 		}
+		ev = Event{Type: EventChangelogAppended, Ticket: res.TicketID, Path: relPath, Data: map[string]any{"date": date, "kind": string(kind)}}
+		hook = payload
 		return nil
 	}); err != nil {
 		return err
 	}
+	hooks.Fire(r.Context(), hook)
 
 	if _, err := s.mgr.Refresh(r.Context()); err != nil {
 		return err
 	}
+	s.mgr.Publish(ev)
 
 	return writeJSON(w, http.StatusOK, resp)
 }
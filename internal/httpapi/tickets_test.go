@@ -111,6 +111,32 @@ RelatedFiles:
 		}
 	}
 
+	// Cross-ticket task board (from the index).
+	{
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks?checked=false&topics=test", nil)
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("tasks: expected %d, got %d (%s)", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		var got tasksResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+			t.Fatalf("tasks decode: %v", err)
+		}
+		if len(got.Results) != 1 || got.Results[0].Ref != "ab12" || got.Results[0].Ticket != "TST-123" ||
+			got.Results[0].TicketTitle != "Test Ticket" || got.Results[0].Section != "TODO" ||
+			got.Results[0].Path != "2026/01/03/TST-123--example/tasks.md" || len(got.Sections) != 1 {
+			t.Fatalf("unexpected tasks response: %s", rr.Body.String())
+		}
+
+		req2 := httptest.NewRequest(http.MethodGet, "/api/v1/tasks?checked=maybe", nil)
+		rr2 := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr2, req2)
+		if rr2.Code != http.StatusBadRequest {
+			t.Fatalf("tasks invalid checked: expected %d, got %d (%s)", http.StatusBadRequest, rr2.Code, rr2.Body.String())
+		}
+	}
+
 	// Toggle task stable ref to checked.
 	{
 		body, _ := json.Marshal(map[string]any{
@@ -184,3 +210,69 @@ func mustWriteFile(t *testing.T, path string, content string) {
 		t.Fatalf("write %s: %v", path, err)
 	}
 }
+
+func TestTicketsGraph_WorkspaceSharedFilesAndLinks(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join(t.TempDir(), "ttmp")
+	writeIndex := func(dir, ticket, status, topics, extra string) {
+		mustMkdirAll(t, filepath.Join(root, "2026", "01", "03", dir))
+		mustWriteFile(t, filepath.Join(root, "2026", "01", "03", dir, "index.md"), "---\nTitle: "+ticket+"\nTicket: "+ticket+"\nStatus: "+status+"\nDocType: index\nTopics: ["+topics+"]\n"+extra+"---\n")
+	}
+	writeIndex("A-1--a", "A-1", "active", "backend", "DependsOn: [B-2]\nRelatesTo: [GONE-9]\nRelatedFiles:\n  - Path: pkg/shared.go\n  - Path: pkg/a.go\n")
+	writeIndex("B-2--b", "B-2", "active", "backend", "RelatedFiles:\n  - Path: pkg/shared.go\n")
+	writeIndex("C-3--c", "C-3", "complete", "frontend", "RelatedFiles:\n  - Path: pkg/shared.go\n")
+
+	mgr := NewIndexManager(root)
+	if _, err := mgr.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	s := NewServer(mgr, ServerOptions{})
+
+	get := func(url string) workspaceGraphResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected %d, got %d (%s)", url, http.StatusOK, rr.Code, rr.Body.String())
+		}
+		var got workspaceGraphResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+			t.Fatalf("%s decode: %v", url, err)
+		}
+		return got
+	}
+	edgeKinds := func(resp workspaceGraphResponse) map[string]int {
+		out := map[string]int{}
+		for _, e := range resp.Edges {
+			out[e.Kind]++
+		}
+		return out
+	}
+
+	all := get("/api/v1/tickets/graph?all=1")
+	// A-1, B-2, C-3 plus the missing GONE-9 link target.
+	if len(all.Nodes) != 4 || all.Mermaid == "" {
+		t.Fatalf("expected 4 ticket nodes and mermaid output, got %+v", all)
+	}
+	if k := edgeKinds(all); k["shared_files"] != 3 || k["DependsOn"] != 1 || k["RelatesTo"] != 1 {
+		t.Fatalf("unexpected edge kinds: %v", k)
+	}
+
+	filtered := get("/api/v1/tickets/graph?all=1&topics=backend&status=active&format=dot&includeDocs=1")
+	if filtered.DOT == "" || filtered.Mermaid != "" {
+		t.Fatalf("expected only dot output, got %+v", filtered)
+	}
+	kinds := map[string]int{}
+	for _, n := range filtered.Nodes {
+		kinds[n.Kind]++
+	}
+	// A-1, B-2, GONE-9; two index docs; pkg/shared.go and pkg/a.go.
+	if kinds["ticket"] != 3 || kinds["doc"] != 2 || kinds["file"] != 2 {
+		t.Fatalf("unexpected filtered nodes: %v", kinds)
+	}
+	if k := edgeKinds(filtered); k["shared_files"] != 1 || k["contains"] != 2 || k["related_file"] != 3 {
+		t.Fatalf("unexpected filtered edge kinds: %v", k)
+	}
+}
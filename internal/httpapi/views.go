@@ -0,0 +1,34 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/go-go-golems/docmgr/internal/views"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+)
+
+type viewsResponse struct {
+	Path  string       `json:"path"`
+	Views []views.View `json:"views"`
+}
+
+func (s *Server) handleViews(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return NewHTTPError(http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed", nil)
+	}
+
+	var resp viewsResponse
+	if err := s.mgr.WithWorkspace(func(ws *workspace.Workspace) error {
+		root := ws.Context().Root
+		all, err := views.Load(root)
+		if err != nil {
+			return err
+		}
+		resp = viewsResponse{Path: views.Path(root), Views: all}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return writeJSON(w, http.StatusOK, resp)
+}
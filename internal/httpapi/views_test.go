@@ -0,0 +1,55 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestViewsEndpoint_ListsSavedViews(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join(t.TempDir(), "ttmp")
+	mustMkdirAll(t, root)
+	mustWriteFile(t, filepath.Join(root, "views.yaml"), `views:
+  - name: zz-drafts
+    status: draft
+  - name: my-active-api
+    description: Active API docs
+    topics: [api]
+    status: active
+    where: NOT owner:bob
+`)
+
+	mgr := NewIndexManager(root)
+	if _, err := mgr.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	s := NewServer(mgr, ServerOptions{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/views", nil)
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("views: expected %d, got %d (%s)", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var body struct {
+		Views []struct {
+			Name   string   `json:"name"`
+			Topics []string `json:"topics"`
+			Where  string   `json:"where"`
+		} `json:"views"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("views json: %v", err)
+	}
+	if len(body.Views) != 2 || body.Views[0].Name != "my-active-api" || body.Views[1].Name != "zz-drafts" {
+		t.Fatalf("views: unexpected results: %+v", body)
+	}
+	if len(body.Views[0].Topics) != 1 || body.Views[0].Topics[0] != "api" || body.Views[0].Where != "NOT owner:bob" {
+		t.Fatalf("views: unexpected view: %+v", body.Views[0])
+	}
+}
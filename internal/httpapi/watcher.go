@@ -0,0 +1,491 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	docignore "github.com/go-go-golems/docmgr/internal/ignore"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+)
+
+// WatchOptions configures the docs-root watcher started by `docmgr api serve`.
+type WatchOptions struct {
+	// Debounce is how long the tree must stay quiet before pending changes are
+	// applied, so editors that write a file in several steps cause one update.
+	Debounce time.Duration
+	// PollInterval is how often the docs root is rescanned when OS file
+	// notifications are unavailable (for example when the inotify watch limit
+	// is reached).
+	PollInterval time.Duration
+}
+
+const (
+	defaultWatchDebounce     = 300 * time.Millisecond
+	defaultWatchPollInterval = 30 * time.Second
+)
+
+// Watch modes reported in WatchStats.Mode.
+const (
+	WatchModeNotify = "notify"
+	WatchModePoll   = "poll"
+)
+
+// FileChangeOp is the kind of change observed for a file under the docs root.
+type FileChangeOp string
+
+const (
+	FileUpserted FileChangeOp = "upsert"
+	FileRemoved  FileChangeOp = "remove"
+)
+
+// FileChange is one pending per-file index update.
+type FileChange struct {
+	Path string
+	Op   FileChangeOp
+	// Created is set for upserts of files that were not known before.
+	Created bool
+}
+
+// WatchStats are the watcher counters exposed by /api/v1/workspace/status.
+type WatchStats struct {
+	Enabled       bool      `json:"enabled"`
+	Mode          string    `json:"mode,omitempty"`
+	Batches       int64     `json:"batches"`
+	DocsUpserted  int64     `json:"docsUpserted"`
+	DocsRemoved   int64     `json:"docsRemoved"`
+	FullRefreshes int64     `json:"fullRefreshes"`
+	Errors        int64     `json:"errors"`
+	LastAppliedAt time.Time `json:"lastAppliedAt"`
+	LastError     string    `json:"lastError,omitempty"`
+}
+
+type fileStamp struct {
+	modTime int64
+	size    int64
+}
+
+// Watch follows changes under the docs root and applies per-file
+// upserts/removes to the index until ctx is cancelled.
+//
+// It uses OS file notifications (fsnotify) on every directory the index
+// covers, honoring the same skip rules as indexing (.meta/, .docmgr/, _*/ and
+// .docmgrignore), and applies changes once they have been quiet for the
+// debounce window. When notifications cannot be set up it falls back to
+// rescanning the tree every PollInterval. A change to any .docmgrignore file,
+// or a notification queue overflow, triggers a full Refresh.
+func (m *IndexManager) Watch(ctx context.Context, opts WatchOptions) error {
+	if opts.Debounce <= 0 {
+		opts.Debounce = defaultWatchDebounce
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultWatchPollInterval
+	}
+
+	m.mu.Lock()
+	m.watch.Enabled = true
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.watch.Enabled = false
+		m.watch.Mode = ""
+		m.mu.Unlock()
+	}()
+
+	w, err := fsnotify.NewWatcher()
+	if err == nil {
+		err = m.watchNotify(ctx, w, opts)
+		_ = w.Close()
+		if err == nil {
+			return nil
+		}
+	}
+	m.recordWatchError(fmt.Errorf("file notifications unavailable, polling every %s: %w", opts.PollInterval, err))
+	return m.watchPoll(ctx, opts)
+}
+
+// watchNotify applies fsnotify events until ctx is cancelled. It returns an
+// error only when the watches cannot be set up.
+func (m *IndexManager) watchNotify(ctx context.Context, w *fsnotify.Watcher, opts WatchOptions) error {
+	known, dirs := m.scanDocsTree(ctx, "")
+	for _, dir := range dirs {
+		if err := w.Add(dir); err != nil {
+			return err
+		}
+	}
+	m.setWatchMode(WatchModeNotify)
+
+	batch := newWatchBatch()
+	timer := time.NewTimer(opts.Debounce)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			m.noteFSEvent(ctx, w, ev, known, batch)
+			if !batch.empty() {
+				timer.Reset(opts.Debounce)
+			}
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			// Dropped events (e.g. a queue overflow) leave the index behind.
+			m.recordWatchError(err)
+			batch.refresh = true
+			timer.Reset(opts.Debounce)
+		case <-timer.C:
+			refresh := batch.refresh
+			m.applyWatchBatch(ctx, batch)
+			if refresh {
+				// The ignore rules may have changed: resync files and watches.
+				var dirs []string
+				known, dirs = m.scanDocsTree(ctx, "")
+				for _, dir := range dirs {
+					_ = w.Add(dir)
+				}
+			}
+		}
+	}
+}
+
+// noteFSEvent turns one fsnotify event into pending changes, adding watches
+// for new directories and updating known (the files currently indexed).
+func (m *IndexManager) noteFSEvent(ctx context.Context, w *fsnotify.Watcher, ev fsnotify.Event, known map[string]fileStamp, batch *watchBatch) {
+	path := filepath.Clean(ev.Name)
+	if ev.Has(fsnotify.Remove) || ev.Has(fsnotify.Rename) {
+		if _, ok := known[path]; ok {
+			delete(known, path)
+			batch.add(FileChange{Path: path, Op: FileRemoved})
+		}
+		// A removed or renamed directory takes its files with it.
+		if ev.Has(fsnotify.Rename) {
+			_ = w.Remove(path)
+		}
+		prefix := path + string(filepath.Separator)
+		for p := range known {
+			if strings.HasPrefix(p, prefix) {
+				delete(known, p)
+				batch.add(FileChange{Path: p, Op: FileRemoved})
+			}
+		}
+		return
+	}
+	if !ev.Has(fsnotify.Create) && !ev.Has(fsnotify.Write) {
+		return
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	if fi.IsDir() {
+		if !ev.Has(fsnotify.Create) {
+			return
+		}
+		// Files may land in a new directory before its watch is added.
+		files, dirs := m.scanDocsTree(ctx, path)
+		for _, dir := range dirs {
+			_ = w.Add(dir)
+		}
+		for p, st := range files {
+			if old, ok := known[p]; !ok || old != st {
+				batch.add(FileChange{Path: p, Op: FileUpserted, Created: !ok})
+				known[p] = st
+			}
+		}
+		return
+	}
+	if !m.watchedFile(path) {
+		return
+	}
+	st := fileStamp{modTime: fi.ModTime().UnixNano(), size: fi.Size()}
+	_, ok := known[path]
+	known[path] = st
+	batch.add(FileChange{Path: path, Op: FileUpserted, Created: !ok})
+}
+
+// watchPoll rescans the docs root every PollInterval until ctx is cancelled.
+func (m *IndexManager) watchPoll(ctx context.Context, opts WatchOptions) error {
+	m.setWatchMode(WatchModePoll)
+	prev, _ := m.scanDocsTree(ctx, "")
+	batch := newWatchBatch()
+	var lastChange time.Time
+
+	ticker := time.NewTicker(min(opts.PollInterval, opts.Debounce))
+	defer ticker.Stop()
+	lastScan := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if time.Since(lastScan) >= opts.PollInterval {
+			cur, _ := m.scanDocsTree(ctx, "")
+			lastScan = time.Now()
+			changed := diffScans(prev, cur)
+			prev = cur
+			if len(changed) > 0 {
+				lastChange = time.Now()
+				for _, c := range changed {
+					batch.add(c)
+				}
+			}
+		}
+		if batch.empty() || time.Since(lastChange) < opts.Debounce {
+			continue
+		}
+		m.applyWatchBatch(ctx, batch)
+	}
+}
+
+func (m *IndexManager) setWatchMode(mode string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.watch.Mode = mode
+}
+
+// watchBatch collects changes until the debounce window has passed.
+type watchBatch struct {
+	pending map[string]FileChange
+	refresh bool
+}
+
+func newWatchBatch() *watchBatch {
+	return &watchBatch{pending: map[string]FileChange{}}
+}
+
+func (b *watchBatch) add(c FileChange) {
+	if filepath.Base(c.Path) == docignore.FileName {
+		b.refresh = true
+		return
+	}
+	// A file created and then edited within one debounce window is still new.
+	if old, ok := b.pending[c.Path]; ok && old.Created && c.Op == FileUpserted {
+		c.Created = true
+	}
+	b.pending[c.Path] = c
+}
+
+func (b *watchBatch) empty() bool {
+	return len(b.pending) == 0 && !b.refresh
+}
+
+// applyWatchBatch applies and clears the pending changes.
+func (m *IndexManager) applyWatchBatch(ctx context.Context, b *watchBatch) {
+	if b.refresh {
+		_, err := m.Refresh(ctx)
+		m.recordWatchBatch(0, 0, true, err)
+	} else if len(b.pending) > 0 {
+		changes := make([]FileChange, 0, len(b.pending))
+		for _, c := range b.pending {
+			changes = append(changes, c)
+		}
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+		_ = m.ApplyChanges(ctx, changes)
+	}
+	b.pending = map[string]FileChange{}
+	b.refresh = false
+}
+
+// ApplyChanges applies per-file updates to the current index under the write lock
+// and publishes the corresponding events once the lock is released.
+func (m *IndexManager) ApplyChanges(ctx context.Context, changes []FileChange) error {
+	m.mu.Lock()
+	ws := m.workspace
+	if ws == nil {
+		m.mu.Unlock()
+		return ErrIndexNotReady
+	}
+
+	var upserted, removed int64
+	var firstErr error
+	var events []Event
+	for _, c := range withControlDocSiblings(changes) {
+		var err error
+		var ticket string
+		switch c.Op {
+		case FileRemoved:
+			ticket = indexedDocTicket(ctx, ws, c.Path)
+			err = ws.RemoveDocument(ctx, c.Path)
+			removed++
+		case FileUpserted:
+			err = ws.UpsertDocument(ctx, c.Path)
+			ticket = indexedDocTicket(ctx, ws, c.Path)
+			upserted++
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		events = append(events, fileChangeEvents(ws, c, ticket)...)
+	}
+	if count, err := countAllDocs(ctx, ws); err == nil {
+		m.docsCount = count
+	}
+	m.indexedAt = time.Now()
+	m.mu.Unlock()
+
+	m.recordWatchBatch(upserted, removed, false, firstErr)
+	for _, ev := range events {
+		m.Publish(ev)
+	}
+	return firstErr
+}
+
+func (m *IndexManager) recordWatchBatch(upserted, removed int64, fullRefresh bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.watch.Batches++
+	m.watch.DocsUpserted += upserted
+	m.watch.DocsRemoved += removed
+	if fullRefresh {
+		m.watch.FullRefreshes++
+	}
+	m.watch.LastAppliedAt = time.Now()
+	if err != nil {
+		m.watch.Errors++
+		m.watch.LastError = err.Error()
+	}
+}
+
+func (m *IndexManager) recordWatchError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.watch.Errors++
+	m.watch.LastError = err.Error()
+}
+
+// WatchStats returns a copy of the watcher counters.
+func (m *IndexManager) WatchStats() WatchStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.watch
+}
+
+// scanDocsTree stats every indexable markdown file and every .docmgrignore
+// file under dir (the docs root when empty), and lists the directories the
+// walk descended into.
+func (m *IndexManager) scanDocsTree(ctx context.Context, dir string) (map[string]fileStamp, []string) {
+	m.mu.RLock()
+	ws := m.workspace
+	m.mu.RUnlock()
+	out := map[string]fileStamp{}
+	if ws == nil {
+		return out, nil
+	}
+	root := ws.Context().Root
+	if dir == "" {
+		dir = root
+	}
+	ignore := ws.IgnoreMatcher()
+
+	var dirs []string
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Files can disappear mid-walk; treat them as absent.
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() {
+			if path != root && (workspace.DefaultIngestSkipDir(path, d) || (ignore != nil && ignore.Ignore(path, true))) {
+				return fs.SkipDir
+			}
+			dirs = append(dirs, path)
+			return nil
+		}
+		name := d.Name()
+		if name != docignore.FileName {
+			if strings.ToLower(filepath.Ext(name)) != ".md" || (ignore != nil && ignore.Ignore(path, false)) {
+				return nil
+			}
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		out[path] = fileStamp{modTime: fi.ModTime().UnixNano(), size: fi.Size()}
+		return nil
+	})
+	return out, dirs
+}
+
+// watchedFile reports whether a file event at path concerns the index: a
+// .docmgrignore file, or a markdown file the ignore rules don't exclude.
+// Events only arrive for directories the walk descended into.
+func (m *IndexManager) watchedFile(path string) bool {
+	name := filepath.Base(path)
+	if name == docignore.FileName {
+		return true
+	}
+	if strings.ToLower(filepath.Ext(name)) != ".md" {
+		return false
+	}
+	m.mu.RLock()
+	ws := m.workspace
+	m.mu.RUnlock()
+	if ws == nil {
+		return false
+	}
+	ignore := ws.IgnoreMatcher()
+	return ignore == nil || !ignore.Ignore(path, false)
+}
+
+// diffScans returns per-file changes between two scans.
+func diffScans(prev, cur map[string]fileStamp) []FileChange {
+	var changes []FileChange
+	for path, st := range cur {
+		old, ok := prev[path]
+		if !ok || old != st {
+			changes = append(changes, FileChange{Path: path, Op: FileUpserted, Created: !ok})
+		}
+	}
+	for path := range prev {
+		if _, ok := cur[path]; !ok {
+			changes = append(changes, FileChange{Path: path, Op: FileRemoved})
+		}
+	}
+	return changes
+}
+
+// withControlDocSiblings re-indexes README.md/tasks.md/changelog.md next to an
+// index.md that appeared or disappeared, since their is_control_doc tag depends on it.
+func withControlDocSiblings(changes []FileChange) []FileChange {
+	seen := map[string]bool{}
+	for _, c := range changes {
+		seen[c.Path] = true
+	}
+	out := append([]FileChange(nil), changes...)
+	for _, c := range changes {
+		if !strings.EqualFold(filepath.Base(c.Path), "index.md") {
+			continue
+		}
+		for _, name := range []string{"README.md", "tasks.md", "changelog.md"} {
+			sibling := filepath.Join(filepath.Dir(c.Path), name)
+			if seen[sibling] {
+				continue
+			}
+			if _, err := os.Stat(sibling); err == nil {
+				seen[sibling] = true
+				out = append(out, FileChange{Path: sibling, Op: FileUpserted})
+			}
+		}
+	}
+	return out
+}
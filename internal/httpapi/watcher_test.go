@@ -0,0 +1,111 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIndexManagerWatch_AppliesFileChanges(t *testing.T) {
+	s := setupWriteTestServer(t)
+	mgr := s.mgr
+	root := mgr.Snapshot().Workspace.Context().Root
+	ticketDir := filepath.Join(root, "2026", "01", "03", "WRT-9--writes")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = mgr.Watch(ctx, WatchOptions{Debounce: 20 * time.Millisecond})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if cond() {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("timed out waiting for %s (stats=%+v)", what, mgr.WatchStats())
+	}
+	waitFor("watcher start", func() bool { return mgr.WatchStats().Mode == WatchModeNotify })
+
+	// A directory created after the watch started is picked up too.
+	designPath := filepath.Join(ticketDir, "research", "notes", "01-watched.md")
+	mustMkdirAll(t, filepath.Dir(designPath))
+	mustWriteFile(t, designPath, `---
+Title: Watched
+Ticket: WRT-9
+DocType: design-doc
+---
+`)
+	waitFor("upsert", func() bool { return mgr.Snapshot().DocsIndexed == 2 })
+
+	// Ignored paths are never indexed.
+	mustMkdirAll(t, filepath.Join(ticketDir, "_drafts"))
+	mustWriteFile(t, filepath.Join(ticketDir, "_drafts", "x.md"), "---\nTitle: X\n---\n")
+
+	if err := os.RemoveAll(filepath.Join(ticketDir, "research")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	waitFor("remove", func() bool { return mgr.WatchStats().DocsRemoved >= 1 })
+	if got := mgr.Snapshot().DocsIndexed; got != 1 {
+		t.Fatalf("expected 1 doc after removal, got %d", got)
+	}
+
+	rr := doJSON(t, s, http.MethodGet, "/api/v1/workspace/status", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status: %d %s", rr.Code, rr.Body.String())
+	}
+	var status struct {
+		Watch WatchStats `json:"watch"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &status); err != nil {
+		t.Fatalf("decode status: %v", err)
+	}
+	if !status.Watch.Enabled || status.Watch.DocsUpserted < 1 || status.Watch.DocsRemoved < 1 {
+		t.Fatalf("expected watch counters in status, got %+v", status.Watch)
+	}
+}
+
+func TestIndexManagerWatchPoll_AppliesFileChanges(t *testing.T) {
+	s := setupWriteTestServer(t)
+	mgr := s.mgr
+	root := mgr.Snapshot().Workspace.Context().Root
+	ticketDir := filepath.Join(root, "2026", "01", "03", "WRT-9--writes")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = mgr.watchPoll(ctx, WatchOptions{PollInterval: 20 * time.Millisecond, Debounce: 20 * time.Millisecond})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	deadline := time.Now().Add(5 * time.Second)
+	for mgr.WatchStats().Mode != WatchModePoll && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mustMkdirAll(t, filepath.Join(ticketDir, "design"))
+	mustWriteFile(t, filepath.Join(ticketDir, "design", "01-polled.md"), "---\nTitle: Polled\nTicket: WRT-9\nDocType: design-doc\n---\n")
+	for mgr.Snapshot().DocsIndexed != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for poll upsert (stats=%+v)", mgr.WatchStats())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -2,6 +2,7 @@ package httpapi
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"sort"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/docmgr/pkg/commands"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/fixes"
 	"github.com/go-go-golems/glazed/pkg/cmds/schema"
 	"github.com/go-go-golems/glazed/pkg/cmds/values"
 	"github.com/go-go-golems/glazed/pkg/types"
@@ -20,6 +22,8 @@ type doctorFinding struct {
 	Severity string `json:"severity"`
 	Message  string `json:"message"`
 	Path     string `json:"path"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
 }
 
 type doctorRollupItem struct {
@@ -128,6 +132,8 @@ func (s *Server) handleWorkspaceDoctor(w http.ResponseWriter, r *http.Request) e
 			Severity: rowString(row, "severity"),
 			Message:  rowString(row, "message"),
 			Path:     rowString(row, "path"),
+			Line:     rowInt(row, "line"),
+			Column:   rowInt(row, "column"),
 		}
 		resp.Findings = append(resp.Findings, f)
 		resp.Totals.Findings++
@@ -174,3 +180,135 @@ func rowString(row types.Row, key string) string {
 	}
 	return fmt.Sprint(v)
 }
+
+func rowInt(row types.Row, key string) int {
+	v, ok := row.Get(key)
+	if !ok {
+		return 0
+	}
+	n, _ := v.(int)
+	return n
+}
+
+type doctorFixRequest struct {
+	Ticket string   `json:"ticket"`
+	Only   []string `json:"only"`
+	DryRun bool     `json:"dryRun"`
+}
+
+type doctorFixChange struct {
+	Fixer   string `json:"fixer"`
+	Summary string `json:"summary"`
+}
+
+type doctorFixFile struct {
+	Ticket  string            `json:"ticket"`
+	Path    string            `json:"path"`
+	Fixers  []string          `json:"fixers"`
+	Changes []doctorFixChange `json:"changes"`
+	Diff    string            `json:"diff"`
+	Backup  bool              `json:"backup"`
+}
+
+type doctorFixSkip struct {
+	Ticket string `json:"ticket"`
+	Path   string `json:"path"`
+	Fixer  string `json:"fixer"`
+	Reason string `json:"reason"`
+}
+
+type doctorFixResponse struct {
+	Ticket  string          `json:"ticket"`
+	DryRun  bool            `json:"dryRun"`
+	Fixers  []string        `json:"fixers"`
+	Files   []doctorFixFile `json:"files"`
+	Skipped []doctorFixSkip `json:"skipped"`
+}
+
+// handleWorkspaceDoctorFix wraps 'docmgr doctor --fix': GET previews the
+// fixes as unified diffs (like --dry-run), POST {ticket, only, dryRun}
+// applies them and refreshes the in-memory index. Paths are relative to the
+// docs root; diff headers are relative to the repository root.
+func (s *Server) handleWorkspaceDoctorFix(w http.ResponseWriter, r *http.Request) error {
+	var req doctorFixRequest
+	if r.Method == http.MethodGet {
+		req.Ticket = r.URL.Query().Get("ticket")
+		req.Only = splitCSV(r.URL.Query().Get("only"))
+		req.DryRun = true
+	} else if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return NewHTTPError(http.StatusBadRequest, "invalid_argument", "invalid json body", nil)
+		}
+	} else {
+		return NewHTTPError(http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed", nil)
+	}
+	req.Ticket = strings.TrimSpace(req.Ticket)
+
+	available := commands.DoctorFixers(nil).Fixers()
+	if _, err := fixes.Select(available, req.Only); err != nil {
+		return NewHTTPError(http.StatusBadRequest, "invalid_argument", err.Error(), map[string]any{"field": "only"})
+	}
+
+	resp := doctorFixResponse{
+		DryRun:  req.DryRun,
+		Fixers:  fixes.Names(available),
+		Files:   []doctorFixFile{},
+		Skipped: []doctorFixSkip{},
+	}
+	var plan *fixes.Plan
+	if err := s.mgr.WithWorkspace(func(ws *workspace.Workspace) error {
+		if req.Ticket != "" {
+			res, err := resolveTicketOrHTTPError(r, ws, req.Ticket)
+			if err != nil {
+				return err
+			}
+			resp.Ticket = res.TicketID
+		}
+
+		var err error
+		plan, err = commands.PlanDoctorFixes(r.Context(), ws, commands.DoctorFixOptions{TicketID: resp.Ticket, Only: req.Only})
+		if err != nil {
+			return err
+		}
+
+		diffBase := ws.Context().RepoRoot
+		if diffBase == "" {
+			diffBase = ws.Context().Root
+		}
+		for _, f := range plan.Files {
+			file := doctorFixFile{
+				Ticket:  f.Ticket,
+				Path:    relPath(ws, f.Path),
+				Fixers:  f.Fixers(),
+				Changes: make([]doctorFixChange, 0, len(f.Changes)),
+				Diff:    f.Diff(diffBase),
+				Backup:  f.Backup,
+			}
+			for _, c := range f.Changes {
+				file.Changes = append(file.Changes, doctorFixChange{Fixer: c.Fixer, Summary: c.Summary})
+			}
+			resp.Files = append(resp.Files, file)
+		}
+		for _, sk := range plan.Skipped {
+			resp.Skipped = append(resp.Skipped, doctorFixSkip{Ticket: sk.Ticket, Path: relPath(ws, sk.Path), Fixer: sk.Fixer, Reason: sk.Reason})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Write outside the workspace lock; the plan refuses files that changed
+	// on disk since it was built.
+	if !req.DryRun && len(plan.Files) > 0 {
+		if err := commands.ApplyDoctorFixes(r.Context(), plan); err != nil {
+			return err
+		}
+		if _, err := s.mgr.Refresh(r.Context()); err != nil {
+			return err
+		}
+		for _, f := range resp.Files {
+			s.mgr.Publish(Event{Type: EventDocUpdated, Ticket: f.Ticket, Path: f.Path, Data: map[string]any{"fixers": f.Fixers}})
+		}
+	}
+	return writeJSON(w, http.StatusOK, resp)
+}
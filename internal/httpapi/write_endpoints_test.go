@@ -121,6 +121,46 @@ func TestDocsMeta_UnknownFieldIs400(t *testing.T) {
 	}
 }
 
+func TestDocsMeta_EnforcesWorkflow(t *testing.T) {
+	s := setupWriteTestServer(t)
+	mustWriteFile(t, ".ttmp.yaml", `root: ttmp
+workflow:
+  transitions:
+    active: [review]
+    review: [complete, active]
+  require:
+    review: {minOwners: 1}
+`)
+
+	docPath := "2026/01/03/WRT-9--writes/index.md"
+	rr := doJSON(t, s, http.MethodPost, "/api/v1/docs/meta", map[string]any{
+		"path":  docPath,
+		"field": "Status",
+		"value": "complete",
+	})
+	if rr.Code != http.StatusConflict || !strings.Contains(rr.Body.String(), "transition_not_allowed") {
+		t.Fatalf("expected %d transition_not_allowed, got %d (%s)", http.StatusConflict, rr.Code, rr.Body.String())
+	}
+
+	rr = doJSON(t, s, http.MethodPost, "/api/v1/docs/meta", map[string]any{
+		"path":  docPath,
+		"field": "Status",
+		"value": "review",
+	})
+	if rr.Code != http.StatusConflict || !strings.Contains(rr.Body.String(), "owner") {
+		t.Fatalf("expected the minOwners precondition to fail, got %d (%s)", rr.Code, rr.Body.String())
+	}
+
+	rr = doJSON(t, s, http.MethodPost, "/api/v1/docs/meta", map[string]any{"path": docPath, "field": "Owners", "value": "alice"})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("set owners: expected %d, got %d (%s)", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	rr = doJSON(t, s, http.MethodPost, "/api/v1/docs/meta", map[string]any{"path": docPath, "field": "Status", "value": "review"})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("transition to review: expected %d, got %d (%s)", http.StatusOK, rr.Code, rr.Body.String())
+	}
+}
+
 func TestDocsMeta_ForbidsTraversal(t *testing.T) {
 	s := setupWriteTestServer(t)
 
@@ -228,6 +268,7 @@ func TestTicketsChangelog_AppendAndParse(t *testing.T) {
 		"ticket": "WRT-9",
 		"title":  "Testing",
 		"entry":  "Implemented the write endpoints.",
+		"kind":   "added",
 	})
 	if rr2.Code != http.StatusOK {
 		t.Fatalf("changelog post: expected %d, got %d (%s)", http.StatusOK, rr2.Code, rr2.Body.String())
@@ -242,6 +283,7 @@ func TestTicketsChangelog_AppendAndParse(t *testing.T) {
 		Exists  bool `json:"exists"`
 		Entries []struct {
 			Date  string `json:"date"`
+			Kind  string `json:"kind"`
 			Title string `json:"title"`
 			Body  string `json:"body"`
 		} `json:"entries"`
@@ -253,7 +295,7 @@ func TestTicketsChangelog_AppendAndParse(t *testing.T) {
 		t.Fatalf("expected 1 entry, got %s", rr3.Body.String())
 	}
 	e := got.Entries[0]
-	if e.Title != "Testing" || e.Date == "" || !strings.Contains(e.Body, "Implemented the write endpoints.") {
+	if e.Title != "Testing" || e.Kind != "added" || e.Date == "" || !strings.Contains(e.Body, "Implemented the write endpoints.") {
 		t.Fatalf("unexpected entry: %+v", e)
 	}
 
@@ -265,6 +307,16 @@ func TestTicketsChangelog_AppendAndParse(t *testing.T) {
 	if rr4.Code != http.StatusBadRequest {
 		t.Fatalf("expected %d for empty entry, got %d (%s)", http.StatusBadRequest, rr4.Code, rr4.Body.String())
 	}
+
+	// Unknown kind rejected.
+	rr5 := doJSON(t, s, http.MethodPost, "/api/v1/tickets/changelog", map[string]any{
+		"ticket": "WRT-9",
+		"entry":  "Something.",
+		"kind":   "misc",
+	})
+	if rr5.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d for unknown kind, got %d (%s)", http.StatusBadRequest, rr5.Code, rr5.Body.String())
+	}
 }
 
 func TestWorkspaceDoctor_ReturnsFindingsAndRollup(t *testing.T) {
@@ -314,3 +366,93 @@ func TestWorkspaceDoctor_ReturnsFindingsAndRollup(t *testing.T) {
 		t.Fatalf("expected %d, got %d (%s)", http.StatusNotFound, rr2.Code, rr2.Body.String())
 	}
 }
+
+func TestWorkspaceDoctor_FindingsCarryPositions(t *testing.T) {
+	s := setupWriteTestServer(t)
+	mustWriteFile(t, filepath.Join("ttmp", "2026", "01", "03", "WRT-9--writes", "index.md"), `---
+Title: Write Endpoints
+Ticket: WRT-9
+Status: active
+DocType: index
+Topics: [docmgr]
+RelatedFiles:
+    - Path: repo://src/gone.go
+      Note: removed
+LastUpdated: 2026-01-05T00:00:00Z
+---
+
+# Write Endpoints
+`)
+	if _, err := s.mgr.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	rr := doJSON(t, s, http.MethodGet, "/api/v1/workspace/doctor?ticket=WRT-9", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("doctor: expected %d, got %d (%s)", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var got struct {
+		Findings []doctorFinding `json:"findings"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal doctor: %v", err)
+	}
+	for _, f := range got.Findings {
+		if f.Issue == "missing_related_file" {
+			if f.Line != 8 || f.Column != 7 {
+				t.Fatalf("missing_related_file at %d:%d, want 8:7", f.Line, f.Column)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected a missing_related_file finding, got %s", rr.Body.String())
+}
+
+func TestWorkspaceDoctorFix_PreviewThenApply(t *testing.T) {
+	s := setupWriteTestServer(t)
+	mustWriteFile(t, filepath.Join("ttmp", "vocabulary.yaml"), "topics:\n    - slug: docmgr\n      description: docmgr\n")
+	index := filepath.Join("ttmp", "2026", "01", "03", "WRT-9--writes", "index.md")
+	mustWriteFile(t, index, `---
+Title: Write Endpoints
+Ticket: WRT-9
+Status: active
+DocType: index
+Topics: [DocMgr]
+LastUpdated: 2026-01-05T00:00:00Z
+---
+
+# Write Endpoints
+`)
+
+	rr := doJSON(t, s, http.MethodGet, "/api/v1/workspace/doctor/fix?ticket=WRT-9&only=unknown_topic", nil)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("preview status=%d body=%s", rr.Code, rr.Body.String())
+	}
+	var preview doctorFixResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &preview); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !preview.DryRun || len(preview.Files) != 1 {
+		t.Fatalf("unexpected preview: %+v", preview)
+	}
+	f := preview.Files[0]
+	if f.Path != "2026/01/03/WRT-9--writes/index.md" || !strings.Contains(f.Diff, "+Topics:\n+    - docmgr\n") {
+		t.Fatalf("unexpected preview file: %+v", f)
+	}
+	if b, _ := os.ReadFile(index); !strings.Contains(string(b), "[DocMgr]") {
+		t.Fatalf("preview wrote the doc:\n%s", b)
+	}
+
+	rr = doJSON(t, s, http.MethodPost, "/api/v1/workspace/doctor/fix", map[string]any{"ticket": "WRT-9", "only": []string{"unknown_topic"}})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("apply status=%d body=%s", rr.Code, rr.Body.String())
+	}
+	if b, _ := os.ReadFile(index); strings.Contains(string(b), "DocMgr") {
+		t.Fatalf("apply did not fix the topic:\n%s", b)
+	}
+
+	rr = doJSON(t, s, http.MethodPost, "/api/v1/workspace/doctor/fix", map[string]any{"only": []string{"nope"}})
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "unknown fixer") {
+		t.Fatalf("expected 400 for unknown fixer, got %d %s", rr.Code, rr.Body.String())
+	}
+}
@@ -0,0 +1,550 @@
+// Package journal records the file operations of multi-file docmgr commands
+// (ticket rename/move, doc move, renumber, layout-fix) so a failed run can be
+// rolled back and a completed one undone.
+//
+// Each operation lives in <docs-root>/.docmgr/journal/<op-id>/: op.json lists
+// the steps in order and blobs/ keeps the previous content of every file that
+// was overwritten or removed. Steps are recorded before they are performed
+// (write-ahead), so an operation interrupted mid-way stays "pending" and can
+// still be reverted with Undo.
+package journal
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultKeep is how many operations Commit keeps in the journal.
+const DefaultKeep = 50
+
+const (
+	stateDirName = ".docmgr" // workspace.StateDirName
+	journalDir   = "journal"
+	opFileName   = "op.json"
+	blobsDirName = "blobs"
+)
+
+// Status is the lifecycle state of an operation.
+type Status string
+
+const (
+	// StatusPending means the operation started but neither committed nor
+	// rolled back (e.g. the process was killed). Undo reverts what was done.
+	StatusPending    Status = "pending"
+	StatusCommitted  Status = "committed"
+	StatusRolledBack Status = "rolled-back"
+	StatusUndone     Status = "undone"
+)
+
+// Action is the kind of a recorded step.
+type Action string
+
+const (
+	ActionWrite  Action = "write"  // file created or overwritten
+	ActionRename Action = "rename" // file or directory moved
+	ActionRemove Action = "remove" // file deleted
+	ActionMkdir  Action = "mkdir"  // directory created
+)
+
+// Entry is one step of an operation. Paths are slash-separated and relative to
+// the docs root (absolute when outside of it).
+type Entry struct {
+	Action Action `json:"action"`
+	Path   string `json:"path"`
+	To     string `json:"to,omitempty"` // rename destination
+	// Backup names the blob holding the file's previous content; empty when the
+	// file did not exist before a write.
+	Backup string      `json:"backup,omitempty"`
+	Mode   fs.FileMode `json:"mode,omitempty"`
+	// SHA256 is the content hash after a write, used to detect later edits.
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// Op is a journaled operation.
+type Op struct {
+	ID         string    `json:"id"`
+	Command    string    `json:"command"`
+	Summary    string    `json:"summary"`
+	Status     Status    `json:"status"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+	UndoneAt   time.Time `json:"undoneAt,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Entries    []Entry   `json:"entries"`
+}
+
+// Dir returns the journal directory for a docs root.
+func Dir(root string) string {
+	return filepath.Join(root, stateDirName, journalDir)
+}
+
+// Tx records the steps of one operation. Use it like a database transaction:
+//
+//	tx, err := journal.Begin(root, "doc move", summary)
+//	...
+//	defer func() { _ = tx.Rollback() }()
+//	... tx.Rename / tx.WriteFile / tx.Write ...
+//	return tx.Commit()
+type Tx struct {
+	root string
+	dir  string
+	op   Op
+	done bool
+}
+
+// Begin starts a new pending operation under root.
+func Begin(root, command, summary string) (*Tx, error) {
+	now := time.Now()
+	id, err := newOpID(now)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(Dir(root), id)
+	if err := os.MkdirAll(filepath.Join(dir, blobsDirName), 0o755); err != nil {
+		return nil, errors.Wrap(err, "create journal dir")
+	}
+	t := &Tx{
+		root: root,
+		dir:  dir,
+		op: Op{
+			ID:        id,
+			Command:   command,
+			Summary:   summary,
+			Status:    StatusPending,
+			StartedAt: now,
+			Entries:   []Entry{},
+		},
+	}
+	if err := t.save(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ID returns the operation ID.
+func (t *Tx) ID() string { return t.op.ID }
+
+// Write records a change to path performed by write (which may create the
+// file or replace it, e.g. through an atomic temp-file rename).
+func (t *Tx) Write(path string, write func() error) error {
+	e := Entry{Action: ActionWrite, Path: t.rel(path)}
+	if st, err := os.Stat(path); err == nil {
+		if st.IsDir() {
+			return errors.Errorf("cannot journal write to directory %s", path)
+		}
+		backup, err := t.backup(path)
+		if err != nil {
+			return err
+		}
+		e.Backup = backup
+		e.Mode = st.Mode().Perm()
+	} else if !os.IsNotExist(err) {
+		return errors.Wrapf(err, "stat %s", path)
+	}
+	if err := t.record(e); err != nil {
+		return err
+	}
+	if err := write(); err != nil {
+		return err
+	}
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	t.op.Entries[len(t.op.Entries)-1].SHA256 = sum
+	return t.save()
+}
+
+// WriteFile writes data to path, recording the previous content.
+func (t *Tx) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	return t.Write(path, func() error {
+		return os.WriteFile(path, data, perm)
+	})
+}
+
+// Rename moves a file or directory.
+func (t *Tx) Rename(from, to string) error {
+	if err := t.record(Entry{Action: ActionRename, Path: t.rel(from), To: t.rel(to)}); err != nil {
+		return err
+	}
+	if err := os.Rename(from, to); err != nil {
+		return t.unrecord(err)
+	}
+	return nil
+}
+
+// Remove deletes a file, keeping its content for undo.
+func (t *Tx) Remove(path string) error {
+	st, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrapf(err, "stat %s", path)
+	}
+	backup, err := t.backup(path)
+	if err != nil {
+		return err
+	}
+	if err := t.record(Entry{Action: ActionRemove, Path: t.rel(path), Backup: backup, Mode: st.Mode().Perm()}); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return t.unrecord(err)
+	}
+	return nil
+}
+
+// MkdirAll creates dir and missing parents, recording each created directory.
+func (t *Tx) MkdirAll(dir string, perm fs.FileMode) error {
+	var missing []string
+	for d := filepath.Clean(dir); ; d = filepath.Dir(d) {
+		if _, err := os.Stat(d); err == nil {
+			break
+		}
+		missing = append(missing, d)
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+	for i := len(missing) - 1; i >= 0; i-- {
+		if err := t.record(Entry{Action: ActionMkdir, Path: t.rel(missing[i])}); err != nil {
+			return err
+		}
+		if err := os.Mkdir(missing[i], perm); err != nil && !os.IsExist(err) {
+			return t.unrecord(errors.Wrapf(err, "mkdir %s", missing[i]))
+		}
+	}
+	return nil
+}
+
+// Empty reports whether no step has been recorded.
+func (t *Tx) Empty() bool { return len(t.op.Entries) == 0 }
+
+// Commit marks the operation as completed and prunes old operations. An
+// operation without steps is dropped from the journal.
+func (t *Tx) Commit() error {
+	if t.done {
+		return nil
+	}
+	t.done = true
+	if t.Empty() {
+		return errors.Wrap(os.RemoveAll(t.dir), "remove empty journal entry")
+	}
+	t.op.Status = StatusCommitted
+	t.op.FinishedAt = time.Now()
+	if err := t.save(); err != nil {
+		return err
+	}
+	// Pruning is housekeeping; a failure must not fail the operation.
+	_ = Prune(t.root, DefaultKeep)
+	return nil
+}
+
+// Rollback reverts every recorded step in reverse order. It is a no-op after
+// Commit, so it can be deferred unconditionally.
+func (t *Tx) Rollback() error {
+	if t.done {
+		return nil
+	}
+	t.done = true
+	if err := revert(t.root, t.dir, t.op.Entries); err != nil {
+		t.op.Error = err.Error()
+		_ = t.save()
+		return errors.Wrapf(err, "rollback of %s failed; run 'docmgr undo %s' to retry", t.op.ID, t.op.ID)
+	}
+	t.op.Status = StatusRolledBack
+	t.op.FinishedAt = time.Now()
+	return t.save()
+}
+
+func (t *Tx) record(e Entry) error {
+	t.op.Entries = append(t.op.Entries, e)
+	return t.save()
+}
+
+// unrecord drops the last entry after its step failed without touching the
+// filesystem, and returns err.
+func (t *Tx) unrecord(err error) error {
+	t.op.Entries = t.op.Entries[:len(t.op.Entries)-1]
+	_ = t.save()
+	return err
+}
+
+func (t *Tx) backup(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "read %s for journal", path)
+	}
+	name := fmt.Sprintf("%04d", len(t.op.Entries))
+	if err := os.WriteFile(filepath.Join(t.dir, blobsDirName, name), data, 0o644); err != nil {
+		return "", errors.Wrap(err, "write journal backup")
+	}
+	return name, nil
+}
+
+func (t *Tx) rel(path string) string {
+	return relPath(t.root, path)
+}
+
+func (t *Tx) save() error {
+	return writeOp(t.dir, &t.op)
+}
+
+// List returns the journaled operations of root, newest first.
+func List(root string) ([]Op, error) {
+	entries, err := os.ReadDir(Dir(root))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "read journal dir")
+	}
+	var ops []Op
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		op, err := readOp(filepath.Join(Dir(root), e.Name()))
+		if err != nil {
+			continue
+		}
+		ops = append(ops, *op)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].ID > ops[j].ID })
+	return ops, nil
+}
+
+// Load reads one operation by ID.
+func Load(root, id string) (*Op, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return nil, errors.Errorf("invalid operation id %q", id)
+	}
+	op, err := readOp(filepath.Join(Dir(root), id))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, errors.Errorf("unknown operation %q", id)
+		}
+		return nil, err
+	}
+	return op, nil
+}
+
+// Undo reverts an operation. With an empty id it picks the most recent
+// committed or pending operation. Unless force is set, Undo refuses when a file
+// the operation wrote was changed afterwards; the conflicting paths are listed
+// in the error.
+func Undo(root, id string, force bool) (*Op, error) {
+	var op *Op
+	if id == "" {
+		ops, err := List(root)
+		if err != nil {
+			return nil, err
+		}
+		for i := range ops {
+			if ops[i].Status == StatusCommitted || ops[i].Status == StatusPending {
+				op = &ops[i]
+				break
+			}
+		}
+		if op == nil {
+			return nil, errors.New("nothing to undo")
+		}
+	} else {
+		var err error
+		if op, err = Load(root, id); err != nil {
+			return nil, err
+		}
+	}
+	switch op.Status {
+	case StatusCommitted, StatusPending:
+	case StatusUndone:
+		return nil, errors.Errorf("operation %s was already undone", op.ID)
+	case StatusRolledBack:
+		return nil, errors.Errorf("operation %s was rolled back when it failed; nothing to undo", op.ID)
+	}
+
+	if !force {
+		if conflicts := Conflicts(root, op); len(conflicts) > 0 {
+			return nil, errors.Errorf("files changed since operation %s: %s (use --force to undo anyway)", op.ID, strings.Join(conflicts, ", "))
+		}
+	}
+	dir := filepath.Join(Dir(root), op.ID)
+	if err := revert(root, dir, op.Entries); err != nil {
+		return nil, errors.Wrapf(err, "undo %s", op.ID)
+	}
+	op.Status = StatusUndone
+	op.UndoneAt = time.Now()
+	if err := writeOp(dir, op); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// Conflicts lists files (docs-root relative) written by op whose current
+// content no longer matches what op left behind, following later renames
+// within the same operation.
+func Conflicts(root string, op *Op) []string {
+	expect := map[string]string{}
+	for i, e := range op.Entries {
+		switch e.Action {
+		case ActionWrite:
+			if e.SHA256 != "" {
+				expect[followRenames(op.Entries[i+1:], e.Path)] = e.SHA256
+			}
+		case ActionRemove:
+			delete(expect, followRenames(op.Entries[i+1:], e.Path))
+		case ActionRename, ActionMkdir:
+		}
+	}
+	var out []string
+	for p, sum := range expect {
+		cur, err := fileSHA256(absPath(root, p))
+		if err != nil || cur != sum {
+			out = append(out, p)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Prune removes all but the keep most recent operations.
+func Prune(root string, keep int) error {
+	ops, err := List(root)
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(ops); i++ {
+		if err := os.RemoveAll(filepath.Join(Dir(root), ops[i].ID)); err != nil {
+			return errors.Wrap(err, "prune journal")
+		}
+	}
+	return nil
+}
+
+// revert undoes entries in reverse order. Each step tolerates not having been
+// performed, so pending operations interrupted mid-step revert cleanly.
+func revert(root, dir string, entries []Entry) error {
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		p := absPath(root, e.Path)
+		switch e.Action {
+		case ActionWrite, ActionRemove:
+			if e.Backup == "" {
+				if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+					return errors.Wrapf(err, "remove %s", e.Path)
+				}
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, blobsDirName, e.Backup))
+			if err != nil {
+				return errors.Wrapf(err, "read backup of %s", e.Path)
+			}
+			mode := e.Mode
+			if mode == 0 {
+				mode = 0o644
+			}
+			if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+				return errors.Wrapf(err, "restore %s", e.Path)
+			}
+			if err := os.WriteFile(p, data, mode); err != nil {
+				return errors.Wrapf(err, "restore %s", e.Path)
+			}
+		case ActionRename:
+			to := absPath(root, e.To)
+			if _, err := os.Stat(to); os.IsNotExist(err) {
+				continue
+			}
+			if _, err := os.Stat(p); err == nil {
+				return errors.Errorf("cannot move %s back: %s exists", e.To, e.Path)
+			}
+			if err := os.Rename(to, p); err != nil {
+				return errors.Wrapf(err, "move %s back to %s", e.To, e.Path)
+			}
+		case ActionMkdir:
+			// os.Remove only removes directories that are empty again.
+			_ = os.Remove(p)
+		}
+	}
+	return nil
+}
+
+// followRenames maps path through the renames in later entries, including
+// renames of a parent directory.
+func followRenames(later []Entry, path string) string {
+	for _, e := range later {
+		if e.Action != ActionRename {
+			continue
+		}
+		if path == e.Path {
+			path = e.To
+		} else if strings.HasPrefix(path, e.Path+"/") {
+			path = e.To + strings.TrimPrefix(path, e.Path)
+		}
+	}
+	return path
+}
+
+func relPath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+func absPath(root, p string) string {
+	if filepath.IsAbs(filepath.FromSlash(p)) {
+		return filepath.FromSlash(p)
+	}
+	return filepath.Join(root, filepath.FromSlash(p))
+}
+
+func writeOp(dir string, op *Op) error {
+	b, err := json.MarshalIndent(op, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encode journal")
+	}
+	tmp := filepath.Join(dir, opFileName+".tmp")
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return errors.Wrap(err, "write journal")
+	}
+	return errors.Wrap(os.Rename(tmp, filepath.Join(dir, opFileName)), "write journal")
+}
+
+func readOp(dir string) (*Op, error) {
+	b, err := os.ReadFile(filepath.Join(dir, opFileName))
+	if err != nil {
+		return nil, err
+	}
+	var op Op
+	if err := json.Unmarshal(b, &op); err != nil {
+		return nil, errors.Wrapf(err, "parse %s", filepath.Join(dir, opFileName))
+	}
+	return &op, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// newOpID returns a sortable ID like 20261018T153012.042Z-3f9a.
+func newOpID(now time.Time) (string, error) {
+	var b [2]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", errors.Wrap(err, "generate operation id")
+	}
+	return now.UTC().Format("20060102T150405.000Z") + "-" + hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,167 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func mustWrite(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func mustRead(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(b)
+}
+
+func TestRollbackRestoresPartialOperation(t *testing.T) {
+	root := t.TempDir()
+	doc := filepath.Join(root, "T-1--demo", "design", "01-a.md")
+	mustWrite(t, doc, "original")
+
+	tx, err := Begin(root, "test", "partial")
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if err := tx.WriteFile(doc, []byte("changed"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	dest := filepath.Join(root, "T-1--demo", "reference", "01-a.md")
+	if err := tx.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := tx.Rename(doc, dest); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	// A failing step is not recorded.
+	if err := tx.Rename(filepath.Join(root, "missing.md"), filepath.Join(root, "x.md")); err == nil {
+		t.Fatalf("expected rename of missing file to fail")
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+
+	if got := mustRead(t, doc); got != "original" {
+		t.Fatalf("expected original content, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Dir(dest)); !os.IsNotExist(err) {
+		t.Fatalf("expected created directory to be removed, stat err=%v", err)
+	}
+	op, err := Load(root, tx.ID())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if op.Status != StatusRolledBack || len(op.Entries) != 3 {
+		t.Fatalf("unexpected op after rollback: %+v", op)
+	}
+	if _, err := Undo(root, "", false); err == nil || !strings.Contains(err.Error(), "nothing to undo") {
+		t.Fatalf("expected nothing to undo, got %v", err)
+	}
+}
+
+func TestUndoRevertsLastOperationAndDetectsConflicts(t *testing.T) {
+	root := t.TempDir()
+	oldDir := filepath.Join(root, "T-1--demo")
+	newDir := filepath.Join(root, "T-2--demo")
+	index := filepath.Join(oldDir, "index.md")
+	mustWrite(t, index, "Ticket: T-1")
+	stray := filepath.Join(oldDir, "stray.md")
+	mustWrite(t, stray, "stray")
+
+	// Rename-style operation: rewrite inside the old dir, then move the dir.
+	tx, err := Begin(root, "ticket rename", "T-1 -> T-2")
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if err := tx.WriteFile(index, []byte("Ticket: T-2"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := tx.Remove(stray); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := tx.Rename(oldDir, newDir); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	// An edit after the operation blocks undo unless forced.
+	mustWrite(t, filepath.Join(newDir, "index.md"), "Ticket: T-2\nedited")
+	if _, err := Undo(root, "", false); err == nil || !strings.Contains(err.Error(), "T-2--demo/index.md") {
+		t.Fatalf("expected conflict on index.md, got %v", err)
+	}
+	mustWrite(t, filepath.Join(newDir, "index.md"), "Ticket: T-2")
+
+	op, err := Undo(root, "", false)
+	if err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if op.ID != tx.ID() || op.Status != StatusUndone {
+		t.Fatalf("unexpected undone op: %+v", op)
+	}
+	if got := mustRead(t, index); got != "Ticket: T-1" {
+		t.Fatalf("expected index restored, got %q", got)
+	}
+	if got := mustRead(t, stray); got != "stray" {
+		t.Fatalf("expected removed file restored, got %q", got)
+	}
+	if _, err := os.Stat(newDir); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be moved back", newDir)
+	}
+	if _, err := Undo(root, tx.ID(), false); err == nil || !strings.Contains(err.Error(), "already undone") {
+		t.Fatalf("expected already undone error, got %v", err)
+	}
+}
+
+func TestCommitDropsEmptyOperationsAndPrunes(t *testing.T) {
+	root := t.TempDir()
+	tx, err := Begin(root, "test", "noop")
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if _, err := Load(root, tx.ID()); err == nil {
+		t.Fatalf("expected empty operation to be dropped")
+	}
+
+	for i := 0; i < 3; i++ {
+		tx, err := Begin(root, "test", "write")
+		if err != nil {
+			t.Fatalf("Begin: %v", err)
+		}
+		if err := tx.WriteFile(filepath.Join(root, "f.md"), []byte{byte('a' + i)}, 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("Commit: %v", err)
+		}
+	}
+	if err := Prune(root, 2); err != nil {
+		t.Fatalf("Prune: %v", err)
+	}
+	ops, err := List(root)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(ops) != 2 || ops[0].ID < ops[1].ID {
+		t.Fatalf("expected 2 ops newest first, got %+v", ops)
+	}
+	if _, err := Load(root, "../etc"); err == nil {
+		t.Fatalf("expected invalid id error")
+	}
+}
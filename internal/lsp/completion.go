@@ -0,0 +1,236 @@
+package lsp
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/paths"
+	"github.com/go-go-golems/docmgr/pkg/commands"
+	"github.com/go-go-golems/docmgr/pkg/models"
+)
+
+// triggerSuggest asks the client to reopen completion after inserting an
+// anchor or directory, so paths can be completed segment by segment.
+var triggerSuggest = &Command{Title: "Complete path", Command: "editor.action.triggerSuggest"}
+
+// completionAnchors are offered for RelatedFiles paths; they are the anchors
+// docmgr itself writes (see paths.Resolver.AnchoredFor).
+var completionAnchors = []paths.Scheme{paths.SchemeRepo, paths.SchemeWs, paths.SchemeDocs}
+
+// valueContext describes the frontmatter value under the cursor.
+type valueContext struct {
+	field string // top-level key, e.g. "Topics"
+	// prefix is the value text typed before the cursor; it starts at byte
+	// offset start of the line.
+	prefix string
+	start  int
+	// relatedPath is set for RelatedFiles paths ("- Path: ..." or legacy "- ...").
+	relatedPath bool
+}
+
+// valueAt inspects the line under the cursor. ok is false outside the
+// frontmatter or before a key's colon.
+func valueAt(lines []string, pos Position) (valueContext, bool) {
+	key, keyLine, ok := fieldAt(lines, pos.Line)
+	if !ok {
+		return valueContext{}, false
+	}
+	line := lines[pos.Line]
+	cursor := utf16ToByte(line, pos.Character)
+	before := line[:cursor]
+
+	vc := valueContext{field: key}
+	if pos.Line == keyLine {
+		colon := strings.Index(before, ":")
+		if colon < 0 {
+			return valueContext{}, false
+		}
+		// Inline values and flow lists: "DocType: de", "Topics: [api, ba".
+		vc.start = colon + 1
+		if i := strings.LastIndexAny(before, "[,"); i >= vc.start {
+			vc.start = i + 1
+		}
+	} else {
+		trimmed := strings.TrimLeft(before, " \t")
+		vc.start = len(before) - len(trimmed)
+		if strings.HasPrefix(trimmed, "-") {
+			vc.start++
+		}
+		if strings.EqualFold(key, "RelatedFiles") {
+			rest := strings.TrimLeft(before[vc.start:], " \t")
+			if m := mappingKeyRe.FindStringSubmatch(rest); m != nil {
+				if !strings.EqualFold(m[1], "Path") {
+					return valueContext{}, false
+				}
+				vc.start = len(before) - len(rest) + len(m[1]) + 1
+			}
+			vc.relatedPath = true
+		}
+	}
+	for vc.start < len(before) && strings.ContainsRune(" \t\"'", rune(before[vc.start])) {
+		vc.start++
+	}
+	vc.prefix = before[vc.start:]
+	return vc, true
+}
+
+func (s *Server) completion(p TextDocumentPositionParams) (any, error) {
+	text, ok := s.text(p.TextDocument.URI)
+	if !ok {
+		return nil, nil
+	}
+	lines := splitLines(text)
+	if p.Position.Line >= len(lines) {
+		return nil, nil
+	}
+	vc, ok := valueAt(lines, p.Position)
+	if !ok {
+		return nil, nil
+	}
+	line := lines[p.Position.Line]
+	replace := Range{
+		Start: Position{Line: p.Position.Line, Character: byteToUTF16(line, vc.start)},
+		End:   p.Position,
+	}
+
+	if vc.relatedPath {
+		return CompletionList{Items: s.pathCompletions(vc.prefix, replace)}, nil
+	}
+	items, err := vocabularyItems(vc.field)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]CompletionItem, 0, len(items))
+	for _, item := range items {
+		out = append(out, CompletionItem{
+			Label:    item.Slug,
+			Kind:     CompletionItemKindEnumMember,
+			Detail:   item.Description,
+			TextEdit: &TextEdit{Range: replace, NewText: item.Slug},
+		})
+	}
+	return CompletionList{Items: out}, nil
+}
+
+// vocabularyItems returns the vocabulary category backing a frontmatter
+// field (nil for fields without one). The vocabulary is re-read per request
+// so `docmgr vocab add` shows up without restarting the server.
+func vocabularyItems(field string) ([]models.VocabItem, error) {
+	if !strings.EqualFold(field, "Topics") && !strings.EqualFold(field, "DocType") &&
+		!strings.EqualFold(field, "Status") && !strings.EqualFold(field, "Intent") {
+		return nil, nil
+	}
+	vocab, err := commands.LoadVocabulary()
+	if err != nil || vocab == nil {
+		return nil, err
+	}
+	if strings.EqualFold(field, "Topics") {
+		return vocab.Topics, nil
+	}
+	if strings.EqualFold(field, "DocType") {
+		return vocab.DocTypes, nil
+	}
+	if strings.EqualFold(field, "Status") {
+		return vocab.Status, nil
+	}
+	return vocab.Intent, nil
+}
+
+// pathCompletions completes anchored RelatedFiles paths: the anchor schemes
+// first, then one directory level at a time below the anchor's base.
+func (s *Server) pathCompletions(prefix string, replace Range) []CompletionItem {
+	scheme, rest, anchored := strings.Cut(prefix, "://")
+	if !anchored {
+		var out []CompletionItem
+		for _, a := range completionAnchors {
+			if a == paths.SchemeWs && (s.ws == nil || s.ws.Context().WorkspaceRoot == "") {
+				continue
+			}
+			text := string(a) + "://"
+			out = append(out, CompletionItem{
+				Label:    text,
+				Kind:     CompletionItemKindFolder,
+				Detail:   anchorDetail(a),
+				TextEdit: &TextEdit{Range: replace, NewText: text},
+				Command:  triggerSuggest,
+			})
+		}
+		return out
+	}
+	if s.ws == nil {
+		return nil
+	}
+
+	c := s.ws.Context()
+	head := strings.ToLower(scheme) + "://"
+	var base string
+	if strings.EqualFold(scheme, string(paths.SchemeRepo)) {
+		base = c.RepoRoot
+	} else if strings.EqualFold(scheme, string(paths.SchemeDocs)) {
+		base = c.Root
+	} else if strings.EqualFold(scheme, string(paths.SchemeWs)) {
+		member, memberRest, found := strings.Cut(rest, "/")
+		if !found {
+			// Complete the go.work member directory.
+			return dirCompletions(c.WorkspaceRoot, "", "", head, replace, true)
+		}
+		base = filepath.Join(c.WorkspaceRoot, member)
+		head += member + "/"
+		rest = memberRest
+	}
+	if base == "" {
+		return nil
+	}
+	dir, partial := path.Split(rest)
+	return dirCompletions(base, dir, partial, head, replace, false)
+}
+
+// dirCompletions lists base/dir; each item inserts head+dir+name. Hidden
+// entries are only listed once the typed name starts with a dot.
+func dirCompletions(base, dir, partial, head string, replace Range, dirsOnly bool) []CompletionItem {
+	if base == "" {
+		return nil
+	}
+	target := filepath.Join(base, filepath.FromSlash(dir))
+	if rel, err := filepath.Rel(base, target); err != nil || strings.HasPrefix(rel, "..") {
+		return nil
+	}
+	entries, err := os.ReadDir(target)
+	if err != nil {
+		return nil
+	}
+	var out []CompletionItem
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(partial, ".") {
+			continue
+		}
+		if dirsOnly && !e.IsDir() {
+			continue
+		}
+		item := CompletionItem{Label: name, Kind: CompletionItemKindFile}
+		text := head + dir + name
+		if e.IsDir() {
+			item.Label += "/"
+			item.Kind = CompletionItemKindFolder
+			item.Command = triggerSuggest
+			text += "/"
+		}
+		item.FilterText = text
+		item.TextEdit = &TextEdit{Range: replace, NewText: text}
+		out = append(out, item)
+	}
+	return out
+}
+
+func anchorDetail(a paths.Scheme) string {
+	if a == paths.SchemeRepo {
+		return "relative to the repository root"
+	}
+	if a == paths.SchemeWs {
+		return "relative to a go.work workspace member"
+	}
+	return "relative to the docs root"
+}
@@ -0,0 +1,93 @@
+package lsp
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/pkg/commands"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/report"
+)
+
+// locationPrefixRe matches the ":line:col: " left after stripping the file
+// path from a context summary.
+var locationPrefixRe = regexp.MustCompile(`^(:\d+)*:?\s*`)
+
+// publishDiagnostics runs the doctor checks for a doc in the docs root and
+// publishes them. Diagnostics reflect the file on disk (doctor reads files),
+// so they are refreshed on open and save, not while typing.
+func (s *Server) publishDiagnostics(ctx context.Context, uri string) error {
+	path := URIToPath(uri)
+	if s.ws == nil || path == "" || !s.ws.IsIndexablePath(path) {
+		return nil
+	}
+	text, ok := s.text(uri)
+	if !ok {
+		return nil
+	}
+	taxes, err := commands.DoctorDocTaxonomies(ctx, s.ws, path)
+	if err != nil {
+		return err
+	}
+	lines := splitLines(text)
+	diags := make([]Diagnostic, 0, len(taxes))
+	for _, t := range taxes {
+		diags = append(diags, toDiagnostic(report.FromTaxonomy("", t), lines))
+	}
+	return s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: uri, Diagnostics: diags})
+}
+
+// toDiagnostic maps a finding onto the rest of its line (the whole first line
+// when the finding has no position).
+func toDiagnostic(f report.Finding, lines []string) Diagnostic {
+	line, col := 0, 0
+	if f.Line > 0 {
+		line = f.Line - 1
+	}
+	if f.Column > 0 {
+		col = f.Column - 1
+	}
+	text := ""
+	if line < len(lines) {
+		text = lines[line]
+	}
+	end := byteToUTF16(text, len(text))
+	start := runeToUTF16(text, col)
+	if start >= end {
+		start = 0
+	}
+	return Diagnostic{
+		Range: Range{
+			Start: Position{Line: line, Character: start},
+			End:   Position{Line: line, Character: end},
+		},
+		Severity: diagnosticSeverity(f.Severity),
+		Code:     f.RuleID(),
+		Source:   "docmgr",
+		Message:  diagnosticMessage(f),
+	}
+}
+
+// diagnosticMessage drops the "file:line:col" prefix of the context summary;
+// the editor already shows where the finding is.
+func diagnosticMessage(f report.Finding) string {
+	msg := f.Message
+	if f.Path != "" && strings.HasPrefix(msg, f.Path) {
+		msg = locationPrefixRe.ReplaceAllString(strings.TrimPrefix(msg, f.Path), "")
+	}
+	if msg == "" {
+		return f.RuleID()
+	}
+	return msg
+}
+
+func diagnosticSeverity(sev core.Severity) int {
+	if sev == core.SeverityError {
+		return DiagnosticSeverityError
+	}
+	if sev == core.SeverityWarning {
+		return DiagnosticSeverityWarning
+	}
+	return DiagnosticSeverityInformation
+}
@@ -0,0 +1,131 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// JSON-RPC 2.0 error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// message is an incoming request or notification. Requests carry an ID;
+// notifications don't.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+func (m *message) isRequest() bool {
+	return len(m.ID) > 0 && string(m.ID) != "null"
+}
+
+// response is a successful reply; Result is always present (null included).
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result"`
+}
+
+type errorResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   *rpcError       `json:"error"`
+}
+
+type notification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// maxMessageSize bounds the Content-Length of an incoming frame.
+const maxMessageSize = 64 << 20
+
+// conn reads and writes LSP base-protocol frames (Content-Length headers
+// followed by a JSON body).
+type conn struct {
+	r  *bufio.Reader
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{r: bufio.NewReader(r), w: w}
+}
+
+// read returns the next frame's body. io.EOF means the client closed the stream.
+func (c *conn) read() ([]byte, error) {
+	headers, err := textproto.NewReader(c.r).ReadMIMEHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) && len(headers) == 0 {
+			return nil, io.EOF
+		}
+		return nil, errors.Wrap(err, "read message headers")
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(headers.Get("Content-Length")))
+	if err != nil || length < 0 {
+		return nil, errors.Errorf("invalid Content-Length header %q", headers.Get("Content-Length"))
+	}
+	if length > maxMessageSize {
+		return nil, errors.Errorf("message of %d bytes exceeds the %d byte limit", length, maxMessageSize)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.r, body); err != nil {
+		return nil, errors.Wrap(err, "read message body")
+	}
+	return body, nil
+}
+
+func (c *conn) write(v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return errors.Wrap(err, "write message header")
+	}
+	_, err = c.w.Write(body)
+	return errors.Wrap(err, "write message body")
+}
+
+func (c *conn) reply(id json.RawMessage, result any) error {
+	return c.write(response{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (c *conn) replyError(id json.RawMessage, code int, msg string) error {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return c.write(errorResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: msg}})
+}
+
+func (c *conn) notify(method string, params any) error {
+	return c.write(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
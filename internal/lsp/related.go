@@ -0,0 +1,146 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/paths"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/models"
+)
+
+// relatedFileAt returns the RelatedFiles entry whose lines contain line
+// (0-based). The buffer must parse; mid-edit YAML errors yield no entry.
+func relatedFileAt(path, text string, line int) (models.RelatedFile, bool) {
+	doc, _, err := documents.ParseDocumentWithFrontmatter(path, []byte(text))
+	if err != nil || doc == nil {
+		return models.RelatedFile{}, false
+	}
+	fp, ok := doc.Positions.Field("RelatedFiles")
+	if !ok || len(fp.Items) == 0 || len(fp.Items) != len(doc.RelatedFiles) {
+		return models.RelatedFile{}, false
+	}
+
+	// The block ends at the next top-level key or the closing "---".
+	end := frontmatterEnd(splitLines(text)) + 1
+	for _, other := range doc.Positions {
+		if other.Key.Line > fp.Key.Line && other.Key.Line < end {
+			end = other.Key.Line
+		}
+	}
+	target := line + 1 // positions are 1-based
+	if target < fp.Items[0].Line || target >= end {
+		return models.RelatedFile{}, false
+	}
+	idx := 0
+	for i, item := range fp.Items {
+		if item.Line <= target {
+			idx = i
+		}
+	}
+	return doc.RelatedFiles[idx], true
+}
+
+func (s *Server) hover(ctx context.Context, p TextDocumentPositionParams) (any, error) {
+	docPath := URIToPath(p.TextDocument.URI)
+	text, ok := s.text(p.TextDocument.URI)
+	if docPath == "" || !ok {
+		return nil, nil
+	}
+	rf, ok := relatedFileAt(docPath, text, p.Position.Line)
+	if !ok {
+		return nil, nil
+	}
+	n := s.resolverFor(docPath).Resolve(rf.Path)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**`%s`**", rf.Path)
+	if n.Abs != "" {
+		fmt.Fprintf(&b, " → `%s`", n.Abs)
+	}
+	if !n.Exists {
+		b.WriteString(" *(not found)*")
+	}
+	b.WriteString("\n\n")
+	if strings.TrimSpace(rf.Note) != "" {
+		b.WriteString(strings.TrimSpace(rf.Note))
+	} else {
+		b.WriteString("*No note.*")
+	}
+	b.WriteString("\n")
+
+	others, err := s.relatedFrom(ctx, docPath, n)
+	if err != nil {
+		return nil, err
+	}
+	if len(others) > 0 {
+		b.WriteString("\nAlso related from:\n")
+		for _, o := range others {
+			b.WriteString("\n- " + o)
+		}
+		b.WriteString("\n")
+	}
+	return Hover{Contents: MarkupContent{Kind: "markdown", Value: b.String()}}, nil
+}
+
+// relatedFrom lists the other indexed docs relating the same file, with their notes.
+func (s *Server) relatedFrom(ctx context.Context, docPath string, target paths.NormalizedPath) ([]string, error) {
+	if s.ws == nil || target.Abs == "" {
+		return nil, nil
+	}
+	res, err := s.ws.QueryDocs(ctx, workspace.DocQuery{
+		Scope:   workspace.Scope{Kind: workspace.ScopeRepo},
+		Filters: workspace.DocFilters{RelatedFile: []string{target.Abs}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, h := range res.Docs {
+		if h.Doc == nil || filepath.Clean(h.Path) == filepath.Clean(docPath) {
+			continue
+		}
+		line := fmt.Sprintf("`%s`", s.displayPath(h.Path))
+		resolver := s.resolverFor(h.Path)
+		for _, rf := range h.Doc.RelatedFiles {
+			if note := strings.TrimSpace(rf.Note); note != "" && paths.MatchPaths(resolver.ResolveNoFS(rf.Path), target) {
+				line += ": " + note
+				break
+			}
+		}
+		out = append(out, line)
+	}
+	return out, nil
+}
+
+// displayPath shows doc paths relative to the repository root.
+func (s *Server) displayPath(path string) string {
+	if s.ws == nil {
+		return path
+	}
+	rel, err := filepath.Rel(s.ws.Context().RepoRoot, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (s *Server) definition(p TextDocumentPositionParams) (any, error) {
+	docPath := URIToPath(p.TextDocument.URI)
+	text, ok := s.text(p.TextDocument.URI)
+	if docPath == "" || !ok {
+		return nil, nil
+	}
+	rf, ok := relatedFileAt(docPath, text, p.Position.Line)
+	if !ok {
+		return nil, nil
+	}
+	n := s.resolverFor(docPath).Resolve(rf.Path)
+	if !n.Exists || n.Abs == "" {
+		return nil, nil
+	}
+	return Location{URI: PathToURI(n.Abs)}, nil
+}
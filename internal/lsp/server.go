@@ -0,0 +1,250 @@
+// Package lsp implements `docmgr lsp`: a Language Server Protocol server over
+// stdio for docmgr workspaces. It completes vocabulary slugs and anchored
+// RelatedFiles paths in frontmatter, publishes doctor diagnostics when a doc
+// is opened or saved, shows related-file notes on hover, and jumps to
+// anchored paths (repo://, ws://, docs://, ...) on go-to-definition.
+//
+// The server keeps the workspace index in memory and updates it per file on
+// save, like the HTTP API watcher.
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/paths"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/pkg/errors"
+)
+
+// Options configures a Server.
+type Options struct {
+	// Root overrides the docs root (defaults to the configured root, usually "ttmp").
+	Root string
+	// Chdir makes the server change into the client's workspace folder on
+	// initialize, so .ttmp.yaml and vocabulary discovery see the editor's
+	// project rather than the directory the editor was started from.
+	Chdir bool
+}
+
+// Server is a docmgr language server. Messages are handled one at a time, so
+// index updates on save never interleave with queries.
+type Server struct {
+	opts Options
+	conn *conn
+
+	ws   *workspace.Workspace
+	docs map[string]string // open documents: URI -> text
+
+	shutdown bool
+}
+
+// NewServer builds a server; call Serve to run it.
+func NewServer(opts Options) *Server {
+	return &Server{opts: opts, docs: map[string]string{}}
+}
+
+// Serve reads LSP messages from r and writes replies to w until the client
+// sends exit or closes the stream.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.conn = newConn(r, w)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+		body, err := s.conn.read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		var msg message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			if err := s.conn.replyError(nil, codeParseError, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		if err := s.handle(ctx, &msg); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches one message. Only transport errors are returned; handler
+// errors become JSON-RPC error replies (requests) or log messages (notifications).
+func (s *Server) handle(ctx context.Context, msg *message) error {
+	if msg.isRequest() && s.shutdown {
+		return s.conn.replyError(msg.ID, codeInvalidRequest, "server is shutting down")
+	}
+
+	var result any
+	var err error
+	switch msg.Method {
+	case "initialize":
+		result, err = s.initialize(ctx, msg.Params)
+	case "shutdown":
+		s.shutdown = true
+	case "textDocument/completion":
+		result, err = withParams(msg.Params, func(p TextDocumentPositionParams) (any, error) { return s.completion(p) })
+	case "textDocument/hover":
+		result, err = withParams(msg.Params, func(p TextDocumentPositionParams) (any, error) { return s.hover(ctx, p) })
+	case "textDocument/definition":
+		result, err = withParams(msg.Params, func(p TextDocumentPositionParams) (any, error) { return s.definition(p) })
+	case "textDocument/didOpen":
+		_, err = withParams(msg.Params, func(p DidOpenTextDocumentParams) (any, error) {
+			s.docs[p.TextDocument.URI] = p.TextDocument.Text
+			return nil, s.publishDiagnostics(ctx, p.TextDocument.URI)
+		})
+	case "textDocument/didChange":
+		_, err = withParams(msg.Params, func(p DidChangeTextDocumentParams) (any, error) {
+			if n := len(p.ContentChanges); n > 0 {
+				s.docs[p.TextDocument.URI] = p.ContentChanges[n-1].Text
+			}
+			return nil, nil
+		})
+	case "textDocument/didSave":
+		_, err = withParams(msg.Params, func(p DidSaveTextDocumentParams) (any, error) {
+			return nil, s.didSave(ctx, p.TextDocument.URI)
+		})
+	case "textDocument/didClose":
+		_, err = withParams(msg.Params, func(p DidCloseTextDocumentParams) (any, error) {
+			delete(s.docs, p.TextDocument.URI)
+			return nil, s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: p.TextDocument.URI, Diagnostics: []Diagnostic{}})
+		})
+	default:
+		if msg.isRequest() {
+			return s.conn.replyError(msg.ID, codeMethodNotFound, fmt.Sprintf("method not supported: %s", msg.Method))
+		}
+	}
+
+	if !msg.isRequest() {
+		if err != nil {
+			return s.logf(MessageTypeError, "%s: %v", msg.Method, err)
+		}
+		return nil
+	}
+	if err != nil {
+		code := codeInternalError
+		var perr *paramsError
+		if errors.As(err, &perr) {
+			code = codeInvalidParams
+		}
+		return s.conn.replyError(msg.ID, code, err.Error())
+	}
+	return s.conn.reply(msg.ID, result)
+}
+
+type paramsError struct{ err error }
+
+func (e *paramsError) Error() string { return "invalid params: " + e.err.Error() }
+
+// withParams decodes raw params into P and calls fn.
+func withParams[P any](raw json.RawMessage, fn func(P) (any, error)) (any, error) {
+	var p P
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return nil, &paramsError{err: err}
+		}
+	}
+	return fn(p)
+}
+
+func (s *Server) initialize(ctx context.Context, raw json.RawMessage) (any, error) {
+	var params InitializeParams
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, &paramsError{err: err}
+		}
+	}
+	if s.opts.Chdir {
+		dir := URIToPath(params.RootURI)
+		if dir == "" {
+			dir = strings.TrimSpace(params.RootPath)
+		}
+		if dir != "" {
+			if err := os.Chdir(dir); err != nil {
+				_ = s.logf(MessageTypeError, "docmgr: cannot use workspace folder %s: %v", dir, err)
+			}
+		}
+	}
+
+	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: s.opts.Root})
+	if err == nil {
+		err = ws.InitIndex(ctx, workspace.BuildIndexOptions{})
+	}
+	if err != nil {
+		// Keep serving: vocabulary completion works without an index.
+		_ = s.logf(MessageTypeError, "docmgr: workspace index unavailable: %v", err)
+	} else {
+		s.ws = ws
+		_ = s.logf(MessageTypeInfo, "docmgr: indexed docs root %s", ws.Context().Root)
+	}
+
+	return InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    TextDocumentSyncKindFull,
+				Save:      SaveOptions{IncludeText: false},
+			},
+			CompletionProvider: CompletionOptions{TriggerCharacters: []string{":", "/", " ", "-", "[", ","}},
+			HoverProvider:      true,
+			DefinitionProvider: true,
+		},
+		ServerInfo: ServerInfo{Name: "docmgr"},
+	}, nil
+}
+
+// didSave refreshes the saved file in the index and republishes its diagnostics.
+func (s *Server) didSave(ctx context.Context, uri string) error {
+	path := URIToPath(uri)
+	if s.ws != nil && path != "" && s.ws.IsIndexablePath(path) {
+		if err := s.ws.UpsertDocument(ctx, path); err != nil {
+			_ = s.logf(MessageTypeError, "docmgr: index update failed for %s: %v", path, err)
+		}
+	}
+	return s.publishDiagnostics(ctx, uri)
+}
+
+// text returns the open buffer for uri, falling back to the file on disk.
+func (s *Server) text(uri string) (string, bool) {
+	if t, ok := s.docs[uri]; ok {
+		return t, true
+	}
+	path := URIToPath(uri)
+	if path == "" {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// resolverFor returns a resolver anchored at docPath (for legacy and doc://
+// paths), with the workspace's repo, docs and go.work roots.
+func (s *Server) resolverFor(docPath string) *paths.Resolver {
+	opts := paths.ResolverOptions{DocPath: docPath}
+	if s.ws != nil {
+		c := s.ws.Context()
+		opts.DocsRoot = c.Root
+		opts.ConfigDir = c.ConfigDir
+		opts.RepoRoot = c.RepoRoot
+		opts.WorkspaceRoot = c.WorkspaceRoot
+	}
+	return paths.NewResolver(opts)
+}
+
+func (s *Server) logf(typ int, format string, args ...any) error {
+	return s.conn.notify("window/logMessage", LogMessageParams{Type: typ, Message: fmt.Sprintf(format, args...)})
+}
@@ -0,0 +1,346 @@
+package lsp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const designDoc = `---
+Title: Routes
+Ticket: LSP-1
+Status: active
+DocType: design
+Topics:
+    - api
+    - bogus
+Owners: []
+RelatedFiles:
+    - Path: repo://src/main.go
+      Note: HTTP entry point
+    - Path: repo://src/gone.go
+      Note: removed handler
+LastUpdated: 2026-01-05T00:00:00Z
+---
+
+# Routes
+`
+
+// setupLSPRepo builds a temp repo (go.mod + .ttmp.yaml + vocabulary + two
+// docs relating src/main.go) and chdirs into it. Tests using it must NOT call
+// t.Parallel (they change the process working directory).
+func setupLSPRepo(t *testing.T) string {
+	t.Helper()
+
+	repo := t.TempDir()
+	ticketDir := filepath.Join(repo, "ttmp", "2026", "01", "03", "LSP-1--routes")
+	writeFile(t, filepath.Join(repo, "go.mod"), "module example.com/lsptest\n\ngo 1.23\n")
+	writeFile(t, filepath.Join(repo, ".ttmp.yaml"), "root: ttmp\n")
+	writeFile(t, filepath.Join(repo, "src", "main.go"), "package main\n")
+	writeFile(t, filepath.Join(repo, "ttmp", "vocabulary.yaml"), `topics:
+    - slug: api
+      description: HTTP API
+    - slug: backend
+      description: Backend services
+docTypes:
+    - slug: design
+      description: Design doc
+status:
+    - slug: active
+      description: In progress
+intent: []
+`)
+	writeFile(t, filepath.Join(ticketDir, "design", "01-routes.md"), designDoc)
+	writeFile(t, filepath.Join(ticketDir, "index.md"), `---
+Title: Routes
+Ticket: LSP-1
+Status: active
+DocType: design
+Topics: [api]
+RelatedFiles:
+    - Path: repo://src/main.go
+      Note: wires the router
+LastUpdated: 2026-01-05T00:00:00Z
+---
+
+# Routes
+`)
+
+	oldCwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldCwd) })
+
+	// Resolve symlinks (macOS /var -> /private/var) so URIs match the index.
+	resolved, err := filepath.EvalSymlinks(filepath.Join(ticketDir, "design", "01-routes.md"))
+	if err != nil {
+		t.Fatalf("eval symlinks: %v", err)
+	}
+	return resolved
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+type rpcIn struct {
+	ID     int    `json:"id,omitempty"`
+	Method string `json:"method"`
+	Params any    `json:"params,omitempty"`
+}
+
+type rpcOut struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// runSession feeds msgs to a server and returns every message it wrote.
+func runSession(t *testing.T, msgs ...rpcIn) []rpcOut {
+	t.Helper()
+	var in bytes.Buffer
+	for _, m := range msgs {
+		body, err := json.Marshal(struct {
+			JSONRPC string `json:"jsonrpc"`
+			rpcIn
+		}{"2.0", m})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+	var out bytes.Buffer
+	if err := NewServer(Options{}).Serve(context.Background(), &in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	c := newConn(&out, nil)
+	var got []rpcOut
+	for {
+		body, err := c.read()
+		if err != nil {
+			break
+		}
+		var m rpcOut
+		if err := json.Unmarshal(body, &m); err != nil {
+			t.Fatalf("bad server message %s: %v", body, err)
+		}
+		got = append(got, m)
+	}
+	return got
+}
+
+func reply(t *testing.T, msgs []rpcOut, id int, v any) {
+	t.Helper()
+	for _, m := range msgs {
+		if m.ID != nil && *m.ID == id && m.Method == "" {
+			if m.Error != nil {
+				t.Fatalf("request %d failed: %v", id, m.Error)
+			}
+			if err := json.Unmarshal(m.Result, v); err != nil {
+				t.Fatalf("request %d: bad result %s: %v", id, m.Result, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("no reply to request %d", id)
+}
+
+func at(uri string, line, char int) TextDocumentPositionParams {
+	return TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{URI: uri}, Position: Position{Line: line, Character: char}}
+}
+
+func TestServerSession(t *testing.T) {
+	docPath := setupLSPRepo(t)
+	uri := PathToURI(docPath)
+	// Line 6 is "    - api"; line 10 is "    - Path: repo://src/main.go".
+	text := strings.Replace(designDoc, "    - Path: repo://src/main.go", "    - Path: repo://sr", 1)
+
+	msgs := runSession(t,
+		rpcIn{ID: 1, Method: "initialize", Params: InitializeParams{}},
+		rpcIn{Method: "initialized", Params: struct{}{}},
+		rpcIn{Method: "textDocument/didOpen", Params: DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: uri, LanguageID: "markdown", Text: designDoc}}},
+		rpcIn{ID: 2, Method: "textDocument/completion", Params: at(uri, 6, len("    - a"))},
+		rpcIn{ID: 3, Method: "textDocument/hover", Params: at(uri, 11, 8)},
+		rpcIn{ID: 4, Method: "textDocument/definition", Params: at(uri, 10, 20)},
+		rpcIn{ID: 5, Method: "textDocument/definition", Params: at(uri, 12, 20)},
+		rpcIn{Method: "textDocument/didChange", Params: DidChangeTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: uri}, ContentChanges: []TextDocumentContentChangeEvent{{Text: text}}}},
+		rpcIn{ID: 6, Method: "textDocument/completion", Params: at(uri, 10, len("    - Path: repo://sr"))},
+		rpcIn{ID: 7, Method: "textDocument/completion", Params: at(uri, 10, len("    - Path: "))},
+		rpcIn{ID: 8, Method: "workspace/symbol", Params: struct{}{}},
+		rpcIn{ID: 9, Method: "shutdown"},
+		rpcIn{Method: "exit"},
+	)
+
+	var init InitializeResult
+	reply(t, msgs, 1, &init)
+	if !init.Capabilities.HoverProvider || init.Capabilities.TextDocumentSync.Change != TextDocumentSyncKindFull {
+		t.Fatalf("unexpected capabilities: %+v", init.Capabilities)
+	}
+
+	var diags *PublishDiagnosticsParams
+	for _, m := range msgs {
+		if m.Method == "textDocument/publishDiagnostics" {
+			diags = &PublishDiagnosticsParams{}
+			if err := json.Unmarshal(m.Params, diags); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if diags == nil || diags.URI != uri {
+		t.Fatalf("expected diagnostics for %s, got %+v", uri, msgs)
+	}
+	var sawTopic, sawMissing bool
+	for _, d := range diags.Diagnostics {
+		if d.Code == "docmgr.vocabulary/unknown_value" && d.Range.Start == (Position{Line: 7, Character: 6}) {
+			sawTopic = strings.Contains(d.Message, "bogus") && !strings.Contains(d.Message, docPath)
+		}
+		if d.Code == "docmgr.related_files/missing_file" && d.Range.Start.Line == 12 {
+			sawMissing = d.Severity == DiagnosticSeverityWarning
+		}
+	}
+	if !sawTopic || !sawMissing {
+		t.Fatalf("expected unknown topic and missing related file diagnostics, got %+v", diags.Diagnostics)
+	}
+
+	var topics CompletionList
+	reply(t, msgs, 2, &topics)
+	if len(topics.Items) != 2 || topics.Items[0].Label != "api" || topics.Items[0].Detail != "HTTP API" ||
+		topics.Items[0].TextEdit.Range.Start.Character != len("    - ") {
+		t.Fatalf("unexpected topic completions: %+v", topics.Items)
+	}
+
+	var hover Hover
+	reply(t, msgs, 3, &hover)
+	if !strings.Contains(hover.Contents.Value, "HTTP entry point") ||
+		!strings.Contains(hover.Contents.Value, "ttmp/2026/01/03/LSP-1--routes/index.md`: wires the router") {
+		t.Fatalf("unexpected hover: %s", hover.Contents.Value)
+	}
+
+	var loc Location
+	reply(t, msgs, 4, &loc)
+	if !strings.HasSuffix(loc.URI, "/src/main.go") {
+		t.Fatalf("unexpected definition: %+v", loc)
+	}
+	var missing *Location
+	reply(t, msgs, 5, &missing)
+	if missing != nil {
+		t.Fatalf("expected no definition for a missing file, got %+v", missing)
+	}
+
+	var paths CompletionList
+	reply(t, msgs, 6, &paths)
+	// Entries are listed per directory; the client filters on the typed name.
+	var sawSrc bool
+	for _, item := range paths.Items {
+		if item.Label == "src/" {
+			sawSrc = item.TextEdit.NewText == "repo://src/" && item.Command != nil
+		}
+		if strings.HasPrefix(item.Label, ".") {
+			t.Fatalf("hidden entry listed: %+v", item)
+		}
+	}
+	if !sawSrc {
+		t.Fatalf("expected repo://src/ completion: %+v", paths.Items)
+	}
+	var anchors CompletionList
+	reply(t, msgs, 7, &anchors)
+	if len(anchors.Items) == 0 || anchors.Items[0].Label != "repo://" {
+		t.Fatalf("unexpected anchor completions: %+v", anchors.Items)
+	}
+
+	for _, m := range msgs {
+		if m.ID != nil && *m.ID == 8 {
+			if m.Error == nil || m.Error.Code != codeMethodNotFound {
+				t.Fatalf("expected method-not-found for workspace/symbol, got %+v", m)
+			}
+		}
+	}
+}
+
+func TestValueAt(t *testing.T) {
+	lines := splitLines(designDoc)
+	cases := []struct {
+		line, char  int
+		field       string
+		prefix      string
+		relatedPath bool
+		ok          bool
+	}{
+		{3, len("Status: ac"), "Status", "ac", false, true},
+		{3, len("Stat"), "", "", false, false},
+		{7, len("    - bo"), "Topics", "bo", false, true},
+		{10, len("    - Path: repo://s"), "RelatedFiles", "repo://s", true, true},
+		{11, len("      Note: H"), "", "", false, false},
+		{17, 0, "", "", false, false},
+	}
+	for _, tc := range cases {
+		vc, ok := valueAt(lines, Position{Line: tc.line, Character: tc.char})
+		if ok != tc.ok || vc.field != tc.field || vc.prefix != tc.prefix || vc.relatedPath != tc.relatedPath {
+			t.Errorf("line %d char %d: got %+v ok=%v", tc.line, tc.char, vc, ok)
+		}
+	}
+}
+
+func TestServerPublishesTeamRuleDiagnostics(t *testing.T) {
+	docPath := setupLSPRepo(t)
+	writeFile(t, filepath.Join("ttmp", ".docmgr", "rules.yaml"), `rules:
+  - id: design-owner
+    where: doctype:design AND NOT owner:*
+    severity: error
+    message: "{{.Title}} has no Owners"
+`)
+	uri := PathToURI(docPath)
+
+	msgs := runSession(t,
+		rpcIn{ID: 1, Method: "initialize", Params: InitializeParams{}},
+		rpcIn{Method: "initialized", Params: struct{}{}},
+		rpcIn{Method: "textDocument/didOpen", Params: DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: uri, LanguageID: "markdown", Text: designDoc}}},
+		rpcIn{ID: 2, Method: "shutdown"},
+		rpcIn{Method: "exit"},
+	)
+
+	for _, m := range msgs {
+		if m.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+		var diags PublishDiagnosticsParams
+		if err := json.Unmarshal(m.Params, &diags); err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range diags.Diagnostics {
+			if d.Code == "docmgr.custom/design-owner" {
+				if d.Severity != DiagnosticSeverityError || !strings.Contains(d.Message, "Routes has no Owners") {
+					t.Fatalf("unexpected team rule diagnostic: %+v", d)
+				}
+				return
+			}
+		}
+		t.Fatalf("expected a design-owner diagnostic, got %+v", diags.Diagnostics)
+	}
+	t.Fatal("no diagnostics published")
+}
+
+func TestConnRejectsOversizedMessage(t *testing.T) {
+	in := strings.NewReader(fmt.Sprintf("Content-Length: %d\r\n\r\n{}", maxMessageSize+1))
+	if _, err := newConn(in, nil).read(); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Fatalf("expected an oversized message error, got %v", err)
+	}
+}
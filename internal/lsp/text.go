@@ -0,0 +1,96 @@
+package lsp
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// topLevelKeyRe matches an unindented frontmatter key line ("Topics:", "DocType: design").
+var topLevelKeyRe = regexp.MustCompile(`^([A-Za-z_][\w-]*)\s*:(.*)$`)
+
+// mappingKeyRe matches a "Key: value" pair inside a list item ("Path: pkg/x.go").
+var mappingKeyRe = regexp.MustCompile(`^([A-Za-z_][\w-]*):(\s|$)`)
+
+// splitLines splits a buffer into lines without their line endings.
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSuffix(l, "\r")
+	}
+	return lines
+}
+
+// frontmatterEnd returns the index of the closing "---" line, or -1 when the
+// buffer does not start with a frontmatter block.
+func frontmatterEnd(lines []string) int {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return -1
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return i
+		}
+	}
+	return -1
+}
+
+// fieldAt returns the top-level frontmatter key whose block contains line:
+// the key line itself or one of its indented/list continuation lines.
+func fieldAt(lines []string, line int) (key string, keyLine int, ok bool) {
+	end := frontmatterEnd(lines)
+	if end < 0 || line <= 0 || line >= end {
+		return "", 0, false
+	}
+	for i := line; i > 0; i-- {
+		if m := topLevelKeyRe.FindStringSubmatch(lines[i]); m != nil {
+			return m[1], i, true
+		}
+	}
+	return "", 0, false
+}
+
+// utf16ToByte converts an LSP character offset (UTF-16 code units) into a
+// byte offset in line, clamped to the line length.
+func utf16ToByte(line string, u int) int {
+	units := 0
+	for i, r := range line {
+		if units >= u {
+			return i
+		}
+		units += utf16Width(r)
+	}
+	return len(line)
+}
+
+// byteToUTF16 converts a byte offset in line into UTF-16 code units.
+func byteToUTF16(line string, b int) int {
+	if b > len(line) {
+		b = len(line)
+	}
+	units := 0
+	for _, r := range line[:b] {
+		units += utf16Width(r)
+	}
+	return units
+}
+
+// runeToUTF16 converts a 0-based rune column (YAML positions) into UTF-16 code units.
+func runeToUTF16(line string, col int) int {
+	units, n := 0, 0
+	for _, r := range line {
+		if n >= col {
+			break
+		}
+		units += utf16Width(r)
+		n++
+	}
+	return units
+}
+
+func utf16Width(r rune) int {
+	if r >= 0x10000 && r <= utf8.MaxRune {
+		return 2
+	}
+	return 1
+}
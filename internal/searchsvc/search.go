@@ -29,6 +29,9 @@ type SearchQuery struct {
 	File string
 	Dir  string
 
+	// Where is a query expression (see workspace.ParseDocExpr).
+	Where string
+
 	ExternalSource string
 	Since          string
 	Until          string
@@ -83,6 +86,7 @@ func SearchDocs(ctx context.Context, ws *workspace.Workspace, q SearchQuery) (Se
 			strings.TrimSpace(q.Status) == "" &&
 			strings.TrimSpace(q.File) == "" &&
 			strings.TrimSpace(q.Dir) == "" &&
+			strings.TrimSpace(q.Where) == "" &&
 			strings.TrimSpace(q.ExternalSource) == "" &&
 			strings.TrimSpace(q.Since) == "" &&
 			strings.TrimSpace(q.Until) == "" &&
@@ -109,6 +113,11 @@ func SearchDocs(ctx context.Context, ws *workspace.Workspace, q SearchQuery) (Se
 		return SearchResponse{}, fmt.Errorf("invalid --updated-since date: %w", err)
 	}
 
+	whereExpr, err := workspace.ParseDocExpr(q.Where)
+	if err != nil {
+		return SearchResponse{}, err
+	}
+
 	scope := workspace.Scope{Kind: workspace.ScopeRepo}
 	if strings.TrimSpace(q.Ticket) != "" {
 		scope = workspace.Scope{Kind: workspace.ScopeTicket, TicketID: strings.TrimSpace(q.Ticket)}
@@ -122,6 +131,9 @@ func SearchDocs(ctx context.Context, ws *workspace.Workspace, q SearchQuery) (Se
 			DocType:   strings.TrimSpace(q.DocType),
 			TopicsAny: q.Topics,
 			TextQuery: strings.TrimSpace(q.TextQuery),
+			Where:     whereExpr,
+
+			ExternalSource: strings.TrimSpace(q.ExternalSource),
 			RelatedFile: func() []string {
 				if strings.TrimSpace(q.File) == "" {
 					return nil
@@ -189,17 +201,6 @@ func SearchDocs(ctx context.Context, ws *workspace.Workspace, q SearchQuery) (Se
 			}
 		}
 
-		// External source filter (best-effort; re-read frontmatter).
-		if strings.TrimSpace(q.ExternalSource) != "" {
-			fm, _, ferr := documents.ReadDocumentWithFrontmatterFS(docsFS, relPath)
-			if ferr != nil {
-				continue
-			}
-			if fm == nil || !externalSourceMatch(fm.ExternalSources, q.ExternalSource) {
-				continue
-			}
-		}
-
 		// Date filters.
 		if fi, err := fs.Stat(docsFS, relPath); err == nil {
 			createdTime := fi.ModTime()
@@ -257,23 +258,6 @@ func SearchDocs(ctx context.Context, ws *workspace.Workspace, q SearchQuery) (Se
 	}, nil
 }
 
-func externalSourceMatch(externalSources []string, query string) bool {
-	query = strings.TrimSpace(query)
-	if query == "" {
-		return true
-	}
-	for _, es := range externalSources {
-		es = strings.TrimSpace(es)
-		if es == "" {
-			continue
-		}
-		if strings.Contains(es, query) || strings.Contains(query, es) {
-			return true
-		}
-	}
-	return false
-}
-
 func matchRelatedFiles(ws *workspace.Workspace, docPath string, relatedFiles models.RelatedFiles, fileQueryRaw string) ([]string, []string) {
 	docResolver := paths.NewResolver(paths.ResolverOptions{
 		DocsRoot:      ws.Context().Root,
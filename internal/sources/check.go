@@ -0,0 +1,182 @@
+package sources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/models"
+	"github.com/pkg/errors"
+)
+
+// DriftStatus classifies a recorded source against the files on disk.
+type DriftStatus string
+
+const (
+	// DriftOK means the local copy still hashes to the recorded SHA.
+	DriftOK DriftStatus = "ok"
+	// DriftModified means the local copy was changed after it was imported.
+	DriftModified DriftStatus = "modified"
+	// DriftMissing means the local copy no longer exists.
+	DriftMissing DriftStatus = "missing"
+	// DriftStale means the copy is intact but the origin file changed since
+	// the import (re-import with --refresh).
+	DriftStale DriftStatus = "stale"
+	// DriftUnrecorded means the entry has no SHA to compare against (legacy
+	// string entries, hand-written frontmatter).
+	DriftUnrecorded DriftStatus = "unrecorded"
+)
+
+// Drift is the check result for one ExternalSources entry with a local copy.
+type Drift struct {
+	Ticket  string
+	DocPath string
+	Source  models.ExternalSource
+	// CopyPath is the absolute path of the local copy.
+	CopyPath string
+	Status   DriftStatus
+	// CurrentSHA is the sha256 of the local copy ("" if missing).
+	CurrentSHA string
+	// OriginSHA is the sha256 of the origin file when it is a readable local path.
+	OriginSHA string
+}
+
+// CheckOptions scopes Check.
+type CheckOptions struct {
+	Ticket string
+}
+
+// Check recomputes the SHA-256 of every local source copy recorded in
+// ExternalSources and compares it with the recorded SHA (and, for sources
+// imported from a local path, with the current origin file).
+//
+// Entries without a local copy (plain URLs) are skipped. The markdown
+// renditions under sources/ repeat their source's entry and are not checked
+// separately.
+func Check(ctx context.Context, ws *workspace.Workspace, opts CheckOptions) ([]Drift, error) {
+	scope := workspace.Scope{Kind: workspace.ScopeRepo}
+	if t := strings.TrimSpace(opts.Ticket); t != "" {
+		scope = workspace.Scope{Kind: workspace.ScopeTicket, TicketID: t}
+	}
+	res, err := ws.QueryDocs(ctx, workspace.DocQuery{
+		Scope: scope,
+		Options: workspace.DocQueryOptions{
+			IncludeArchivedPath: true,
+			IncludeScriptsPath:  true,
+			OrderBy:             workspace.OrderByPath,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "query docs")
+	}
+
+	root := ws.Context().Root
+	repoRoot := ws.Context().RepoRoot
+	seen := map[string]bool{}
+	var out []Drift
+	for _, h := range res.Docs {
+		if h.Doc == nil || len(h.Doc.ExternalSources) == 0 {
+			continue
+		}
+		ticketDir := ticketDirFor(root, h.Path)
+		for _, es := range h.Doc.ExternalSources {
+			copyPath := localCopyPath(ticketDir, es)
+			if copyPath == "" || seen[copyPath] {
+				continue
+			}
+			seen[copyPath] = true
+			out = append(out, checkOne(h, es, copyPath, repoRoot))
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Ticket != out[j].Ticket {
+			return out[i].Ticket < out[j].Ticket
+		}
+		return out[i].CopyPath < out[j].CopyPath
+	})
+	return out, nil
+}
+
+func checkOne(h workspace.DocHandle, es models.ExternalSource, copyPath, repoRoot string) Drift {
+	d := Drift{
+		Ticket:   h.Doc.Ticket,
+		DocPath:  h.Path,
+		Source:   es,
+		CopyPath: copyPath,
+	}
+	data, err := os.ReadFile(copyPath)
+	if err != nil {
+		d.Status = DriftMissing
+		return d
+	}
+	d.CurrentSHA = SHA256(data)
+	if origin := originPath(repoRoot, es); origin != "" && filepath.Clean(origin) != filepath.Clean(copyPath) {
+		if od, err := os.ReadFile(origin); err == nil {
+			d.OriginSHA = SHA256(od)
+		}
+	}
+
+	recorded := strings.ToLower(strings.TrimSpace(es.SHA))
+	switch {
+	case recorded == "":
+		d.Status = DriftUnrecorded
+	case d.CurrentSHA != recorded:
+		d.Status = DriftModified
+	case d.OriginSHA != "" && d.OriginSHA != recorded:
+		d.Status = DriftStale
+	default:
+		d.Status = DriftOK
+	}
+	return d
+}
+
+// localCopyPath resolves the local copy of an entry: LocalCopy relative to the
+// ticket directory, or sources/local/<name> for legacy "local:<name>" entries.
+func localCopyPath(ticketDir string, es models.ExternalSource) string {
+	if ticketDir == "" {
+		return ""
+	}
+	if lc := strings.TrimSpace(es.LocalCopy); lc != "" {
+		if filepath.IsAbs(lc) {
+			return filepath.Clean(lc)
+		}
+		return filepath.Join(ticketDir, filepath.FromSlash(lc))
+	}
+	if es.Type == "local" && es.Path != "" && !strings.ContainsAny(es.Path, `/\`) {
+		return filepath.Join(ticketDir, "sources", "local", es.Path)
+	}
+	return ""
+}
+
+// originPath returns the origin file of an entry imported from a local path
+// (absolute, or relative to the repository root).
+func originPath(repoRoot string, es models.ExternalSource) string {
+	p := strings.TrimSpace(es.Path)
+	if p == "" || es.LocalCopy == "" {
+		return ""
+	}
+	if filepath.IsAbs(p) {
+		return p
+	}
+	if repoRoot == "" {
+		return ""
+	}
+	return filepath.Join(repoRoot, filepath.FromSlash(p))
+}
+
+// ticketDirFor returns the nearest directory above docPath (within root) that
+// holds an index.md.
+func ticketDirFor(root, docPath string) string {
+	root = filepath.Clean(root)
+	for dir := filepath.Dir(docPath); ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "index.md")); err == nil {
+			return dir
+		}
+		if dir == root || dir == filepath.Dir(dir) || !strings.HasPrefix(dir, root) {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+)
+
+func TestCheckReportsDrift(t *testing.T) {
+	ctx := context.Background()
+	tmp := t.TempDir()
+	docsRoot := filepath.Join(tmp, "ttmp")
+	ticketDir := filepath.Join(docsRoot, "2026", "10", "18", "SRC-1--sources")
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	origin := filepath.Join(tmp, "downloads", "spec.pdf")
+	write(origin, "spec v2")
+	write(filepath.Join(ticketDir, "sources", "local", "spec.pdf"), "spec v1")
+	write(filepath.Join(ticketDir, "sources", "local", "notes.txt"), "edited notes")
+	write(filepath.Join(ticketDir, "sources", "local", "page.html"), "<p>page</p>")
+	write(filepath.Join(ticketDir, "sources", "local", "legacy.txt"), "legacy")
+	write(filepath.Join(ticketDir, "index.md"), fmt.Sprintf(`---
+Title: Sources
+Ticket: SRC-1
+DocType: index
+ExternalSources:
+  - Type: pdf
+    Path: %s
+    LocalCopy: sources/local/spec.pdf
+    SHA: %s
+  - Type: local
+    Path: notes.txt
+    LocalCopy: sources/local/notes.txt
+    SHA: %s
+  - Type: html
+    LocalCopy: sources/local/page.html
+    SHA: %s
+  - Type: local
+    LocalCopy: sources/local/gone.txt
+    SHA: %s
+  - local:legacy.txt
+  - https://example.com/only-a-link
+---
+`, origin, SHA256([]byte("spec v1")), SHA256([]byte("notes")), SHA256([]byte("<p>page</p>")), SHA256([]byte("gone"))))
+
+	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: docsRoot})
+	if err != nil {
+		t.Fatalf("DiscoverWorkspace: %v", err)
+	}
+	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{}); err != nil {
+		t.Fatalf("InitIndex: %v", err)
+	}
+
+	drifts, err := Check(ctx, ws, CheckOptions{Ticket: "SRC-1"})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	got := map[string]DriftStatus{}
+	for _, d := range drifts {
+		got[filepath.Base(d.CopyPath)] = d.Status
+	}
+	want := map[string]DriftStatus{
+		"spec.pdf":   DriftStale,
+		"notes.txt":  DriftModified,
+		"page.html":  DriftOK,
+		"gone.txt":   DriftMissing,
+		"legacy.txt": DriftUnrecorded,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for name, status := range want {
+		if got[name] != status {
+			t.Errorf("%s: status %q, want %q", name, got[name], status)
+		}
+	}
+}
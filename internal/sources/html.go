@@ -0,0 +1,315 @@
+package sources
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+)
+
+// HTMLToMarkdown converts an HTML page into readable markdown and returns the
+// page title (<title>, else the first <h1>). Scripts, styles and navigation
+// chrome are dropped; headings, paragraphs, lists, links, emphasis, code
+// blocks, quotes and simple tables are kept.
+func HTMLToMarkdown(data []byte) (string, string, error) {
+	doc, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return "", "", errors.Wrap(err, "parse html")
+	}
+	c := &htmlConverter{}
+	c.blocks(doc)
+	title := strings.TrimSpace(c.title)
+	if title == "" {
+		title = c.firstH1
+	}
+	return title, strings.Join(c.out, "\n\n") + "\n", nil
+}
+
+type htmlConverter struct {
+	out     []string
+	title   string
+	firstH1 string
+}
+
+func (c *htmlConverter) emit(block string) {
+	if block = strings.TrimRight(block, " \n"); strings.TrimSpace(block) != "" {
+		c.out = append(c.out, block)
+	}
+}
+
+// blocks renders n's children, grouping runs of inline content into paragraphs.
+func (c *htmlConverter) blocks(n *html.Node) {
+	var run []*html.Node
+	flush := func() {
+		var b strings.Builder
+		for _, r := range run {
+			b.WriteString(inlineNode(r))
+		}
+		c.emit(cleanInline(b.String()))
+		run = run[:0]
+	}
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		if ch.Type == html.ElementNode && (skipElement(ch) || isBlock(ch)) {
+			flush()
+			c.block(ch)
+			continue
+		}
+		if ch.Type == html.TextNode || ch.Type == html.ElementNode {
+			run = append(run, ch)
+		}
+	}
+	flush()
+}
+
+func (c *htmlConverter) block(n *html.Node) {
+	switch n.Data {
+	case "title":
+		c.title = cleanInline(textContent(n))
+		return
+	case "head":
+		// Only the title is of interest in <head>.
+		for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+			if ch.Type == html.ElementNode && ch.Data == "title" {
+				c.block(ch)
+			}
+		}
+		return
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		text := cleanInline(inlineChildren(n))
+		if text == "" {
+			return
+		}
+		if n.Data == "h1" && c.firstH1 == "" {
+			c.firstH1 = text
+		}
+		level := int(n.Data[1] - '0')
+		c.emit(strings.Repeat("#", level) + " " + text)
+		return
+	case "p":
+		c.emit(cleanInline(inlineChildren(n)))
+		return
+	case "pre":
+		c.emit("```\n" + strings.Trim(textContent(n), "\n") + "\n```")
+		return
+	case "ul", "ol":
+		c.emit(renderList(n, 0))
+		return
+	case "blockquote":
+		sub := &htmlConverter{}
+		sub.blocks(n)
+		var lines []string
+		for _, line := range strings.Split(strings.Join(sub.out, "\n\n"), "\n") {
+			lines = append(lines, strings.TrimRight("> "+line, " "))
+		}
+		c.emit(strings.Join(lines, "\n"))
+		return
+	case "table":
+		c.emit(renderTable(n))
+		return
+	case "hr":
+		c.emit("---")
+		return
+	}
+	if skipElement(n) {
+		return
+	}
+	c.blocks(n)
+}
+
+func skipElement(n *html.Node) bool {
+	switch n.Data {
+	case "script", "style", "noscript", "template", "svg", "iframe",
+		"nav", "button", "form", "select", "input", "textarea":
+		return true
+	}
+	return false
+}
+
+func isBlock(n *html.Node) bool {
+	switch n.Data {
+	case "html", "head", "body", "title",
+		"address", "article", "aside", "blockquote", "details", "dialog",
+		"dd", "div", "dl", "dt", "fieldset", "figcaption", "figure",
+		"footer", "header", "h1", "h2", "h3", "h4", "h5", "h6",
+		"hr", "li", "main", "ol", "p", "pre", "section", "summary",
+		"table", "ul":
+		return true
+	}
+	return false
+}
+
+func inlineChildren(n *html.Node) string {
+	var b strings.Builder
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		b.WriteString(inlineNode(ch))
+	}
+	return b.String()
+}
+
+func inlineNode(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return n.Data
+	case html.ElementNode:
+	case html.ErrorNode, html.DocumentNode, html.CommentNode, html.DoctypeNode, html.RawNode:
+		return ""
+	}
+	if skipElement(n) {
+		return ""
+	}
+	switch n.Data {
+	case "br":
+		return "\n"
+	case "strong", "b":
+		return wrapInline("**", inlineChildren(n))
+	case "em", "i":
+		return wrapInline("*", inlineChildren(n))
+	case "code", "kbd", "samp":
+		text := strings.TrimSpace(textContent(n))
+		if text == "" {
+			return ""
+		}
+		return "`" + text + "`"
+	case "a":
+		text := cleanInline(inlineChildren(n))
+		href := strings.TrimSpace(attr(n, "href"))
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") || text == "" {
+			return text
+		}
+		return fmt.Sprintf("[%s](%s)", text, href)
+	case "img":
+		alt := strings.TrimSpace(attr(n, "alt"))
+		src := strings.TrimSpace(attr(n, "src"))
+		if alt == "" || src == "" {
+			return ""
+		}
+		return fmt.Sprintf("![%s](%s)", alt, src)
+	}
+	// Block elements nested in inline context (e.g. <p> inside <li>) become line breaks.
+	if isBlock(n) {
+		return "\n" + inlineChildren(n) + "\n"
+	}
+	return inlineChildren(n)
+}
+
+// wrapInline wraps text in a markdown marker, keeping surrounding spaces outside.
+func wrapInline(marker, text string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	lead := text[:len(text)-len(strings.TrimLeft(text, " \t\n"))]
+	trail := text[len(strings.TrimRight(text, " \t\n")):]
+	return lead + marker + trimmed + marker + trail
+}
+
+// cleanInline collapses whitespace within lines and trims empty lines.
+func cleanInline(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func renderList(n *html.Node, depth int) string {
+	var lines []string
+	i := 0
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.Data != "li" {
+			continue
+		}
+		i++
+		marker := "- "
+		if n.Data == "ol" {
+			marker = fmt.Sprintf("%d. ", i)
+		}
+		indent := strings.Repeat("  ", depth)
+		var text strings.Builder
+		var nested []string
+		for ch := li.FirstChild; ch != nil; ch = ch.NextSibling {
+			if ch.Type == html.ElementNode && (ch.Data == "ul" || ch.Data == "ol") {
+				nested = append(nested, renderList(ch, depth+1))
+				continue
+			}
+			text.WriteString(inlineNode(ch))
+		}
+		item := strings.ReplaceAll(cleanInline(text.String()), "\n", "\n"+indent+"  ")
+		lines = append(lines, indent+marker+item)
+		lines = append(lines, nested...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func renderTable(n *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+			if ch.Type != html.ElementNode {
+				continue
+			}
+			switch ch.Data {
+			case "tr":
+				var cells []string
+				for cell := ch.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+						text := strings.ReplaceAll(cleanInline(inlineChildren(cell)), "\n", " ")
+						cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+					}
+				}
+				if len(cells) > 0 {
+					rows = append(rows, cells)
+				}
+			default:
+				// thead/tbody/tfoot; nested tables are flattened into the outer one.
+				walk(ch)
+			}
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+	width := 0
+	for _, r := range rows {
+		if len(r) > width {
+			width = len(r)
+		}
+	}
+	line := func(cells []string) string {
+		for len(cells) < width {
+			cells = append(cells, "")
+		}
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+	out := []string{line(rows[0]), line(strings.Split(strings.Repeat("---,", width-1)+"---", ","))}
+	for _, r := range rows[1:] {
+		out = append(out, line(r))
+	}
+	return strings.Join(out, "\n")
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		b.WriteString(textContent(ch))
+	}
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,616 @@
+package sources
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoText is returned when a source yields no extractable text (scanned
+// PDFs, fonts without a usable encoding, empty pages, ...).
+var ErrNoText = errors.New("no extractable text")
+
+// maxInflatedStream bounds a single decompressed PDF stream.
+const maxInflatedStream = 64 << 20
+
+var (
+	pdfObjRe      = regexp.MustCompile(`(?s)(\d+)\s+\d+\s+obj\b(.*?)\bendobj\b`)
+	pdfStreamRe   = regexp.MustCompile(`(?s)^(.*?)\bstream\r?\n`)
+	pdfTitleRe    = regexp.MustCompile(`/Title\s*(\(|<)`)
+	pdfRefRe      = regexp.MustCompile(`(\d+)\s+\d+\s+R\b`)
+	pdfCatalogRe  = regexp.MustCompile(`/Type\s*/Catalog\b`)
+	pdfPagesRe    = regexp.MustCompile(`/Pages\s+(\d+)\s+\d+\s+R\b`)
+	pdfKidsRe     = regexp.MustCompile(`/Kids\s*\[([^\]]*)\]`)
+	pdfContentsRe = regexp.MustCompile(`/Contents\s*(\[[^\]]*\]|\d+\s+\d+\s+R\b)`)
+	pdfXRefTypeRe = regexp.MustCompile(`/Type\s*/XRef\b`)
+	pdfInfoRe     = regexp.MustCompile(`/Info\s+(\d+)\s+\d+\s+R\b`)
+)
+
+// maxPDFArrayDepth bounds array nesting in content streams; deeper brackets
+// are lexed as plain tokens.
+const maxPDFArrayDepth = 32
+
+// PDFToMarkdown extracts the text of a PDF as plain markdown paragraphs and
+// returns the document title from the trailer's Info dictionary when present.
+//
+// This is a small, dependency-free extractor: it decodes uncompressed and
+// FlateDecode content streams and interprets the text-showing operators
+// (Tj, TJ, ', ") with line breaks from positioning operators. Pages come in
+// page-tree order (Catalog /Pages -> /Kids). When the tree cannot be followed
+// because its objects live in compressed object streams, content streams are
+// read in file order instead, which may not match the page order. It handles
+// typical text PDFs produced by office tools and browsers; fonts whose glyph
+// codes need a ToUnicode CMap (most CID fonts) and scanned pages produce
+// little or no text, reported as ErrNoText.
+func PDFToMarkdown(data []byte) (string, string, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, "\x00\t\r\n "), []byte("%PDF-")) {
+		return "", "", errors.New("not a PDF file")
+	}
+
+	objects := map[string][]byte{}
+	var order [][]byte
+	for _, m := range pdfObjRe.FindAllSubmatch(data, -1) {
+		// Later definitions (incremental updates) replace earlier ones.
+		objects[string(m[1])] = m[2]
+		order = append(order, m[2])
+	}
+	trailers := pdfTrailers(data, order)
+	if pdfEncrypted(trailers) {
+		return "", "", errors.New("encrypted PDFs are not supported")
+	}
+
+	pages := pdfTreePages(objects, order)
+	if pages == nil {
+		for _, body := range order {
+			content, ok := pdfObjectContent(body)
+			if !ok || !bytes.Contains(content, []byte("BT")) {
+				continue
+			}
+			if text := strings.TrimSpace(pdfContentText(content)); text != "" {
+				pages = append(pages, text)
+			}
+		}
+	}
+
+	title := pdfInfoTitle(trailers, objects)
+	if len(pages) == 0 {
+		return title, "", ErrNoText
+	}
+	return title, strings.Join(pages, "\n\n") + "\n", nil
+}
+
+// pdfTrailers returns the trailer dictionaries in file order: classic
+// "trailer << >>" dictionaries, then cross-reference stream dictionaries.
+func pdfTrailers(data []byte, order [][]byte) [][]byte {
+	var out [][]byte
+	rest := data
+	for {
+		i := bytes.Index(rest, []byte("trailer"))
+		if i < 0 {
+			break
+		}
+		rest = rest[i+len("trailer"):]
+		dict := bytes.TrimLeft(rest, "\x00\t\r\n\f ")
+		if bytes.HasPrefix(dict, []byte("<<")) {
+			if d := pdfDict(dict); d != nil {
+				out = append(out, d)
+			}
+		}
+	}
+	for _, body := range order {
+		if dict := pdfStreamDict(body); pdfXRefTypeRe.MatchString(dict) {
+			out = append(out, []byte(dict))
+		}
+	}
+	return out
+}
+
+// pdfEncrypted reports whether a trailer dictionary has an /Encrypt entry.
+func pdfEncrypted(trailers [][]byte) bool {
+	for _, t := range trailers {
+		if bytes.Contains(t, []byte("/Encrypt")) {
+			return true
+		}
+	}
+	return false
+}
+
+// pdfInfoTitle returns /Title from the Info dictionary referenced by the
+// last trailer that has one. Titles elsewhere (outline items, annotations)
+// are ignored.
+func pdfInfoTitle(trailers [][]byte, objects map[string][]byte) string {
+	for i := len(trailers) - 1; i >= 0; i-- {
+		m := pdfInfoRe.FindSubmatch(trailers[i])
+		if m == nil {
+			continue
+		}
+		info := []byte(pdfStreamDict(objects[string(m[1])]))
+		loc := pdfTitleRe.FindSubmatchIndex(info)
+		if loc == nil {
+			return ""
+		}
+		if s, _, ok := readPDFString(info, loc[2]); ok {
+			return strings.TrimSpace(decodePDFText(s))
+		}
+		return ""
+	}
+	return ""
+}
+
+// pdfDict returns the "<< ... >>" dictionary data starts with, nested
+// dictionaries included, or nil when it is unterminated.
+func pdfDict(data []byte) []byte {
+	depth := 0
+	for i := 0; i+1 < len(data); i++ {
+		if data[i] == '<' && data[i+1] == '<' {
+			depth++
+			i++
+		} else if data[i] == '>' && data[i+1] == '>' {
+			depth--
+			i++
+			if depth == 0 {
+				return data[:i+1]
+			}
+		}
+	}
+	return nil
+}
+
+// pdfTreePages returns the text of each page in page-tree order, or nil when
+// the catalog or page tree cannot be resolved from top-level objects. order
+// holds object bodies in file order; the last catalog wins.
+func pdfTreePages(objects map[string][]byte, order [][]byte) []string {
+	var root string
+	for i := len(order) - 1; i >= 0; i-- {
+		dict := pdfStreamDict(order[i])
+		if !pdfCatalogRe.MatchString(dict) {
+			continue
+		}
+		if m := pdfPagesRe.FindStringSubmatch(dict); m != nil {
+			root = m[1]
+			break
+		}
+	}
+	if root == "" {
+		return nil
+	}
+
+	var pages []string
+	found := false
+	seen := map[string]bool{}
+	var walk func(id string)
+	walk = func(id string) {
+		body, ok := objects[id]
+		if !ok || seen[id] {
+			return
+		}
+		seen[id] = true
+		dict := pdfStreamDict(body)
+		if m := pdfKidsRe.FindStringSubmatch(dict); m != nil {
+			for _, ref := range pdfRefRe.FindAllStringSubmatch(m[1], -1) {
+				walk(ref[1])
+			}
+			return
+		}
+		m := pdfContentsRe.FindStringSubmatch(dict)
+		if m == nil {
+			return
+		}
+		found = true
+		// A page's content array is one stream split into parts.
+		var content []byte
+		for _, ref := range pdfRefRe.FindAllStringSubmatch(m[1], -1) {
+			if part, ok := pdfObjectContent(objects[ref[1]]); ok {
+				content = append(append(content, part...), '\n')
+			}
+		}
+		if text := strings.TrimSpace(pdfContentText(content)); text != "" {
+			pages = append(pages, text)
+		}
+	}
+	walk(root)
+	if !found {
+		return nil
+	}
+	return pages
+}
+
+// pdfStreamDict returns the dictionary text of an object body: everything
+// before "stream" for stream objects, the whole body otherwise.
+func pdfStreamDict(body []byte) string {
+	if sm := pdfStreamRe.FindSubmatchIndex(body); sm != nil {
+		return string(body[sm[2]:sm[3]])
+	}
+	return string(body)
+}
+
+// pdfObjectContent decodes the stream of an object body, skipping streams
+// that cannot hold page content (images, fonts, xref and object streams).
+func pdfObjectContent(body []byte) ([]byte, bool) {
+	sm := pdfStreamRe.FindSubmatchIndex(body)
+	if sm == nil {
+		return nil, false
+	}
+	dict := string(body[sm[2]:sm[3]])
+	if skipPDFStream(dict) {
+		return nil, false
+	}
+	raw := body[sm[1]:]
+	if end := bytes.LastIndex(raw, []byte("endstream")); end >= 0 {
+		raw = raw[:end]
+	}
+	return decodePDFStream(dict, raw)
+}
+
+func skipPDFStream(dict string) bool {
+	for _, marker := range []string{"/Image", "/XRef", "/ObjStm", "/Length1", "/Length2", "/FontFile", "/Metadata", "/EmbeddedFile", "/ICCBased", "/N 3", "/N 4"} {
+		if strings.Contains(dict, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func decodePDFStream(dict string, raw []byte) ([]byte, bool) {
+	if !strings.Contains(dict, "/Filter") {
+		return raw, true
+	}
+	// Only a lone FlateDecode filter is supported.
+	if !strings.Contains(dict, "/FlateDecode") || strings.Count(dict, "Decode") > 1 {
+		return nil, false
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, false
+	}
+	defer func() { _ = zr.Close() }()
+	out, err := io.ReadAll(io.LimitReader(zr, maxInflatedStream))
+	if err != nil && len(out) == 0 {
+		return nil, false
+	}
+	return out, true
+}
+
+// pdfContentText interprets the text operators of a page content stream.
+func pdfContentText(content []byte) string {
+	var b strings.Builder
+	var operands []pdfToken
+	newline := func() {
+		s := b.String()
+		if s != "" && !strings.HasSuffix(s, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	space := func() {
+		s := b.String()
+		if s != "" && !strings.HasSuffix(s, " ") && !strings.HasSuffix(s, "\n") {
+			b.WriteString(" ")
+		}
+	}
+	show := func(s []byte) { b.WriteString(decodePDFText(s)) }
+
+	lex := &pdfLexer{data: content}
+	for {
+		tok, ok := lex.next()
+		if !ok {
+			break
+		}
+		if tok.kind != pdfOperator {
+			operands = append(operands, tok)
+			continue
+		}
+		switch tok.text {
+		case "Tj":
+			if n := len(operands); n > 0 && operands[n-1].kind == pdfString {
+				show(operands[n-1].str)
+			}
+		case "'", `"`:
+			newline()
+			if n := len(operands); n > 0 && operands[n-1].kind == pdfString {
+				show(operands[n-1].str)
+			}
+		case "TJ":
+			if n := len(operands); n > 0 && operands[n-1].kind == pdfArray {
+				for _, el := range operands[n-1].items {
+					switch el.kind {
+					case pdfString:
+						show(el.str)
+					case pdfNumber:
+						// Large negative adjustments are word gaps.
+						if el.num < -180 {
+							space()
+						}
+					case pdfOperator, pdfArray, pdfOther:
+					}
+				}
+			}
+		case "Td", "TD":
+			if n := len(operands); n >= 2 {
+				switch {
+				case operands[n-1].num != 0:
+					newline()
+				case operands[n-2].num > 0:
+					space()
+				}
+			}
+		case "T*", "ET":
+			newline()
+		case "Tm":
+			newline()
+		}
+		operands = operands[:0]
+	}
+
+	// Collapse runs of blank lines and trailing spaces.
+	var lines []string
+	for _, line := range strings.Split(b.String(), "\n") {
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+	out := strings.Join(lines, "\n")
+	for strings.Contains(out, "\n\n\n") {
+		out = strings.ReplaceAll(out, "\n\n\n", "\n\n")
+	}
+	return out
+}
+
+// decodePDFText turns string bytes into text: UTF-16BE with a BOM, UTF-16BE
+// guessed from 0x00-prefixed code pairs, otherwise PDFDocEncoding (≈ Latin-1).
+func decodePDFText(s []byte) string {
+	if len(s) >= 2 && s[0] == 0xFE && s[1] == 0xFF {
+		return utf16BE(s[2:])
+	}
+	if len(s) >= 2 && len(s)%2 == 0 {
+		zeroHigh := true
+		for i := 0; i < len(s); i += 2 {
+			if s[i] != 0 {
+				zeroHigh = false
+				break
+			}
+		}
+		if zeroHigh {
+			return utf16BE(s)
+		}
+	}
+	var b strings.Builder
+	for _, c := range s {
+		switch {
+		case c == '\t' || c == '\n' || c == '\r':
+			b.WriteByte(' ')
+		case c < 0x20:
+		default:
+			b.WriteRune(rune(c))
+		}
+	}
+	return b.String()
+}
+
+func utf16BE(s []byte) string {
+	u := make([]uint16, 0, len(s)/2)
+	for i := 0; i+1 < len(s); i += 2 {
+		u = append(u, uint16(s[i])<<8|uint16(s[i+1]))
+	}
+	return string(utf16.Decode(u))
+}
+
+type pdfTokenKind int
+
+const (
+	pdfOperator pdfTokenKind = iota
+	pdfString
+	pdfNumber
+	pdfArray
+	pdfOther // names, dictionaries, booleans, ...
+)
+
+type pdfToken struct {
+	kind  pdfTokenKind
+	text  string
+	str   []byte
+	num   float64
+	items []pdfToken
+}
+
+type pdfLexer struct {
+	data  []byte
+	pos   int
+	depth int // open arrays
+}
+
+func (l *pdfLexer) next() (pdfToken, bool) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return pdfToken{}, false
+	}
+	c := l.data[l.pos]
+	switch {
+	case c == '(' || (c == '<' && l.peek(1) != '<'):
+		s, end, ok := readPDFString(l.data, l.pos)
+		if !ok {
+			l.pos = len(l.data)
+			return pdfToken{}, false
+		}
+		l.pos = end
+		return pdfToken{kind: pdfString, str: s}, true
+	case c == '<' || c == '>':
+		// Dictionary delimiters (inline images, marked-content properties).
+		l.pos += 2
+		return pdfToken{kind: pdfOther}, true
+	case c == '[' && l.depth >= maxPDFArrayDepth:
+		l.pos++
+		return pdfToken{kind: pdfOther}, true
+	case c == '[':
+		l.pos++
+		l.depth++
+		defer func() { l.depth-- }()
+		var items []pdfToken
+		for {
+			l.skipSpace()
+			if l.pos >= len(l.data) {
+				break
+			}
+			if l.data[l.pos] == ']' {
+				l.pos++
+				break
+			}
+			tok, ok := l.next()
+			if !ok {
+				break
+			}
+			items = append(items, tok)
+		}
+		return pdfToken{kind: pdfArray, items: items}, true
+	case c == ']' || c == '{' || c == '}' || c == ')':
+		l.pos++
+		return pdfToken{kind: pdfOther}, true
+	case c == '/':
+		start := l.pos
+		l.pos++
+		for l.pos < len(l.data) && !isPDFDelim(l.data[l.pos]) {
+			l.pos++
+		}
+		return pdfToken{kind: pdfOther, text: string(l.data[start:l.pos])}, true
+	}
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFDelim(l.data[l.pos]) {
+		l.pos++
+	}
+	if l.pos == start {
+		l.pos++
+		return pdfToken{kind: pdfOther}, true
+	}
+	word := string(l.data[start:l.pos])
+	if f, err := strconv.ParseFloat(word, 64); err == nil {
+		return pdfToken{kind: pdfNumber, num: f, text: word}, true
+	}
+	if word == "BI" {
+		// Skip inline image data up to EI.
+		if end := bytes.Index(l.data[l.pos:], []byte("EI")); end >= 0 {
+			l.pos += end + 2
+		} else {
+			l.pos = len(l.data)
+		}
+		return pdfToken{kind: pdfOther}, true
+	}
+	return pdfToken{kind: pdfOperator, text: word}, true
+}
+
+func (l *pdfLexer) peek(off int) byte {
+	if l.pos+off < len(l.data) {
+		return l.data[l.pos+off]
+	}
+	return 0
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if c == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' && c != '\f' && c != 0 {
+			return
+		}
+		l.pos++
+	}
+}
+
+func isPDFDelim(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n', '\f', 0, '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+// readPDFString reads a literal "(...)" or hex "<...>" string starting at pos
+// and returns its bytes and the position after it.
+func readPDFString(data []byte, pos int) ([]byte, int, bool) {
+	if pos >= len(data) {
+		return nil, pos, false
+	}
+	if data[pos] == '<' {
+		end := bytes.IndexByte(data[pos:], '>')
+		if end < 0 {
+			return nil, pos, false
+		}
+		hex := make([]byte, 0, end)
+		for _, c := range data[pos+1 : pos+end] {
+			if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+				hex = append(hex, c)
+			}
+		}
+		if len(hex)%2 == 1 {
+			hex = append(hex, '0')
+		}
+		out := make([]byte, len(hex)/2)
+		for i := range out {
+			v, _ := strconv.ParseUint(string(hex[2*i:2*i+2]), 16, 8)
+			out[i] = byte(v)
+		}
+		return out, pos + end + 1, true
+	}
+	if data[pos] != '(' {
+		return nil, pos, false
+	}
+	var out []byte
+	depth := 0
+	for i := pos; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '(':
+			depth++
+			if depth == 1 {
+				continue
+			}
+		case ')':
+			depth--
+			if depth == 0 {
+				return out, i + 1, true
+			}
+		case '\\':
+			i++
+			if i >= len(data) {
+				return out, i, true
+			}
+			e := data[i]
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// Line continuation.
+				if e == '\r' && i+1 < len(data) && data[i+1] == '\n' {
+					i++
+				}
+			default:
+				if e >= '0' && e <= '7' {
+					v := 0
+					j := 0
+					for ; j < 3 && i+j < len(data) && data[i+j] >= '0' && data[i+j] <= '7'; j++ {
+						v = v*8 + int(data[i+j]-'0')
+					}
+					i += j - 1
+					out = append(out, byte(v))
+				} else {
+					out = append(out, e)
+				}
+			}
+			continue
+		}
+		out = append(out, c)
+	}
+	return out, len(data), true
+}
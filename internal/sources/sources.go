@@ -0,0 +1,86 @@
+// Package sources converts imported source artifacts (saved web pages, PDFs)
+// into markdown so they can be stored next to the original under a ticket's
+// sources/ directory and indexed for search.
+package sources
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Source kinds understood by Extract.
+const (
+	KindHTML = "html"
+	KindPDF  = "pdf"
+)
+
+// Extracted is the markdown rendition of a source artifact.
+type Extracted struct {
+	Kind     string
+	Title    string
+	Markdown string
+}
+
+// DetectKind guesses the kind of a source from its file name, an optional
+// HTTP Content-Type and its leading bytes. It returns "" for content that has
+// no text extractor (plain text, markdown, images, archives, ...).
+func DetectKind(name string, data []byte, contentType string) string {
+	ct := strings.ToLower(contentType)
+	switch {
+	case strings.HasPrefix(ct, "application/pdf"):
+		return KindPDF
+	case strings.HasPrefix(ct, "text/html"), strings.HasPrefix(ct, "application/xhtml"):
+		return KindHTML
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".pdf":
+		return KindPDF
+	case ".html", ".htm", ".xhtml":
+		return KindHTML
+	}
+
+	head := bytes.ToLower(bytes.TrimLeft(data[:min(len(data), 512)], "\xef\xbb\xbf \t\r\n"))
+	switch {
+	case bytes.HasPrefix(head, []byte("%pdf-")):
+		return KindPDF
+	case bytes.HasPrefix(head, []byte("<!doctype html")), bytes.HasPrefix(head, []byte("<html")):
+		return KindHTML
+	}
+	return ""
+}
+
+// Extract converts data of the given kind into markdown.
+func Extract(kind string, data []byte) (*Extracted, error) {
+	var (
+		title, md string
+		err       error
+	)
+	switch kind {
+	case KindHTML:
+		title, md, err = HTMLToMarkdown(data)
+	case KindPDF:
+		title, md, err = PDFToMarkdown(data)
+	default:
+		return nil, errors.Errorf("no text extractor for source kind %q", kind)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "extract %s", kind)
+	}
+	if strings.TrimSpace(md) == "" {
+		return nil, errors.Wrapf(ErrNoText, "extract %s", kind)
+	}
+	return &Extracted{Kind: kind, Title: title, Markdown: md}, nil
+}
+
+// SHA256 returns the hex-encoded SHA-256 of data, the form stored in
+// ExternalSource.SHA.
+func SHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
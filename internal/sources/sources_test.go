@@ -0,0 +1,161 @@
+package sources
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHTMLToMarkdown(t *testing.T) {
+	page := `<!DOCTYPE html>
+<html><head><title>Retry Budget RFC</title><style>body{}</style></head>
+<body>
+<nav><a href="/">Home</a></nav>
+<h1>Retry budgets</h1>
+<p>Clients <strong>must</strong> cap retries; see <a href="https://example.com/sre">the SRE book</a>.</p>
+<ul><li>Per-route budget</li><li>Global <em>fallback</em><ol><li>Jitter</li></ol></li></ul>
+<pre>retry: 3
+backoff: 200ms</pre>
+<table><tr><th>Route</th><th>Budget</th></tr><tr><td>/api</td><td>10%</td></tr></table>
+<script>alert("x")</script>
+</body></html>`
+
+	title, md, err := HTMLToMarkdown([]byte(page))
+	if err != nil {
+		t.Fatalf("HTMLToMarkdown: %v", err)
+	}
+	if title != "Retry Budget RFC" {
+		t.Errorf("title = %q", title)
+	}
+	for _, want := range []string{
+		"# Retry budgets",
+		"Clients **must** cap retries; see [the SRE book](https://example.com/sre).",
+		"- Per-route budget\n- Global *fallback*\n  1. Jitter",
+		"```\nretry: 3\nbackoff: 200ms\n```",
+		"| Route | Budget |\n| --- | --- |\n| /api | 10% |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	for _, unwanted := range []string{"alert", "body{}", "Home"} {
+		if strings.Contains(md, unwanted) {
+			t.Errorf("markdown should not contain %q:\n%s", unwanted, md)
+		}
+	}
+}
+
+// buildPDF assembles a minimal one-page PDF with a Flate-compressed content stream.
+func buildPDF(t *testing.T, content string) []byte {
+	t.Helper()
+	return buildPDFPages(t, "", content)
+}
+
+// buildPDFPages assembles a PDF with one Flate-compressed content stream per
+// page. Content objects are written in reverse page order so extraction has
+// to follow the page tree; trailerExtra is appended to the trailer dictionary.
+func buildPDFPages(t *testing.T, trailerExtra string, pages ...string) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	b.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	var kids []string
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 10+i))
+	}
+	fmt.Fprintf(&b, "2 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(pages))
+	for i := range pages {
+		fmt.Fprintf(&b, "%d 0 obj\n<< /Type /Page /Parent 2 0 R /Contents %d 0 R >>\nendobj\n", 10+i, 100+i)
+	}
+	for i := len(pages) - 1; i >= 0; i-- {
+		var z bytes.Buffer
+		zw := zlib.NewWriter(&z)
+		if _, err := zw.Write([]byte(pages[i])); err != nil {
+			t.Fatalf("compress: %v", err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatalf("compress: %v", err)
+		}
+		fmt.Fprintf(&b, "%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", 100+i, z.Len())
+		b.Write(z.Bytes())
+		b.WriteString("\nendstream\nendobj\n")
+	}
+	b.WriteString("5 0 obj\n<< /Title (Quarterly \\(Q3\\) Report) >>\nendobj\n")
+	fmt.Fprintf(&b, "trailer\n<< /Root 1 0 R /Info 5 0 R %s>>\n%%%%EOF\n", trailerExtra)
+	return b.Bytes()
+}
+
+func TestPDFToMarkdown(t *testing.T) {
+	data := buildPDF(t, `BT /F1 12 Tf 72 720 Td (Latency budget) Tj 0 -14 Td [(p99 under) -250 (200ms)] TJ T* <FEFF00E9007400E9> Tj ET`)
+
+	if kind := DetectKind("report.bin", data, ""); kind != KindPDF {
+		t.Fatalf("DetectKind = %q, want pdf", kind)
+	}
+	ex, err := Extract(KindPDF, data)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if ex.Title != "Quarterly (Q3) Report" {
+		t.Errorf("title = %q", ex.Title)
+	}
+	want := "Latency budget\np99 under 200ms\nété\n"
+	if ex.Markdown != want {
+		t.Errorf("markdown = %q, want %q", ex.Markdown, want)
+	}
+}
+
+func TestPDFToMarkdownFollowsPageTree(t *testing.T) {
+	data := buildPDFPages(t, "", `BT (First page) Tj ET`, `BT (Second page) Tj ET`, `BT (Third page) Tj ET`)
+	_, md, err := PDFToMarkdown(data)
+	if err != nil {
+		t.Fatalf("PDFToMarkdown: %v", err)
+	}
+	if want := "First page\n\nSecond page\n\nThird page\n"; md != want {
+		t.Errorf("markdown = %q, want %q", md, want)
+	}
+}
+
+func TestPDFToMarkdownEncryption(t *testing.T) {
+	// /Encrypt in page text is not an encryption dictionary.
+	data := buildPDFPages(t, "", `BT (Set /Encrypt in the trailer) Tj ET`)
+	if _, md, err := PDFToMarkdown(data); err != nil || md != "Set /Encrypt in the trailer\n" {
+		t.Fatalf("PDFToMarkdown = %q, %v", md, err)
+	}
+
+	data = buildPDFPages(t, "/Encrypt 9 0 R ", `BT (Secret) Tj ET`)
+	if _, _, err := PDFToMarkdown(data); err == nil || !strings.Contains(err.Error(), "encrypted") {
+		t.Fatalf("expected an encrypted PDF error, got %v", err)
+	}
+}
+
+func TestPDFToMarkdownTakesTitleFromInfo(t *testing.T) {
+	data := buildPDFPages(t, "", `BT (Body) Tj ET`)
+	// An outline item's /Title comes first in the file but is not the title.
+	data = bytes.Replace(data, []byte("1 0 obj"), []byte("6 0 obj\n<< /Title (Chapter 1) /Parent 7 0 R >>\nendobj\n1 0 obj"), 1)
+	title, _, err := PDFToMarkdown(data)
+	if err != nil {
+		t.Fatalf("PDFToMarkdown: %v", err)
+	}
+	if title != "Quarterly (Q3) Report" {
+		t.Errorf("title = %q", title)
+	}
+}
+
+func TestPDFToMarkdownSurvivesDeepArrays(t *testing.T) {
+	data := buildPDFPages(t, "", "BT (Kept) Tj "+strings.Repeat("[", 1<<20)+" ET")
+	if _, md, err := PDFToMarkdown(data); err != nil || md != "Kept\n" {
+		t.Fatalf("PDFToMarkdown = %q, %v", md, err)
+	}
+}
+
+func TestExtractReportsMissingText(t *testing.T) {
+	data := buildPDF(t, `q 1 0 0 1 0 0 cm Q`)
+	if _, err := Extract(KindPDF, data); err == nil {
+		t.Fatal("expected an error for a PDF without text")
+	}
+	if _, err := Extract("", []byte("plain")); err == nil {
+		t.Fatal("expected an error for an unknown kind")
+	}
+}
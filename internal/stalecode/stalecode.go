@@ -0,0 +1,240 @@
+// Package stalecode finds documents whose related files changed in git after
+// the document's LastUpdated timestamp.
+package stalecode
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/pkg/errors"
+)
+
+// Options restricts which documents Scan looks at.
+type Options struct {
+	// Ticket limits the scan to one ticket (exact ID); empty scans all docs.
+	Ticket string
+	// IncludeArchived also scans docs under archive/.
+	IncludeArchived bool
+}
+
+// FileChange summarizes the commits to one related file after a doc's LastUpdated.
+type FileChange struct {
+	Path         string    // as displayed: repo-relative when known, else the raw frontmatter path
+	Abs          string    // resolved absolute path (related_files.norm_abs)
+	Commits      int       // commits touching the file after LastUpdated
+	LastAuthor   string    // author of the newest such commit
+	LastCommit   string    // abbreviated hash of the newest such commit
+	LastCommitAt time.Time // committer date of the newest such commit
+}
+
+// DocReport is a document with at least one related file changed since LastUpdated.
+type DocReport struct {
+	Ticket      string
+	Path        string // absolute doc path (docs.path)
+	LastUpdated time.Time
+	Files       []FileChange // sorted by Path
+}
+
+type commit struct {
+	hash   string
+	author string
+	at     time.Time
+}
+
+type relatedRow struct {
+	docPath     string
+	ticket      string
+	lastUpdated time.Time
+	abs         string
+	display     string
+}
+
+// Scan reads related files from the workspace index and asks git for commits
+// newer than each document's LastUpdated. Docs without LastUpdated and files
+// outside any git repository are skipped. Reports are sorted by ticket, path.
+func Scan(ctx context.Context, ws *workspace.Workspace, opts Options) ([]DocReport, error) {
+	if ws == nil || ws.DB() == nil {
+		return nil, errors.New("workspace index not initialized")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, errors.Wrap(err, "stale code detection requires git")
+	}
+
+	rows, err := queryRelatedRows(ctx, ws.DB(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// One git log per file, reaching back to the oldest doc that relates it.
+	since := map[string]time.Time{}
+	for _, r := range rows {
+		if t, ok := since[r.abs]; !ok || r.lastUpdated.Before(t) {
+			since[r.abs] = r.lastUpdated
+		}
+	}
+	history := map[string][]commit{}
+	for abs, t := range since {
+		commits, err := gitLog(ctx, abs, t)
+		if err != nil {
+			return nil, err
+		}
+		history[abs] = commits
+	}
+
+	byDoc := map[string]*DocReport{}
+	var order []string
+	for _, r := range rows {
+		fc := FileChange{Path: r.display, Abs: r.abs}
+		for _, c := range history[r.abs] {
+			if !c.at.After(r.lastUpdated) {
+				continue
+			}
+			fc.Commits++
+			if c.at.After(fc.LastCommitAt) {
+				fc.LastCommitAt = c.at
+				fc.LastAuthor = c.author
+				fc.LastCommit = c.hash
+			}
+		}
+		if fc.Commits == 0 {
+			continue
+		}
+		rep, ok := byDoc[r.docPath]
+		if !ok {
+			rep = &DocReport{Ticket: r.ticket, Path: r.docPath, LastUpdated: r.lastUpdated}
+			byDoc[r.docPath] = rep
+			order = append(order, r.docPath)
+		}
+		rep.Files = append(rep.Files, fc)
+	}
+
+	out := make([]DocReport, 0, len(order))
+	for _, p := range order {
+		rep := byDoc[p]
+		sort.Slice(rep.Files, func(i, j int) bool { return rep.Files[i].Path < rep.Files[j].Path })
+		out = append(out, *rep)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Ticket != out[j].Ticket {
+			return out[i].Ticket < out[j].Ticket
+		}
+		return out[i].Path < out[j].Path
+	})
+	return out, nil
+}
+
+func queryRelatedRows(ctx context.Context, db *sql.DB, opts Options) ([]relatedRow, error) {
+	q := `
+SELECT d.path, COALESCE(d.ticket_id,''), d.last_updated, rf.norm_abs,
+       COALESCE(rf.norm_repo_rel,''), COALESCE(rf.raw_path,'')
+FROM related_files rf
+JOIN docs d ON d.doc_id = rf.doc_id
+WHERE d.parse_ok = 1
+  AND COALESCE(d.last_updated,'') != ''
+  AND COALESCE(rf.norm_abs,'') != ''`
+	var args []any
+	if strings.TrimSpace(opts.Ticket) != "" {
+		q += " AND d.ticket_id = ?"
+		args = append(args, strings.TrimSpace(opts.Ticket))
+	}
+	if !opts.IncludeArchived {
+		q += " AND d.is_archived_path = 0"
+	}
+	q += " ORDER BY d.path, rf.rf_id"
+
+	rs, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "query related files")
+	}
+	defer func() { _ = rs.Close() }()
+
+	var out []relatedRow
+	seen := map[string]struct{}{}
+	for rs.Next() {
+		var r relatedRow
+		var lastUpdated, repoRel, raw string
+		if err := rs.Scan(&r.docPath, &r.ticket, &lastUpdated, &r.abs, &repoRel, &raw); err != nil {
+			return nil, errors.Wrap(err, "scan related file row")
+		}
+		t, err := time.Parse(time.RFC3339Nano, lastUpdated)
+		if err != nil {
+			continue
+		}
+		r.lastUpdated = t
+		r.display = repoRel
+		if r.display == "" {
+			r.display = raw
+		}
+		key := r.docPath + "\x00" + r.abs
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, r)
+	}
+	return out, errors.Wrap(rs.Err(), "iterate related files")
+}
+
+// gitLog lists commits touching abs after since. Files outside a git
+// repository yield no commits.
+func gitLog(ctx context.Context, abs string, since time.Time) ([]commit, error) {
+	// Run from the nearest existing directory so deleted files still match.
+	dir := filepath.Dir(abs)
+	for {
+		if st, err := os.Stat(dir); err == nil && st.IsDir() {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+	rel, err := filepath.Rel(dir, abs)
+	if err != nil {
+		return nil, nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "-C", dir, "log",
+		"--format=%h%x1f%an%x1f%cI",
+		"--since="+since.UTC().Format(time.RFC3339),
+		"--", filepath.ToSlash(rel))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if strings.Contains(stderr.String(), "not a git repository") {
+			return nil, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// Paths outside the repository that contains dir: nothing to report.
+		if strings.Contains(stderr.String(), "outside repository") {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "git log %s: %s", abs, strings.TrimSpace(stderr.String()))
+	}
+
+	var out []commit
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		parts := strings.Split(strings.TrimSpace(line), "\x1f")
+		if len(parts) != 3 {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, parts[2])
+		if err != nil {
+			continue
+		}
+		out = append(out, commit{hash: parts[0], author: parts[1], at: at})
+	}
+	return out, nil
+}
@@ -0,0 +1,103 @@
+package stalecode
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+)
+
+func TestScanCountsCommitsAfterLastUpdated(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	ctx := context.Background()
+	repoRoot := t.TempDir()
+	docsRoot := filepath.Join(repoRoot, "ttmp")
+
+	git := func(author, date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=" + author, "-c", "user.email=" + author + "@example.com"}, args...)...)
+		cmd.Dir = repoRoot
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	git("alice", "2026-01-01T00:00:00Z", "init", "-q")
+	write(filepath.Join(repoRoot, "pkg", "a.go"), "package pkg\n")
+	write(filepath.Join(repoRoot, "pkg", "b.go"), "package pkg\n")
+	git("alice", "2026-01-01T00:00:00Z", "add", ".")
+	git("alice", "2026-01-01T00:00:00Z", "commit", "-q", "-m", "init")
+
+	write(filepath.Join(repoRoot, "pkg", "a.go"), "package pkg\n\n// v2\n")
+	git("bob", "2026-02-01T00:00:00Z", "commit", "-q", "-am", "a v2")
+	write(filepath.Join(repoRoot, "pkg", "a.go"), "package pkg\n\n// v3\n")
+	git("carol", "2026-03-01T00:00:00Z", "commit", "-q", "-am", "a v3")
+
+	ticketDir := filepath.Join(docsRoot, "2026", "01", "15", "STALE-1--demo")
+	write(filepath.Join(ticketDir, "index.md"), `---
+Title: Stale Demo
+Ticket: STALE-1
+DocType: index
+LastUpdated: 2026-01-15T00:00:00Z
+RelatedFiles:
+  - Path: repo://pkg/a.go
+    Note: changed twice since
+  - Path: repo://pkg/b.go
+    Note: untouched
+  - Path: repo://pkg/gone.go
+    Note: never existed
+---
+`)
+	write(filepath.Join(ticketDir, "design", "01-fresh.md"), `---
+Title: Fresh
+Ticket: STALE-1
+DocType: design-doc
+LastUpdated: 2026-04-01T00:00:00Z
+RelatedFiles:
+  - Path: repo://pkg/a.go
+---
+`)
+
+	ws, err := workspace.NewWorkspaceFromContext(workspace.WorkspaceContext{Root: docsRoot, ConfigDir: repoRoot, RepoRoot: repoRoot})
+	if err != nil {
+		t.Fatalf("NewWorkspaceFromContext: %v", err)
+	}
+	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{}); err != nil {
+		t.Fatalf("InitIndex: %v", err)
+	}
+
+	reports, err := Scan(ctx, ws, Options{})
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(reports) != 1 {
+		t.Fatalf("expected only index.md to be stale, got %+v", reports)
+	}
+	rep := reports[0]
+	if rep.Ticket != "STALE-1" || filepath.Base(rep.Path) != "index.md" || len(rep.Files) != 1 {
+		t.Fatalf("unexpected report: %+v", rep)
+	}
+	f := rep.Files[0]
+	if f.Path != "pkg/a.go" || f.Commits != 2 || f.LastAuthor != "carol" || f.LastCommit == "" {
+		t.Fatalf("unexpected file change: %+v", f)
+	}
+
+	if reports, err := Scan(ctx, ws, Options{Ticket: "OTHER-9"}); err != nil || len(reports) != 0 {
+		t.Fatalf("expected no reports for another ticket, got %+v (err=%v)", reports, err)
+	}
+}
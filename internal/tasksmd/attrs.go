@@ -0,0 +1,222 @@
+package tasksmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Attrs are inline task attributes, written as a trailing run of tokens after
+// the task text (before the stable-ID marker), as in the tasks.md line
+//
+//	"- [ ] Design routes @alice due:2026-11-01 !high #api <!-- t:ab12 -->"
+//
+// Tokens elsewhere in the text ("ping @alice about #12") are plain text.
+type Attrs struct {
+	// Assignees are the @name tokens, without the "@".
+	Assignees []string `json:"assignees,omitempty"`
+	// Due is the due:YYYY-MM-DD date.
+	Due string `json:"due,omitempty"`
+	// Priority is one of Priorities (from a !priority token).
+	Priority string `json:"priority,omitempty"`
+	// Tags are the #tag tokens, without the "#".
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Priorities are the accepted !priority values, highest first.
+var Priorities = []string{"high", "medium", "low"}
+
+// DueLayout is the format of due: dates.
+const DueLayout = "2006-01-02"
+
+var (
+	assigneeTokenRe = regexp.MustCompile(`^@([A-Za-z0-9][A-Za-z0-9._-]*)$`)
+	dueTokenRe      = regexp.MustCompile(`^due:(\d{4}-\d{2}-\d{2})$`)
+	priorityTokenRe = regexp.MustCompile(`^!(high|medium|low)$`)
+	tagTokenRe      = regexp.MustCompile(`^#([A-Za-z][A-Za-z0-9_/-]*)$`)
+)
+
+// ExtractAttrs splits task text (without the stable-ID marker) into the text
+// and its trailing attributes. A task consisting only of attribute-like
+// tokens keeps them as text.
+func ExtractAttrs(text string) (string, Attrs) {
+	text = strings.TrimSpace(text)
+	tokens := strings.Fields(text)
+	start := len(tokens)
+	for start > 1 && isAttrToken(tokens[start-1]) {
+		start--
+	}
+	if start == len(tokens) {
+		return text, Attrs{}
+	}
+	var a Attrs
+	for _, tok := range tokens[start:] {
+		a.add(tok)
+	}
+	// Cut the original string (not the re-joined tokens) to keep inner spacing.
+	clean := text
+	for i := len(tokens) - 1; i >= start; i-- {
+		clean = strings.TrimSpace(strings.TrimSuffix(clean, tokens[i]))
+	}
+	return clean, a
+}
+
+func isAttrToken(tok string) bool {
+	return assigneeTokenRe.MatchString(tok) || dueTokenRe.MatchString(tok) ||
+		priorityTokenRe.MatchString(tok) || tagTokenRe.MatchString(tok)
+}
+
+func (a *Attrs) add(tok string) {
+	if m := assigneeTokenRe.FindStringSubmatch(tok); m != nil {
+		a.Assignees = appendUnique(a.Assignees, m[1])
+		return
+	}
+	if m := dueTokenRe.FindStringSubmatch(tok); m != nil {
+		a.Due = m[1]
+		return
+	}
+	if m := priorityTokenRe.FindStringSubmatch(tok); m != nil {
+		a.Priority = m[1]
+		return
+	}
+	if m := tagTokenRe.FindStringSubmatch(tok); m != nil {
+		a.Tags = appendUnique(a.Tags, m[1])
+	}
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, existing := range list {
+		if strings.EqualFold(existing, v) {
+			return list
+		}
+	}
+	return append(list, v)
+}
+
+// IsZero reports whether no attribute is set.
+func (a Attrs) IsZero() bool {
+	return len(a.Assignees) == 0 && a.Due == "" && a.Priority == "" && len(a.Tags) == 0
+}
+
+// String renders the attributes as tokens in canonical order:
+// "@alice due:2026-11-01 !high #api".
+func (a Attrs) String() string {
+	var tokens []string
+	for _, as := range a.Assignees {
+		tokens = append(tokens, "@"+as)
+	}
+	if a.Due != "" {
+		tokens = append(tokens, "due:"+a.Due)
+	}
+	if a.Priority != "" {
+		tokens = append(tokens, "!"+a.Priority)
+	}
+	for _, t := range a.Tags {
+		tokens = append(tokens, "#"+t)
+	}
+	return strings.Join(tokens, " ")
+}
+
+// JoinAttrs appends the attribute tokens to text.
+func JoinAttrs(text string, a Attrs) string {
+	text = strings.TrimSpace(text)
+	if a.IsZero() {
+		return text
+	}
+	return text + " " + a.String()
+}
+
+// DueDate parses Due; ok is false when no (valid) due date is set.
+func (a Attrs) DueDate() (time.Time, bool) {
+	if a.Due == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(DueLayout, a.Due)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Overdue reports whether the due date lies before the day of now.
+func (a Attrs) Overdue(now time.Time) bool {
+	due, ok := a.DueDate()
+	if !ok {
+		return false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return due.Before(today)
+}
+
+// NormalizeAttrs validates and cleans attribute values given as flags
+// (assignees and tags with or without their "@"/"#" prefix).
+func NormalizeAttrs(a Attrs) (Attrs, error) {
+	var out Attrs
+	for _, as := range a.Assignees {
+		as = strings.TrimPrefix(strings.TrimSpace(as), "@")
+		if as == "" {
+			continue
+		}
+		if !assigneeTokenRe.MatchString("@" + as) {
+			return Attrs{}, fmt.Errorf("invalid assignee %q (letters, digits, '.', '_' and '-')", as)
+		}
+		out.Assignees = appendUnique(out.Assignees, as)
+	}
+	if due := strings.TrimSpace(a.Due); due != "" {
+		if !dueTokenRe.MatchString("due:" + due) {
+			return Attrs{}, fmt.Errorf("invalid due date %q (expected YYYY-MM-DD)", due)
+		}
+		if _, err := time.Parse(DueLayout, due); err != nil {
+			return Attrs{}, fmt.Errorf("invalid due date %q (expected YYYY-MM-DD)", due)
+		}
+		out.Due = due
+	}
+	if p := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(a.Priority), "!")); p != "" {
+		if !priorityTokenRe.MatchString("!" + p) {
+			return Attrs{}, fmt.Errorf("invalid priority %q (expected one of: %s)", p, strings.Join(Priorities, ", "))
+		}
+		out.Priority = p
+	}
+	for _, t := range a.Tags {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "#")
+		if t == "" {
+			continue
+		}
+		if !tagTokenRe.MatchString("#" + t) {
+			return Attrs{}, fmt.Errorf("invalid tag %q (must start with a letter)", t)
+		}
+		out.Tags = appendUnique(out.Tags, t)
+	}
+	return out, nil
+}
+
+// Merge returns a with the attributes set in b added or replaced: assignees
+// and tags are appended, due date and priority replaced.
+func (a Attrs) Merge(b Attrs) Attrs {
+	out := Attrs{
+		Assignees: append([]string{}, a.Assignees...),
+		Due:       a.Due,
+		Priority:  a.Priority,
+		Tags:      append([]string{}, a.Tags...),
+	}
+	for _, as := range b.Assignees {
+		out.Assignees = appendUnique(out.Assignees, as)
+	}
+	if b.Due != "" {
+		out.Due = b.Due
+	}
+	if b.Priority != "" {
+		out.Priority = b.Priority
+	}
+	for _, t := range b.Tags {
+		out.Tags = appendUnique(out.Tags, t)
+	}
+	if len(out.Assignees) == 0 {
+		out.Assignees = nil
+	}
+	if len(out.Tags) == 0 {
+		out.Tags = nil
+	}
+	return out
+}
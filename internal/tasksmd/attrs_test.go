@@ -0,0 +1,77 @@
+package tasksmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExtractAttrs(t *testing.T) {
+	cases := []struct {
+		in       string
+		wantText string
+		want     Attrs
+	}{
+		{"Design routes", "Design routes", Attrs{}},
+		{
+			"Design  routes @alice @bob due:2026-11-01 !high #api #v2",
+			"Design  routes",
+			Attrs{Assignees: []string{"alice", "bob"}, Due: "2026-11-01", Priority: "high", Tags: []string{"api", "v2"}},
+		},
+		// Only the trailing run counts.
+		{"Ping @bob about #12 first", "Ping @bob about #12 first", Attrs{}},
+		{"Ping @bob about it #later", "Ping @bob about it", Attrs{Tags: []string{"later"}}},
+		// A task made only of attribute-like tokens keeps them as text.
+		{"#hashtag", "#hashtag", Attrs{}},
+		{"!urgent stays text", "!urgent stays text", Attrs{}},
+	}
+	for _, tc := range cases {
+		text, attrs := ExtractAttrs(tc.in)
+		if text != tc.wantText || !reflect.DeepEqual(attrs, tc.want) {
+			t.Fatalf("ExtractAttrs(%q) = %q, %+v; want %q, %+v", tc.in, text, attrs, tc.wantText, tc.want)
+		}
+	}
+}
+
+func TestParseKeepsAttrsOutOfText(t *testing.T) {
+	p, byID := Parse([]string{"## TODO", "- [ ] Write docs @alice due:2026-11-01 <!-- t:ab12 -->"})
+	if p.Total != 1 {
+		t.Fatalf("expected 1 task, got %d", p.Total)
+	}
+	item := p.Sections[0].Items[0]
+	if item.Text != "Write docs" || item.StableID != "ab12" || item.Due != "2026-11-01" || byID[1].Attrs.Assignees[0] != "alice" {
+		t.Fatalf("unexpected item: %+v", item)
+	}
+}
+
+func TestNormalizeAttrs(t *testing.T) {
+	got, err := NormalizeAttrs(Attrs{Assignees: []string{"@alice", " bob "}, Due: "2026-11-01", Priority: "HIGH", Tags: []string{"#api"}})
+	if err != nil {
+		t.Fatalf("NormalizeAttrs: %v", err)
+	}
+	want := Attrs{Assignees: []string{"alice", "bob"}, Due: "2026-11-01", Priority: "high", Tags: []string{"api"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if JoinAttrs("Design", got) != "Design @alice @bob due:2026-11-01 !high #api" {
+		t.Fatalf("unexpected JoinAttrs: %q", JoinAttrs("Design", got))
+	}
+	for _, bad := range []Attrs{{Due: "2026-13-01"}, {Due: "tomorrow"}, {Priority: "urgent"}, {Assignees: []string{"a b"}}, {Tags: []string{"12"}}} {
+		if _, err := NormalizeAttrs(bad); err == nil {
+			t.Fatalf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestOverdue(t *testing.T) {
+	now := time.Date(2026, 11, 1, 15, 0, 0, 0, time.Local)
+	if (Attrs{Due: "2026-11-01"}).Overdue(now) {
+		t.Fatalf("a task due today is not overdue")
+	}
+	if !(Attrs{Due: "2026-10-31"}).Overdue(now) {
+		t.Fatalf("a task due yesterday is overdue")
+	}
+	if (Attrs{}).Overdue(now) {
+		t.Fatalf("a task without due date is never overdue")
+	}
+}
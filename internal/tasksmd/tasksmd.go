@@ -20,6 +20,9 @@ type Item struct {
 	// Tasks without markers have an empty StableID and are addressed by
 	// position (ID) only.
 	StableID string `json:"stableId,omitempty"`
+	// Attrs are the inline attributes (@assignee, due:, !priority, #tag);
+	// Text excludes them.
+	Attrs
 }
 
 type Section struct {
@@ -40,6 +43,7 @@ type parsedTaskLine struct {
 	Text      string
 	Section   string
 	StableID  string
+	Attrs     Attrs
 }
 
 var (
@@ -160,12 +164,13 @@ func Parse(lines []string) (Parsed, map[int]parsedTaskLine) {
 		checked := strings.HasPrefix(strings.ToLower(trimmed), "- [x]") || strings.HasPrefix(strings.ToLower(trimmed), "* [x]")
 
 		stableID, text := ExtractStableID(strings.TrimSpace(m[2]))
+		text, attrs := ExtractAttrs(text)
 		total++
 		if checked {
 			done++
 		}
 
-		item := Item{ID: total, Checked: checked, Text: text, StableID: stableID}
+		item := Item{ID: total, Checked: checked, Text: text, StableID: stableID, Attrs: attrs}
 		sections[secIdx].Items = append(sections[secIdx].Items, item)
 		taskByID[item.ID] = parsedTaskLine{
 			ID:        item.ID,
@@ -174,6 +179,7 @@ func Parse(lines []string) (Parsed, map[int]parsedTaskLine) {
 			Text:      text,
 			Section:   sections[secIdx].Title,
 			StableID:  stableID,
+			Attrs:     attrs,
 		}
 	}
 
@@ -185,12 +191,17 @@ func ReadFile(path string) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	return SplitLines(b), nil
+}
+
+// SplitLines splits tasks.md content into lines the way ReadFile does.
+func SplitLines(b []byte) []string {
 	s := strings.ReplaceAll(string(b), "\r\n", "\n")
 	s = strings.TrimSuffix(s, "\n")
 	if s == "" {
-		return []string{}, nil
+		return []string{}
 	}
-	return strings.Split(s, "\n"), nil
+	return strings.Split(s, "\n")
 }
 
 func WriteFile(path string, lines []string) error {
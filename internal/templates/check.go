@@ -0,0 +1,260 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/pkg/errors"
+)
+
+// CheckDocTemplate parses a document template body and type-checks its field
+// references against DocData: {{ .Doc.Titel }} or {{ .Ticket.Nope }} are
+// reported without rendering. References inside range/with follow the element
+// type; references whose type is unknown (custom variables, dict values) are
+// not checked. include calls with a literal name must name an existing
+// partial. Problems are returned as "name:line:col: message".
+//
+// lineOffset is added to reported line numbers (the frontmatter lines that
+// precede the body in the template file).
+func CheckDocTemplate(root, name, body string, lineOffset int) []string {
+	return checkTemplate(root, name, body, lineOffset, reflect.TypeOf(&DocData{}))
+}
+
+// CheckPartial checks a partial under _templates/_partials. Its data is
+// whatever the include call passes, so only syntax, helpers, and nested
+// include names are checked.
+func CheckPartial(root, name, body string) []string {
+	return checkTemplate(root, name, body, 0, nil)
+}
+
+func checkTemplate(root, name, body string, lineOffset int, dataType reflect.Type) []string {
+	tmpl, err := template.New(name).Funcs(Renderer{Root: root}.DocFuncMap(nil)).Parse(body)
+	if err != nil {
+		return []string{shiftLine(err.Error(), name, lineOffset)}
+	}
+	c := &checker{root: root, name: name, offset: lineOffset, data: dataType}
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil || t.Tree.Root == nil {
+			continue
+		}
+		c.tree = t.Tree
+		if t.Name() == name {
+			c.walk(t.Tree.Root, dataType)
+		} else {
+			// {{define}} blocks: dot is whatever {{template}} passes.
+			c.walk(t.Tree.Root, nil)
+		}
+	}
+	return c.problems
+}
+
+type checker struct {
+	root     string
+	name     string
+	offset   int
+	data     reflect.Type
+	tree     *parse.Tree
+	problems []string
+}
+
+func (c *checker) report(n parse.Node, format string, args ...interface{}) {
+	location, _ := c.tree.ErrorContext(n)
+	c.problems = append(c.problems, fmt.Sprintf("%s: %s", shiftLine(location, c.name, c.offset), fmt.Sprintf(format, args...)))
+}
+
+func (c *checker) walk(node parse.Node, dot reflect.Type) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			c.walk(child, dot)
+		}
+	case *parse.ActionNode:
+		c.pipe(n.Pipe, dot)
+	case *parse.IfNode:
+		c.pipe(n.Pipe, dot)
+		c.walk(n.List, dot)
+		c.walk(n.ElseList, dot)
+	case *parse.WithNode:
+		t := c.pipe(n.Pipe, dot)
+		c.walk(n.List, t)
+		c.walk(n.ElseList, dot)
+	case *parse.RangeNode:
+		t := c.pipe(n.Pipe, dot)
+		c.walk(n.List, elemType(t))
+		c.walk(n.ElseList, dot)
+	case *parse.TemplateNode:
+		c.pipe(n.Pipe, dot)
+	}
+}
+
+// pipe checks a pipeline and returns its result type (nil if unknown).
+func (c *checker) pipe(p *parse.PipeNode, dot reflect.Type) reflect.Type {
+	if p == nil {
+		return nil
+	}
+	var t reflect.Type
+	for _, cmd := range p.Cmds {
+		t = c.command(cmd, dot)
+	}
+	return t
+}
+
+func (c *checker) command(cmd *parse.CommandNode, dot reflect.Type) reflect.Type {
+	if len(cmd.Args) == 0 {
+		return nil
+	}
+	for _, arg := range cmd.Args[1:] {
+		c.arg(arg, dot)
+	}
+	if fn, ok := cmd.Args[0].(*parse.IdentifierNode); ok {
+		if fn.Ident == "include" && len(cmd.Args) > 1 {
+			if s, ok := cmd.Args[1].(*parse.StringNode); ok {
+				if _, err := os.Stat(PartialPath(c.root, s.Text)); err != nil {
+					c.report(s, "include %q: no partial at %s", s.Text, PartialPath(c.root, s.Text))
+				}
+			}
+		}
+		return helperResultTypes[fn.Ident]
+	}
+	return c.arg(cmd.Args[0], dot)
+}
+
+func (c *checker) arg(arg parse.Node, dot reflect.Type) reflect.Type {
+	switch n := arg.(type) {
+	case *parse.DotNode:
+		return dot
+	case *parse.FieldNode:
+		return c.chain(n, dot, n.Ident)
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" {
+			return c.chain(n, c.data, n.Ident[1:])
+		}
+		return nil
+	case *parse.ChainNode:
+		var base reflect.Type
+		if p, ok := n.Node.(*parse.PipeNode); ok {
+			base = c.pipe(p, dot)
+		} else {
+			base = c.arg(n.Node, dot)
+		}
+		return c.chain(n, base, n.Field)
+	case *parse.PipeNode:
+		return c.pipe(n, dot)
+	}
+	return nil
+}
+
+// chain follows .A.B.C from t, reporting the first name t has no field or
+// method for.
+func (c *checker) chain(n parse.Node, t reflect.Type, idents []string) reflect.Type {
+	for _, ident := range idents {
+		if t == nil {
+			return nil
+		}
+		if m, ok := t.MethodByName(ident); ok {
+			t = methodResult(m)
+			continue
+		}
+		base := t
+		if base.Kind() == reflect.Pointer {
+			base = base.Elem()
+		}
+		if m, ok := reflect.PointerTo(base).MethodByName(ident); ok {
+			t = methodResult(m)
+			continue
+		}
+		kind := base.Kind()
+		if kind == reflect.Interface {
+			return nil
+		}
+		if kind == reflect.Map {
+			t = base.Elem()
+			continue
+		}
+		if kind != reflect.Struct {
+			c.report(n, "can't evaluate .%s on %s", ident, describeType(base))
+			return nil
+		}
+		f, ok := base.FieldByName(ident)
+		if !ok || !f.IsExported() {
+			c.report(n, "%s has no field %s%s", describeType(base), ident, suggestField(base, ident))
+			return nil
+		}
+		t = f.Type
+	}
+	return t
+}
+
+func methodResult(m reflect.Method) reflect.Type {
+	if m.Type.NumOut() == 0 {
+		return nil
+	}
+	return m.Type.Out(0)
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if k := t.Kind(); k == reflect.Slice || k == reflect.Array || k == reflect.Map || k == reflect.Chan {
+		return t.Elem()
+	}
+	return nil
+}
+
+// suggestField names a field differing only in case, a common typo.
+func suggestField(t reflect.Type, ident string) string {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.IsExported() && strings.EqualFold(f.Name, ident) {
+			return fmt.Sprintf(" (did you mean %s?)", f.Name)
+		}
+	}
+	return ""
+}
+
+// shiftLine adds offset to the line number of a "name:line:col" location
+// at the start of msg (also "template: name:line:" parse errors).
+func shiftLine(msg, name string, offset int) string {
+	if offset == 0 {
+		return msg
+	}
+	prefix := name + ":"
+	i := strings.Index(msg, prefix)
+	if i < 0 {
+		return msg
+	}
+	rest := msg[i+len(prefix):]
+	var line int
+	if _, err := fmt.Sscanf(rest, "%d", &line); err != nil {
+		return msg
+	}
+	digits := len(fmt.Sprint(line))
+	return msg[:i] + prefix + fmt.Sprint(line+offset) + rest[digits:]
+}
+
+// LoadDocTemplateBody reads a _templates/<docType>.md file and returns its
+// body (the part rendered into new documents) and the number of lines before it.
+func LoadDocTemplateBody(path string) (string, int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "read %s", path)
+	}
+	tpl := string(content)
+	_, body := ExtractFrontmatterAndBody(tpl)
+	offset := 0
+	if strings.HasSuffix(tpl, body) {
+		offset = strings.Count(tpl[:len(tpl)-len(body)], "\n")
+	}
+	return body, offset, nil
+}
@@ -0,0 +1,237 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-go-golems/docmgr/pkg/models"
+	"github.com/pkg/errors"
+)
+
+// PartialsDir is the directory under _templates/ holding partials for the
+// include helper.
+const PartialsDir = "_partials"
+
+// maxIncludeDepth bounds nested include calls (guards against include cycles).
+const maxIncludeDepth = 16
+
+// DocData is the data model document templates in <root>/_templates/ are
+// rendered with (text/template).
+//
+//	{{ .Doc.Title }}            the document being created
+//	{{ .Ticket.Title }}         the ticket it belongs to
+//	{{ .Defaults.Owners }}      defaults from .ttmp.yaml
+//	{{ .Vars.component }}       --var component=... from 'docmgr doc add'
+//	{{ date "2006-01-02" .Now }}
+//
+// The legacy placeholders ({{TITLE}}, {{TICKET}}, {{TOPICS}}, ...) remain
+// available as helper functions.
+type DocData struct {
+	Doc      *models.Document
+	Ticket   *TicketData
+	Defaults Defaults
+	Vars     map[string]string
+	Root     string
+	Now      time.Time
+}
+
+// TicketData is the ticket metadata available to document templates.
+type TicketData struct {
+	ID          string
+	Title       string
+	Status      string
+	Intent      string
+	Summary     string
+	Topics      []string
+	Owners      []string
+	Path        string
+	LastUpdated time.Time
+}
+
+// Defaults mirrors the defaults section of .ttmp.yaml.
+type Defaults struct {
+	Owners []string
+	Intent string
+}
+
+// TicketLookup resolves a ticket ID for the ticket template helper.
+type TicketLookup func(id string) (*TicketData, error)
+
+// TicketDataFromDocument builds TicketData from a ticket index document.
+func TicketDataFromDocument(doc *models.Document, path string) *TicketData {
+	if doc == nil {
+		return nil
+	}
+	return &TicketData{
+		ID:          doc.Ticket,
+		Title:       doc.Title,
+		Status:      doc.Status,
+		Intent:      doc.Intent,
+		Summary:     doc.Summary,
+		Topics:      doc.Topics,
+		Owners:      doc.Owners,
+		Path:        path,
+		LastUpdated: doc.LastUpdated,
+	}
+}
+
+// Renderer renders document templates of one docs root.
+type Renderer struct {
+	Root string
+	// LookupTicket backs the ticket helper; nil makes it fail.
+	LookupTicket TicketLookup
+}
+
+// Render executes a document template body with data. Missing map keys
+// (e.g. an unset --var) render as empty strings.
+func (r Renderer) Render(name, body string, data *DocData) (string, error) {
+	if data.Doc == nil {
+		data.Doc = &models.Document{}
+	}
+	if data.Now.IsZero() {
+		data.Now = time.Now()
+	}
+	if data.Root == "" {
+		data.Root = r.Root
+	}
+	tmpl, err := r.parse(name, body, data, 0)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrapf(err, "render template %s", name)
+	}
+	return buf.String(), nil
+}
+
+// RenderOrLegacy renders body like Render. Templates written for the legacy
+// placeholder engine may not be valid text/template (an unknown {{FOO}}
+// token, a literal {{ .x }} in a code sample); those are rendered with
+// RenderTemplateBody instead, and the template error is returned as
+// fallbackErr so callers can warn about it.
+func (r Renderer) RenderOrLegacy(name, body string, data *DocData) (out string, fallbackErr error) {
+	out, err := r.Render(name, body, data)
+	if err == nil {
+		return out, nil
+	}
+	return RenderTemplateBody(body, data.Doc), err
+}
+
+func (r Renderer) parse(name, body string, data *DocData, depth int) (*template.Template, error) {
+	tmpl, err := template.New(name).
+		Option("missingkey=zero").
+		Funcs(r.funcs(data, depth)).
+		Parse(body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse template %s", name)
+	}
+	return tmpl, nil
+}
+
+// PartialPath returns the file backing {{ include "name" }}.
+func PartialPath(root, name string) string {
+	return filepath.Join(root, "_templates", PartialsDir, name+".md")
+}
+
+// DocFuncMap returns the helper functions available to document templates,
+// bound to data. It is also used to parse templates for validation.
+func (r Renderer) DocFuncMap(data *DocData) template.FuncMap {
+	return r.funcs(data, 0)
+}
+
+func (r Renderer) funcs(data *DocData, depth int) template.FuncMap {
+	if data == nil {
+		data = &DocData{}
+	}
+	doc := data.Doc
+	if doc == nil {
+		doc = &models.Document{}
+	}
+	fm := GetTemplateFuncMap()
+
+	// Legacy placeholders.
+	fm["TITLE"] = func() string { return doc.Title }
+	fm["TICKET"] = func() string { return doc.Ticket }
+	fm["STATUS"] = func() string { return doc.Status }
+	fm["SUMMARY"] = func() string { return doc.Summary }
+	fm["DATE"] = func() string { return data.Now.Format("2006-01-02") }
+	fm["TOPICS_LIST"] = func() string { return prefixLines(doc.Topics, "- ", "") }
+	fm["TOPICS"] = func() string { return prefixLines(doc.Topics, "  - ", "[]") }
+	fm["OWNERS"] = func() string { return prefixLines(doc.Owners, "  - ", "[]") }
+
+	fm["now"] = time.Now
+	fm["date"] = func(layout string, t time.Time) string { return t.Format(layout) }
+	fm["join"] = func(sep string, list []string) string { return strings.Join(list, sep) }
+	fm["bullets"] = func(list []string) string { return prefixLines(list, "- ", "") }
+	fm["upper"] = strings.ToUpper
+	fm["lower"] = strings.ToLower
+	fm["trim"] = strings.TrimSpace
+	fm["default"] = func(fallback string, v string) string {
+		if strings.TrimSpace(v) == "" {
+			return fallback
+		}
+		return v
+	}
+	fm["ticket"] = func(id string) (*TicketData, error) {
+		if r.LookupTicket == nil {
+			return nil, errors.Errorf("ticket %q: ticket lookups are not available here", id)
+		}
+		return r.LookupTicket(id)
+	}
+	fm["include"] = func(name string, args ...interface{}) (string, error) {
+		if depth >= maxIncludeDepth {
+			return "", errors.Errorf("include %q: nested too deeply (cycle?)", name)
+		}
+		if len(args) > 1 {
+			return "", errors.Errorf("include %q: expected at most one data argument", name)
+		}
+		content, err := os.ReadFile(PartialPath(r.Root, name))
+		if err != nil {
+			return "", errors.Wrapf(err, "include %q", name)
+		}
+		tmpl, err := r.parse(name, string(content), data, depth+1)
+		if err != nil {
+			return "", err
+		}
+		var dot interface{} = data
+		if len(args) == 1 {
+			dot = args[0]
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, dot); err != nil {
+			return "", errors.Wrapf(err, "include %q", name)
+		}
+		return buf.String(), nil
+	}
+	return fm
+}
+
+func prefixLines(list []string, prefix string, empty string) string {
+	if len(list) == 0 {
+		return empty
+	}
+	lines := make([]string, 0, len(list))
+	for _, item := range list {
+		lines = append(lines, prefix+item)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// helperResultTypes maps helpers whose result the validator can follow
+// (e.g. {{ (ticket "MEN-1").Title }}) to their result type.
+var helperResultTypes = map[string]reflect.Type{
+	"ticket": reflect.TypeOf(&TicketData{}),
+	"now":    reflect.TypeOf(time.Time{}),
+}
+
+// describeType renders a type for validation messages.
+func describeType(t reflect.Type) string {
+	return strings.TrimPrefix(fmt.Sprint(t), "*")
+}
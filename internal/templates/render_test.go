@@ -0,0 +1,112 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-go-golems/docmgr/pkg/models"
+)
+
+func TestRendererRender(t *testing.T) {
+	root := t.TempDir()
+	partial := PartialPath(root, "owners")
+	if err := os.MkdirAll(filepath.Dir(partial), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(partial, []byte(`Owners: {{ join ", " .Owners | default "none" }}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := Renderer{
+		Root: root,
+		LookupTicket: func(id string) (*TicketData, error) {
+			return &TicketData{ID: id, Title: "Parent"}, nil
+		},
+	}
+	body := `# {{TITLE}}
+{{TOPICS_LIST}}
+{{ .Ticket.ID }}: {{ .Ticket.Title }} ({{ date "2006-01-02" .Now }})
+{{ if .Vars.component }}component={{ .Vars.component }}{{ end }} missing=[{{ .Vars.missing }}]
+{{ include "owners" .Doc }}
+{{ (ticket "MEN-1").Title }} / {{ .Defaults.Intent }}`
+	out, err := r.Render("design-doc.md", body, &DocData{
+		Doc:      &models.Document{Title: "Cache", Topics: []string{"api", "backend"}, Owners: []string{"alice"}},
+		Ticket:   &TicketData{ID: "MEN-2", Title: "Caching"},
+		Defaults: Defaults{Intent: "long-term"},
+		Vars:     map[string]string{"component": "cache"},
+		Now:      time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	want := `# Cache
+- api
+- backend
+MEN-2: Caching (2026-10-18)
+component=cache missing=[]
+Owners: alice
+Parent / long-term`
+	if out != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", out, want)
+	}
+
+	if _, err := r.Render("bad.md", `{{ include "nope" }}`, &DocData{}); err == nil {
+		t.Fatalf("expected an error for a missing partial")
+	}
+}
+
+func TestCheckDocTemplate(t *testing.T) {
+	root := t.TempDir()
+	body := `# {{ .Doc.Title }} {{ .Doc.titel }}
+{{ range .Doc.Topics }}{{ . }}{{ .Name }}{{ end }}
+{{ with .Ticket }}{{ .Title }}{{ .Nope }}{{ end }}
+{{ $.Vars.anything }} {{ .Now.Year }} {{ (ticket "X").Bogus }}
+{{ include "missing" }}`
+	problems := CheckDocTemplate(root, "t.md", body, 3)
+	want := []string{
+		"t.md:4:", "has no field titel",
+		"t.md:5:", "can't evaluate .Name on string",
+		"t.md:6:", "has no field Nope",
+		"t.md:7:", "has no field Bogus",
+		"t.md:8:", `include "missing"`,
+	}
+	if len(problems) != len(want)/2 {
+		t.Fatalf("expected %d problems, got %d:\n%s", len(want)/2, len(problems), strings.Join(problems, "\n"))
+	}
+	for i, p := range problems {
+		if !strings.HasPrefix(p, want[2*i]) || !strings.Contains(p, want[2*i+1]) {
+			t.Errorf("problem %d = %q, want %q ... %q", i, p, want[2*i], want[2*i+1])
+		}
+	}
+
+	if problems := CheckDocTemplate(root, "ok.md", "{{TITLE}} {{ .Doc.LastUpdated.Format \"2006\" }}", 0); len(problems) != 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+	if problems := CheckDocTemplate(root, "syntax.md", "{{ if }}", 0); len(problems) != 1 {
+		t.Fatalf("expected a parse error, got %v", problems)
+	}
+}
+
+func TestRendererRenderOrLegacyKeepsLiteralBraces(t *testing.T) {
+	// Written for the legacy placeholder engine: a Go template code sample
+	// and an unknown placeholder must pass through untouched.
+	body := "# {{TITLE}}\n\n```go\ntmpl := `Hello {{ .x }}`\n```\n\n{{FOO}}\n"
+	doc := &models.Document{Title: "Cache"}
+
+	out, fallbackErr := Renderer{Root: t.TempDir()}.RenderOrLegacy("design-doc.md", body, &DocData{Doc: doc})
+	if fallbackErr == nil {
+		t.Fatalf("expected the template error to be reported")
+	}
+	want := "# Cache\n\n```go\ntmpl := `Hello {{ .x }}`\n```\n\n{{FOO}}\n"
+	if out != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", out, want)
+	}
+
+	out, fallbackErr = Renderer{}.RenderOrLegacy("ok.md", "# {{ .Doc.Title }}", &DocData{Doc: doc})
+	if fallbackErr != nil || out != "# Cache" {
+		t.Fatalf("valid template: %q, %v", out, fallbackErr)
+	}
+}
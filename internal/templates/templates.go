@@ -505,7 +505,10 @@ func ExtractFrontmatterAndBody(tpl string) (string, string) {
 	return "", body
 }
 
-// renderTemplateBody replaces placeholders in the template body based on the document values
+// RenderTemplateBody replaces the legacy placeholders ({{TITLE}}, {{TICKET}}, ...)
+// in the template body based on the document values. Document creation renders
+// templates with Renderer, which supports these placeholders too, and falls
+// back to this when a body is not a valid text/template (RenderOrLegacy).
 func RenderTemplateBody(body string, doc *models.Document) string {
 	now := time.Now().Format("2006-01-02")
 
@@ -0,0 +1,445 @@
+package ticketgraph
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/paths"
+	"github.com/go-go-golems/docmgr/internal/tickets"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/models"
+)
+
+// Node kinds in a workspace graph.
+const (
+	NodeTicket = "ticket"
+	NodeDoc    = "doc"
+	NodeFile   = "file"
+)
+
+// Edge kinds in a workspace graph. Ticket link edges use the link kind
+// (DependsOn, Blocks, Supersedes, RelatesTo) as their Kind.
+const (
+	EdgeContains    = "contains"     // ticket -> doc
+	EdgeRelatedFile = "related_file" // doc -> file
+	EdgeSharedFiles = "shared_files" // ticket -- ticket, Weight = number of shared files
+)
+
+// WorkspaceOptions selects what BuildWorkspace includes.
+type WorkspaceOptions struct {
+	// Topics keeps tickets whose index.md has any of these topics (case-insensitive).
+	Topics []string
+	// Statuses keeps tickets whose status is one of these (case-insensitive).
+	Statuses []string
+	// IncludeDocs adds doc and related-file nodes; otherwise the graph only
+	// holds tickets and ticket-to-ticket edges.
+	IncludeDocs bool
+
+	IncludeArchived    bool
+	IncludeScripts     bool
+	IncludeControlDocs bool
+}
+
+// GraphNode is a node of a workspace graph. IDs are stable across runs.
+type GraphNode struct {
+	ID      string `json:"id"`
+	Kind    string `json:"kind"`
+	Label   string `json:"label"`
+	Ticket  string `json:"ticket,omitempty"`
+	Status  string `json:"status,omitempty"`
+	DocType string `json:"docType,omitempty"`
+	Path    string `json:"path,omitempty"`    // docs-root relative for tickets/docs, canonical key for files
+	Missing bool   `json:"missing,omitempty"` // link target that matches no ticket
+}
+
+// GraphEdge is a directed edge. shared_files and RelatesTo edges are
+// undirected; shared_files edges run from the smaller ticket ID.
+type GraphEdge struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Kind   string `json:"kind"`
+	Label  string `json:"label,omitempty"`
+	Weight int    `json:"weight,omitempty"`
+}
+
+// Graph is a workspace graph ready to render or serialize as JSON.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+	Stats Stats       `json:"stats"`
+}
+
+// BuildWorkspace builds a graph over all tickets matching opts: ticket nodes,
+// ticket link edges, and ticket-to-ticket edges for tickets whose docs relate
+// the same files. With IncludeDocs it also adds each ticket's docs and their
+// related files. Docs without a ticket are not part of the graph.
+func BuildWorkspace(ctx context.Context, ws *workspace.Workspace, opts WorkspaceOptions) (*Graph, error) {
+	if ws == nil {
+		return nil, fmt.Errorf("nil workspace")
+	}
+	links, err := tickets.LoadLinkGraph(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &graphBuilder{ws: ws, nodes: map[string]GraphNode{}, edges: map[string]GraphEdge{}}
+
+	// Tickets come from their index docs so topic filters apply to the ticket.
+	indexRes, err := ws.QueryDocs(ctx, workspace.DocQuery{
+		Scope:   workspace.Scope{Kind: workspace.ScopeRepo},
+		Filters: workspace.DocFilters{DocType: "index", TopicsAny: opts.Topics},
+		Options: workspace.DocQueryOptions{
+			IncludeControlDocs:  true,
+			IncludeArchivedPath: opts.IncludeArchived,
+			IncludeScriptsPath:  opts.IncludeScripts,
+			IncludeSourcesPath:  true,
+			OrderBy:             workspace.OrderByPath,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	selected := map[string]bool{}
+	for _, h := range indexRes.Docs {
+		if h.Doc == nil || strings.TrimSpace(h.Doc.Ticket) == "" || !matchesAny(h.Doc.Status, opts.Statuses) {
+			continue
+		}
+		id := strings.TrimSpace(h.Doc.Ticket)
+		if selected[id] {
+			continue
+		}
+		selected[id] = true
+		b.addNode(GraphNode{
+			ID:     ticketNodeID(id),
+			Kind:   NodeTicket,
+			Label:  ticketLabel(id, h.Doc.Title),
+			Ticket: id,
+			Status: h.Doc.Status,
+			Path:   b.relPath(filepath.Dir(h.Path)),
+		})
+	}
+
+	for _, e := range links.Edges {
+		if !selected[e.From] || (!e.Missing && !selected[e.To]) {
+			continue
+		}
+		if e.Missing {
+			b.addNode(GraphNode{ID: ticketNodeID(e.To), Kind: NodeTicket, Label: e.To, Ticket: e.To, Missing: true})
+		}
+		b.addEdge(GraphEdge{From: ticketNodeID(e.From), To: ticketNodeID(e.To), Kind: string(e.Kind), Label: linkLabel(e.Kind)})
+	}
+
+	docsRes, err := ws.QueryDocs(ctx, workspace.DocQuery{
+		Scope: workspace.Scope{Kind: workspace.ScopeRepo},
+		Options: workspace.DocQueryOptions{
+			IncludeControlDocs:  opts.IncludeControlDocs,
+			IncludeArchivedPath: opts.IncludeArchived,
+			IncludeScriptsPath:  opts.IncludeScripts,
+			IncludeSourcesPath:  true,
+			OrderBy:             workspace.OrderByPath,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ticketsByFile := map[string]map[string]struct{}{}
+	for _, h := range docsRes.Docs {
+		if h.Doc == nil || !selected[strings.TrimSpace(h.Doc.Ticket)] {
+			continue
+		}
+		ticket := strings.TrimSpace(h.Doc.Ticket)
+		docPath := filepath.ToSlash(filepath.Clean(h.Path))
+		docID := mermaidID("doc", docPath)
+		if opts.IncludeDocs {
+			label := h.Doc.Title
+			if strings.TrimSpace(label) == "" {
+				label = filepath.Base(docPath)
+			}
+			b.addNode(GraphNode{
+				ID:      docID,
+				Kind:    NodeDoc,
+				Label:   label,
+				Ticket:  ticket,
+				Status:  h.Doc.Status,
+				DocType: h.Doc.DocType,
+				Path:    b.relPath(docPath),
+			})
+			b.addEdge(GraphEdge{From: ticketNodeID(ticket), To: docID, Kind: EdgeContains})
+		}
+
+		resolver := paths.NewResolver(paths.ResolverOptions{
+			DocsRoot:      ws.Context().Root,
+			ConfigDir:     ws.Context().ConfigDir,
+			RepoRoot:      ws.Context().RepoRoot,
+			WorkspaceRoot: ws.Context().WorkspaceRoot,
+			DocPath:       filepath.FromSlash(docPath),
+		})
+		for _, rf := range h.Doc.RelatedFiles {
+			fileKey := canonicalizeForGraph(resolver, rf.Path)
+			if strings.TrimSpace(fileKey) == "" {
+				continue
+			}
+			if ticketsByFile[fileKey] == nil {
+				ticketsByFile[fileKey] = map[string]struct{}{}
+			}
+			ticketsByFile[fileKey][ticket] = struct{}{}
+			if opts.IncludeDocs {
+				fileID := mermaidID("file", fileKey)
+				b.addNode(GraphNode{ID: fileID, Kind: NodeFile, Label: fileKey, Path: fileKey})
+				b.addEdge(GraphEdge{From: docID, To: fileID, Kind: EdgeRelatedFile, Label: edgeLabel(rf.Note)})
+			}
+		}
+	}
+
+	shared := map[[2]string]int{}
+	for _, set := range ticketsByFile {
+		ids := make([]string, 0, len(set))
+		for id := range set {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for i := 0; i < len(ids); i++ {
+			for j := i + 1; j < len(ids); j++ {
+				shared[[2]string{ids[i], ids[j]}]++
+			}
+		}
+	}
+	for pair, n := range shared {
+		label := "1 shared file"
+		if n != 1 {
+			label = fmt.Sprintf("%d shared files", n)
+		}
+		b.addEdge(GraphEdge{From: ticketNodeID(pair[0]), To: ticketNodeID(pair[1]), Kind: EdgeSharedFiles, Label: label, Weight: n})
+	}
+
+	return b.graph(), nil
+}
+
+type graphBuilder struct {
+	ws    *workspace.Workspace
+	nodes map[string]GraphNode
+	edges map[string]GraphEdge
+}
+
+func (b *graphBuilder) addNode(n GraphNode) {
+	if _, ok := b.nodes[n.ID]; ok {
+		return
+	}
+	b.nodes[n.ID] = n
+}
+
+func (b *graphBuilder) addEdge(e GraphEdge) {
+	key := e.From + "\x00" + e.To + "\x00" + e.Kind + "\x00" + e.Label
+	if _, ok := b.edges[key]; ok {
+		return
+	}
+	b.edges[key] = e
+}
+
+func (b *graphBuilder) relPath(abs string) string {
+	if rel, err := filepath.Rel(b.ws.Context().Root, filepath.FromSlash(abs)); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(abs)
+}
+
+// graph returns nodes ordered tickets, docs, files (each by path/label) and
+// edges ordered by endpoints, so output is deterministic.
+func (b *graphBuilder) graph() *Graph {
+	g := &Graph{Nodes: make([]GraphNode, 0, len(b.nodes)), Edges: make([]GraphEdge, 0, len(b.edges))}
+	for _, n := range b.nodes {
+		g.Nodes = append(g.Nodes, n)
+	}
+	kindRank := map[string]int{NodeTicket: 0, NodeDoc: 1, NodeFile: 2}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		a, c := g.Nodes[i], g.Nodes[j]
+		if kindRank[a.Kind] != kindRank[c.Kind] {
+			return kindRank[a.Kind] < kindRank[c.Kind]
+		}
+		if a.Ticket != c.Ticket {
+			return a.Ticket < c.Ticket
+		}
+		if a.Path != c.Path {
+			return a.Path < c.Path
+		}
+		return a.ID < c.ID
+	})
+	for _, e := range b.edges {
+		g.Edges = append(g.Edges, e)
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, c := g.Edges[i], g.Edges[j]
+		if a.From != c.From {
+			return a.From < c.From
+		}
+		if a.To != c.To {
+			return a.To < c.To
+		}
+		if a.Kind != c.Kind {
+			return a.Kind < c.Kind
+		}
+		return a.Label < c.Label
+	})
+	g.Stats = Stats{Nodes: len(g.Nodes), Edges: len(g.Edges)}
+	return g
+}
+
+func linkLabel(kind models.TicketLinkKind) string {
+	switch kind {
+	case models.TicketLinkDependsOn:
+		return "depends on"
+	case models.TicketLinkBlocks:
+		return "blocks"
+	case models.TicketLinkSupersedes:
+		return "supersedes"
+	case models.TicketLinkRelatesTo:
+		return "relates to"
+	}
+	return string(kind)
+}
+
+func ticketNodeID(ticket string) string {
+	return mermaidID("ticket", strings.ToLower(ticket))
+}
+
+func ticketLabel(id, title string) string {
+	if strings.TrimSpace(title) == "" {
+		return id
+	}
+	return id + ": " + title
+}
+
+func matchesAny(value string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	value = strings.TrimSpace(value)
+	for _, a := range allowed {
+		if strings.EqualFold(value, strings.TrimSpace(a)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderMermaid renders g as a Mermaid flowchart.
+func (g *Graph) RenderMermaid(direction string) (string, error) {
+	direction, err := normalizeDirection(direction)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString("graph ")
+	b.WriteString(direction)
+	b.WriteString("\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  %s[\"%s\"]:::%s\n", n.ID, escapeMermaidLabel(n.Label), nodeClass(n))
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		switch e.Kind {
+		case EdgeSharedFiles, string(models.TicketLinkRelatesTo):
+			arrow = "---"
+		case string(models.TicketLinkSupersedes):
+			arrow = "-.->"
+		}
+		if strings.TrimSpace(e.Label) != "" {
+			fmt.Fprintf(&b, "  %s %s|\"%s\"| %s\n", e.From, arrow, escapeMermaidLabel(e.Label), e.To)
+		} else {
+			fmt.Fprintf(&b, "  %s %s %s\n", e.From, arrow, e.To)
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString("classDef ticket fill:#fff4e0,stroke:#b7791f,stroke-width:1px;\n")
+	b.WriteString("classDef closed fill:#eee,stroke:#888,stroke-width:1px,color:#666;\n")
+	b.WriteString("classDef missing fill:#fee,stroke:#a44,stroke-width:1px,stroke-dasharray:3;\n")
+	b.WriteString("classDef doc fill:#e8f2ff,stroke:#2b6cb0,stroke-width:1px;\n")
+	b.WriteString("classDef file fill:#f6f6f6,stroke:#555,stroke-width:1px;\n")
+	return b.String(), nil
+}
+
+// RenderDOT renders g as a Graphviz digraph.
+func (g *Graph) RenderDOT(direction string) (string, error) {
+	direction, err := normalizeDirection(direction)
+	if err != nil {
+		return "", err
+	}
+	rankdir := "TB"
+	if direction == "LR" {
+		rankdir = "LR"
+	}
+	var b strings.Builder
+	b.WriteString("digraph docmgr {\n")
+	fmt.Fprintf(&b, "  rankdir=%s;\n", rankdir)
+	b.WriteString("  node [fontname=\"Helvetica\", fontsize=10];\n")
+	b.WriteString("  edge [fontname=\"Helvetica\", fontsize=9];\n")
+	for _, n := range g.Nodes {
+		attrs := []string{"label=" + dotQuote(n.Label)}
+		switch nodeClass(n) {
+		case "ticket":
+			attrs = append(attrs, "shape=box", "style=\"rounded,filled\"", "fillcolor=\"#fff4e0\"")
+		case "closed":
+			attrs = append(attrs, "shape=box", "style=\"rounded,filled\"", "fillcolor=\"#eeeeee\"", "fontcolor=\"#666666\"")
+		case "missing":
+			attrs = append(attrs, "shape=box", "style=\"rounded,dashed\"", "color=\"#aa4444\"")
+		case "doc":
+			attrs = append(attrs, "shape=note", "style=filled", "fillcolor=\"#e8f2ff\"")
+		case "file":
+			attrs = append(attrs, "shape=plaintext")
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", n.ID, strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		var attrs []string
+		if strings.TrimSpace(e.Label) != "" {
+			attrs = append(attrs, "label="+dotQuote(e.Label))
+		}
+		switch e.Kind {
+		case EdgeSharedFiles, string(models.TicketLinkRelatesTo):
+			attrs = append(attrs, "dir=none", "style=dashed")
+		case string(models.TicketLinkSupersedes):
+			attrs = append(attrs, "style=dotted")
+		}
+		if len(attrs) == 0 {
+			fmt.Fprintf(&b, "  %s -> %s;\n", e.From, e.To)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", e.From, e.To, strings.Join(attrs, ", "))
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+func nodeClass(n GraphNode) string {
+	switch {
+	case n.Kind != NodeTicket:
+		return n.Kind
+	case n.Missing:
+		return "missing"
+	case tickets.IsClosedStatus(n.Status):
+		return "closed"
+	default:
+		return "ticket"
+	}
+}
+
+func normalizeDirection(direction string) (string, error) {
+	direction = strings.ToUpper(strings.TrimSpace(direction))
+	if direction == "" {
+		direction = "TD"
+	}
+	if direction != "TD" && direction != "LR" {
+		return "", fmt.Errorf("invalid direction %q", direction)
+	}
+	return direction, nil
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "\"", "\\\"")
+	s = strings.ReplaceAll(s, "\n", "\\n")
+	return "\"" + s + "\""
+}
@@ -0,0 +1,190 @@
+package ticketgraph
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+)
+
+func writeTestDoc(t *testing.T, docsRoot, ticket, name, frontmatter string) {
+	t.Helper()
+	path := filepath.Join(docsRoot, "2026", "01", "02", ticket+"--demo", name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	content := fmt.Sprintf("---\nTicket: %s\n%s---\n\n# %s\n", ticket, frontmatter, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func newTestWorkspace(t *testing.T, docsRoot string) *workspace.Workspace {
+	t.Helper()
+	ws, err := workspace.NewWorkspaceFromContext(workspace.WorkspaceContext{Root: docsRoot, ConfigDir: filepath.Dir(docsRoot), RepoRoot: filepath.Dir(docsRoot)})
+	if err != nil {
+		t.Fatalf("NewWorkspaceFromContext: %v", err)
+	}
+	if err := ws.InitIndex(context.Background(), workspace.BuildIndexOptions{}); err != nil {
+		t.Fatalf("InitIndex: %v", err)
+	}
+	return ws
+}
+
+// smallGraph is an API ticket that depends on a closed DB ticket, with one doc
+// relating one file.
+func smallGraph() *Graph {
+	return &Graph{
+		Nodes: []GraphNode{
+			{ID: "ticket_a", Kind: NodeTicket, Label: "API-1: Say \"hi\"\nnow", Ticket: "API-1", Status: "active"},
+			{ID: "ticket_b", Kind: NodeTicket, Label: "DB-2", Ticket: "DB-2", Status: "complete"},
+			{ID: "doc_a", Kind: NodeDoc, Label: "Design", Ticket: "API-1"},
+			{ID: "file_a", Kind: NodeFile, Label: `pkg\api.go`},
+		},
+		Edges: []GraphEdge{
+			{From: "doc_a", To: "file_a", Kind: EdgeRelatedFile},
+			{From: "ticket_a", To: "doc_a", Kind: EdgeContains},
+			{From: "ticket_a", To: "ticket_b", Kind: "DependsOn", Label: "depends on"},
+		},
+	}
+}
+
+func TestRenderDOT(t *testing.T) {
+	out, err := smallGraph().RenderDOT("lr")
+	if err != nil {
+		t.Fatalf("RenderDOT: %v", err)
+	}
+	want := `digraph docmgr {
+  rankdir=LR;
+  node [fontname="Helvetica", fontsize=10];
+  edge [fontname="Helvetica", fontsize=9];
+  ticket_a [label="API-1: Say \"hi\"\nnow", shape=box, style="rounded,filled", fillcolor="#fff4e0"];
+  ticket_b [label="DB-2", shape=box, style="rounded,filled", fillcolor="#eeeeee", fontcolor="#666666"];
+  doc_a [label="Design", shape=note, style=filled, fillcolor="#e8f2ff"];
+  file_a [label="pkg\\api.go", shape=plaintext];
+  doc_a -> file_a;
+  ticket_a -> doc_a;
+  ticket_a -> ticket_b [label="depends on"];
+}
+`
+	if out != want {
+		t.Fatalf("unexpected DOT:\n%s\nwant:\n%s", out, want)
+	}
+	if _, err := smallGraph().RenderDOT("BT"); err == nil {
+		t.Fatalf("expected error for invalid direction")
+	}
+}
+
+func TestRenderMermaid(t *testing.T) {
+	out, err := smallGraph().RenderMermaid("")
+	if err != nil {
+		t.Fatalf("RenderMermaid: %v", err)
+	}
+	for _, want := range []string{
+		"graph TD\n",
+		"  ticket_a[\"API-1: Say \\\"hi\\\" now\"]:::ticket\n",
+		"  ticket_b[\"DB-2\"]:::closed\n",
+		"  file_a[\"pkg\\\\api.go\"]:::file\n",
+		"  doc_a --> file_a\n",
+		"  ticket_a -->|\"depends on\"| ticket_b\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in Mermaid output:\n%s", want, out)
+		}
+	}
+}
+
+func TestDotQuote(t *testing.T) {
+	for in, want := range map[string]string{
+		"":           `""`,
+		"plain":      `"plain"`,
+		`a "b"`:      `"a \"b\""`,
+		"two\nlines": `"two\nlines"`,
+		`back\slash`: `"back\\slash"`,
+	} {
+		if got := dotQuote(in); got != want {
+			t.Fatalf("dotQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestBuildWorkspaceFiltersAndOrder(t *testing.T) {
+	docsRoot := filepath.Join(t.TempDir(), "ttmp")
+	writeTestDoc(t, docsRoot, "API-1", "index.md", "Title: API\nStatus: active\nDocType: index\nTopics: [api]\nDependsOn: [DB-2]\n")
+	writeTestDoc(t, docsRoot, "API-1", "design.md", "Title: Design\nDocType: design-doc\nRelatedFiles:\n  - Path: pkg/api.go\n")
+	writeTestDoc(t, docsRoot, "DB-2", "index.md", "Title: DB\nStatus: complete\nDocType: index\nTopics: [db, api]\n")
+	writeTestDoc(t, docsRoot, "DB-2", "schema.md", "Title: Schema\nDocType: reference\nRelatedFiles:\n  - Path: pkg/api.go\n")
+	writeTestDoc(t, docsRoot, "UI-3", "index.md", "Title: UI\nStatus: active\nDocType: index\nTopics: [ui]\n")
+	ws := newTestWorkspace(t, docsRoot)
+	ctx := context.Background()
+
+	tickets := func(g *Graph) string {
+		var ids []string
+		for _, n := range g.Nodes {
+			if n.Kind == NodeTicket {
+				ids = append(ids, n.Ticket)
+			}
+		}
+		return strings.Join(ids, ",")
+	}
+
+	g, err := BuildWorkspace(ctx, ws, WorkspaceOptions{Topics: []string{"api"}})
+	if err != nil {
+		t.Fatalf("BuildWorkspace: %v", err)
+	}
+	if got := tickets(g); got != "API-1,DB-2" {
+		t.Fatalf("topic filter: got tickets %s", got)
+	}
+	var kinds []string
+	for _, e := range g.Edges {
+		kinds = append(kinds, e.Kind)
+	}
+	if len(kinds) != 2 || !strings.Contains(strings.Join(kinds, ","), "DependsOn") || !strings.Contains(strings.Join(kinds, ","), EdgeSharedFiles) {
+		t.Fatalf("expected a DependsOn and a shared_files edge, got %v", kinds)
+	}
+
+	g, err = BuildWorkspace(ctx, ws, WorkspaceOptions{Statuses: []string{"ACTIVE"}})
+	if err != nil {
+		t.Fatalf("BuildWorkspace: %v", err)
+	}
+	if got := tickets(g); got != "API-1,UI-3" {
+		t.Fatalf("status filter: got tickets %s", got)
+	}
+	if len(g.Edges) != 0 {
+		t.Fatalf("expected no edges once DB-2 is filtered out, got %+v", g.Edges)
+	}
+
+	g, err = BuildWorkspace(ctx, ws, WorkspaceOptions{IncludeDocs: true})
+	if err != nil {
+		t.Fatalf("BuildWorkspace: %v", err)
+	}
+	var order []string
+	for _, n := range g.Nodes {
+		order = append(order, n.Kind+":"+n.Label)
+	}
+	want := "ticket:API-1: API,ticket:DB-2: DB,ticket:UI-3: UI,doc:Design,doc:API,doc:DB,doc:Schema,doc:UI,file:pkg/api.go"
+	if got := strings.Join(order, ","); got != want {
+		t.Fatalf("node order:\n got %s\nwant %s", got, want)
+	}
+	first, err := g.RenderDOT("TD")
+	if err != nil {
+		t.Fatalf("RenderDOT: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		again, err := BuildWorkspace(ctx, ws, WorkspaceOptions{IncludeDocs: true})
+		if err != nil {
+			t.Fatalf("BuildWorkspace: %v", err)
+		}
+		out, err := again.RenderDOT("TD")
+		if err != nil {
+			t.Fatalf("RenderDOT: %v", err)
+		}
+		if out != first {
+			t.Fatalf("DOT output differs between runs:\n%s\n---\n%s", first, out)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/models"
+)
+
+// ClosedStatuses are the ticket statuses link checks treat as done.
+var ClosedStatuses = []string{"complete", "archived"}
+
+// IsClosedStatus reports whether status (case-insensitive) is in ClosedStatuses.
+func IsClosedStatus(status string) bool {
+	for _, s := range ClosedStatuses {
+		if strings.EqualFold(strings.TrimSpace(status), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// LinkNode is a ticket in the link graph.
+type LinkNode struct {
+	ID        string
+	Title     string
+	Status    string
+	IndexPath string
+}
+
+// LinkEdge is one relation declared in From's index.md. To is the canonical
+// ticket ID when the target exists, else the target as written.
+type LinkEdge struct {
+	From    string
+	To      string
+	Kind    models.TicketLinkKind
+	Missing bool // target does not match any ticket
+}
+
+// LinkGraph holds every ticket and the relations declared between them.
+type LinkGraph struct {
+	Nodes map[string]LinkNode // key: ticket ID
+	Edges []LinkEdge          // sorted by From, Kind, To
+}
+
+// LinkIssue is a problem found by LinkGraph.Check, attributed to Ticket.
+type LinkIssue struct {
+	Ticket   string
+	Issue    string // unknown_ticket_link | closed_blocker | dependency_cycle
+	Severity string // warning | error
+	Message  string
+	Path     string // index.md of Ticket
+}
+
+// LoadLinkGraph builds the link graph from the ticket index docs in ws.
+// Targets match ticket IDs case-insensitively.
+func LoadLinkGraph(ctx context.Context, ws *workspace.Workspace) (*LinkGraph, error) {
+	handles, err := queryIndexDocs(ctx, ws)
+	if err != nil {
+		return nil, err
+	}
+	g := &LinkGraph{Nodes: map[string]LinkNode{}}
+	byLower := map[string]string{}
+	for _, h := range handles {
+		id := strings.TrimSpace(h.Doc.Ticket)
+		if id == "" {
+			continue
+		}
+		if _, ok := g.Nodes[id]; ok {
+			continue
+		}
+		g.Nodes[id] = LinkNode{ID: id, Title: h.Doc.Title, Status: h.Doc.Status, IndexPath: h.Path}
+		byLower[strings.ToLower(id)] = id
+	}
+
+	seen := map[string]struct{}{}
+	for _, h := range handles {
+		from := strings.TrimSpace(h.Doc.Ticket)
+		if from == "" {
+			continue
+		}
+		for _, l := range h.Doc.AllTicketLinks() {
+			e := LinkEdge{From: from, To: l.Target, Kind: l.Kind}
+			if id, ok := byLower[strings.ToLower(l.Target)]; ok {
+				e.To = id
+			} else {
+				e.Missing = true
+			}
+			key := e.From + "\x00" + string(e.Kind) + "\x00" + e.To
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			g.Edges = append(g.Edges, e)
+		}
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.Kind != b.Kind {
+			return kindOrder(a.Kind) < kindOrder(b.Kind)
+		}
+		return a.To < b.To
+	})
+	return g, nil
+}
+
+func kindOrder(k models.TicketLinkKind) int {
+	for i, kk := range models.TicketLinkKinds {
+		if kk == k {
+			return i
+		}
+	}
+	return len(models.TicketLinkKinds)
+}
+
+// blockingPair returns (blocker, blocked) for ordering relations; ok is false
+// for Supersedes/RelatesTo and for edges to unknown tickets.
+func (e LinkEdge) blockingPair() (string, string, bool) {
+	if e.Missing {
+		return "", "", false
+	}
+	switch e.Kind {
+	case models.TicketLinkDependsOn:
+		return e.To, e.From, true
+	case models.TicketLinkBlocks:
+		return e.From, e.To, true
+	case models.TicketLinkSupersedes, models.TicketLinkRelatesTo:
+		return "", "", false
+	}
+	return "", "", false
+}
+
+// BlockedBy returns the open tickets that block id, sorted.
+func (g *LinkGraph) BlockedBy(id string) []string {
+	set := map[string]struct{}{}
+	for _, e := range g.Edges {
+		blocker, blocked, ok := e.blockingPair()
+		if !ok || blocked != id || IsClosedStatus(g.Nodes[blocker].Status) {
+			continue
+		}
+		set[blocker] = struct{}{}
+	}
+	return sortedKeys(set)
+}
+
+// Check reports links to unknown tickets, closed tickets still blocking open
+// ones, and dependency cycles. Issues are attributed to the ticket whose
+// index.md declares the link (cycles: to every ticket in the cycle).
+func (g *LinkGraph) Check() []LinkIssue {
+	var out []LinkIssue
+	for _, e := range g.Edges {
+		if e.Missing {
+			out = append(out, LinkIssue{
+				Ticket:   e.From,
+				Issue:    "unknown_ticket_link",
+				Severity: "warning",
+				Message:  fmt.Sprintf("%s links to unknown ticket %s", e.Kind, e.To),
+				Path:     g.Nodes[e.From].IndexPath,
+			})
+			continue
+		}
+		blocker, blocked, ok := e.blockingPair()
+		if !ok || blocker == blocked {
+			continue
+		}
+		if IsClosedStatus(g.Nodes[blocker].Status) && !IsClosedStatus(g.Nodes[blocked].Status) {
+			out = append(out, LinkIssue{
+				Ticket:   e.From,
+				Issue:    "closed_blocker",
+				Severity: "warning",
+				Message: fmt.Sprintf("%s is %s but still blocks open ticket %s (%s); remove the link with 'docmgr ticket link --remove' or reopen %s",
+					blocker, g.Nodes[blocker].Status, blocked, e.Kind, blocker),
+				Path: g.Nodes[e.From].IndexPath,
+			})
+		}
+	}
+
+	for _, cycle := range g.Cycles() {
+		msg := fmt.Sprintf("dependency cycle (→ = depends on): %s", strings.Join(append(append([]string{}, cycle...), cycle[0]), " → "))
+		for _, id := range cycle {
+			out = append(out, LinkIssue{
+				Ticket:   id,
+				Issue:    "dependency_cycle",
+				Severity: "error",
+				Message:  msg,
+				Path:     g.Nodes[id].IndexPath,
+			})
+		}
+	}
+	return out
+}
+
+// Cycles returns the cycles in the ordering relation (DependsOn/Blocks), one
+// per strongly connected component, each listed from its smallest ID in
+// depends-on order (each ticket depends on the next).
+func (g *LinkGraph) Cycles() [][]string {
+	adj := map[string][]string{}
+	for _, e := range g.Edges {
+		blocker, blocked, ok := e.blockingPair()
+		if !ok {
+			continue
+		}
+		adj[blocked] = append(adj[blocked], blocker)
+	}
+	for k := range adj {
+		adj[k] = uniqueSorted(adj[k])
+	}
+
+	// Tarjan's strongly connected components.
+	index := map[string]int{}
+	low := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var sccs [][]string
+	next := 0
+	var visit func(v string)
+	visit = func(v string) {
+		index[v] = next
+		low[v] = next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range adj[v] {
+			if _, ok := index[w]; !ok {
+				visit(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+		if low[v] != index[v] {
+			return
+		}
+		var scc []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			scc = append(scc, w)
+			if w == v {
+				break
+			}
+		}
+		sccs = append(sccs, scc)
+	}
+	starts := make([]string, 0, len(adj))
+	for k := range adj {
+		starts = append(starts, k)
+	}
+	sort.Strings(starts)
+	for _, v := range starts {
+		if _, ok := index[v]; !ok {
+			visit(v)
+		}
+	}
+
+	var out [][]string
+	for _, scc := range sccs {
+		if len(scc) == 1 && !containsID(adj[scc[0]], scc[0]) {
+			continue
+		}
+		out = append(out, cyclePath(adj, scc))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+	return out
+}
+
+// cyclePath walks one cycle through scc starting at its smallest member.
+func cyclePath(adj map[string][]string, scc []string) []string {
+	members := map[string]bool{}
+	for _, id := range scc {
+		members[id] = true
+	}
+	start := uniqueSorted(scc)[0]
+
+	// BFS back to start within the component gives a shortest cycle.
+	prev := map[string]string{}
+	queue := []string{start}
+	visited := map[string]bool{start: true}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range adj[v] {
+			if !members[w] {
+				continue
+			}
+			if w == start {
+				path := []string{v}
+				for path[0] != start {
+					path = append([]string{prev[path[0]]}, path...)
+				}
+				return path
+			}
+			if !visited[w] {
+				visited[w] = true
+				prev[w] = v
+				queue = append(queue, w)
+			}
+		}
+	}
+	return []string{start}
+}
+
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueSorted(ids []string) []string {
+	set := map[string]struct{}{}
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return sortedKeys(set)
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
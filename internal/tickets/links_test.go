@@ -0,0 +1,105 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+)
+
+func writeTicketIndex(t *testing.T, docsRoot, ticket, status, extra string) {
+	t.Helper()
+	path := filepath.Join(docsRoot, "2026", "01", "02", ticket+"--demo", "index.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	content := fmt.Sprintf("---\nTitle: %s\nTicket: %s\nStatus: %s\nDocType: index\n%s---\n\n# %s\n", ticket, ticket, status, extra, ticket)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func loadTestLinkGraph(t *testing.T, docsRoot string) *LinkGraph {
+	t.Helper()
+	ctx := context.Background()
+	ws, err := workspace.NewWorkspaceFromContext(workspace.WorkspaceContext{Root: docsRoot, ConfigDir: filepath.Dir(docsRoot), RepoRoot: filepath.Dir(docsRoot)})
+	if err != nil {
+		t.Fatalf("NewWorkspaceFromContext: %v", err)
+	}
+	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{}); err != nil {
+		t.Fatalf("InitIndex: %v", err)
+	}
+	g, err := LoadLinkGraph(ctx, ws)
+	if err != nil {
+		t.Fatalf("LoadLinkGraph: %v", err)
+	}
+	return g
+}
+
+func issuesByKind(issues []LinkIssue) map[string][]LinkIssue {
+	out := map[string][]LinkIssue{}
+	for _, is := range issues {
+		out[is.Issue] = append(out[is.Issue], is)
+	}
+	return out
+}
+
+func TestLinkGraphCheckReportsCycleAndUnknownTarget(t *testing.T) {
+	docsRoot := filepath.Join(t.TempDir(), "ttmp")
+	writeTicketIndex(t, docsRoot, "A-1", "active", "DependsOn: [b-2]\nRelatesTo: [GONE-9]\n")
+	writeTicketIndex(t, docsRoot, "B-2", "active", "DependsOn: [C-3]\n")
+	writeTicketIndex(t, docsRoot, "C-3", "active", "Blocks: [B-2]\nDependsOn: [A-1]\n")
+
+	g := loadTestLinkGraph(t, docsRoot)
+	if len(g.Nodes) != 3 {
+		t.Fatalf("expected 3 nodes, got %d", len(g.Nodes))
+	}
+
+	cycles := g.Cycles()
+	if len(cycles) != 1 || strings.Join(cycles[0], ",") != "A-1,B-2,C-3" {
+		t.Fatalf("expected one cycle A-1,B-2,C-3, got %v", cycles)
+	}
+
+	issues := issuesByKind(g.Check())
+	if got := len(issues["dependency_cycle"]); got != 3 {
+		t.Fatalf("expected dependency_cycle on 3 tickets, got %d: %+v", got, issues)
+	}
+	if !strings.Contains(issues["dependency_cycle"][0].Message, "A-1 → B-2 → C-3 → A-1") {
+		t.Fatalf("unexpected cycle message: %q", issues["dependency_cycle"][0].Message)
+	}
+	unknown := issues["unknown_ticket_link"]
+	if len(unknown) != 1 || unknown[0].Ticket != "A-1" || !strings.Contains(unknown[0].Message, "GONE-9") {
+		t.Fatalf("expected one unknown_ticket_link on A-1, got %+v", unknown)
+	}
+	if len(issues["closed_blocker"]) != 0 {
+		t.Fatalf("expected no closed_blocker issues, got %+v", issues["closed_blocker"])
+	}
+}
+
+func TestLinkGraphBlockedByAndClosedBlocker(t *testing.T) {
+	docsRoot := filepath.Join(t.TempDir(), "ttmp")
+	writeTicketIndex(t, docsRoot, "A-1", "active", "DependsOn: [B-2, C-3]\nSupersedes: [D-4]\n")
+	writeTicketIndex(t, docsRoot, "B-2", "review", "")
+	writeTicketIndex(t, docsRoot, "C-3", "complete", "")
+	writeTicketIndex(t, docsRoot, "D-4", "active", "")
+
+	g := loadTestLinkGraph(t, docsRoot)
+	if got := g.BlockedBy("A-1"); strings.Join(got, ",") != "B-2" {
+		t.Fatalf("expected A-1 blocked by B-2 only, got %v", got)
+	}
+	if got := g.BlockedBy("D-4"); len(got) != 0 {
+		t.Fatalf("Supersedes must not block, got %v", got)
+	}
+
+	closed := issuesByKind(g.Check())["closed_blocker"]
+	if len(closed) != 1 || closed[0].Ticket != "A-1" || !strings.Contains(closed[0].Message, "C-3") {
+		t.Fatalf("expected one closed_blocker for C-3 on A-1, got %+v", closed)
+	}
+	if !strings.HasSuffix(closed[0].Path, "index.md") {
+		t.Fatalf("expected issue path to point at index.md, got %q", closed[0].Path)
+	}
+}
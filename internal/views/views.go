@@ -0,0 +1,172 @@
+// Package views stores named document queries ("saved views") in the docs root.
+package views
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the views file, stored directly in the docs root.
+const FileName = "views.yaml"
+
+// View is a named document query. Its fields mirror the `docmgr search` flags;
+// empty fields do not filter.
+type View struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Query       string   `yaml:"query,omitempty" json:"query,omitempty"` // FTS5 text query
+	Where       string   `yaml:"where,omitempty" json:"where,omitempty"` // query expression
+	Ticket      string   `yaml:"ticket,omitempty" json:"ticket,omitempty"`
+	Topics      []string `yaml:"topics,omitempty" json:"topics,omitempty"`
+	DocType     string   `yaml:"docType,omitempty" json:"docType,omitempty"`
+	Status      string   `yaml:"status,omitempty" json:"status,omitempty"`
+	File        string   `yaml:"file,omitempty" json:"file,omitempty"`
+	Dir         string   `yaml:"dir,omitempty" json:"dir,omitempty"`
+	OrderBy     string   `yaml:"orderBy,omitempty" json:"orderBy,omitempty"`
+}
+
+type viewsFile struct {
+	Views []View `yaml:"views"`
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ErrNotFound is returned when a view name is not defined.
+var ErrNotFound = errors.New("view not found")
+
+// Path returns the views file location for a docs root.
+func Path(root string) string {
+	return filepath.Join(root, FileName)
+}
+
+// Validate checks the view name, that it filters on something and that its
+// query expression and ordering parse.
+func (v View) Validate() error {
+	if !namePattern.MatchString(v.Name) {
+		return errors.Errorf("invalid view name %q (letters, digits, '.', '_' and '-')", v.Name)
+	}
+	if v.IsEmpty() {
+		return errors.Errorf("view %q has no query or filters", v.Name)
+	}
+	if _, err := workspace.ParseDocExpr(v.Where); err != nil {
+		return errors.Wrapf(err, "view %q", v.Name)
+	}
+	switch workspace.OrderBy(v.OrderBy) {
+	case "", workspace.OrderByPath, workspace.OrderByLastUpdated, workspace.OrderByRank:
+	default:
+		return errors.Errorf("view %q: invalid orderBy %q (path|last_updated|rank)", v.Name, v.OrderBy)
+	}
+	return nil
+}
+
+// IsEmpty reports whether the view sets no query or filter.
+func (v View) IsEmpty() bool {
+	return strings.TrimSpace(v.Query) == "" && strings.TrimSpace(v.Where) == "" &&
+		strings.TrimSpace(v.Ticket) == "" && len(v.Topics) == 0 &&
+		strings.TrimSpace(v.DocType) == "" && strings.TrimSpace(v.Status) == "" &&
+		strings.TrimSpace(v.File) == "" && strings.TrimSpace(v.Dir) == ""
+}
+
+// Load reads the views defined in root, sorted by name. A missing file yields
+// no views.
+func Load(root string) ([]View, error) {
+	data, err := os.ReadFile(Path(root))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []View{}, nil
+		}
+		return nil, errors.Wrap(err, "read views file")
+	}
+	var f viewsFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, errors.Wrapf(err, "parse %s", Path(root))
+	}
+	if f.Views == nil {
+		f.Views = []View{}
+	}
+	sort.Slice(f.Views, func(i, j int) bool { return f.Views[i].Name < f.Views[j].Name })
+	return f.Views, nil
+}
+
+// Save writes views to root, sorted by name.
+func Save(root string, views []View) error {
+	sorted := append([]View(nil), views...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	data, err := yaml.Marshal(viewsFile{Views: sorted})
+	if err != nil {
+		return errors.Wrap(err, "marshal views")
+	}
+	if err := os.WriteFile(Path(root), data, 0o644); err != nil {
+		return errors.Wrap(err, "write views file")
+	}
+	return nil
+}
+
+// Get returns the view named name from root.
+func Get(root, name string) (View, error) {
+	all, err := Load(root)
+	if err != nil {
+		return View{}, err
+	}
+	for _, v := range all {
+		if v.Name == name {
+			return v, nil
+		}
+	}
+	return View{}, errors.Wrapf(ErrNotFound, "%q (see 'docmgr view list')", name)
+}
+
+// Put adds or (with replace) overwrites a view.
+func Put(root string, v View, replace bool) error {
+	if err := v.Validate(); err != nil {
+		return err
+	}
+	all, err := Load(root)
+	if err != nil {
+		return err
+	}
+	for i := range all {
+		if all[i].Name == v.Name {
+			if !replace {
+				return errors.Errorf("view %q already exists (use --force to overwrite)", v.Name)
+			}
+			all[i] = v
+			return Save(root, all)
+		}
+	}
+	return Save(root, append(all, v))
+}
+
+// Delete removes the view named name.
+func Delete(root, name string) error {
+	all, err := Load(root)
+	if err != nil {
+		return err
+	}
+	for i := range all {
+		if all[i].Name == name {
+			return Save(root, append(all[:i], all[i+1:]...))
+		}
+	}
+	return errors.Wrapf(ErrNotFound, "%q", name)
+}
+
+// CombineWhere ANDs two query expressions, either of which may be empty.
+func CombineWhere(a, b string) string {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	default:
+		return "(" + a + ") AND (" + b + ")"
+	}
+}
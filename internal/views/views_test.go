@@ -0,0 +1,73 @@
+package views
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPutGetDelete(t *testing.T) {
+	root := t.TempDir()
+
+	if all, err := Load(root); err != nil || len(all) != 0 {
+		t.Fatalf("expected no views in empty root, got %v, %v", all, err)
+	}
+
+	api := View{Name: "my-active-api", Topics: []string{"api"}, Status: "active", Where: "NOT owner:bob"}
+	if err := Put(root, api, false); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := Put(root, View{Name: "drafts", Status: "draft"}, false); err != nil {
+		t.Fatalf("Put drafts: %v", err)
+	}
+	if err := Put(root, View{Name: "my-active-api", Status: "review"}, false); err == nil {
+		t.Fatalf("expected error when overwriting without replace")
+	}
+
+	got, err := Get(root, "my-active-api")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !reflect.DeepEqual(got, api) {
+		t.Fatalf("got %+v, want %+v", got, api)
+	}
+
+	api.Status = "review"
+	if err := Put(root, api, true); err != nil {
+		t.Fatalf("Put replace: %v", err)
+	}
+	all, err := Load(root)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(all) != 2 || all[0].Name != "drafts" || all[1].Status != "review" {
+		t.Fatalf("unexpected views: %+v", all)
+	}
+
+	if err := Delete(root, "drafts"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := Get(root, "drafts"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+	if err := Delete(root, "drafts"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, v := range []View{
+		{Name: "", Status: "active"},
+		{Name: "has space", Status: "active"},
+		{Name: "empty"},
+		{Name: "bad-where", Where: "status:active AND"},
+		{Name: "bad-order", Status: "active", OrderBy: "title"},
+	} {
+		if err := v.Validate(); err == nil {
+			t.Fatalf("expected validation error for %+v", v)
+		}
+	}
+	if err := (View{Name: "recent.designs_1", DocType: "design-doc", OrderBy: "last_updated"}).Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
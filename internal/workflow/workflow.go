@@ -0,0 +1,218 @@
+// Package workflow enforces the ticket lifecycle declared in the workflow
+// section of .ttmp.yaml: which Status values a ticket may move to, and what
+// must hold (tasks done, owners, fields) before it enters a status.
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/models"
+	"github.com/pkg/errors"
+)
+
+// ErrTransitionNotAllowed matches every *TransitionError via errors.Is.
+var ErrTransitionNotAllowed = errors.New("status transition not allowed")
+
+// Ticket is the state a transition is checked against.
+type Ticket struct {
+	Doc       *models.Document
+	OpenTasks int
+	DoneTasks int
+}
+
+// TransitionError lists why a ticket may not move from one status to another.
+type TransitionError struct {
+	From    string
+	To      string
+	Reasons []string
+}
+
+func (e *TransitionError) Error() string {
+	from := e.From
+	if from == "" {
+		from = "(none)"
+	}
+	return fmt.Sprintf("status transition %s → %s not allowed: %s", from, e.To, strings.Join(e.Reasons, "; "))
+}
+
+// Is makes errors.Is(err, ErrTransitionNotAllowed) hold for transition errors.
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrTransitionNotAllowed
+}
+
+// Workflow is a normalized WorkflowConfig. Status names compare
+// case-insensitively.
+type Workflow struct {
+	transitions map[string][]string
+	require     map[string]workspace.WorkflowRequirement
+	states      map[string]bool
+}
+
+// New normalizes a workflow config. A zero config yields a workflow that
+// allows every transition.
+func New(cfg workspace.WorkflowConfig) (*Workflow, error) {
+	w := &Workflow{
+		transitions: map[string][]string{},
+		require:     map[string]workspace.WorkflowRequirement{},
+		states:      map[string]bool{},
+	}
+	for from, tos := range cfg.Transitions {
+		key := normalize(from)
+		if key == "" {
+			return nil, errors.New("workflow: empty status in transitions")
+		}
+		w.states[key] = true
+		if _, ok := w.transitions[key]; !ok {
+			// Listed with no targets: a final status.
+			w.transitions[key] = []string{}
+		}
+		for _, to := range tos {
+			to = normalize(to)
+			if to == "" {
+				return nil, errors.Errorf("workflow: empty target status in transitions from %q", from)
+			}
+			w.states[to] = true
+			w.transitions[key] = append(w.transitions[key], to)
+		}
+	}
+	for status, req := range cfg.Require {
+		key := normalize(status)
+		if len(w.states) > 0 && !w.states[key] {
+			return nil, errors.Errorf("workflow: requirements for %q, which is not a status in transitions", status)
+		}
+		if req.MinOwners < 0 {
+			return nil, errors.Errorf("workflow: negative minOwners for %q", status)
+		}
+		w.require[key] = req
+	}
+	return w, nil
+}
+
+// Enabled reports whether the workflow constrains anything.
+func (w *Workflow) Enabled() bool {
+	return w != nil && (len(w.transitions) > 0 || len(w.require) > 0)
+}
+
+// Next returns the statuses a ticket in status from may move to.
+func (w *Workflow) Next(from string) []string {
+	if !w.Enabled() {
+		return nil
+	}
+	if tos, ok := w.transitions[normalize(from)]; ok {
+		return append([]string(nil), tos...)
+	}
+	out := make([]string, 0, len(w.states))
+	for s := range w.states {
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Check returns a *TransitionError when moving t from status from to status
+// to breaks the workflow. Staying in the same status is always allowed. A
+// ticket whose current status is not listed in transitions (legacy or unset)
+// may move to any known status, so existing tickets can join the workflow.
+func (w *Workflow) Check(from, to string, t Ticket) error {
+	if !w.Enabled() {
+		return nil
+	}
+	fromKey, toKey := normalize(from), normalize(to)
+	if toKey == "" {
+		return &TransitionError{From: from, To: to, Reasons: []string{"target status is empty"}}
+	}
+	if fromKey == toKey {
+		return nil
+	}
+
+	var reasons []string
+	if len(w.transitions) > 0 {
+		if !w.states[toKey] {
+			reasons = append(reasons, fmt.Sprintf("%q is not a workflow status (known: %s)", to, strings.Join(w.Next(""), ", ")))
+		} else if tos, ok := w.transitions[fromKey]; ok && !contains(tos, toKey) {
+			if len(tos) == 0 {
+				reasons = append(reasons, fmt.Sprintf("%s is a final status", from))
+			} else {
+				reasons = append(reasons, fmt.Sprintf("allowed from %s: %s", from, strings.Join(tos, ", ")))
+			}
+		}
+	}
+	if req, ok := w.require[toKey]; ok {
+		reasons = append(reasons, unmet(req, t)...)
+	}
+	if len(reasons) > 0 {
+		return &TransitionError{From: from, To: to, Reasons: reasons}
+	}
+	return nil
+}
+
+// unmet lists the preconditions of req that t does not satisfy.
+func unmet(req workspace.WorkflowRequirement, t Ticket) []string {
+	var out []string
+	if req.TasksDone && t.OpenTasks > 0 {
+		out = append(out, fmt.Sprintf("%d open task(s) in tasks.md", t.OpenTasks))
+	}
+	doc := t.Doc
+	if doc == nil {
+		doc = &models.Document{}
+	}
+	if req.MinOwners > 0 && len(doc.Owners) < req.MinOwners {
+		out = append(out, fmt.Sprintf("needs at least %d owner(s) in Owners, has %d", req.MinOwners, len(doc.Owners)))
+	}
+	for _, f := range req.Fields {
+		if !fieldSet(doc, f) {
+			out = append(out, fmt.Sprintf("%s must be set", f))
+		}
+	}
+	return out
+}
+
+// fieldSet reports whether a built-in or custom frontmatter field has a value.
+func fieldSet(doc *models.Document, name string) bool {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "title":
+		return strings.TrimSpace(doc.Title) != ""
+	case "ticket":
+		return strings.TrimSpace(doc.Ticket) != ""
+	case "status":
+		return strings.TrimSpace(doc.Status) != ""
+	case "topics":
+		return len(doc.Topics) > 0
+	case "doctype":
+		return strings.TrimSpace(doc.DocType) != ""
+	case "intent":
+		return strings.TrimSpace(doc.Intent) != ""
+	case "owners":
+		return len(doc.Owners) > 0
+	case "relatedfiles":
+		return len(doc.RelatedFiles) > 0
+	case "externalsources":
+		return len(doc.ExternalSources) > 0
+	case "summary":
+		return strings.TrimSpace(doc.Summary) != ""
+	}
+	_, v, ok := doc.ExtraField(name)
+	if !ok || v == nil {
+		return false
+	}
+	if s, isString := v.(string); isString {
+		return strings.TrimSpace(s) != ""
+	}
+	return true
+}
+
+func normalize(status string) string {
+	return strings.ToLower(strings.TrimSpace(status))
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/models"
+)
+
+func testWorkflow(t *testing.T) *Workflow {
+	t.Helper()
+	w, err := New(workspace.WorkflowConfig{
+		Transitions: map[string][]string{
+			"draft":    {"active"},
+			"active":   {"review", "draft"},
+			"review":   {"complete", "active"},
+			"complete": {"archived", "active"},
+			"archived": {},
+		},
+		Require: map[string]workspace.WorkflowRequirement{
+			"review":   {MinOwners: 1},
+			"complete": {TasksDone: true, Fields: []string{"Summary", "Reviewer"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return w
+}
+
+func TestCheck(t *testing.T) {
+	w := testWorkflow(t)
+	ready := &models.Document{
+		Owners:  []string{"alice"},
+		Summary: "done",
+		Extra:   map[string]any{"reviewer": "bob"},
+	}
+
+	cases := []struct {
+		name    string
+		from    string
+		to      string
+		ticket  Ticket
+		reasons []string
+	}{
+		{name: "allowed", from: "draft", to: "active", ticket: Ticket{Doc: &models.Document{}}},
+		{name: "case-insensitive", from: "Active", to: "REVIEW", ticket: Ticket{Doc: ready}},
+		{name: "same status", from: "review", to: "review", ticket: Ticket{Doc: &models.Document{}}},
+		{name: "legacy status may join", from: "wip", to: "active", ticket: Ticket{Doc: &models.Document{}}},
+		{name: "skipping a step", from: "draft", to: "complete", ticket: Ticket{Doc: ready}, reasons: []string{"allowed from draft: active"}},
+		{name: "unknown target", from: "active", to: "done", ticket: Ticket{Doc: ready}, reasons: []string{`"done" is not a workflow status`}},
+		{name: "final status", from: "archived", to: "active", ticket: Ticket{Doc: ready}, reasons: []string{"archived is a final status"}},
+		{name: "no owners", from: "active", to: "review", ticket: Ticket{Doc: &models.Document{}}, reasons: []string{"needs at least 1 owner(s)"}},
+		{
+			name:    "open tasks and missing fields",
+			from:    "review",
+			to:      "complete",
+			ticket:  Ticket{Doc: &models.Document{}, OpenTasks: 2, DoneTasks: 1},
+			reasons: []string{"2 open task(s)", "Summary must be set", "Reviewer must be set"},
+		},
+		{name: "ready to complete", from: "review", to: "complete", ticket: Ticket{Doc: ready, DoneTasks: 3}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := w.Check(tc.from, tc.to, tc.ticket)
+			if len(tc.reasons) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrTransitionNotAllowed) {
+				t.Fatalf("expected ErrTransitionNotAllowed, got %v", err)
+			}
+			var te *TransitionError
+			if !errors.As(err, &te) || len(te.Reasons) != len(tc.reasons) {
+				t.Fatalf("reasons = %v, want %v", err, tc.reasons)
+			}
+			for i, want := range tc.reasons {
+				if !strings.Contains(te.Reasons[i], want) {
+					t.Errorf("reason %d = %q, want it to contain %q", i, te.Reasons[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestEmptyWorkflowAllowsEverything(t *testing.T) {
+	w, err := New(workspace.WorkflowConfig{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if w.Enabled() {
+		t.Fatalf("empty workflow should not be enabled")
+	}
+	if err := w.Check("complete", "whatever", Ticket{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNewRejectsRequirementsForUnknownStatus(t *testing.T) {
+	_, err := New(workspace.WorkflowConfig{
+		Transitions: map[string][]string{"draft": {"active"}},
+		Require:     map[string]workspace.WorkflowRequirement{"review": {MinOwners: 1}},
+	})
+	if err == nil {
+		t.Fatalf("expected an error for requirements on an unknown status")
+	}
+}
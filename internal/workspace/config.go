@@ -42,8 +42,8 @@ func VerboseLog(format string, args ...interface{}) {
 // WorkspaceConfig defines repository-level configuration for docmgr.
 //
 // WorkspaceConfig holds settings for the documentation workspace, including the root
-// directory path, default metadata values, vocabulary file location, and filename
-// prefix policies.
+// directory path, default metadata values, vocabulary and custom-field schema file
+// locations, and filename prefix policies.
 //
 // Example config file (.ttmp.yaml):
 //
@@ -52,7 +52,24 @@ func VerboseLog(format string, args ...interface{}) {
 //	  owners: [alice, bob]
 //	  intent: long-term
 //	vocabulary: ~/projects/myapp/docs/vocabulary.yaml
+//	schema: ~/projects/myapp/docs/schema.yaml
 //	filenamePrefixPolicy: numeric
+//	index:
+//	  cache: true
+//	workflow:
+//	  transitions:
+//	    draft: [active]
+//	    active: [review, draft]
+//	    review: [complete, active]
+//	    complete: [archived, active]
+//	  require:
+//	    review: {minOwners: 2}
+//	    complete: {tasksDone: true}
+//	hooks:
+//	  - name: rebuild-index
+//	    command: ./scripts/rebuild-ticket-index.sh
+//	    events: [ticket.created, status.changed]
+//	    timeout: 30s
 //
 // The root directory contains ticket workspaces organized by date:
 //
@@ -70,8 +87,54 @@ type WorkspaceConfig struct {
 		Owners []string `yaml:"owners"`
 		Intent string   `yaml:"intent"`
 	} `yaml:"defaults"`
-	FilenamePrefixPolicy string `yaml:"filenamePrefixPolicy"`
-	Vocabulary           string `yaml:"vocabulary"`
+	FilenamePrefixPolicy string         `yaml:"filenamePrefixPolicy"`
+	Vocabulary           string         `yaml:"vocabulary"`
+	Schema               string         `yaml:"schema"`
+	Index                IndexConfig    `yaml:"index"`
+	Workflow             WorkflowConfig `yaml:"workflow,omitempty"`
+	Hooks                []HookConfig   `yaml:"hooks,omitempty"`
+}
+
+// IndexConfig controls how the workspace index is built.
+type IndexConfig struct {
+	// Cache persists parsed documents to <root>/.docmgr/index.sqlite so later
+	// invocations only re-parse files whose mtime/size/content changed.
+	Cache bool `yaml:"cache"`
+	// CachePath overrides the cache file location (relative to the config file).
+	CachePath string `yaml:"cachePath"`
+}
+
+// WorkflowConfig declares the ticket lifecycle enforced on the Status of
+// ticket index documents. An empty config leaves Status free-form.
+type WorkflowConfig struct {
+	// Transitions maps a status to the statuses a ticket may move to from it.
+	Transitions map[string][]string `yaml:"transitions,omitempty"`
+	// Require lists preconditions that must hold before entering a status.
+	Require map[string]WorkflowRequirement `yaml:"require,omitempty"`
+}
+
+// WorkflowRequirement is a set of preconditions for entering a status.
+type WorkflowRequirement struct {
+	// TasksDone requires every task in tasks.md to be checked.
+	TasksDone bool `yaml:"tasksDone"`
+	// MinOwners requires at least this many entries in Owners.
+	MinOwners int `yaml:"minOwners"`
+	// Fields lists frontmatter fields (built-in or custom) that must be set.
+	Fields []string `yaml:"fields"`
+}
+
+// HookConfig runs a local command when docmgr writes to the workspace
+// (see internal/hooks for the events and payload).
+type HookConfig struct {
+	Name string `yaml:"name,omitempty"`
+	// Command runs through the shell in the directory of .ttmp.yaml, with the
+	// JSON event payload on stdin.
+	Command string `yaml:"command"`
+	// Events are glob patterns ("task.*") selecting the events that trigger
+	// the hook. Empty means every event.
+	Events []string `yaml:"events,omitempty"`
+	// Timeout bounds one run (Go duration, default 10s).
+	Timeout string `yaml:"timeout,omitempty"`
 }
 
 // TTMPConfig is a deprecated alias for WorkspaceConfig.
@@ -160,6 +223,14 @@ func LoadWorkspaceConfig() (*WorkspaceConfig, error) {
 		cfg.Vocabulary = filepath.Join(filepath.Dir(path), cfg.Vocabulary)
 		verboseLog("Resolved relative vocabulary path: %s", cfg.Vocabulary)
 	}
+	if cfg.Schema != "" && !filepath.IsAbs(cfg.Schema) {
+		cfg.Schema = filepath.Join(filepath.Dir(path), cfg.Schema)
+		verboseLog("Resolved relative schema path: %s", cfg.Schema)
+	}
+	if cfg.Index.CachePath != "" && !filepath.IsAbs(cfg.Index.CachePath) {
+		cfg.Index.CachePath = filepath.Join(filepath.Dir(path), cfg.Index.CachePath)
+		verboseLog("Resolved relative index cache path: %s", cfg.Index.CachePath)
+	}
 	return &cfg, nil
 }
 
@@ -407,3 +478,55 @@ func ResolveVocabularyPath() (string, error) {
 
 	return "", fmt.Errorf("vocabulary.yaml not found")
 }
+
+// ResolveFieldSchemaPath returns the path of the custom field schema file.
+// Priority:
+// 1) If .ttmp.yaml defines 'schema', use it (relative to the config file if not absolute)
+// 2) Else, use '<root>/schema.yaml' where root comes from .ttmp.yaml (default 'ttmp' relative to config)
+// 3) Else, search upwards for 'ttmp/schema.yaml'
+//
+// The returned file may not exist; a missing schema simply means no custom fields are declared.
+func ResolveFieldSchemaPath() (string, error) {
+	cfgPath, err := FindTTMPConfigPath()
+	if err == nil {
+		data, err := os.ReadFile(cfgPath)
+		if err == nil {
+			var cfg WorkspaceConfig
+			if yaml.Unmarshal(data, &cfg) == nil {
+				if cfg.Schema != "" {
+					if filepath.IsAbs(cfg.Schema) {
+						return cfg.Schema, nil
+					}
+					return filepath.Join(filepath.Dir(cfgPath), cfg.Schema), nil
+				}
+				rootPath := cfg.Root
+				if rootPath == "" {
+					rootPath = "ttmp"
+				}
+				if !filepath.IsAbs(rootPath) {
+					rootPath = filepath.Join(filepath.Dir(cfgPath), rootPath)
+				}
+				return filepath.Join(rootPath, FieldSchemaFileName), nil
+			}
+		}
+	}
+
+	dir, err := os.Getwd()
+	if err == nil {
+		for {
+			p := filepath.Join(dir, "ttmp", FieldSchemaFileName)
+			if _, err2 := os.Stat(p); err2 == nil {
+				return p, nil
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+	return "", fmt.Errorf("%s not found", FieldSchemaFileName)
+}
+
+// FieldSchemaFileName is the default custom field schema file name under the docs root.
+const FieldSchemaFileName = "schema.yaml"
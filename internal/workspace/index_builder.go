@@ -4,12 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"io/fs"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/go-go-golems/docmgr/internal/documents"
 	"github.com/go-go-golems/docmgr/internal/paths"
+	"github.com/go-go-golems/docmgr/internal/tasksmd"
 	"github.com/go-go-golems/docmgr/pkg/models"
 	"github.com/pkg/errors"
 )
@@ -19,11 +21,25 @@ type BuildIndexOptions struct {
 	// IncludeBody stores the full markdown body into docs.body.
 	// Default false to keep memory usage low.
 	IncludeBody bool
+
+	// CachePath enables the on-disk index cache at this path. When empty, the cache
+	// is enabled by `index.cache` in .ttmp.yaml (default <root>/.docmgr/index.sqlite).
+	CachePath string
+	// NoCache disables the on-disk index cache regardless of configuration.
+	NoCache bool
+
+	// Revision builds the index from the docs root as of this git revision
+	// (any rev git understands: HEAD~3, a tag, "main@{last tuesday}") instead of
+	// the working tree. Empty uses the revision the workspace was discovered
+	// with (DiscoverOptions.Revision), if any. The on-disk cache is not used.
+	Revision string
 }
 
 // InitIndex initializes (or rebuilds) the in-memory SQLite index for this workspace.
 //
-// Current policy: rebuild from scratch per CLI invocation (Decision Q16).
+// Current policy: rebuild from scratch per CLI invocation (Decision Q16). When the
+// on-disk index cache is enabled, unchanged documents are served from the cache
+// instead of being re-read and re-parsed; the in-memory tables are still rebuilt.
 func (w *Workspace) InitIndex(ctx context.Context, opts BuildIndexOptions) error {
 	if ctx == nil {
 		return errors.New("nil context")
@@ -62,26 +78,161 @@ func (w *Workspace) InitIndex(ctx context.Context, opts BuildIndexOptions) error
 		}
 	}
 
-	if err := ingestWorkspaceDocs(ctx, db, w.ctx, w.ignore, opts, ftsOK); err != nil {
+	if opts.Revision == "" {
+		opts.Revision = w.revision
+	}
+	if opts.Revision != "" {
+		parsed, commit, err := w.ingestRevisionDocs(ctx, db, opts, ftsOK, opts.Revision)
+		if err != nil {
+			_ = db.Close()
+			return err
+		}
+		w.db = db
+		w.ftsAvailable = ftsOK
+		w.indexStats = IndexStats{Parsed: parsed, Revision: commit}
+		w.indexOpts = opts
+		return nil
+	}
+
+	stats := IndexStats{}
+	var cache *indexCache
+	if cachePath := w.indexCachePath(opts); cachePath != "" {
+		// Best-effort: a broken or locked cache must never prevent indexing.
+		c, err := openIndexCache(ctx, cachePath)
+		if err != nil {
+			verboseLog("warning: index cache unavailable (continuing without it): %v", err)
+		} else {
+			cache = c
+			stats.CachePath = cachePath
+			defer func() { _ = cache.Close() }()
+		}
+	}
+
+	parsed, err := ingestWorkspaceDocs(ctx, db, w.ctx, w.ignore, opts, ftsOK, cache)
+	if err != nil {
 		_ = db.Close()
 		return err
 	}
+	stats.Parsed = parsed
+	if cache != nil {
+		stats.Parsed = cache.misses
+		stats.Cached = cache.hits
+		if err := cache.flush(ctx); err != nil {
+			verboseLog("warning: failed to update index cache: %v", err)
+		}
+	}
 
 	w.db = db
 	w.ftsAvailable = ftsOK
+	w.indexStats = stats
+	w.indexOpts = opts
 	return nil
 }
 
+// LastIndexStats reports how the most recent InitIndex obtained its documents.
+func (w *Workspace) LastIndexStats() IndexStats {
+	return w.indexStats
+}
+
+func (w *Workspace) indexCachePath(opts BuildIndexOptions) string {
+	if opts.NoCache {
+		return ""
+	}
+	if opts.CachePath != "" {
+		return opts.CachePath
+	}
+	cfg := w.ctx.Config
+	if cfg == nil || !cfg.Index.Cache {
+		return ""
+	}
+	if cfg.Index.CachePath != "" {
+		return cfg.Index.CachePath
+	}
+	return DefaultIndexCachePath(w.ctx.Root)
+}
+
+// ingestWorkspaceDocs walks the docs root and inserts every document into db.
+// It returns the number of documents read. When cache is non-nil, documents are
+// read through it.
 func ingestWorkspaceDocs(ctx context.Context, db *sql.DB, wctx WorkspaceContext, ignoreMatcher interface {
 	Ignore(path string, isDir bool) bool
-}, opts BuildIndexOptions, ftsOK bool) error {
+}, opts BuildIndexOptions, ftsOK bool, cache *indexCache) (int, error) {
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		return errors.Wrap(err, "begin ingest tx")
+		return 0, errors.Wrap(err, "begin ingest tx")
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	insertDocStmt, err := tx.PrepareContext(ctx, `
+	ins, err := newDocInserter(ctx, tx, wctx, opts, ftsOK)
+	if err != nil {
+		return 0, err
+	}
+	defer ins.Close()
+
+	readDoc := documents.ReadDocumentWithFrontmatter
+	if cache != nil {
+		readDoc = cache.read
+	}
+
+	count := 0
+	walkErr := documents.WalkDocuments(wctx.Root, func(path string, doc *models.Document, body string, readErr error) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		count++
+		return ins.Insert(ctx, path, doc, body, readErr)
+	}, documents.WithSkipDir(func(path string, d fs.DirEntry) bool {
+		if DefaultIngestSkipDir(path, d) {
+			return true
+		}
+		if ignoreMatcher != nil && ignoreMatcher.Ignore(path, true) {
+			return true
+		}
+		return false
+	}), documents.WithSkipFile(func(path string, d fs.DirEntry) bool {
+		if ignoreMatcher != nil && ignoreMatcher.Ignore(path, false) {
+			return true
+		}
+		return false
+	}), documents.WithReadDocument(readDoc))
+
+	if walkErr != nil {
+		return 0, errors.Wrap(walkErr, "walk documents for ingest")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, "commit ingest tx")
+	}
+	return count, nil
+}
+
+// docInserter holds the prepared statements used to insert one document (and its
+// topics/owners/custom fields/ticket links/external sources/tasks/related files/FTS row) within a transaction. It is shared by the
+// full ingest walk and by per-file upserts (UpsertDocument).
+type docInserter struct {
+	wctx WorkspaceContext
+	opts BuildIndexOptions
+
+	// readFile returns the raw content of tasks.md files, which usually have no
+	// frontmatter and therefore no parsed body. Revision ingest reads git blobs.
+	readFile func(path string) ([]byte, error)
+
+	insertDoc   *sql.Stmt
+	insertTopic *sql.Stmt
+	insertOwner *sql.Stmt
+	insertField *sql.Stmt
+	insertLink  *sql.Stmt
+	insertES    *sql.Stmt
+	insertTask  *sql.Stmt
+	insertRF    *sql.Stmt
+	insertFTS   *sql.Stmt
+}
+
+func newDocInserter(ctx context.Context, tx *sql.Tx, wctx WorkspaceContext, opts BuildIndexOptions, ftsOK bool) (*docInserter, error) {
+	ins := &docInserter{wctx: wctx, opts: opts, readFile: os.ReadFile}
+	var err error
+
+	ins.insertDoc, err = tx.PrepareContext(ctx, `
 INSERT INTO docs (
   path, ticket_id, doc_type, status, intent, title, last_updated,
   what_for, when_to_use,
@@ -91,29 +242,70 @@ INSERT INTO docs (
 ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `)
 	if err != nil {
-		return errors.Wrap(err, "prepare insert docs")
+		ins.Close()
+		return nil, errors.Wrap(err, "prepare insert docs")
 	}
-	defer func() { _ = insertDocStmt.Close() }()
 
-	insertTopicStmt, err := tx.PrepareContext(ctx, `
+	ins.insertTopic, err = tx.PrepareContext(ctx, `
 INSERT OR IGNORE INTO doc_topics (doc_id, topic_lower, topic_original)
 VALUES (?, ?, ?)
 `)
 	if err != nil {
-		return errors.Wrap(err, "prepare insert doc_topics")
+		ins.Close()
+		return nil, errors.Wrap(err, "prepare insert doc_topics")
 	}
-	defer func() { _ = insertTopicStmt.Close() }()
 
-	insertOwnerStmt, err := tx.PrepareContext(ctx, `
+	ins.insertOwner, err = tx.PrepareContext(ctx, `
 INSERT OR IGNORE INTO doc_owners (doc_id, owner_lower, owner_original)
 VALUES (?, ?, ?)
 `)
 	if err != nil {
-		return errors.Wrap(err, "prepare insert doc_owners")
+		ins.Close()
+		return nil, errors.Wrap(err, "prepare insert doc_owners")
 	}
-	defer func() { _ = insertOwnerStmt.Close() }()
 
-	insertRFStmt, err := tx.PrepareContext(ctx, `
+	ins.insertField, err = tx.PrepareContext(ctx, `
+INSERT INTO doc_fields (doc_id, field_lower, field_original, value_lower, value_original, is_list, position)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`)
+	if err != nil {
+		ins.Close()
+		return nil, errors.Wrap(err, "prepare insert doc_fields")
+	}
+
+	ins.insertLink, err = tx.PrepareContext(ctx, `
+INSERT INTO ticket_links (doc_id, kind, target, target_lower, position)
+VALUES (?, ?, ?, ?, ?)
+`)
+	if err != nil {
+		ins.Close()
+		return nil, errors.Wrap(err, "prepare insert ticket_links")
+	}
+
+	ins.insertES, err = tx.PrepareContext(ctx, `
+INSERT INTO external_sources (
+  doc_id, position, type, path, repo, url, local_copy, sha, last_fetched, display, match_lower
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`)
+	if err != nil {
+		ins.Close()
+		return nil, errors.Wrap(err, "prepare insert external_sources")
+	}
+
+	ins.insertTask, err = tx.PrepareContext(ctx, `
+INSERT INTO tasks (
+  doc_id, ticket_id, index_path, position, stable_id, section, checked, text, line,
+  assignees, due, priority, tags
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`)
+	if err != nil {
+		ins.Close()
+		return nil, errors.Wrap(err, "prepare insert tasks")
+	}
+
+	ins.insertRF, err = tx.PrepareContext(ctx, `
 INSERT INTO related_files (
   doc_id, note,
   anchor, norm_abs, norm_repo_rel, raw_path
@@ -121,183 +313,265 @@ INSERT INTO related_files (
 VALUES (?, ?, ?, ?, ?, ?)
 `)
 	if err != nil {
-		return errors.Wrap(err, "prepare insert related_files")
+		ins.Close()
+		return nil, errors.Wrap(err, "prepare insert related_files")
 	}
-	defer func() { _ = insertRFStmt.Close() }()
 
-	var insertFTSStmt *sql.Stmt
 	if ftsOK {
-		insertFTSStmt, err = tx.PrepareContext(ctx, `
+		ins.insertFTS, err = tx.PrepareContext(ctx, `
 INSERT INTO docs_fts (rowid, title, body, topics, doc_type, ticket_id)
 VALUES (?, ?, ?, ?, ?, ?)
 `)
 		if err != nil {
-			return errors.Wrap(err, "prepare insert docs_fts")
+			ins.Close()
+			return nil, errors.Wrap(err, "prepare insert docs_fts")
 		}
-		defer func() { _ = insertFTSStmt.Close() }()
 	}
+	return ins, nil
+}
 
-	walkErr := documents.WalkDocuments(wctx.Root, func(path string, doc *models.Document, body string, readErr error) error {
-		if err := ctx.Err(); err != nil {
-			return err
+// Close releases the prepared statements.
+func (ins *docInserter) Close() {
+	for _, stmt := range []*sql.Stmt{ins.insertDoc, ins.insertTopic, ins.insertOwner, ins.insertField, ins.insertLink, ins.insertES, ins.insertTask, ins.insertRF, ins.insertFTS} {
+		if stmt != nil {
+			_ = stmt.Close()
 		}
+	}
+}
 
-		absPath, err := filepath.Abs(path)
-		if err != nil {
-			absPath = path
-		}
-		absPath = filepath.Clean(absPath)
-
-		tags := ComputePathTags(absPath)
-
-		parseOK := 1
-		parseErr := ""
-		var ticketID, docType, status, intent, title sql.NullString
-		var lastUpdated sql.NullString
-		var whatFor, whenToUse sql.NullString
-		var bodyVal sql.NullString
-
-		if readErr != nil || doc == nil {
-			parseOK = 0
-			// Fallback: infer ticket ID from directory structure so broken docs can still be
-			// discovered by ticket-scoped queries (useful for diagnostics/repair flows).
-			//
-			// Example: <docsRoot>/YYYY/MM/DD/<TICKET--slug>/... -> ticket_id = <TICKET>
-			ticketID = nullString(inferTicketIDFromPath(wctx.Root, absPath))
-			if readErr != nil {
-				parseErr = readErr.Error()
-			} else {
-				parseErr = "unknown read error"
-			}
+// Insert adds a single document to the index. doc/body are nil/empty when readErr is set.
+func (ins *docInserter) Insert(ctx context.Context, path string, doc *models.Document, body string, readErr error) error {
+	wctx := ins.wctx
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		absPath = path
+	}
+	absPath = filepath.Clean(absPath)
+
+	tags := ComputePathTags(absPath)
+
+	parseOK := 1
+	parseErr := ""
+	var ticketID, docType, status, intent, title sql.NullString
+	var lastUpdated sql.NullString
+	var whatFor, whenToUse sql.NullString
+	var bodyVal sql.NullString
+
+	if readErr != nil || doc == nil {
+		parseOK = 0
+		// Fallback: infer ticket ID from directory structure so broken docs can still be
+		// discovered by ticket-scoped queries (useful for diagnostics/repair flows).
+		//
+		// Example: <docsRoot>/YYYY/MM/DD/<TICKET--slug>/... -> ticket_id = <TICKET>
+		ticketID = nullString(inferTicketIDFromPath(wctx.Root, absPath))
+		if readErr != nil {
+			parseErr = readErr.Error()
 		} else {
-			ticketID = nullString(doc.Ticket)
-			docType = nullString(doc.DocType)
-			status = nullString(doc.Status)
-			intent = nullString(doc.Intent)
-			title = nullString(doc.Title)
-			whatFor = nullString(doc.WhatFor)
-			whenToUse = nullString(doc.WhenToUse)
-			if !doc.LastUpdated.IsZero() {
-				lastUpdated = sql.NullString{String: doc.LastUpdated.UTC().Format(time.RFC3339Nano), Valid: true}
-			}
-			if opts.IncludeBody {
-				bodyVal = sql.NullString{String: body, Valid: true}
-			}
+			parseErr = "unknown read error"
+		}
+	} else {
+		ticketID = nullString(doc.Ticket)
+		docType = nullString(doc.DocType)
+		status = nullString(doc.Status)
+		intent = nullString(doc.Intent)
+		title = nullString(doc.Title)
+		whatFor = nullString(doc.WhatFor)
+		whenToUse = nullString(doc.WhenToUse)
+		if !doc.LastUpdated.IsZero() {
+			lastUpdated = sql.NullString{String: doc.LastUpdated.UTC().Format(time.RFC3339Nano), Valid: true}
+		}
+		if ins.opts.IncludeBody {
+			bodyVal = sql.NullString{String: body, Valid: true}
+		}
+	}
+
+	res, err := ins.insertDoc.ExecContext(
+		ctx,
+		filepath.ToSlash(absPath),
+		ticketID, docType, status, intent, title, lastUpdated,
+		whatFor, whenToUse,
+		parseOK, nullString(parseErr),
+		boolToInt(tags.IsIndex),
+		boolToInt(tags.IsArchivedPath),
+		boolToInt(tags.IsScriptsPath),
+		boolToInt(tags.IsSourcesPath),
+		boolToInt(tags.IsControlDoc),
+		bodyVal,
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert docs row")
+	}
+	docID, err := res.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "docs last insert id")
+	}
+
+	// tasks.md is indexed whether or not it has frontmatter.
+	if strings.EqualFold(filepath.Base(absPath), "tasks.md") {
+		if err := ins.insertTasks(ctx, docID, ticketID.String, absPath); err != nil {
+			return err
 		}
+	}
+
+	if parseOK == 0 || doc == nil {
+		return nil
+	}
 
-		res, err := insertDocStmt.ExecContext(
+	if ins.insertFTS != nil {
+		topicsText := strings.TrimSpace(strings.Join(doc.Topics, " "))
+		_, err := ins.insertFTS.ExecContext(
 			ctx,
-			filepath.ToSlash(absPath),
-			ticketID, docType, status, intent, title, lastUpdated,
-			whatFor, whenToUse,
-			parseOK, nullString(parseErr),
-			boolToInt(tags.IsIndex),
-			boolToInt(tags.IsArchivedPath),
-			boolToInt(tags.IsScriptsPath),
-			boolToInt(tags.IsSourcesPath),
-			boolToInt(tags.IsControlDoc),
-			bodyVal,
+			docID,
+			nullString(doc.Title),
+			nullString(body),
+			nullString(topicsText),
+			nullString(doc.DocType),
+			nullString(doc.Ticket),
 		)
 		if err != nil {
-			return errors.Wrap(err, "insert docs row")
-		}
-		docID, err := res.LastInsertId()
-		if err != nil {
-			return errors.Wrap(err, "docs last insert id")
+			return errors.Wrap(err, "insert docs_fts row")
 		}
+	}
 
-		if parseOK == 0 || doc == nil {
-			return nil
+	for _, topic := range doc.Topics {
+		topic = strings.TrimSpace(topic)
+		if topic == "" {
+			continue
 		}
-
-		if insertFTSStmt != nil {
-			topicsText := strings.TrimSpace(strings.Join(doc.Topics, " "))
-			_, err := insertFTSStmt.ExecContext(
-				ctx,
-				docID,
-				nullString(doc.Title),
-				nullString(body),
-				nullString(topicsText),
-				nullString(doc.DocType),
-				nullString(doc.Ticket),
-			)
-			if err != nil {
-				return errors.Wrap(err, "insert docs_fts row")
-			}
+		_, err := ins.insertTopic.ExecContext(ctx, docID, strings.ToLower(topic), topic)
+		if err != nil {
+			return errors.Wrap(err, "insert doc_topics row")
 		}
+	}
 
-		for _, topic := range doc.Topics {
-			topic = strings.TrimSpace(topic)
-			if topic == "" {
-				continue
-			}
-			_, err := insertTopicStmt.ExecContext(ctx, docID, strings.ToLower(topic), topic)
-			if err != nil {
-				return errors.Wrap(err, "insert doc_topics row")
-			}
+	for _, owner := range doc.Owners {
+		owner = strings.TrimSpace(owner)
+		if owner == "" {
+			continue
 		}
+		_, err := ins.insertOwner.ExecContext(ctx, docID, strings.ToLower(owner), owner)
+		if err != nil {
+			return errors.Wrap(err, "insert doc_owners row")
+		}
+	}
 
-		for _, owner := range doc.Owners {
-			owner = strings.TrimSpace(owner)
-			if owner == "" {
-				continue
-			}
-			_, err := insertOwnerStmt.ExecContext(ctx, docID, strings.ToLower(owner), owner)
+	for _, name := range doc.ExtraFieldNames() {
+		values, isList := models.FieldValueStrings(doc.Extra[name])
+		for i, v := range values {
+			_, err := ins.insertField.ExecContext(ctx, docID, strings.ToLower(name), name, strings.ToLower(v), v, boolToInt(isList), i)
 			if err != nil {
-				return errors.Wrap(err, "insert doc_owners row")
+				return errors.Wrap(err, "insert doc_fields row")
 			}
 		}
+	}
 
-		// Use a resolver anchored at this document path so doc-relative entries normalize correctly.
-		resolver := paths.NewResolver(paths.ResolverOptions{
-			DocsRoot:      wctx.Root,
-			DocPath:       absPath,
-			ConfigDir:     wctx.ConfigDir,
-			RepoRoot:      wctx.RepoRoot,
-			WorkspaceRoot: wctx.WorkspaceRoot,
-		})
-		for _, rf := range doc.RelatedFiles {
-			raw := strings.TrimSpace(rf.Path)
-			if raw == "" {
+	for _, kind := range models.TicketLinkKinds {
+		for i, target := range doc.TicketLinks(kind) {
+			target = strings.TrimSpace(target)
+			if target == "" {
 				continue
 			}
-			n := normalizeRelatedFile(resolver, raw)
-			_, err := insertRFStmt.ExecContext(
-				ctx,
-				docID,
-				nullString(rf.Note),
-				nullString(n.Anchor),
-				nullString(n.Abs),
-				nullString(n.RepoRelative),
-				nullString(raw),
-			)
+			_, err := ins.insertLink.ExecContext(ctx, docID, string(kind), target, strings.ToLower(target), i)
 			if err != nil {
-				return errors.Wrap(err, "insert related_files row")
+				return errors.Wrap(err, "insert ticket_links row")
 			}
 		}
+	}
 
-		return nil
-	}, documents.WithSkipDir(func(path string, d fs.DirEntry) bool {
-		if DefaultIngestSkipDir(path, d) {
-			return true
+	for i, es := range doc.ExternalSources {
+		display := strings.TrimSpace(es.String())
+		if display == "" && es.SHA == "" {
+			continue
 		}
-		if ignoreMatcher != nil && ignoreMatcher.Ignore(path, true) {
-			return true
+		lastFetched := ""
+		if !es.LastFetched.IsZero() {
+			lastFetched = es.LastFetched.UTC().Format(time.RFC3339)
 		}
-		return false
-	}), documents.WithSkipFile(func(path string, d fs.DirEntry) bool {
-		if ignoreMatcher != nil && ignoreMatcher.Ignore(path, false) {
-			return true
+		match := strings.ToLower(strings.Join([]string{es.Type, es.Path, es.Repo, es.URL, es.LocalCopy, es.SHA, display}, "\n"))
+		_, err := ins.insertES.ExecContext(
+			ctx,
+			docID,
+			i,
+			nullString(es.Type),
+			nullString(es.Path),
+			nullString(es.Repo),
+			nullString(es.URL),
+			nullString(es.LocalCopy),
+			nullString(es.SHA),
+			nullString(lastFetched),
+			display,
+			match,
+		)
+		if err != nil {
+			return errors.Wrap(err, "insert external_sources row")
 		}
-		return false
-	}))
+	}
 
-	if walkErr != nil {
-		return errors.Wrap(walkErr, "walk documents for ingest")
+	// Use a resolver anchored at this document path so doc-relative entries normalize correctly.
+	resolver := paths.NewResolver(paths.ResolverOptions{
+		DocsRoot:      wctx.Root,
+		DocPath:       absPath,
+		ConfigDir:     wctx.ConfigDir,
+		RepoRoot:      wctx.RepoRoot,
+		WorkspaceRoot: wctx.WorkspaceRoot,
+	})
+	for _, rf := range doc.RelatedFiles {
+		raw := strings.TrimSpace(rf.Path)
+		if raw == "" {
+			continue
+		}
+		n := normalizeRelatedFile(resolver, raw)
+		_, err := ins.insertRF.ExecContext(
+			ctx,
+			docID,
+			nullString(rf.Note),
+			nullString(n.Anchor),
+			nullString(n.Abs),
+			nullString(n.RepoRelative),
+			nullString(raw),
+		)
+		if err != nil {
+			return errors.Wrap(err, "insert related_files row")
+		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return errors.Wrap(err, "commit ingest tx")
+	return nil
+}
+
+// insertTasks parses a tasks.md file with tasksmd and inserts one tasks row per
+// checkbox. Unreadable files index no tasks.
+func (ins *docInserter) insertTasks(ctx context.Context, docID int64, ticketID string, absPath string) error {
+	raw, err := ins.readFile(absPath)
+	if err != nil {
+		return nil
+	}
+	parsed, byID := tasksmd.Parse(tasksmd.SplitLines(raw))
+	indexPath := filepath.ToSlash(filepath.Join(filepath.Dir(absPath), "index.md"))
+	for id := 1; id <= parsed.Total; id++ {
+		t, ok := byID[id]
+		if !ok {
+			continue
+		}
+		_, err := ins.insertTask.ExecContext(
+			ctx,
+			docID,
+			nullString(ticketID),
+			indexPath,
+			t.ID,
+			nullString(t.StableID),
+			t.Section,
+			boolToInt(t.Checked),
+			t.Text,
+			t.LineIndex+1,
+			strings.Join(t.Attrs.Assignees, ","),
+			t.Attrs.Due,
+			t.Attrs.Priority,
+			strings.Join(t.Attrs.Tags, ","),
+		)
+		if err != nil {
+			return errors.Wrap(err, "insert tasks row")
+		}
 	}
 	return nil
 }
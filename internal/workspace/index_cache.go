@@ -0,0 +1,269 @@
+package workspace
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/pkg/models"
+	"github.com/pkg/errors"
+)
+
+// StateDirName is the docs-root-relative directory holding docmgr's own state
+// (index cache, ...). It is never ingested as documentation.
+const StateDirName = ".docmgr"
+
+const indexCacheFileName = "index.sqlite"
+
+// DefaultIndexCachePath returns the default on-disk index cache location for a docs root.
+func DefaultIndexCachePath(root string) string {
+	return filepath.Join(root, StateDirName, indexCacheFileName)
+}
+
+// IndexStats summarizes the last InitIndex run.
+type IndexStats struct {
+	// Parsed counts documents whose frontmatter was (re-)parsed.
+	Parsed int
+	// Cached counts documents served from the on-disk index cache.
+	Cached int
+	// CachePath is the cache file used, or "" when caching was disabled.
+	CachePath string
+	// Revision is the commit the index was built from, or "" for the working tree.
+	Revision string
+}
+
+// indexCache persists parsed documents keyed by path and validated by mtime/size/sha256.
+//
+// The in-memory index stays the single query surface (Decision Q16); the cache only
+// short-circuits reading and parsing files that did not change since the last run.
+type indexCache struct {
+	db      *sql.DB
+	entries map[string]indexCacheEntry
+	seen    map[string]struct{}
+	dirty   map[string]indexCacheEntry
+	hits    int
+	misses  int
+}
+
+type indexCacheEntry struct {
+	ModTimeNS int64
+	Size      int64
+	SHA256    string
+	// DocJSON is the JSON-encoded models.Document; empty when parsing failed.
+	DocJSON  string
+	Body     string
+	ParseErr string
+}
+
+func openIndexCache(ctx context.Context, path string) (*indexCache, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, errors.Wrap(err, "create index cache dir")
+	}
+	db, err := sql.Open("sqlite3", "file:"+filepath.ToSlash(path)+"?_busy_timeout=5000")
+	if err != nil {
+		return nil, errors.Wrap(err, "open index cache")
+	}
+	// Single connection: the cache is read once up front and written once at the end.
+	db.SetMaxOpenConns(1)
+
+	c := &indexCache{
+		db:      db,
+		entries: map[string]indexCacheEntry{},
+		seen:    map[string]struct{}{},
+		dirty:   map[string]indexCacheEntry{},
+	}
+	if err := c.migrate(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := c.load(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// migrate drops and recreates the cache when its stamp differs from workspaceSchemaVersion.
+func (c *indexCache) migrate(ctx context.Context) error {
+	var v int
+	if err := c.db.QueryRowContext(ctx, "PRAGMA user_version;").Scan(&v); err != nil {
+		return errors.Wrap(err, "read index cache version")
+	}
+	if v == workspaceSchemaVersion {
+		return nil
+	}
+	if v != 0 {
+		verboseLog("index cache schema version %d != %d; rebuilding", v, workspaceSchemaVersion)
+	}
+	stmts := []string{
+		`DROP TABLE IF EXISTS cached_docs;`,
+		`
+CREATE TABLE cached_docs (
+    path TEXT PRIMARY KEY,                  -- absolute slash-separated path
+    mtime_ns INTEGER NOT NULL,
+    size INTEGER NOT NULL,
+    sha256 TEXT NOT NULL,
+    doc_json TEXT,                          -- JSON-encoded models.Document (NULL on parse error)
+    body TEXT,
+    parse_err TEXT
+);`,
+		// PRAGMA user_version doesn't accept bound parameters.
+		fmt.Sprintf("PRAGMA user_version = %d;", workspaceSchemaVersion),
+	}
+	for _, stmt := range stmts {
+		if _, err := c.db.ExecContext(ctx, stmt); err != nil {
+			return errors.Wrap(err, "migrate index cache")
+		}
+	}
+	return nil
+}
+
+func (c *indexCache) load(ctx context.Context) error {
+	rows, err := c.db.QueryContext(ctx, `SELECT path, mtime_ns, size, sha256, COALESCE(doc_json,''), COALESCE(body,''), COALESCE(parse_err,'') FROM cached_docs`)
+	if err != nil {
+		return errors.Wrap(err, "load index cache")
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var path string
+		var e indexCacheEntry
+		if err := rows.Scan(&path, &e.ModTimeNS, &e.Size, &e.SHA256, &e.DocJSON, &e.Body, &e.ParseErr); err != nil {
+			return errors.Wrap(err, "scan index cache row")
+		}
+		c.entries[path] = e
+	}
+	return rows.Err()
+}
+
+// read implements documents.ReadDocumentFunc.
+func (c *indexCache) read(path string) (*models.Document, string, error) {
+	key := indexCacheKey(path)
+	c.seen[key] = struct{}{}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, "", err
+	}
+	mtime := fi.ModTime().UnixNano()
+	cached, ok := c.entries[key]
+	if ok && cached.ModTimeNS == mtime && cached.Size == fi.Size() {
+		if doc, err := cached.decodeDoc(); err == nil {
+			c.hits++
+			return cached.result(doc)
+		}
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(raw)
+	sha := hex.EncodeToString(sum[:])
+
+	// Touched but unchanged content (e.g. git checkout): refresh stat keys only.
+	if ok && cached.SHA256 == sha {
+		if doc, err := cached.decodeDoc(); err == nil {
+			cached.ModTimeNS = mtime
+			cached.Size = fi.Size()
+			c.dirty[key] = cached
+			c.hits++
+			return cached.result(doc)
+		}
+	}
+
+	c.misses++
+	doc, body, readErr := documents.ParseDocumentWithFrontmatter(path, raw)
+	entry := indexCacheEntry{ModTimeNS: mtime, Size: fi.Size(), SHA256: sha}
+	if readErr != nil || doc == nil {
+		entry.ParseErr = "unknown read error"
+		if readErr != nil {
+			entry.ParseErr = readErr.Error()
+		}
+	} else {
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return doc, body, readErr
+		}
+		entry.DocJSON = string(b)
+		entry.Body = body
+	}
+	c.dirty[key] = entry
+	return doc, body, readErr
+}
+
+// decodeDoc returns the cached document, or nil when the cached parse had failed.
+func (e indexCacheEntry) decodeDoc() (*models.Document, error) {
+	if e.DocJSON == "" {
+		return nil, nil
+	}
+	var doc models.Document
+	if err := json.Unmarshal([]byte(e.DocJSON), &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// result mirrors the documents.ReadDocumentFunc contract for a cached entry.
+func (e indexCacheEntry) result(doc *models.Document) (*models.Document, string, error) {
+	if doc == nil {
+		return nil, "", errors.New(e.ParseErr)
+	}
+	return doc, e.Body, nil
+}
+
+// flush writes changed entries and prunes entries for files that no longer exist
+// (or are now ignored). It must only be called after a complete walk.
+func (c *indexCache) flush(ctx context.Context) error {
+	var stale []string
+	for path := range c.entries {
+		if _, ok := c.seen[path]; !ok {
+			stale = append(stale, path)
+		}
+	}
+	if len(c.dirty) == 0 && len(stale) == 0 {
+		return nil
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "begin index cache tx")
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	upsert, err := tx.PrepareContext(ctx, `
+INSERT INTO cached_docs (path, mtime_ns, size, sha256, doc_json, body, parse_err)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(path) DO UPDATE SET
+  mtime_ns=excluded.mtime_ns, size=excluded.size, sha256=excluded.sha256,
+  doc_json=excluded.doc_json, body=excluded.body, parse_err=excluded.parse_err
+`)
+	if err != nil {
+		return errors.Wrap(err, "prepare index cache upsert")
+	}
+	defer func() { _ = upsert.Close() }()
+	for path, e := range c.dirty {
+		if _, err := upsert.ExecContext(ctx, path, e.ModTimeNS, e.Size, e.SHA256, nullString(e.DocJSON), e.Body, nullString(e.ParseErr)); err != nil {
+			return errors.Wrap(err, "upsert index cache row")
+		}
+	}
+	for _, path := range stale {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM cached_docs WHERE path = ?`, path); err != nil {
+			return errors.Wrap(err, "prune index cache row")
+		}
+	}
+	return errors.Wrap(tx.Commit(), "commit index cache tx")
+}
+
+func (c *indexCache) Close() error {
+	return c.db.Close()
+}
+
+func indexCacheKey(path string) string {
+	return filepath.ToSlash(absCleanPath(path))
+}
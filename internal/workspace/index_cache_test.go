@@ -0,0 +1,161 @@
+package workspace
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWorkspaceInitIndex_CacheReparsesOnlyChangedDocs(t *testing.T) {
+	ctx := context.Background()
+	repoRoot := t.TempDir()
+	docsRoot := filepath.Join(repoRoot, "ttmp")
+	ticketDir := filepath.Join(docsRoot, "2026", "01", "02", "CACHE-1--cache")
+	cachePath := DefaultIndexCachePath(docsRoot)
+
+	writeFile(t, filepath.Join(ticketDir, "index.md"), `---
+Title: Cache Demo
+Ticket: CACHE-1
+Status: active
+Topics: [index]
+DocType: index
+---
+
+# Cache Demo
+`)
+	designPath := filepath.Join(ticketDir, "design", "01-design.md")
+	writeFile(t, designPath, `---
+Title: Design v1
+Ticket: CACHE-1
+Status: draft
+DocType: design-doc
+---
+
+body v1
+`)
+	writeFile(t, filepath.Join(ticketDir, "reference", "zz-broken.md"), `---
+Title: Broken
+Topics: [a
+---
+`)
+
+	ws, err := NewWorkspaceFromContext(WorkspaceContext{Root: docsRoot, ConfigDir: repoRoot, RepoRoot: repoRoot})
+	if err != nil {
+		t.Fatalf("NewWorkspaceFromContext: %v", err)
+	}
+
+	build := func() IndexStats {
+		t.Helper()
+		if err := ws.InitIndex(ctx, BuildIndexOptions{IncludeBody: true, CachePath: cachePath}); err != nil {
+			t.Fatalf("InitIndex: %v", err)
+		}
+		return ws.LastIndexStats()
+	}
+
+	if st := build(); st.Parsed != 3 || st.Cached != 0 {
+		t.Fatalf("cold build: expected 3 parsed / 0 cached, got %+v", st)
+	}
+	if st := build(); st.Parsed != 0 || st.Cached != 3 {
+		t.Fatalf("warm build: expected 0 parsed / 3 cached, got %+v", st)
+	}
+
+	// Cached parse errors keep the doc visible with parse_ok=0.
+	var parseOK int
+	if err := ws.DB().QueryRowContext(ctx, `SELECT parse_ok FROM docs WHERE path LIKE '%/zz-broken.md'`).Scan(&parseOK); err != nil {
+		t.Fatalf("select broken doc: %v", err)
+	}
+	if parseOK != 0 {
+		t.Fatalf("expected cached broken doc parse_ok=0, got %d", parseOK)
+	}
+
+	writeFile(t, designPath, `---
+Title: Design v2
+Ticket: CACHE-1
+Status: active
+DocType: design-doc
+---
+
+body v2 (longer)
+`)
+	if st := build(); st.Parsed != 1 || st.Cached != 2 {
+		t.Fatalf("after edit: expected 1 parsed / 2 cached, got %+v", st)
+	}
+	var title, body string
+	if err := ws.DB().QueryRowContext(ctx, `SELECT title, body FROM docs WHERE path LIKE '%/01-design.md'`).Scan(&title, &body); err != nil {
+		t.Fatalf("select design doc: %v", err)
+	}
+	if title != "Design v2" || body != "\nbody v2 (longer)\n" {
+		t.Fatalf("expected updated title/body, got %q / %q", title, body)
+	}
+
+	// Touching a file without changing its content is detected by hash.
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(designPath, future, future); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	if st := build(); st.Parsed != 0 || st.Cached != 3 {
+		t.Fatalf("after touch: expected 0 parsed / 3 cached, got %+v", st)
+	}
+
+	if err := os.Remove(designPath); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	build()
+	if n := countCachedDocs(t, cachePath); n != 2 {
+		t.Fatalf("expected deleted doc to be pruned from cache, got %d rows", n)
+	}
+}
+
+func TestWorkspaceInitIndex_CacheRebuildsOnSchemaVersionChange(t *testing.T) {
+	ctx := context.Background()
+	repoRoot := t.TempDir()
+	docsRoot := filepath.Join(repoRoot, "ttmp")
+	cachePath := filepath.Join(t.TempDir(), "index.sqlite")
+	writeFile(t, filepath.Join(docsRoot, "2026", "01", "02", "CACHE-2--v", "index.md"), `---
+Title: Versioned
+Ticket: CACHE-2
+DocType: index
+---
+`)
+
+	ws, err := NewWorkspaceFromContext(WorkspaceContext{Root: docsRoot, ConfigDir: repoRoot, RepoRoot: repoRoot})
+	if err != nil {
+		t.Fatalf("NewWorkspaceFromContext: %v", err)
+	}
+	if err := ws.InitIndex(ctx, BuildIndexOptions{CachePath: cachePath}); err != nil {
+		t.Fatalf("InitIndex: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", cachePath)
+	if err != nil {
+		t.Fatalf("open cache: %v", err)
+	}
+	if _, err := db.Exec(`PRAGMA user_version = 9999;`); err != nil {
+		t.Fatalf("stamp cache: %v", err)
+	}
+	_ = db.Close()
+
+	if err := ws.InitIndex(ctx, BuildIndexOptions{CachePath: cachePath}); err != nil {
+		t.Fatalf("InitIndex: %v", err)
+	}
+	if st := ws.LastIndexStats(); st.Parsed != 1 || st.Cached != 0 {
+		t.Fatalf("expected stale-version cache to be rebuilt, got %+v", st)
+	}
+}
+
+func countCachedDocs(t *testing.T, cachePath string) int {
+	t.Helper()
+	db, err := sql.Open("sqlite3", cachePath)
+	if err != nil {
+		t.Fatalf("open cache: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM cached_docs`).Scan(&n); err != nil {
+		t.Fatalf("count cached docs: %v", err)
+	}
+	return n
+}
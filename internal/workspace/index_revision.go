@@ -0,0 +1,211 @@
+package workspace
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/pkg/errors"
+)
+
+// ingestRevisionDocs indexes the markdown files under the docs root as they were
+// at a git revision, reading blobs with local git instead of walking the working
+// tree. Documents keep their working-tree absolute paths so queries, scopes and
+// display behave as usual; the ingest and .docmgrignore policies of the working
+// tree apply. It returns the number of documents read and the resolved commit.
+func (w *Workspace) ingestRevisionDocs(ctx context.Context, db *sql.DB, opts BuildIndexOptions, ftsOK bool, rev string) (int, string, error) {
+	toplevel, prefix, err := gitDocsRootPrefix(ctx, w.ctx.Root)
+	if err != nil {
+		return 0, "", err
+	}
+	commit, err := gitOutput(ctx, toplevel, "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	if err != nil {
+		return 0, "", errors.Errorf("unknown git revision %q", rev)
+	}
+	commit = strings.TrimSpace(commit)
+
+	args := []string{"ls-tree", "-r", "-z", commit}
+	if prefix != "." {
+		args = append(args, "--", prefix)
+	}
+	listing, err := gitOutput(ctx, toplevel, args...)
+	if err != nil {
+		return 0, "", errors.Wrapf(err, "list %s at %s", w.ctx.Root, rev)
+	}
+
+	type entry struct {
+		abs  string
+		blob string
+	}
+	var entries []entry
+	for _, rec := range strings.Split(listing, "\x00") {
+		// "<mode> <type> <object>\t<path>"
+		meta, repoPath, ok := strings.Cut(rec, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		rel := repoPath
+		if prefix != "." {
+			rel = strings.TrimPrefix(strings.TrimPrefix(repoPath, prefix), "/")
+		}
+		abs := filepath.Join(w.ctx.Root, filepath.FromSlash(rel))
+		if !w.IsIndexablePath(abs) {
+			continue
+		}
+		entries = append(entries, entry{abs: abs, blob: fields[2]})
+	}
+
+	blobs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		blobs = append(blobs, e.blob)
+	}
+	contents, err := gitReadBlobs(ctx, toplevel, blobs)
+	if err != nil {
+		return 0, "", err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, "", errors.Wrap(err, "begin ingest tx")
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	ins, err := newDocInserter(ctx, tx, w.ctx, opts, ftsOK)
+	if err != nil {
+		return 0, "", err
+	}
+	defer ins.Close()
+	byPath := make(map[string][]byte, len(entries))
+	for i, e := range entries {
+		byPath[e.abs] = contents[i]
+	}
+	ins.readFile = func(path string) ([]byte, error) {
+		if raw, ok := byPath[path]; ok {
+			return raw, nil
+		}
+		return nil, os.ErrNotExist
+	}
+
+	for i, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return 0, "", err
+		}
+		doc, body, readErr := documents.ParseDocumentWithFrontmatter(e.abs, contents[i])
+		if err := ins.Insert(ctx, e.abs, doc, body, readErr); err != nil {
+			return 0, "", err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, "", errors.Wrap(err, "commit ingest tx")
+	}
+	return len(entries), commit, nil
+}
+
+// gitDocsRootPrefix returns the repository toplevel containing root and root's
+// slash-separated path inside it ("." when root is the toplevel). root does not
+// need to exist in the working tree.
+func gitDocsRootPrefix(ctx context.Context, root string) (string, string, error) {
+	existing, rest := root, ""
+	for {
+		if st, err := os.Stat(existing); err == nil && st.IsDir() {
+			break
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return "", "", errors.Errorf("no existing directory above %s", root)
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+	toplevel, err := gitOutput(ctx, existing, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", "", errors.Wrapf(err, "docs root %s is not inside a git repository", root)
+	}
+	toplevel = filepath.Clean(strings.TrimSpace(toplevel))
+
+	// git reports the toplevel with symlinks resolved; resolve root the same way.
+	if resolved, err := filepath.EvalSymlinks(existing); err == nil {
+		existing = resolved
+	}
+	rel, err := filepath.Rel(toplevel, filepath.Join(existing, rest))
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", "", errors.Errorf("docs root %s is outside repository %s", root, toplevel)
+	}
+	return toplevel, filepath.ToSlash(rel), nil
+}
+
+// gitReadBlobs reads blob contents in order through a single `git cat-file --batch`.
+func gitReadBlobs(ctx context.Context, dir string, blobs []string) ([][]byte, error) {
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+	cmd := exec.CommandContext(ctx, "git", "-C", dir, "cat-file", "--batch")
+	cmd.Stdin = strings.NewReader(strings.Join(blobs, "\n") + "\n")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "git cat-file")
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrap(err, "start git cat-file")
+	}
+
+	r := bufio.NewReader(stdout)
+	out := make([][]byte, 0, len(blobs))
+	for range blobs {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			_ = cmd.Wait()
+			return nil, errors.Wrapf(err, "read git cat-file header: %s", strings.TrimSpace(stderr.String()))
+		}
+		// "<object> blob <size>" or "<object> missing"
+		parts := strings.Fields(header)
+		if len(parts) != 3 {
+			_ = cmd.Wait()
+			return nil, errors.Errorf("unexpected git cat-file output: %q", strings.TrimSpace(header))
+		}
+		size, err := strconv.Atoi(parts[2])
+		if err != nil {
+			_ = cmd.Wait()
+			return nil, errors.Wrapf(err, "parse git cat-file size %q", parts[2])
+		}
+		buf := make([]byte, size+1) // content + trailing newline
+		if _, err := io.ReadFull(r, buf); err != nil {
+			_ = cmd.Wait()
+			return nil, errors.Wrap(err, "read git blob")
+		}
+		out = append(out, buf[:size])
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, errors.Wrapf(err, "git cat-file: %s", strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+func gitOutput(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return "", err
+		}
+		return "", errors.Wrap(err, msg)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,135 @@
+package workspace
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/pkg/errors"
+)
+
+// UpsertDocument re-reads a single markdown file and replaces its rows in the index.
+//
+// This is the incremental counterpart of InitIndex used by long-running processes
+// (e.g. the HTTP API file watcher). Files that the ingest policy would skip (.meta/,
+// .docmgr/, _*/ directories, .docmgrignore matches, non-markdown files) or that no
+// longer exist are removed from the index instead.
+//
+// Callers must serialize UpsertDocument/RemoveDocument with concurrent queries.
+func (w *Workspace) UpsertDocument(ctx context.Context, path string) error {
+	if w.db == nil {
+		return errors.New("workspace index not initialized (db is nil); call InitIndex first")
+	}
+	if w.indexOpts.Revision != "" {
+		return errors.New("workspace index was built from a git revision; per-file updates read the working tree")
+	}
+	absPath := absCleanPath(path)
+	if !w.IsIndexablePath(absPath) {
+		return w.RemoveDocument(ctx, absPath)
+	}
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		return w.RemoveDocument(ctx, absPath)
+	}
+
+	doc, body, readErr := documents.ReadDocumentWithFrontmatter(absPath)
+
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "begin upsert tx")
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := deleteDocRows(ctx, tx, absPath, w.ftsAvailable); err != nil {
+		return err
+	}
+	ins, err := newDocInserter(ctx, tx, w.ctx, w.indexOpts, w.ftsAvailable)
+	if err != nil {
+		return err
+	}
+	defer ins.Close()
+	if err := ins.Insert(ctx, absPath, doc, body, readErr); err != nil {
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "commit upsert tx")
+}
+
+// RemoveDocument deletes a document (and its dependent rows) from the index.
+// Removing a path that is not indexed is a no-op.
+func (w *Workspace) RemoveDocument(ctx context.Context, path string) error {
+	if w.db == nil {
+		return errors.New("workspace index not initialized (db is nil); call InitIndex first")
+	}
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "begin remove tx")
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := deleteDocRows(ctx, tx, absCleanPath(path), w.ftsAvailable); err != nil {
+		return err
+	}
+	return errors.Wrap(tx.Commit(), "commit remove tx")
+}
+
+// IsIndexablePath reports whether InitIndex would ingest the markdown file at path.
+func (w *Workspace) IsIndexablePath(path string) bool {
+	if strings.ToLower(filepath.Ext(path)) != ".md" {
+		return false
+	}
+	rel, err := filepath.Rel(w.ctx.Root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	dir := w.ctx.Root
+	parts := strings.Split(filepath.Dir(rel), string(filepath.Separator))
+	for _, part := range parts {
+		if part == "." || part == "" {
+			continue
+		}
+		dir = filepath.Join(dir, part)
+		if skipIngestDirName(part) {
+			return false
+		}
+		if w.ignore != nil && w.ignore.Ignore(dir, true) {
+			return false
+		}
+	}
+	return w.ignore == nil || !w.ignore.Ignore(path, false)
+}
+
+// deleteDocRows removes a doc row by path together with its dependent rows.
+//
+// Dependent rows are deleted explicitly: PRAGMA foreign_keys is per-connection and
+// the pool may hand us a connection where ON DELETE CASCADE is not enforced.
+func deleteDocRows(ctx context.Context, tx *sql.Tx, absPath string, ftsOK bool) error {
+	key := filepath.ToSlash(absPath)
+	stmts := []string{
+		`DELETE FROM doc_topics WHERE doc_id IN (SELECT doc_id FROM docs WHERE path = ?)`,
+		`DELETE FROM doc_owners WHERE doc_id IN (SELECT doc_id FROM docs WHERE path = ?)`,
+		`DELETE FROM doc_fields WHERE doc_id IN (SELECT doc_id FROM docs WHERE path = ?)`,
+		`DELETE FROM ticket_links WHERE doc_id IN (SELECT doc_id FROM docs WHERE path = ?)`,
+		`DELETE FROM external_sources WHERE doc_id IN (SELECT doc_id FROM docs WHERE path = ?)`,
+		`DELETE FROM tasks WHERE doc_id IN (SELECT doc_id FROM docs WHERE path = ?)`,
+		`DELETE FROM related_files WHERE doc_id IN (SELECT doc_id FROM docs WHERE path = ?)`,
+	}
+	if ftsOK {
+		stmts = append(stmts, `DELETE FROM docs_fts WHERE rowid IN (SELECT doc_id FROM docs WHERE path = ?)`)
+	}
+	stmts = append(stmts, `DELETE FROM docs WHERE path = ?`)
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt, key); err != nil {
+			return errors.Wrap(err, "delete doc rows")
+		}
+	}
+	return nil
+}
+
+func absCleanPath(path string) string {
+	abs, err := filepath.Abs(strings.TrimSpace(path))
+	if err != nil {
+		abs = path
+	}
+	return filepath.Clean(abs)
+}
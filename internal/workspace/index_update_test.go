@@ -0,0 +1,110 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkspaceUpsertAndRemoveDocument(t *testing.T) {
+	ctx := context.Background()
+	repoRoot := t.TempDir()
+	docsRoot := filepath.Join(repoRoot, "ttmp")
+	ticketDir := filepath.Join(docsRoot, "2026", "02", "01", "UPD-1--updates")
+
+	writeFile(t, filepath.Join(ticketDir, "index.md"), `---
+Title: Updates
+Ticket: UPD-1
+Status: active
+Topics: [alpha]
+DocType: index
+---
+`)
+
+	ws, err := NewWorkspaceFromContext(WorkspaceContext{Root: docsRoot, ConfigDir: repoRoot, RepoRoot: repoRoot})
+	if err != nil {
+		t.Fatalf("NewWorkspaceFromContext: %v", err)
+	}
+	if err := ws.InitIndex(ctx, BuildIndexOptions{}); err != nil {
+		t.Fatalf("InitIndex: %v", err)
+	}
+
+	countDocs := func() int {
+		t.Helper()
+		var n int
+		if err := ws.DB().QueryRowContext(ctx, `SELECT COUNT(*) FROM docs`).Scan(&n); err != nil {
+			t.Fatalf("count docs: %v", err)
+		}
+		return n
+	}
+
+	// New doc.
+	designPath := filepath.Join(ticketDir, "design", "01-design.md")
+	writeFile(t, designPath, `---
+Title: Design
+Ticket: UPD-1
+Status: draft
+Topics: [beta]
+DocType: design-doc
+RelatedFiles:
+  - Path: main.go
+---
+`)
+	if err := ws.UpsertDocument(ctx, designPath); err != nil {
+		t.Fatalf("UpsertDocument: %v", err)
+	}
+	if n := countDocs(); n != 2 {
+		t.Fatalf("expected 2 docs after upsert, got %d", n)
+	}
+
+	// Changed doc replaces its rows instead of duplicating them.
+	writeFile(t, designPath, `---
+Title: Design v2
+Ticket: UPD-1
+Status: active
+Topics: [gamma]
+DocType: design-doc
+---
+`)
+	if err := ws.UpsertDocument(ctx, designPath); err != nil {
+		t.Fatalf("UpsertDocument: %v", err)
+	}
+	res, err := ws.QueryDocs(ctx, DocQuery{Scope: Scope{Kind: ScopeRepo}, Filters: DocFilters{TopicsAny: []string{"gamma"}}})
+	if err != nil {
+		t.Fatalf("QueryDocs: %v", err)
+	}
+	if len(res.Docs) != 1 || res.Docs[0].Doc.Title != "Design v2" {
+		t.Fatalf("expected updated doc to match new topic, got %+v", res.Docs)
+	}
+	var staleRows int
+	if err := ws.DB().QueryRowContext(ctx, `SELECT (SELECT COUNT(*) FROM doc_topics WHERE topic_lower='beta') + (SELECT COUNT(*) FROM related_files)`).Scan(&staleRows); err != nil {
+		t.Fatalf("count stale rows: %v", err)
+	}
+	if staleRows != 0 {
+		t.Fatalf("expected old topic/related file rows to be replaced, got %d", staleRows)
+	}
+
+	// Deleted files and skipped paths are removed.
+	if err := os.Remove(designPath); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := ws.UpsertDocument(ctx, designPath); err != nil {
+		t.Fatalf("UpsertDocument (deleted): %v", err)
+	}
+	if n := countDocs(); n != 1 {
+		t.Fatalf("expected deleted doc to be removed, got %d docs", n)
+	}
+
+	metaPath := filepath.Join(ticketDir, ".meta", "notes.md")
+	writeFile(t, metaPath, "---\nTitle: Meta\n---\n")
+	if ws.IsIndexablePath(metaPath) {
+		t.Fatalf("expected .meta docs to be non-indexable")
+	}
+	if err := ws.UpsertDocument(ctx, metaPath); err != nil {
+		t.Fatalf("UpsertDocument (.meta): %v", err)
+	}
+	if n := countDocs(); n != 1 {
+		t.Fatalf("expected .meta doc to stay out of the index, got %d docs", n)
+	}
+}
@@ -192,6 +192,9 @@ func (w *Workspace) QueryDocs(ctx context.Context, q DocQuery) (DocQueryResult,
 	topicsByDocID := map[int64][]string{}
 	ownersByDocID := map[int64][]string{}
 	rfsByDocID := map[int64]models.RelatedFiles{}
+	fieldsByDocID := map[int64]map[string]any{}
+	linksByDocID := map[int64][]models.TicketLink{}
+	sourcesByDocID := map[int64]models.ExternalSources{}
 
 	if len(okDocIDs) > 0 {
 		if topics, err := fetchTopicsByDocIDs(ctx, w.db, okDocIDs); err == nil {
@@ -203,6 +206,15 @@ func (w *Workspace) QueryDocs(ctx context.Context, q DocQuery) (DocQueryResult,
 		if rfs, err := fetchRelatedFilesByDocIDs(ctx, w.db, okDocIDs); err == nil {
 			rfsByDocID = rfs
 		}
+		if extra, err := fetchFieldsByDocIDs(ctx, w.db, okDocIDs); err == nil {
+			fieldsByDocID = extra
+		}
+		if links, err := fetchTicketLinksByDocIDs(ctx, w.db, okDocIDs); err == nil {
+			linksByDocID = links
+		}
+		if sources, err := fetchExternalSourcesByDocIDs(ctx, w.db, okDocIDs); err == nil {
+			sourcesByDocID = sources
+		}
 	}
 
 	handles := make([]DocHandle, 0, len(pending))
@@ -217,6 +229,15 @@ func (w *Workspace) QueryDocs(ctx context.Context, q DocQuery) (DocQueryResult,
 			if rfs, ok := rfsByDocID[p.docID]; ok {
 				p.handle.Doc.RelatedFiles = rfs
 			}
+			if extra, ok := fieldsByDocID[p.docID]; ok {
+				p.handle.Doc.Extra = extra
+			}
+			for _, l := range linksByDocID[p.docID] {
+				p.handle.Doc.AddTicketLink(l.Kind, l.Target)
+			}
+			if sources, ok := sourcesByDocID[p.docID]; ok {
+				p.handle.Doc.ExternalSources = sources
+			}
 		}
 		handles = append(handles, p.handle)
 	}
@@ -317,6 +338,26 @@ type DocFilters struct {
 
 	TopicsAny []string
 	OwnersAny []string
+
+	// ExternalSource matches documents with an ExternalSources entry where any
+	// field (type, path, repo, URL, local copy, SHA) contains it, ignoring case.
+	// An entry whose legacy string form is contained in the query matches too.
+	ExternalSource string
+
+	// Fields filters on custom frontmatter fields (doc_fields); all filters must match.
+	Fields []FieldFilter
+
+	// Where is a parsed query expression (see ParseDocExpr), ANDed with the
+	// other filters.
+	Where DocExpr
+}
+
+// FieldFilter matches documents whose custom field Name (case-insensitive) has
+// any of Values (case-insensitive; any list element counts). With no Values it
+// matches documents that set the field at all.
+type FieldFilter struct {
+	Name   string
+	Values []string
 }
 
 type OrderBy string
@@ -479,6 +520,118 @@ func fetchRelatedFilesByDocIDs(ctx context.Context, db *sql.DB, docIDs []int64)
 	return out, rows.Err()
 }
 
+// fetchFieldsByDocIDs hydrates Document.Extra from doc_fields. Values come back
+// as text: a string for scalars and a []string for lists.
+func fetchFieldsByDocIDs(ctx context.Context, db *sql.DB, docIDs []int64) (map[int64]map[string]any, error) {
+	docIDs = uniqueInt64(docIDs...)
+	if len(docIDs) == 0 || db == nil {
+		return map[int64]map[string]any{}, nil
+	}
+	placeholders := makePlaceholders(len(docIDs))
+	// #nosec G202 -- placeholders are generated ("?,?") and values are bound via args, not string-interpolated.
+	sqlQ := `SELECT doc_id, field_original, value_original, is_list FROM doc_fields WHERE doc_id IN (` + placeholders + `) ORDER BY doc_id, field_lower, position;`
+	args := make([]any, 0, len(docIDs))
+	for _, id := range docIDs {
+		args = append(args, id)
+	}
+	rows, err := db.QueryContext(ctx, sqlQ, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := map[int64]map[string]any{}
+	for rows.Next() {
+		var docID int64
+		var name, value string
+		var isList int
+		if err := rows.Scan(&docID, &name, &value, &isList); err != nil {
+			return nil, err
+		}
+		extra := out[docID]
+		if extra == nil {
+			extra = map[string]any{}
+			out[docID] = extra
+		}
+		if isList == 0 {
+			extra[name] = value
+			continue
+		}
+		list, _ := extra[name].([]string)
+		extra[name] = append(list, value)
+	}
+	return out, rows.Err()
+}
+
+// fetchTicketLinksByDocIDs hydrates the Document ticket relation lists
+// (DependsOn, Blocks, ...) from ticket_links.
+func fetchTicketLinksByDocIDs(ctx context.Context, db *sql.DB, docIDs []int64) (map[int64][]models.TicketLink, error) {
+	docIDs = uniqueInt64(docIDs...)
+	if len(docIDs) == 0 || db == nil {
+		return map[int64][]models.TicketLink{}, nil
+	}
+	placeholders := makePlaceholders(len(docIDs))
+	// #nosec G202 -- placeholders are generated ("?,?") and values are bound via args, not string-interpolated.
+	sqlQ := `SELECT doc_id, kind, target FROM ticket_links WHERE doc_id IN (` + placeholders + `) ORDER BY doc_id, kind, position;`
+	args := make([]any, 0, len(docIDs))
+	for _, id := range docIDs {
+		args = append(args, id)
+	}
+	rows, err := db.QueryContext(ctx, sqlQ, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := map[int64][]models.TicketLink{}
+	for rows.Next() {
+		var docID int64
+		var kind, target string
+		if err := rows.Scan(&docID, &kind, &target); err != nil {
+			return nil, err
+		}
+		out[docID] = append(out[docID], models.TicketLink{Kind: models.TicketLinkKind(kind), Target: target})
+	}
+	return out, rows.Err()
+}
+
+// fetchExternalSourcesByDocIDs hydrates Document.ExternalSources from external_sources.
+func fetchExternalSourcesByDocIDs(ctx context.Context, db *sql.DB, docIDs []int64) (map[int64]models.ExternalSources, error) {
+	docIDs = uniqueInt64(docIDs...)
+	if len(docIDs) == 0 || db == nil {
+		return map[int64]models.ExternalSources{}, nil
+	}
+	placeholders := makePlaceholders(len(docIDs))
+	// #nosec G202 -- placeholders are generated ("?,?") and values are bound via args, not string-interpolated.
+	sqlQ := `SELECT doc_id, COALESCE(type,''), COALESCE(path,''), COALESCE(repo,''), COALESCE(url,''), COALESCE(local_copy,''), COALESCE(sha,''), COALESCE(last_fetched,'') FROM external_sources WHERE doc_id IN (` + placeholders + `) ORDER BY doc_id, position;`
+	args := make([]any, 0, len(docIDs))
+	for _, id := range docIDs {
+		args = append(args, id)
+	}
+	rows, err := db.QueryContext(ctx, sqlQ, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := map[int64]models.ExternalSources{}
+	for rows.Next() {
+		var docID int64
+		var es models.ExternalSource
+		var lastFetched string
+		if err := rows.Scan(&docID, &es.Type, &es.Path, &es.Repo, &es.URL, &es.LocalCopy, &es.SHA, &lastFetched); err != nil {
+			return nil, err
+		}
+		if lastFetched != "" {
+			if t, err := time.Parse(time.RFC3339, lastFetched); err == nil {
+				es.LastFetched = t
+			}
+		}
+		out[docID] = append(out[docID], es)
+	}
+	return out, rows.Err()
+}
+
 func uniqueInt64(values ...int64) []int64 {
 	seen := map[int64]struct{}{}
 	out := make([]int64, 0, len(values))
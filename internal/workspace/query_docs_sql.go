@@ -2,9 +2,12 @@ package workspace
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-go-golems/docmgr/internal/paths"
 	"github.com/pkg/errors"
@@ -108,6 +111,41 @@ func compileDocQueryWithParseFilter(ctx context.Context, w *Workspace, q DocQuer
 		args = append(args, cargs...)
 	}
 
+	// ExternalSource: substring match on any field of any entry.
+	if es := strings.ToLower(strings.TrimSpace(q.Filters.ExternalSource)); es != "" {
+		where = append(where, `EXISTS (SELECT 1 FROM external_sources es WHERE es.doc_id = d.doc_id AND (instr(es.match_lower, ?) > 0 OR (es.display <> '' AND instr(?, lower(es.display)) > 0)))`)
+		args = append(args, es, es)
+	}
+
+	// Fields: AND across fields, OR across the values of one field.
+	for _, f := range q.Filters.Fields {
+		name := strings.ToLower(strings.TrimSpace(f.Name))
+		if name == "" {
+			continue
+		}
+		prefix := `SELECT 1 FROM doc_fields f WHERE f.doc_id = d.doc_id AND f.field_lower = ? AND `
+		values := normalizeLowerList(f.Values)
+		if len(values) == 0 {
+			where = append(where, "EXISTS ("+strings.TrimSuffix(prefix, " AND ")+")")
+			args = append(args, name)
+			continue
+		}
+		clause, cargs := existsInClause(prefix, "f.value_lower", toAny(values))
+		where = append(where, clause)
+		args = append(args, name)
+		args = append(args, cargs...)
+	}
+
+	// Where: structured query expression.
+	if q.Filters.Where != nil {
+		clause, cargs, err := compileDocExpr(w, q.Filters.Where, time.Now())
+		if err != nil {
+			return compiledSQL{}, err
+		}
+		where = append(where, clause)
+		args = append(args, cargs...)
+	}
+
 	// RelatedFile: OR semantics (any file matches).
 	if len(q.Filters.RelatedFile) > 0 {
 		keys := buildQueryPathKeySet(w, q.Filters.RelatedFile)
@@ -191,6 +229,159 @@ FROM docs d
 	return compiledSQL{SQL: sql.String(), Args: args}, nil
 }
 
+// compileDocExpr compiles a parsed query expression into a WHERE clause over
+// docs d. now anchors relative dates.
+func compileDocExpr(w *Workspace, e DocExpr, now time.Time) (string, []any, error) {
+	switch x := e.(type) {
+	case AndExpr:
+		return compileDocExprList(w, x.Terms, " AND ", now)
+	case OrExpr:
+		return compileDocExprList(w, x.Terms, " OR ", now)
+	case NotExpr:
+		clause, args, err := compileDocExpr(w, x.X, now)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + clause, args, nil
+	case TermExpr:
+		if x.Op == ExprOpNe {
+			x.Op = ExprOpEq
+			clause, args, err := compileExprTerm(w, x, now)
+			if err != nil {
+				return "", nil, err
+			}
+			return "NOT " + clause, args, nil
+		}
+		return compileExprTerm(w, x, now)
+	default:
+		return "", nil, errors.Errorf("unsupported query expression %T", e)
+	}
+}
+
+func compileDocExprList(w *Workspace, terms []DocExpr, sep string, now time.Time) (string, []any, error) {
+	parts := make([]string, 0, len(terms))
+	var args []any
+	for _, t := range terms {
+		clause, cargs, err := compileDocExpr(w, t, now)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, clause)
+		args = append(args, cargs...)
+	}
+	return "(" + strings.Join(parts, sep) + ")", args, nil
+}
+
+var exprColumns = map[string]string{
+	ExprKeyTicket:  "d.ticket_id",
+	ExprKeyDocType: "d.doc_type",
+	ExprKeyStatus:  "d.status",
+	ExprKeyIntent:  "d.intent",
+	ExprKeyTitle:   "d.title",
+}
+
+// compileExprTerm compiles a single (non-negated) term.
+func compileExprTerm(w *Workspace, t TermExpr, now time.Time) (string, []any, error) {
+	value := strings.TrimSpace(t.Value)
+	if t.Custom {
+		return compileExprFieldTerm(t, value)
+	}
+	if col, ok := exprColumns[t.Key]; ok {
+		col = "COALESCE(" + col + ",'')"
+		if value == "*" {
+			return "(" + col + " <> '')", nil, nil
+		}
+		clause, arg := exprMatch("lower("+col+")", value)
+		return "(" + clause + ")", []any{arg}, nil
+	}
+
+	switch t.Key {
+	case ExprKeyTopic, ExprKeyOwner:
+		prefix := `SELECT 1 FROM doc_topics x WHERE x.doc_id = d.doc_id`
+		col := "x.topic_lower"
+		if t.Key == ExprKeyOwner {
+			prefix = `SELECT 1 FROM doc_owners x WHERE x.doc_id = d.doc_id`
+			col = "x.owner_lower"
+		}
+		if value == "*" {
+			return "EXISTS (" + prefix + ")", nil, nil
+		}
+		clause, arg := exprMatch(col, value)
+		return "EXISTS (" + prefix + " AND " + clause + ")", []any{arg}, nil
+	case ExprKeyFile:
+		clause, args := relatedFileExistsClause(buildQueryPathKeySet(w, []string{value}), buildQueryFileSuffixPatterns([]string{value}))
+		return clause, args, nil
+	case ExprKeyDir:
+		clause, args := relatedDirExistsClause(buildQueryDirPrefixes(w, []string{value}))
+		return clause, args, nil
+	case ExprKeyUpdated:
+		from, to, err := parseExprDate(value, now)
+		if err != nil {
+			return "", nil, &QueryExprError{Pos: t.Pos, Msg: err.Error()}
+		}
+		// last_updated is stored as UTC RFC3339Nano; compare at second precision.
+		const col = "substr(d.last_updated,1,19)"
+		const layout = "2006-01-02T15:04:05"
+		lo, hi := from.Format(layout), to.Format(layout)
+		switch t.Op {
+		case ExprOpMatch, ExprOpEq, ExprOpNe:
+			return "(" + col + " >= ? AND " + col + " < ?)", []any{lo, hi}, nil
+		case ExprOpGt:
+			return "(" + col + " >= ?)", []any{hi}, nil
+		case ExprOpGe:
+			return "(" + col + " >= ?)", []any{lo}, nil
+		case ExprOpLt:
+			return "(COALESCE(d.last_updated,'') <> '' AND " + col + " < ?)", []any{lo}, nil
+		case ExprOpLe:
+			return "(COALESCE(d.last_updated,'') <> '' AND " + col + " < ?)", []any{hi}, nil
+		}
+	}
+	return "", nil, &QueryExprError{Pos: t.Pos, Msg: fmt.Sprintf("unsupported term %s%s%s", t.Key, t.Op, t.Value)}
+}
+
+// compileExprFieldTerm compiles a term on a custom frontmatter field.
+// Ordering operators compare numerically when the value is a number and
+// lexically otherwise (which orders YYYY-MM-DD dates correctly).
+func compileExprFieldTerm(t TermExpr, value string) (string, []any, error) {
+	prefix := `SELECT 1 FROM doc_fields f WHERE f.doc_id = d.doc_id AND f.field_lower = ?`
+	args := []any{strings.ToLower(strings.TrimSpace(t.Key))}
+	var cmp string
+	switch t.Op {
+	case ExprOpMatch, ExprOpEq, ExprOpNe:
+		if value == "*" {
+			return "EXISTS (" + prefix + ")", args, nil
+		}
+		clause, arg := exprMatch("f.value_lower", value)
+		return "EXISTS (" + prefix + " AND " + clause + ")", append(args, arg), nil
+	case ExprOpGt:
+		cmp = ">"
+	case ExprOpGe:
+		cmp = ">="
+	case ExprOpLt:
+		cmp = "<"
+	case ExprOpLe:
+		cmp = "<="
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		return "EXISTS (" + prefix + " AND CAST(f.value_original AS REAL) " + cmp + " ?)", append(args, n), nil
+	}
+	return "EXISTS (" + prefix + " AND f.value_lower " + cmp + " ?)", append(args, strings.ToLower(value)), nil
+}
+
+// exprMatch returns a case-insensitive equality, or a GLOB when value contains
+// `*` wildcards. col must already be lowercased.
+func exprMatch(col string, value string) (string, any) {
+	value = strings.ToLower(value)
+	if !strings.Contains(value, "*") {
+		return col + " = ?", value
+	}
+	parts := strings.Split(value, "*")
+	for i, part := range parts {
+		parts[i] = escapeGlob(part)
+	}
+	return col + " GLOB ?", strings.Join(parts, "*")
+}
+
 func normalizeLowerList(values []string) []string {
 	seen := map[string]struct{}{}
 	var out []string
@@ -238,3 +238,156 @@ DocType: index
 		t.Fatalf("expected contradictory query to error")
 	}
 }
+
+func TestWorkspaceQueryDocs_CustomFieldFilters(t *testing.T) {
+	ctx := context.Background()
+
+	repoRoot := t.TempDir()
+	docsRoot := filepath.Join(repoRoot, "ttmp")
+	ticketDir := filepath.Join(docsRoot, "2025", "12", "12", "MEN-1--x")
+	writeFile(t, filepath.Join(ticketDir, "index.md"), `---
+Title: Ticket Index
+Ticket: MEN-1
+DocType: index
+Priority: P1
+Reviewers: [alice, Bob]
+---
+`)
+	writeFile(t, filepath.Join(ticketDir, "design", "01-plan.md"), `---
+Title: Plan
+Ticket: MEN-1
+DocType: design
+Priority: P2
+---
+`)
+
+	ws, err := NewWorkspaceFromContext(WorkspaceContext{
+		Root:      docsRoot,
+		ConfigDir: repoRoot,
+		RepoRoot:  repoRoot,
+	})
+	if err != nil {
+		t.Fatalf("NewWorkspaceFromContext: %v", err)
+	}
+	if err := ws.InitIndex(ctx, BuildIndexOptions{}); err != nil {
+		t.Fatalf("InitIndex: %v", err)
+	}
+
+	query := func(fields ...FieldFilter) []DocHandle {
+		t.Helper()
+		res, err := ws.QueryDocs(ctx, DocQuery{
+			Scope:   Scope{Kind: ScopeRepo},
+			Filters: DocFilters{Fields: fields},
+		})
+		if err != nil {
+			t.Fatalf("QueryDocs: %v", err)
+		}
+		return res.Docs
+	}
+
+	// Field names and values match case-insensitively.
+	docs := query(FieldFilter{Name: "priority", Values: []string{"p1"}})
+	if len(docs) != 1 || docs[0].Doc.Title != "Ticket Index" {
+		t.Fatalf("expected only the index doc for Priority=P1, got %d docs", len(docs))
+	}
+	if got := docs[0].Doc.Extra["Priority"]; got != "P1" {
+		t.Fatalf("expected hydrated Priority=P1, got %#v", got)
+	}
+	if got, ok := docs[0].Doc.Extra["Reviewers"].([]string); !ok || len(got) != 2 || got[1] != "Bob" {
+		t.Fatalf("expected hydrated Reviewers list, got %#v", docs[0].Doc.Extra["Reviewers"])
+	}
+
+	// Multiple values are ORed; list elements match individually.
+	if docs := query(FieldFilter{Name: "Priority", Values: []string{"P1", "P2"}}); len(docs) != 2 {
+		t.Fatalf("expected 2 docs for Priority in (P1,P2), got %d", len(docs))
+	}
+	if docs := query(FieldFilter{Name: "Reviewers", Values: []string{"bob"}}); len(docs) != 1 {
+		t.Fatalf("expected 1 doc reviewed by bob, got %d", len(docs))
+	}
+
+	// A bare name is a presence check; separate filters are ANDed.
+	if docs := query(FieldFilter{Name: "Reviewers"}); len(docs) != 1 {
+		t.Fatalf("expected 1 doc with Reviewers set, got %d", len(docs))
+	}
+	if docs := query(FieldFilter{Name: "Reviewers"}, FieldFilter{Name: "Priority", Values: []string{"P2"}}); len(docs) != 0 {
+		t.Fatalf("expected no docs for Reviewers AND Priority=P2, got %d", len(docs))
+	}
+}
+
+func TestWorkspaceQueryDocs_ExternalSourceFilter(t *testing.T) {
+	ctx := context.Background()
+
+	repoRoot := t.TempDir()
+	docsRoot := filepath.Join(repoRoot, "ttmp")
+	ticketDir := filepath.Join(docsRoot, "2025", "12", "12", "MEN-1--x")
+	writeFile(t, filepath.Join(ticketDir, "index.md"), `---
+Title: Ticket Index
+Ticket: MEN-1
+DocType: index
+ExternalSources:
+  - Type: pdf
+    Path: /home/me/Downloads/Spec.pdf
+    LocalCopy: sources/local/spec.pdf
+    SHA: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+    LastFetched: 2025-12-12T10:00:00Z
+---
+`)
+	writeFile(t, filepath.Join(ticketDir, "design", "01-plan.md"), `---
+Title: Plan
+Ticket: MEN-1
+DocType: design
+ExternalSources:
+  - https://example.com/rfc/retry
+---
+`)
+
+	ws, err := NewWorkspaceFromContext(WorkspaceContext{
+		Root:      docsRoot,
+		ConfigDir: repoRoot,
+		RepoRoot:  repoRoot,
+	})
+	if err != nil {
+		t.Fatalf("NewWorkspaceFromContext: %v", err)
+	}
+	if err := ws.InitIndex(ctx, BuildIndexOptions{}); err != nil {
+		t.Fatalf("InitIndex: %v", err)
+	}
+
+	query := func(es string) []DocHandle {
+		t.Helper()
+		res, err := ws.QueryDocs(ctx, DocQuery{
+			Scope:   Scope{Kind: ScopeRepo},
+			Filters: DocFilters{ExternalSource: es},
+		})
+		if err != nil {
+			t.Fatalf("QueryDocs: %v", err)
+		}
+		return res.Docs
+	}
+
+	// Any field matches, case-insensitively.
+	for _, q := range []string{"spec.pdf", "PDF", "sources/local/", "9f86d0818"} {
+		docs := query(q)
+		if len(docs) != 1 || docs[0].Doc.Title != "Ticket Index" {
+			t.Fatalf("query %q: expected only the index doc, got %d docs", q, len(docs))
+		}
+	}
+	docs := query("example.com/rfc")
+	if len(docs) != 1 || docs[0].Doc.Title != "Plan" {
+		t.Fatalf("expected the plan doc for the URL, got %d docs", len(docs))
+	}
+	// A query containing the legacy string form matches too.
+	if docs := query("https://example.com/rfc/retry#section-2"); len(docs) != 1 {
+		t.Fatalf("expected 1 doc for a URL with fragment, got %d", len(docs))
+	}
+	if docs := query("nowhere"); len(docs) != 0 {
+		t.Fatalf("expected no docs, got %d", len(docs))
+	}
+
+	// ExternalSources are hydrated from the index with all fields.
+	docs = query("spec.pdf")
+	es := docs[0].Doc.ExternalSources
+	if len(es) != 1 || es[0].Type != "pdf" || es[0].LocalCopy != "sources/local/spec.pdf" || es[0].LastFetched.IsZero() {
+		t.Fatalf("unexpected hydrated ExternalSources: %+v", es)
+	}
+}
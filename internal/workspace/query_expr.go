@@ -0,0 +1,433 @@
+package workspace
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// DocExpr is a parsed query expression (see ParseDocExpr). It is set on
+// DocFilters.Where and compiled to SQL together with the other filters.
+type DocExpr interface {
+	docExpr()
+}
+
+// AndExpr matches documents matching all of Terms.
+type AndExpr struct {
+	Terms []DocExpr
+}
+
+// OrExpr matches documents matching any of Terms.
+type OrExpr struct {
+	Terms []DocExpr
+}
+
+// NotExpr matches documents not matching X.
+type NotExpr struct {
+	X DocExpr
+}
+
+// TermExpr is a single `key<op>value` comparison.
+type TermExpr struct {
+	// Key is the canonical key (see ExprKey*) or, when Custom is set, the
+	// custom frontmatter field name as written.
+	Key    string
+	Custom bool
+	Op     ExprOp
+	Value  string
+	// Pos is the byte offset of the term in the source expression.
+	Pos int
+}
+
+func (AndExpr) docExpr()  {}
+func (OrExpr) docExpr()   {}
+func (NotExpr) docExpr()  {}
+func (TermExpr) docExpr() {}
+
+// ExprOp is a term comparison operator.
+type ExprOp string
+
+const (
+	ExprOpMatch ExprOp = ":"
+	ExprOpEq    ExprOp = "="
+	ExprOpNe    ExprOp = "!="
+	ExprOpGt    ExprOp = ">"
+	ExprOpGe    ExprOp = ">="
+	ExprOpLt    ExprOp = "<"
+	ExprOpLe    ExprOp = "<="
+)
+
+// Canonical query expression keys. Any other key names a custom frontmatter
+// field (doc_fields).
+const (
+	ExprKeyTicket  = "ticket"
+	ExprKeyDocType = "doctype"
+	ExprKeyStatus  = "status"
+	ExprKeyIntent  = "intent"
+	ExprKeyTitle   = "title"
+	ExprKeyTopic   = "topic"
+	ExprKeyOwner   = "owner"
+	ExprKeyFile    = "file"
+	ExprKeyDir     = "dir"
+	ExprKeyUpdated = "updated"
+)
+
+var exprKeyAliases = map[string]string{
+	"ticket":       ExprKeyTicket,
+	"doctype":      ExprKeyDocType,
+	"doc-type":     ExprKeyDocType,
+	"doc_type":     ExprKeyDocType,
+	"type":         ExprKeyDocType,
+	"status":       ExprKeyStatus,
+	"intent":       ExprKeyIntent,
+	"title":        ExprKeyTitle,
+	"topic":        ExprKeyTopic,
+	"topics":       ExprKeyTopic,
+	"owner":        ExprKeyOwner,
+	"owners":       ExprKeyOwner,
+	"file":         ExprKeyFile,
+	"dir":          ExprKeyDir,
+	"updated":      ExprKeyUpdated,
+	"lastupdated":  ExprKeyUpdated,
+	"last-updated": ExprKeyUpdated,
+	"last_updated": ExprKeyUpdated,
+}
+
+// QueryExprError reports a syntax or semantic error in a query expression.
+type QueryExprError struct {
+	Pos int
+	Msg string
+}
+
+func (e *QueryExprError) Error() string {
+	return fmt.Sprintf("invalid query at position %d: %s", e.Pos+1, e.Msg)
+}
+
+// ParseDocExpr parses a query expression such as
+//
+//	status:active AND topic:api AND NOT owner:bob AND updated>2026-01-01
+//
+// Grammar (keywords are case-insensitive; adjacent terms are ANDed):
+//
+//	expr  := and { OR and }
+//	and   := unary { [AND] unary }
+//	unary := NOT unary | "(" expr ")" | term
+//	term  := key op value
+//	op    := ":" | "=" | "!=" | ">" | ">=" | "<" | "<="
+//	value := word | "quoted string"
+//
+// Values match case-insensitively; `*` in a value is a wildcard and a bare `*`
+// matches any non-empty value. Ordering operators are only valid for `updated`
+// (dates) and custom fields. An empty (or all-whitespace) expression yields nil.
+func ParseDocExpr(s string) (DocExpr, error) {
+	p := &exprParser{src: s}
+	if err := p.lex(); err != nil {
+		return nil, err
+	}
+	if len(p.toks) == 0 {
+		return nil, nil
+	}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &QueryExprError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return e, nil
+}
+
+type exprTokKind int
+
+const (
+	tokEOF exprTokKind = iota
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+	tokTerm
+)
+
+type exprTok struct {
+	kind exprTokKind
+	pos  int
+	text string
+	term TermExpr
+}
+
+type exprParser struct {
+	src  string
+	toks []exprTok
+	i    int
+}
+
+func (p *exprParser) lex() error {
+	s := p.src
+	i := 0
+	for i < len(s) {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			p.toks = append(p.toks, exprTok{kind: tokLParen, pos: i, text: "("})
+			i++
+		case c == ')':
+			p.toks = append(p.toks, exprTok{kind: tokRParen, pos: i, text: ")"})
+			i++
+		default:
+			start := i
+			for i < len(s) && isExprKeyChar(s[i]) {
+				i++
+			}
+			word := s[start:i]
+			if word == "" {
+				return &QueryExprError{Pos: start, Msg: fmt.Sprintf("unexpected %q", string(s[start]))}
+			}
+			op, opLen := exprOpAt(s, i)
+			if opLen == 0 {
+				switch strings.ToUpper(word) {
+				case "AND":
+					p.toks = append(p.toks, exprTok{kind: tokAnd, pos: start, text: word})
+				case "OR":
+					p.toks = append(p.toks, exprTok{kind: tokOr, pos: start, text: word})
+				case "NOT":
+					p.toks = append(p.toks, exprTok{kind: tokNot, pos: start, text: word})
+				default:
+					return &QueryExprError{Pos: start, Msg: fmt.Sprintf("expected key:value, got %q", word)}
+				}
+				continue
+			}
+			i += opLen
+			value, next, err := lexExprValue(s, i)
+			if err != nil {
+				return err
+			}
+			i = next
+			term, err := newTermExpr(word, op, value, start)
+			if err != nil {
+				return err
+			}
+			p.toks = append(p.toks, exprTok{kind: tokTerm, pos: start, text: s[start:i], term: term})
+		}
+	}
+	return nil
+}
+
+func isExprKeyChar(b byte) bool {
+	return b == '_' || b == '-' || b == '.' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+func exprOpAt(s string, i int) (ExprOp, int) {
+	if i >= len(s) {
+		return "", 0
+	}
+	two := ""
+	if i+1 < len(s) {
+		two = s[i : i+2]
+	}
+	switch two {
+	case "!=":
+		return ExprOpNe, 2
+	case ">=":
+		return ExprOpGe, 2
+	case "<=":
+		return ExprOpLe, 2
+	}
+	switch s[i] {
+	case ':':
+		return ExprOpMatch, 1
+	case '=':
+		return ExprOpEq, 1
+	case '>':
+		return ExprOpGt, 1
+	case '<':
+		return ExprOpLt, 1
+	}
+	return "", 0
+}
+
+// lexExprValue reads a quoted or bare value starting at i.
+func lexExprValue(s string, i int) (string, int, error) {
+	if i < len(s) && s[i] == '"' {
+		var b strings.Builder
+		j := i + 1
+		for j < len(s) {
+			switch s[j] {
+			case '\\':
+				if j+1 < len(s) {
+					b.WriteByte(s[j+1])
+					j += 2
+					continue
+				}
+				j++
+			case '"':
+				return b.String(), j + 1, nil
+			default:
+				b.WriteByte(s[j])
+				j++
+			}
+		}
+		return "", 0, &QueryExprError{Pos: i, Msg: "unterminated quoted value"}
+	}
+	j := i
+	for j < len(s) && !unicode.IsSpace(rune(s[j])) && s[j] != '(' && s[j] != ')' && s[j] != '"' {
+		j++
+	}
+	if j == i {
+		return "", 0, &QueryExprError{Pos: i, Msg: "missing value"}
+	}
+	return s[i:j], j, nil
+}
+
+func newTermExpr(key string, op ExprOp, value string, pos int) (TermExpr, error) {
+	t := TermExpr{Op: op, Value: value, Pos: pos}
+	if canon, ok := exprKeyAliases[strings.ToLower(key)]; ok {
+		t.Key = canon
+	} else {
+		t.Key = key
+		t.Custom = true
+	}
+
+	ordering := op == ExprOpGt || op == ExprOpGe || op == ExprOpLt || op == ExprOpLe
+	switch {
+	case t.Custom:
+	case t.Key == ExprKeyUpdated:
+		if _, _, err := parseExprDate(value, time.Now()); err != nil {
+			return TermExpr{}, &QueryExprError{Pos: pos, Msg: fmt.Sprintf("%s: %v", key, err)}
+		}
+	case ordering:
+		return TermExpr{}, &QueryExprError{Pos: pos, Msg: fmt.Sprintf("operator %s is not supported for %s", op, key)}
+	case (t.Key == ExprKeyFile || t.Key == ExprKeyDir) && strings.Contains(value, "*"):
+		return TermExpr{}, &QueryExprError{Pos: pos, Msg: fmt.Sprintf("wildcards are not supported for %s", key)}
+	}
+	return t, nil
+}
+
+func (p *exprParser) peek() exprTok {
+	if p.i >= len(p.toks) {
+		return exprTok{kind: tokEOF, pos: len(p.src), text: "end of query"}
+	}
+	return p.toks[p.i]
+}
+
+func (p *exprParser) next() exprTok {
+	t := p.peek()
+	if p.i < len(p.toks) {
+		p.i++
+	}
+	return t
+}
+
+func (p *exprParser) parseOr() (DocExpr, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	terms := []DocExpr{first}
+	for p.peek().kind == tokOr {
+		p.next()
+		e, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, e)
+	}
+	if len(terms) == 1 {
+		return first, nil
+	}
+	return OrExpr{Terms: terms}, nil
+}
+
+func (p *exprParser) parseAnd() (DocExpr, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	terms := []DocExpr{first}
+	for {
+		switch p.peek().kind {
+		case tokAnd:
+			p.next()
+		case tokNot, tokLParen, tokTerm:
+			// Implicit AND between adjacent terms.
+		case tokEOF, tokRParen, tokOr:
+			if len(terms) == 1 {
+				return first, nil
+			}
+			return AndExpr{Terms: terms}, nil
+		}
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, e)
+	}
+}
+
+func (p *exprParser) parseUnary() (DocExpr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNot:
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return NotExpr{X: x}, nil
+	case tokLParen:
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c.kind != tokRParen {
+			return nil, &QueryExprError{Pos: c.pos, Msg: fmt.Sprintf("expected ')', got %q", c.text)}
+		}
+		return e, nil
+	case tokTerm:
+		return t.term, nil
+	case tokEOF, tokRParen, tokAnd, tokOr:
+		return nil, &QueryExprError{Pos: t.pos, Msg: fmt.Sprintf("expected a term, got %q", t.text)}
+	}
+	return nil, &QueryExprError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q", t.text)}
+}
+
+// parseExprDate parses an `updated` value into the [from, to) interval it
+// denotes: a whole UTC day for YYYY-MM-DD, today/yesterday and relative
+// `<n>d`/`<n>w` (days/weeks ago), one second for RFC3339 timestamps.
+func parseExprDate(s string, now time.Time) (time.Time, time.Time, error) {
+	s = strings.TrimSpace(s)
+	day := func(t time.Time) (time.Time, time.Time, error) {
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return t, t.AddDate(0, 0, 1), nil
+	}
+	now = now.UTC()
+	switch strings.ToLower(s) {
+	case "today":
+		return day(now)
+	case "yesterday":
+		return day(now.AddDate(0, 0, -1))
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return day(t)
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		t = t.UTC().Truncate(time.Second)
+		return t, t.Add(time.Second), nil
+	}
+	if n := len(s); n >= 2 {
+		if v, err := strconv.Atoi(s[:n-1]); err == nil && v >= 0 {
+			switch s[n-1] {
+			case 'd':
+				return day(now.AddDate(0, 0, -v))
+			case 'w':
+				return day(now.AddDate(0, 0, -7*v))
+			}
+		}
+	}
+	return time.Time{}, time.Time{}, errors.Errorf("invalid date %q (use YYYY-MM-DD, RFC3339, today, yesterday, <n>d or <n>w)", s)
+}
@@ -0,0 +1,184 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestParseDocExpr_Precedence(t *testing.T) {
+	e, err := ParseDocExpr(`status:active topic:api OR NOT (owner:bob AND title:"chat protocol")`)
+	if err != nil {
+		t.Fatalf("ParseDocExpr: %v", err)
+	}
+	want := OrExpr{Terms: []DocExpr{
+		AndExpr{Terms: []DocExpr{
+			TermExpr{Key: ExprKeyStatus, Op: ExprOpMatch, Value: "active", Pos: 0},
+			TermExpr{Key: ExprKeyTopic, Op: ExprOpMatch, Value: "api", Pos: 14},
+		}},
+		NotExpr{X: AndExpr{Terms: []DocExpr{
+			TermExpr{Key: ExprKeyOwner, Op: ExprOpMatch, Value: "bob", Pos: 32},
+			TermExpr{Key: ExprKeyTitle, Op: ExprOpMatch, Value: "chat protocol", Pos: 46},
+		}}},
+	}}
+	if !reflect.DeepEqual(e, want) {
+		t.Fatalf("unexpected AST:\n got %#v\nwant %#v", e, want)
+	}
+
+	if e, err := ParseDocExpr("   "); err != nil || e != nil {
+		t.Fatalf("expected nil expression for blank input, got %#v, %v", e, err)
+	}
+	if e, err := ParseDocExpr("Priority>=2"); err != nil || !reflect.DeepEqual(e, TermExpr{Key: "Priority", Custom: true, Op: ExprOpGe, Value: "2"}) {
+		t.Fatalf("expected custom field term, got %#v, %v", e, err)
+	}
+}
+
+func TestParseDocExpr_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		expr string
+		pos  int
+	}{
+		{"status:active AND", 17},
+		{"(status:active", 14},
+		{"status:active )", 14},
+		{"websocket", 0},
+		{"status>active", 0},
+		{"updated>soon", 0},
+		{`title:"open`, 6},
+		{"status:", 7},
+		{"file:*.go", 0},
+	} {
+		_, err := ParseDocExpr(tc.expr)
+		var qerr *QueryExprError
+		if !errors.As(err, &qerr) {
+			t.Fatalf("%q: expected QueryExprError, got %v", tc.expr, err)
+		}
+		if qerr.Pos != tc.pos {
+			t.Fatalf("%q: expected error at %d, got %d (%v)", tc.expr, tc.pos, qerr.Pos, qerr)
+		}
+	}
+}
+
+func TestParseExprDate(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	for _, tc := range []struct {
+		in       string
+		from, to time.Time
+	}{
+		{"2026-01-01", day(2026, 1, 1), day(2026, 1, 2)},
+		{"today", day(2026, 3, 10), day(2026, 3, 11)},
+		{"7d", day(2026, 3, 3), day(2026, 3, 4)},
+		{"2w", day(2026, 2, 24), day(2026, 2, 25)},
+		{"2026-01-01T10:00:00+02:00", time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 8, 0, 1, 0, time.UTC)},
+	} {
+		from, to, err := parseExprDate(tc.in, now)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.in, err)
+		}
+		if !from.Equal(tc.from) || !to.Equal(tc.to) {
+			t.Fatalf("%q: got [%s, %s), want [%s, %s)", tc.in, from, to, tc.from, tc.to)
+		}
+	}
+}
+
+func TestWorkspaceQueryDocs_WhereExpression(t *testing.T) {
+	ctx := context.Background()
+
+	repoRoot := t.TempDir()
+	docsRoot := filepath.Join(repoRoot, "ttmp")
+	ticketDir := filepath.Join(docsRoot, "2026", "01", "05", "QRY-1--query")
+	writeFile(t, filepath.Join(ticketDir, "design", "01-api.md"), `---
+Title: API Design
+Ticket: QRY-1
+DocType: design-doc
+Status: active
+Topics: [api, backend]
+Owners: [alice]
+Priority: 1
+RelatedFiles:
+  - Path: pkg/api/server.go
+LastUpdated: 2026-01-10T12:00:00Z
+---
+`)
+	writeFile(t, filepath.Join(ticketDir, "design", "02-storage.md"), `---
+Title: Storage Layer
+Ticket: QRY-1
+DocType: design-doc
+Status: active
+Topics: [api, storage]
+Owners: [bob]
+Priority: 3
+LastUpdated: 2026-01-20T00:00:00Z
+---
+`)
+	writeFile(t, filepath.Join(ticketDir, "reference", "01-chat-protocol.md"), `---
+Title: Chat Protocol
+Ticket: QRY-1
+DocType: reference
+Status: draft
+Topics: [chat]
+LastUpdated: 2025-12-01T00:00:00Z
+---
+`)
+
+	ws, err := NewWorkspaceFromContext(WorkspaceContext{
+		Root:      docsRoot,
+		ConfigDir: repoRoot,
+		RepoRoot:  repoRoot,
+	})
+	if err != nil {
+		t.Fatalf("NewWorkspaceFromContext: %v", err)
+	}
+	if err := ws.InitIndex(ctx, BuildIndexOptions{}); err != nil {
+		t.Fatalf("InitIndex: %v", err)
+	}
+
+	titles := func(expr string) []string {
+		t.Helper()
+		where, err := ParseDocExpr(expr)
+		if err != nil {
+			t.Fatalf("%q: ParseDocExpr: %v", expr, err)
+		}
+		res, err := ws.QueryDocs(ctx, DocQuery{
+			Scope:   Scope{Kind: ScopeRepo},
+			Filters: DocFilters{Where: where},
+		})
+		if err != nil {
+			t.Fatalf("%q: QueryDocs: %v", expr, err)
+		}
+		var out []string
+		for _, h := range res.Docs {
+			out = append(out, h.Doc.Title)
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	for _, tc := range []struct {
+		expr string
+		want []string
+	}{
+		{"status:active AND topic:api AND NOT owner:bob AND updated>2026-01-01", []string{"API Design"}},
+		{"status:ACTIVE topic:api", []string{"API Design", "Storage Layer"}},
+		{"doctype:reference OR owner:bob", []string{"Chat Protocol", "Storage Layer"}},
+		{"title:*protocol*", []string{"Chat Protocol"}},
+		{"owner:* status!=active", nil},
+		{"NOT owner:*", []string{"Chat Protocol"}},
+		{"updated:2026-01-20", []string{"Storage Layer"}},
+		{"updated<2026-01-10", []string{"Chat Protocol"}},
+		{"updated<=2026-01-10", []string{"API Design", "Chat Protocol"}},
+		{"priority>=2", []string{"Storage Layer"}},
+		{"priority:1 OR priority:3", []string{"API Design", "Storage Layer"}},
+		{"file:pkg/api/server.go", []string{"API Design"}},
+		{"dir:pkg/api", []string{"API Design"}},
+	} {
+		if got := titles(tc.expr); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%q: got %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package workspace
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/tasksmd"
+	"github.com/pkg/errors"
+)
+
+// TaskFilters selects tasks from the tasks table. Ticket-level filters
+// (Status, TopicsAny, OwnersAny) apply to the index.md next to the tasks.md.
+type TaskFilters struct {
+	Ticket string
+	// Checked selects done (true) or open (false) tasks; nil selects both.
+	Checked *bool
+	// Status matches the ticket status, ignoring case.
+	Status    string
+	TopicsAny []string
+	OwnersAny []string
+	// Section matches the enclosing heading, ignoring case.
+	Section string
+	// TextContains matches a substring of the task text, ignoring case.
+	TextContains string
+	// Assignee matches one of the task's @assignees, ignoring case.
+	Assignee string
+	// Priority matches the task's !priority.
+	Priority string
+	// IncludeArchivedPath includes tasks.md files under /archive/ directories.
+	IncludeArchivedPath bool
+}
+
+// TaskHandle is one indexed task with the ticket it belongs to.
+type TaskHandle struct {
+	Ticket       string
+	TicketTitle  string
+	TicketStatus string
+	// Path is the absolute path of the tasks.md file.
+	Path     string
+	Position int
+	StableID string
+	Section  string
+	Checked  bool
+	Text     string
+	Line     int
+	Attrs    tasksmd.Attrs
+}
+
+// DisplayID returns the stable ID when the task carries a marker, else its
+// 1-based position (same rule as 'docmgr task list').
+func (t TaskHandle) DisplayID() string {
+	if t.StableID != "" {
+		return t.StableID
+	}
+	return strconv.Itoa(t.Position)
+}
+
+// QueryTasks returns the tasks of all indexed tasks.md files matching f,
+// ordered by ticket, file, and position.
+func (w *Workspace) QueryTasks(ctx context.Context, f TaskFilters) ([]TaskHandle, error) {
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if w.db == nil {
+		return nil, errors.New("workspace index not initialized (db is nil); call InitIndex first")
+	}
+
+	where := []string{"1=1"}
+	args := []any{}
+	if !f.IncludeArchivedPath {
+		where = append(where, "d.is_archived_path = 0")
+	}
+	if ticket := strings.TrimSpace(f.Ticket); ticket != "" {
+		where = append(where, "COALESCE(idx.ticket_id, t.ticket_id) = ?")
+		args = append(args, ticket)
+	}
+	if f.Checked != nil {
+		where = append(where, "t.checked = ?")
+		args = append(args, boolToInt(*f.Checked))
+	}
+	if status := strings.ToLower(strings.TrimSpace(f.Status)); status != "" {
+		where = append(where, "lower(COALESCE(idx.status, '')) = ?")
+		args = append(args, status)
+	}
+	if section := strings.ToLower(strings.TrimSpace(f.Section)); section != "" {
+		where = append(where, "lower(t.section) = ?")
+		args = append(args, section)
+	}
+	if text := strings.ToLower(strings.TrimSpace(f.TextContains)); text != "" {
+		where = append(where, "instr(lower(t.text), ?) > 0")
+		args = append(args, text)
+	}
+	if assignee := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(f.Assignee), "@")); assignee != "" {
+		where = append(where, "instr(',' || lower(t.assignees) || ',', ?) > 0")
+		args = append(args, ","+assignee+",")
+	}
+	if priority := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(f.Priority), "!")); priority != "" {
+		where = append(where, "t.priority = ?")
+		args = append(args, priority)
+	}
+	if topics := normalizeLowerList(f.TopicsAny); len(topics) > 0 {
+		clause, cargs := existsInClause(
+			`SELECT 1 FROM doc_topics dt WHERE dt.doc_id = idx.doc_id AND `,
+			"dt.topic_lower",
+			toAny(topics),
+		)
+		where = append(where, clause)
+		args = append(args, cargs...)
+	}
+	if owners := normalizeLowerList(f.OwnersAny); len(owners) > 0 {
+		clause, cargs := existsInClause(
+			`SELECT 1 FROM doc_owners o WHERE o.doc_id = idx.doc_id AND `,
+			"o.owner_lower",
+			toAny(owners),
+		)
+		where = append(where, clause)
+		args = append(args, cargs...)
+	}
+
+	// #nosec G202 -- where clauses are fixed strings; values are bound via args.
+	sqlQ := `
+SELECT
+  COALESCE(idx.ticket_id, t.ticket_id, ''), COALESCE(idx.title, ''), COALESCE(idx.status, ''),
+  d.path, t.position, COALESCE(t.stable_id, ''), t.section, t.checked, t.text, t.line,
+  t.assignees, t.due, t.priority, t.tags
+FROM tasks t
+JOIN docs d ON d.doc_id = t.doc_id
+LEFT JOIN docs idx ON idx.path = t.index_path AND idx.parse_ok = 1
+WHERE ` + strings.Join(where, " AND ") + `
+ORDER BY 1, d.path, t.position;`
+
+	rows, err := w.db.QueryContext(ctx, sqlQ, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "query tasks")
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []TaskHandle
+	for rows.Next() {
+		var h TaskHandle
+		var checked int
+		var assignees, tags string
+		if err := rows.Scan(&h.Ticket, &h.TicketTitle, &h.TicketStatus, &h.Path, &h.Position, &h.StableID, &h.Section, &checked, &h.Text, &h.Line,
+			&assignees, &h.Attrs.Due, &h.Attrs.Priority, &tags); err != nil {
+			return nil, errors.Wrap(err, "scan tasks row")
+		}
+		h.Checked = checked == 1
+		h.Attrs.Assignees = splitTaskList(assignees)
+		h.Attrs.Tags = splitTaskList(tags)
+		out = append(out, h)
+	}
+	return out, errors.Wrap(rows.Err(), "iterate tasks rows")
+}
+
+// splitTaskList splits a comma-separated tasks column; "" yields nil.
+func splitTaskList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
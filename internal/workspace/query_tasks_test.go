@@ -0,0 +1,133 @@
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkspaceQueryTasks(t *testing.T) {
+	ctx := context.Background()
+
+	repoRoot := t.TempDir()
+	docsRoot := filepath.Join(repoRoot, "ttmp")
+	apiDir := filepath.Join(docsRoot, "2026", "09", "01", "API-1--rest")
+	chatDir := filepath.Join(docsRoot, "2026", "09", "02", "CHAT-1--emoji")
+
+	writeFile(t, filepath.Join(apiDir, "index.md"), `---
+Title: REST API
+Ticket: API-1
+Status: active
+Topics: [api]
+DocType: index
+Owners: [alice]
+LastUpdated: 2026-09-01T00:00:00Z
+---
+
+# REST API
+`)
+	// No frontmatter, like the tasks.md 'docmgr ticket create' writes.
+	writeFile(t, filepath.Join(apiDir, "tasks.md"), `# Tasks
+
+## TODO
+
+- [ ] Design routes <!-- t:ab12 -->
+- [x] Pick a router
+
+## Later
+
+- [ ] Rate limiting @bob due:2026-12-01 !low #perf <!-- t:cd34 -->
+`)
+	writeFile(t, filepath.Join(chatDir, "index.md"), `---
+Title: Emoji
+Ticket: CHAT-1
+Status: review
+Topics: [chat]
+DocType: index
+Owners: [bob]
+LastUpdated: 2026-09-02T00:00:00Z
+---
+
+# Emoji
+`)
+	writeFile(t, filepath.Join(chatDir, "tasks.md"), "# Tasks\n\n## TODO\n\n- [ ] Emoji picker <!-- t:ef56 -->\n")
+
+	ws, err := NewWorkspaceFromContext(WorkspaceContext{Root: docsRoot, ConfigDir: repoRoot, RepoRoot: repoRoot})
+	if err != nil {
+		t.Fatalf("NewWorkspaceFromContext: %v", err)
+	}
+	if err := ws.InitIndex(ctx, BuildIndexOptions{}); err != nil {
+		t.Fatalf("InitIndex: %v", err)
+	}
+
+	all, err := ws.QueryTasks(ctx, TaskFilters{})
+	if err != nil {
+		t.Fatalf("QueryTasks: %v", err)
+	}
+	if len(all) != 4 {
+		t.Fatalf("expected 4 tasks, got %d: %+v", len(all), all)
+	}
+	first := all[0]
+	if first.Ticket != "API-1" || first.TicketTitle != "REST API" || first.TicketStatus != "active" ||
+		first.StableID != "ab12" || first.Section != "TODO" || first.Checked || first.Text != "Design routes" || first.Line != 5 {
+		t.Fatalf("unexpected first task: %+v", first)
+	}
+	if all[1].DisplayID() != "2" || !all[1].Checked || all[2].Section != "Later" {
+		t.Fatalf("unexpected tasks: %+v", all[1:3])
+	}
+	if a := all[2].Attrs; all[2].Text != "Rate limiting" || len(a.Assignees) != 1 || a.Assignees[0] != "bob" ||
+		a.Due != "2026-12-01" || a.Priority != "low" || len(a.Tags) != 1 || a.Tags[0] != "perf" {
+		t.Fatalf("unexpected task attributes: %+v", all[2])
+	}
+
+	open := false
+	cases := []struct {
+		name string
+		f    TaskFilters
+		want []string
+	}{
+		{"unchecked", TaskFilters{Checked: &open}, []string{"ab12", "cd34", "ef56"}},
+		{"topics", TaskFilters{Checked: &open, TopicsAny: []string{"API"}}, []string{"ab12", "cd34"}},
+		{"owners", TaskFilters{OwnersAny: []string{"bob"}}, []string{"ef56"}},
+		{"status", TaskFilters{Status: "Review"}, []string{"ef56"}},
+		{"ticket+section", TaskFilters{Ticket: "API-1", Section: "later"}, []string{"cd34"}},
+		{"text", TaskFilters{TextContains: "PICKER"}, []string{"ef56"}},
+		{"assignee", TaskFilters{Assignee: "@Bob"}, []string{"cd34"}},
+		{"priority", TaskFilters{Priority: "low"}, []string{"cd34"}},
+	}
+	for _, tc := range cases {
+		got, err := ws.QueryTasks(ctx, tc.f)
+		if err != nil {
+			t.Fatalf("%s: QueryTasks: %v", tc.name, err)
+		}
+		var ids []string
+		for _, h := range got {
+			ids = append(ids, h.DisplayID())
+		}
+		if len(ids) != len(tc.want) {
+			t.Fatalf("%s: got %v, want %v", tc.name, ids, tc.want)
+		}
+		for i := range ids {
+			if ids[i] != tc.want[i] {
+				t.Fatalf("%s: got %v, want %v", tc.name, ids, tc.want)
+			}
+		}
+	}
+
+	// Per-file updates replace the task rows.
+	tasksPath := filepath.Join(chatDir, "tasks.md")
+	if err := os.WriteFile(tasksPath, []byte("# Tasks\n\n## TODO\n\n- [x] Emoji picker <!-- t:ef56 -->\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := ws.UpsertDocument(ctx, tasksPath); err != nil {
+		t.Fatalf("UpsertDocument: %v", err)
+	}
+	got, err := ws.QueryTasks(ctx, TaskFilters{Ticket: "CHAT-1"})
+	if err != nil {
+		t.Fatalf("QueryTasks after upsert: %v", err)
+	}
+	if len(got) != 1 || !got[0].Checked {
+		t.Fatalf("expected the upserted task to be checked, got %+v", got)
+	}
+}
@@ -22,10 +22,14 @@ type PathTags struct {
 //
 // Spec: §6.1 (directories).
 // - Always skip `.meta/` entirely.
+// - Always skip `.docmgr/` (docmgr's own state: index cache, ...).
 // - Always skip underscore dirs (`_*/`) entirely (templates, guidelines, etc.).
 func DefaultIngestSkipDir(_ string, d fs.DirEntry) bool {
-	name := d.Name()
-	if name == ".meta" {
+	return skipIngestDirName(d.Name())
+}
+
+func skipIngestDirName(name string) bool {
+	if name == ".meta" || name == StateDirName {
 		return true
 	}
 	if name != "." && strings.HasPrefix(name, "_") {
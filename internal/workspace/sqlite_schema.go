@@ -13,6 +13,12 @@ import (
 
 var workspaceSQLiteCounter uint64
 
+// workspaceSchemaVersion stamps the on-disk index cache (see index_cache.go).
+//
+// Bump it whenever the DDL below or the way documents are ingested changes, so
+// existing caches are discarded and rebuilt instead of feeding stale rows.
+const workspaceSchemaVersion = 5
+
 // openInMemorySQLite opens an in-memory SQLite database connection.
 //
 // Note: This uses the sqlite3 driver. The DSN uses a shared cache so multiple
@@ -129,6 +135,82 @@ CREATE TABLE IF NOT EXISTS doc_owners (
 `,
 		`CREATE INDEX IF NOT EXISTS idx_doc_owners_owner ON doc_owners(owner_lower);`,
 
+		// doc_fields: custom frontmatter fields (models.Document.Extra), one row per
+		// scalar value or list element.
+		`
+CREATE TABLE IF NOT EXISTS doc_fields (
+    doc_id INTEGER NOT NULL,
+    field_lower TEXT NOT NULL,              -- lowercase field name for case-insensitive matching
+    field_original TEXT NOT NULL,           -- field name as written in frontmatter
+    value_lower TEXT NOT NULL,              -- lowercase value for case-insensitive matching
+    value_original TEXT NOT NULL,           -- value rendered as text (dates as YYYY-MM-DD/RFC3339)
+    is_list INTEGER NOT NULL DEFAULT 0,     -- 1 if the frontmatter value is a list
+    position INTEGER NOT NULL DEFAULT 0,    -- list position (0 for scalars)
+    FOREIGN KEY (doc_id) REFERENCES docs(doc_id) ON DELETE CASCADE
+);
+`,
+		`CREATE INDEX IF NOT EXISTS idx_doc_fields_doc_id ON doc_fields(doc_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_doc_fields_field_value ON doc_fields(field_lower, value_lower);`,
+
+		// ticket_links: ticket-to-ticket relations (DependsOn/Blocks/Supersedes/RelatesTo),
+		// one row per target.
+		`
+CREATE TABLE IF NOT EXISTS ticket_links (
+    doc_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,                     -- models.TicketLinkKind (e.g. DependsOn)
+    target TEXT NOT NULL,                   -- target ticket ID as written in frontmatter
+    target_lower TEXT NOT NULL,             -- lowercase target for case-insensitive matching
+    position INTEGER NOT NULL DEFAULT 0,    -- position within the kind's list
+    FOREIGN KEY (doc_id) REFERENCES docs(doc_id) ON DELETE CASCADE
+);
+`,
+		`CREATE INDEX IF NOT EXISTS idx_ticket_links_doc_id ON ticket_links(doc_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_ticket_links_target ON ticket_links(target_lower);`,
+
+		// external_sources: one row per ExternalSources entry (models.ExternalSource).
+		`
+CREATE TABLE IF NOT EXISTS external_sources (
+    es_id INTEGER PRIMARY KEY,
+    doc_id INTEGER NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,    -- position within ExternalSources
+    type TEXT,                              -- local/html/pdf/url/... (legacy "type:" prefix)
+    path TEXT,                              -- origin path
+    repo TEXT,
+    url TEXT,
+    local_copy TEXT,                        -- ticket-relative copy under sources/
+    sha TEXT,                               -- sha256 of the source bytes at last fetch
+    last_fetched TEXT,                      -- RFC3339, empty if unknown
+    display TEXT NOT NULL,                  -- legacy string form (ExternalSource.String)
+    match_lower TEXT NOT NULL,              -- lowercase concatenation of all fields (substring matching)
+    FOREIGN KEY (doc_id) REFERENCES docs(doc_id) ON DELETE CASCADE
+);
+`,
+		`CREATE INDEX IF NOT EXISTS idx_external_sources_doc_id ON external_sources(doc_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_external_sources_sha ON external_sources(sha);`,
+
+		// tasks: one row per checkbox task of a ticket's tasks.md (internal/tasksmd).
+		`
+CREATE TABLE IF NOT EXISTS tasks (
+    task_id INTEGER PRIMARY KEY,
+    doc_id INTEGER NOT NULL,                -- the tasks.md docs row
+    ticket_id TEXT,                         -- frontmatter Ticket, else inferred from the ticket directory
+    index_path TEXT NOT NULL,               -- absolute path of the sibling index.md (joins the ticket)
+    position INTEGER NOT NULL,              -- 1-based position in the file (legacy task ID)
+    stable_id TEXT,                         -- "<!-- t:xxxx -->" marker ID, NULL if unmarked
+    section TEXT NOT NULL,                  -- enclosing heading (e.g. TODO, Done)
+    checked INTEGER NOT NULL DEFAULT 0,
+    text TEXT NOT NULL,                     -- task text without the marker and inline attributes
+    line INTEGER NOT NULL,                  -- 1-based line number
+    assignees TEXT NOT NULL DEFAULT '',     -- @assignee tokens, comma-separated without "@"
+    due TEXT NOT NULL DEFAULT '',           -- due:YYYY-MM-DD date
+    priority TEXT NOT NULL DEFAULT '',      -- !priority (high, medium, low)
+    tags TEXT NOT NULL DEFAULT '',          -- #tag tokens, comma-separated without "#"
+    FOREIGN KEY (doc_id) REFERENCES docs(doc_id) ON DELETE CASCADE
+);
+`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_doc_id ON tasks(doc_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_tasks_index_path ON tasks(index_path);`,
+
 		// related_files: one row per RelatedFiles entry.
 		//
 		// Paths v2 (design doc DOCMGR-200 §8.1): one resolver produces one
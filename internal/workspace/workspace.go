@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"path/filepath"
+	"strings"
 
 	docignore "github.com/go-go-golems/docmgr/internal/ignore"
 	"github.com/go-go-golems/docmgr/internal/paths"
@@ -24,6 +25,11 @@ type Workspace struct {
 	// ftsAvailable indicates whether this workspace index instance has an FTS table.
 	// It is set during InitIndex after best-effort FTS table creation.
 	ftsAvailable bool
+	indexStats   IndexStats
+	// indexOpts are the options of the last InitIndex, reused by per-file updates.
+	indexOpts BuildIndexOptions
+	// revision is the default git revision InitIndex reads from ("" = working tree).
+	revision string
 }
 
 // WorkspaceContext captures the resolved "environment" for a workspace instance.
@@ -44,6 +50,10 @@ type DiscoverOptions struct {
 	// RootOverride overrides the default docs root (typically "ttmp").
 	// If empty, discovery uses the default resolution chain (config/git/cwd).
 	RootOverride string
+
+	// Revision makes InitIndex read the docs root from this git revision
+	// instead of the working tree (see BuildIndexOptions.Revision).
+	Revision string
 }
 
 // DiscoverWorkspace resolves a best-effort WorkspaceContext from process state and options.
@@ -87,13 +97,18 @@ func DiscoverWorkspace(ctx context.Context, opts DiscoverOptions) (*Workspace, e
 		return nil, errors.Wrap(err, "failed to resolve repository root")
 	}
 
-	return NewWorkspaceFromContext(WorkspaceContext{
+	ws, err := NewWorkspaceFromContext(WorkspaceContext{
 		Root:          root,
 		ConfigDir:     configDir,
 		RepoRoot:      repoRoot,
 		WorkspaceRoot: FindWorkspaceRoot(repoRoot),
 		Config:        cfg,
 	})
+	if err != nil {
+		return nil, err
+	}
+	ws.revision = strings.TrimSpace(opts.Revision)
+	return ws, nil
 }
 
 // NewWorkspaceFromContext constructs a Workspace from an explicit context.
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/hooks"
 	"github.com/go-go-golems/docmgr/internal/templates"
 	"github.com/go-go-golems/docmgr/internal/tickets"
 	"github.com/go-go-golems/docmgr/internal/workspace"
@@ -40,6 +41,7 @@ type AddSettings struct {
 	ExternalSources []string `glazed:"external-sources"`
 	Summary         string   `glazed:"summary"`
 	RelatedFiles    []string `glazed:"related-files"`
+	Vars            []string `glazed:"var"`
 }
 
 type AddResult struct {
@@ -77,6 +79,13 @@ Examples:
   docmgr doc add --ticket MEN-3475 --doc-type reference --title "Trace Links" \
     --external-sources "https://example.com/spec,https://github.com/org/repo/issues/123" \
     --related-files "pkg/commands/add.go,pkg/commands/relate.go"
+
+  # Pass values to the doc-type template ({{ .Vars.component }} in _templates/design-doc.md)
+  docmgr doc add --ticket MEN-3475 --doc-type design-doc --title "Cache Layer" \
+    --var component=cache --var reviewer=alice
+
+Templates in <root>/_templates/<doc-type>.md are Go text/templates; see
+'docmgr help templates-and-guidelines' for the data model and helpers.
 `),
 			cmds.WithFlags(
 				fields.New(
@@ -145,6 +154,12 @@ Examples:
 					fields.WithHelp("Comma-separated list of related files to seed frontmatter"),
 					fields.WithDefault([]string{}),
 				),
+				fields.New(
+					"var",
+					fields.TypeStringList,
+					fields.WithHelp("Repeatable template variable (format: key=value), available as {{ .Vars.key }}"),
+					fields.WithDefault([]string{}),
+				),
 			),
 		),
 	}, nil
@@ -180,6 +195,10 @@ func (c *AddCommand) createDocument(ctx context.Context, settings *AddSettings)
 	if ctx == nil {
 		return nil, fmt.Errorf("nil context")
 	}
+	vars, err := parseTemplateVars(settings.Vars)
+	if err != nil {
+		return nil, err
+	}
 	settings.Root = workspace.ResolveRoot(settings.Root)
 	cfgPath, _ := workspace.FindTTMPConfigPath()
 	vocabPath, _ := workspace.ResolveVocabularyPath()
@@ -264,12 +283,12 @@ func (c *AddCommand) createDocument(ctx context.Context, settings *AddSettings)
 		intent = settings.Intent
 	}
 
-	external := []string{}
+	external := models.ExternalSources{}
 	if len(settings.ExternalSources) > 0 {
 		for _, s := range settings.ExternalSources {
 			s = strings.TrimSpace(s)
 			if s != "" {
-				external = append(external, s)
+				external = append(external, models.ParseExternalSource(s))
 			}
 		}
 	}
@@ -298,17 +317,16 @@ func (c *AddCommand) createDocument(ctx context.Context, settings *AddSettings)
 		LastUpdated:     time.Now(),
 	}
 
-	content := ""
-	if tpl, ok := templates.LoadTemplate(settings.Root, settings.DocType); ok {
-		_, body := templates.ExtractFrontmatterAndBody(tpl)
-		doc.Title = settings.Title
-		content = templates.RenderTemplateBody(body, &doc)
-	}
 	// If no template found, content remains empty - document will have only frontmatter
+	content, _ := renderDocTemplate(ctx, settings.Root, settings.DocType, &doc, templates.TicketDataFromDocument(ticketDoc, ticketDir), vars)
 
 	if err := documents.WriteDocumentWithFrontmatter(docPath, &doc, content, false); err != nil {
 		return nil, fmt.Errorf("failed to write document: %w", err)
 	}
+	fireHook(ctx, hooks.EventDocAdded, canonicalTicketID, docPath, map[string]any{
+		"docType": settings.DocType,
+		"title":   settings.Title,
+	})
 
 	guidelineText := ""
 	if guideline, ok := templates.LoadGuideline(settings.Root, settings.DocType); ok {
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/blueprints"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/glazed/pkg/cmds"
+	"github.com/go-go-golems/glazed/pkg/cmds/fields"
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+	"github.com/go-go-golems/glazed/pkg/middlewares"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+// BlueprintListCommand lists the ticket blueprints usable with 'ticket create --blueprint'.
+type BlueprintListCommand struct {
+	*cmds.CommandDescription
+}
+
+// BlueprintListSettings holds the parameters for the blueprint list command
+type BlueprintListSettings struct {
+	Root string `glazed:"root"`
+}
+
+func NewBlueprintListCommand() (*BlueprintListCommand, error) {
+	return &BlueprintListCommand{
+		CommandDescription: cmds.NewCommandDescription(
+			"list",
+			cmds.WithShort("List ticket blueprints"),
+			cmds.WithLong(`Lists the blueprints usable with 'docmgr ticket create --blueprint NAME'.
+
+Blueprints are read from '<root>/_blueprints/<name>.yaml'; the built-in
+bugfix, feature, and spike blueprints are listed unless the root overrides them.
+
+Columns:
+  name,description,docs,topics,tasks,source
+
+Examples:
+  docmgr blueprint list
+  docmgr blueprint list --with-glaze-output --output json
+`),
+			cmds.WithFlags(
+				fields.New(
+					"root",
+					fields.TypeString,
+					fields.WithHelp("Root directory for docs"),
+					fields.WithDefault("ttmp"),
+				),
+			),
+		),
+	}, nil
+}
+
+func (c *BlueprintListCommand) RunIntoGlazeProcessor(ctx context.Context, parsedValues *values.Values, gp middlewares.Processor) error {
+	settings := &BlueprintListSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+	all, err := blueprints.List(workspace.ResolveRoot(settings.Root))
+	if err != nil {
+		return err
+	}
+	for _, b := range all {
+		if err := gp.AddRow(ctx, types.NewRow(
+			types.MRP("name", b.Name),
+			types.MRP("description", b.Description),
+			types.MRP("docs", blueprintDocTypes(b)),
+			types.MRP("topics", b.Topics),
+			types.MRP("tasks", len(b.Tasks)),
+			types.MRP("source", b.Source),
+		)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *BlueprintListCommand) Run(ctx context.Context, parsedValues *values.Values) error {
+	settings := &BlueprintListSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+	all, err := blueprints.List(workspace.ResolveRoot(settings.Root))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("## Blueprints (%d)\n\n", len(all))
+	for _, b := range all {
+		fmt.Printf("- **%s** — %s\n", b.Name, b.Description)
+		details := []string{fmt.Sprintf("docs: %s", strings.Join(blueprintDocTypes(b), ", "))}
+		if len(b.Topics) > 0 {
+			details = append(details, fmt.Sprintf("topics: %s", strings.Join(b.Topics, ", ")))
+		}
+		details = append(details, fmt.Sprintf("tasks: %d", len(b.Tasks)), fmt.Sprintf("source: %s", b.Source))
+		fmt.Printf("  %s\n", strings.Join(details, "; "))
+	}
+	return nil
+}
+
+var _ cmds.GlazeCommand = &BlueprintListCommand{}
+var _ cmds.BareCommand = &BlueprintListCommand{}
+
+func blueprintDocTypes(b blueprints.Blueprint) []string {
+	out := make([]string, 0, len(b.Docs))
+	for _, d := range b.Docs {
+		out = append(out, d.DocType)
+	}
+	return out
+}
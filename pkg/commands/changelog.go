@@ -25,6 +25,8 @@ type ChangelogUpdateSettings struct {
 	ChangelogFile    string   `glazed:"changelog-file"`
 	Title            string   `glazed:"title"`
 	Entry            string   `glazed:"entry"`
+	Kind             string   `glazed:"kind"`
+	Tasks            []string `glazed:"task"`
 	FileNotes        []string `glazed:"file-note"`
 	Suggest          bool     `glazed:"suggest"`
 	ApplySuggestions bool     `glazed:"apply-suggestions"`
@@ -36,12 +38,20 @@ func NewChangelogUpdateCommand() (*ChangelogUpdateCommand, error) {
 	cmd := cmds.NewCommandDescription(
 		"update",
 		cmds.WithShort("Append an entry to changelog.md for a ticket"),
-		cmds.WithLong(`Append a dated changelog entry with optional title, message, and related files.
+		cmds.WithLong(`Append a dated changelog entry with optional title, message, kind, linked tasks, and related files.
+
+--kind classifies the entry (added, changed, fixed, removed, decision) for
+'docmgr changelog rollup'. --task links tasks of the ticket's tasks.md by
+stable ID (or 1-based position).
 
 Examples:
   # Append an entry with a message
   docmgr changelog update --ticket MEN-4242 --entry "Normalized chat API paths"
 
+  # Record a decision linked to tasks
+  docmgr changelog update --ticket MEN-4242 --kind decision --title "Keep REST paths" \
+    --entry "WebSocket stays on /ws; REST moves under /api/v1" --task ab12 --task cd34
+
   # Include related files with notes
   docmgr changelog update --ticket MEN-4242 \
     --file-note "backend/chat/api/register.go:Source of path normalization" \
@@ -59,6 +69,8 @@ Examples:
 			fields.New("changelog-file", fields.TypeString, fields.WithHelp("Path to changelog.md (overrides --ticket)"), fields.WithDefault("")),
 			fields.New("title", fields.TypeString, fields.WithHelp("Optional entry title"), fields.WithDefault("")),
 			fields.New("entry", fields.TypeString, fields.WithHelp("Entry text to append"), fields.WithDefault("")),
+			fields.New("kind", fields.TypeString, fields.WithHelp("Entry kind: added, changed, fixed, removed, or decision"), fields.WithDefault("")),
+			fields.New("task", fields.TypeStringList, fields.WithHelp("Repeatable task reference (stable ID or 1-based position) to link"), fields.WithDefault([]string{})),
 			fields.New("file-note", fields.TypeStringList, fields.WithHelp("Repeatable path-to-note mapping (path:note or path=note)"), fields.WithDefault([]string{})),
 			fields.New("suggest", fields.TypeBool, fields.WithHelp("Suggest related files using heuristics (git + ripgrep + existing docs)"), fields.WithDefault(false)),
 			fields.New("apply-suggestions", fields.TypeBool, fields.WithHelp("Apply suggested files to this changelog entry"), fields.WithDefault(false)),
@@ -295,7 +307,11 @@ func (c *ChangelogUpdateCommand) RunIntoGlazeProcessor(
 	}
 
 	// Compose and append the entry (shared primitive with the HTTP API).
-	today, err := AppendChangelogEntry(changelogPath, s.Title, s.Entry, final)
+	entry, err := newChangelogEntryFromSettings(ctx, s, changelogPath, final)
+	if err != nil {
+		return err
+	}
+	today, err := AppendChangelog(changelogPath, entry)
 	if err != nil {
 		return err
 	}
@@ -304,6 +320,8 @@ func (c *ChangelogUpdateCommand) RunIntoGlazeProcessor(
 		types.MRP("file", changelogPath),
 		types.MRP("status", "updated"),
 		types.MRP("date", today),
+		types.MRP("kind", string(entry.Kind)),
+		types.MRP("tasks", changelogTaskIDs(entry.Tasks)),
 		types.MRP("files_count", len(final)),
 	)
 	return gp.AddRow(ctx, row)
@@ -418,7 +436,11 @@ func (c *ChangelogUpdateCommand) Run(
 	}
 
 	// Compose and append the entry (shared primitive with the HTTP API).
-	if _, err := AppendChangelogEntry(changelogPath, s.Title, s.Entry, final); err != nil {
+	entry, err := newChangelogEntryFromSettings(ctx, s, changelogPath, final)
+	if err != nil {
+		return err
+	}
+	if _, err := AppendChangelog(changelogPath, entry); err != nil {
 		return err
 	}
 
@@ -428,3 +450,40 @@ func (c *ChangelogUpdateCommand) Run(
 }
 
 var _ cmds.BareCommand = &ChangelogUpdateCommand{}
+
+// newChangelogEntryFromSettings validates --kind and resolves --task
+// references against the tasks.md next to changelogPath.
+func newChangelogEntryFromSettings(ctx context.Context, s *ChangelogUpdateSettings, changelogPath string, files map[string]string) (NewChangelogEntry, error) {
+	kind, err := ParseChangelogKind(s.Kind)
+	if err != nil {
+		return NewChangelogEntry{}, err
+	}
+	entry := NewChangelogEntry{Ticket: ticketForPath(s.Root, changelogPath), Title: s.Title, Entry: s.Entry, Kind: kind, Files: files}
+	if len(s.Tasks) == 0 {
+		return entry, nil
+	}
+	tasksPath := filepath.Join(filepath.Dir(changelogPath), "tasks.md")
+	_, _, tasks, err := loadTasksFile(ctx, "", "", tasksPath)
+	if err != nil {
+		return NewChangelogEntry{}, err
+	}
+	linked, err := resolveTaskRefs(tasks, s.Tasks)
+	if err != nil {
+		return NewChangelogEntry{}, err
+	}
+	for _, t := range linked {
+		if t.StableID == "" {
+			return NewChangelogEntry{}, fmt.Errorf("task %d has no stable id; run 'docmgr task migrate' first", t.TaskIndex)
+		}
+		entry.Tasks = append(entry.Tasks, ChangelogTaskRef{ID: t.StableID, Text: t.Text})
+	}
+	return entry, nil
+}
+
+func changelogTaskIDs(refs []ChangelogTaskRef) []string {
+	ids := make([]string, 0, len(refs))
+	for _, r := range refs {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
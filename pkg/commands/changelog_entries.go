@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,19 +9,102 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/go-go-golems/docmgr/internal/hooks"
+)
+
+// ChangelogKind classifies a changelog entry ("## YYYY-MM-DD [kind] - Title").
+type ChangelogKind string
+
+const (
+	ChangelogKindAdded    ChangelogKind = "added"
+	ChangelogKindChanged  ChangelogKind = "changed"
+	ChangelogKindFixed    ChangelogKind = "fixed"
+	ChangelogKindRemoved  ChangelogKind = "removed"
+	ChangelogKindDecision ChangelogKind = "decision"
 )
 
+// ChangelogKinds lists the entry kinds in report order.
+var ChangelogKinds = []ChangelogKind{
+	ChangelogKindAdded,
+	ChangelogKindChanged,
+	ChangelogKindFixed,
+	ChangelogKindRemoved,
+	ChangelogKindDecision,
+}
+
+// ParseChangelogKind validates a kind name; "" means an untyped entry.
+func ParseChangelogKind(s string) (ChangelogKind, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return "", nil
+	}
+	for _, k := range ChangelogKinds {
+		if string(k) == s {
+			return k, nil
+		}
+	}
+	names := make([]string, 0, len(ChangelogKinds))
+	for _, k := range ChangelogKinds {
+		names = append(names, string(k))
+	}
+	return "", fmt.Errorf("unknown changelog kind %q (expected one of: %s)", s, strings.Join(names, ", "))
+}
+
+// ChangelogTaskRef links an entry to a task in the ticket's tasks.md by
+// stable ID.
+type ChangelogTaskRef struct {
+	ID   string `json:"id"`
+	Text string `json:"text,omitempty"`
+}
+
+// ChangelogFileRef is one line of an entry's "### Related Files" section.
+type ChangelogFileRef struct {
+	Path string `json:"path"`
+	Note string `json:"note,omitempty"`
+}
+
 // ChangelogEntry is one dated section of a ticket's changelog.md
-// ("## YYYY-MM-DD[ - Title]" followed by free-form markdown).
+// ("## YYYY-MM-DD[ [kind]][ - Title]" followed by free-form markdown, with
+// optional "### Related Tasks" and "### Related Files" sections).
 type ChangelogEntry struct {
-	Date    string `json:"date"`
-	Title   string `json:"title"`
-	Heading string `json:"heading"`
-	Body    string `json:"body"`
+	Date    string             `json:"date"`
+	Kind    ChangelogKind      `json:"kind,omitempty"`
+	Title   string             `json:"title"`
+	Heading string             `json:"heading"`
+	Body    string             `json:"body"`
+	Tasks   []ChangelogTaskRef `json:"tasks,omitempty"`
+	Files   []ChangelogFileRef `json:"files,omitempty"`
+}
+
+// Text returns the entry body without the Related Tasks/Files sections.
+func (e ChangelogEntry) Text() string {
+	lines := strings.Split(e.Body, "\n")
+	for i, l := range lines {
+		if changelogSectionRe.MatchString(l) {
+			return strings.TrimSpace(strings.Join(lines[:i], "\n"))
+		}
+	}
+	return strings.TrimSpace(e.Body)
+}
+
+// NewChangelogEntry is an entry to append with AppendChangelog.
+type NewChangelogEntry struct {
+	// Ticket is reported to hooks (default: the ticket owning changelogPath);
+	// it is not written to changelog.md.
+	Ticket string
+	Title  string
+	Entry  string
+	Kind   ChangelogKind
+	Tasks  []ChangelogTaskRef
+	// Files maps related file path -> note.
+	Files map[string]string
 }
 
 var changelogHeadingRe = regexp.MustCompile(`^##\s+(.*)$`)
-var changelogDateRe = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\s*(?:[-–—]\s*)?(.*)$`)
+var changelogDateRe = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\s*(?:\[([A-Za-z]+)\]\s*)?(?:[-–—]\s*)?(.*)$`)
+var changelogSectionRe = regexp.MustCompile(`^###\s+Related\s+(Tasks|Files)\s*$`)
+var changelogTaskLineRe = regexp.MustCompile("^[-*]\\s+`?t:([A-Za-z0-9]+)`?(?:\\s+[-–—]\\s+(.*))?$")
 
 // ParseChangelogEntries splits changelog.md content into its "## " sections,
 // in file order (appends put the newest entry last). Content before the first
@@ -36,6 +120,7 @@ func ParseChangelogEntries(content string) []ChangelogEntry {
 			return
 		}
 		cur.Body = strings.Trim(strings.Join(body, "\n"), "\n")
+		cur.Tasks, cur.Files = parseChangelogRefs(body)
 		entries = append(entries, *cur)
 		cur = nil
 		body = nil
@@ -48,7 +133,14 @@ func ParseChangelogEntries(content string) []ChangelogEntry {
 			e := ChangelogEntry{Heading: heading}
 			if dm := changelogDateRe.FindStringSubmatch(heading); dm != nil {
 				e.Date = dm[1]
-				e.Title = strings.TrimSpace(dm[2])
+				if kind, err := ParseChangelogKind(dm[2]); err == nil {
+					e.Kind = kind
+					e.Title = strings.TrimSpace(dm[3])
+				} else {
+					// Not a known kind: keep "[...]" as part of the title.
+					e.Title = strings.TrimSpace(strings.TrimPrefix(heading, dm[1]))
+					e.Title = strings.TrimSpace(strings.TrimLeft(e.Title, "-–—"))
+				}
 			} else {
 				e.Title = heading
 			}
@@ -63,27 +155,85 @@ func ParseChangelogEntries(content string) []ChangelogEntry {
 	return entries
 }
 
+// parseChangelogRefs reads the "### Related Tasks" and "### Related Files"
+// sections of an entry body.
+func parseChangelogRefs(body []string) ([]ChangelogTaskRef, []ChangelogFileRef) {
+	var tasks []ChangelogTaskRef
+	var files []ChangelogFileRef
+	section := ""
+	for _, line := range body {
+		if m := changelogSectionRe.FindStringSubmatch(line); m != nil {
+			section = m[1]
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			section = ""
+			continue
+		}
+		item := strings.TrimSpace(line)
+		if !strings.HasPrefix(item, "- ") && !strings.HasPrefix(item, "* ") {
+			continue
+		}
+		switch section {
+		case "Tasks":
+			if m := changelogTaskLineRe.FindStringSubmatch(item); m != nil {
+				tasks = append(tasks, ChangelogTaskRef{ID: m[1], Text: strings.TrimSpace(m[2])})
+			}
+		case "Files":
+			path, note, _ := strings.Cut(strings.TrimSpace(item[2:]), " — ")
+			files = append(files, ChangelogFileRef{Path: strings.TrimSpace(path), Note: strings.TrimSpace(note)})
+		}
+	}
+	return tasks, files
+}
+
 // AppendChangelogEntry appends a dated entry (with optional title and
-// optional related-files map path->note) to changelog.md, creating the file
-// with a "# Changelog" header when missing. It returns the entry date
-// (YYYY-MM-DD). This is the shared write primitive behind
-// 'docmgr changelog update' and the HTTP API's POST /tickets/changelog.
+// optional related-files map path->note) to changelog.md; see AppendChangelog.
 func AppendChangelogEntry(changelogPath string, title string, entry string, files map[string]string) (string, error) {
+	return AppendChangelog(changelogPath, NewChangelogEntry{Title: title, Entry: entry, Files: files})
+}
+
+// AppendChangelog appends a dated entry to changelog.md, creating the file
+// with a "# Changelog" header when missing. The kind goes into the heading
+// ("## YYYY-MM-DD [decision] - Title"); tasks and files are written as
+// "### Related Tasks" / "### Related Files" sections. It returns the entry
+// date (YYYY-MM-DD) and runs the changelog.appended hooks.
+func AppendChangelog(changelogPath string, e NewChangelogEntry) (string, error) {
+	date, payload, err := WriteChangelogEntry(changelogPath, e)
+	if err != nil {
+		return "", err
+	}
+	fireHooks(context.Background(), payload)
+	return date, nil
+}
+
+// WriteChangelogEntry appends e like AppendChangelog and returns the
+// changelog.appended hook payload without running it. This is the shared
+// write primitive behind 'docmgr changelog update' and the HTTP API's
+// POST /tickets/changelog.
+func WriteChangelogEntry(changelogPath string, e NewChangelogEntry) (string, hooks.Payload, error) {
+	title, entry, files := e.Title, e.Entry, e.Files
 	if strings.TrimSpace(entry) == "" {
-		return "", fmt.Errorf("entry must not be empty")
+		return "", hooks.Payload{}, fmt.Errorf("entry must not be empty")
+	}
+	if _, err := ParseChangelogKind(string(e.Kind)); err != nil {
+		return "", hooks.Payload{}, err
 	}
 
 	if _, err := os.Stat(changelogPath); os.IsNotExist(err) {
 		if err := os.MkdirAll(filepath.Dir(changelogPath), 0o755); err != nil {
-			return "", fmt.Errorf("failed to create changelog directory: %w", err)
+			return "", hooks.Payload{}, fmt.Errorf("failed to create changelog directory: %w", err)
 		}
 		if err := os.WriteFile(changelogPath, []byte("# Changelog\n\n"), 0o644); err != nil {
-			return "", fmt.Errorf("failed to create changelog.md: %w", err)
+			return "", hooks.Payload{}, fmt.Errorf("failed to create changelog.md: %w", err)
 		}
 	}
 
 	today := time.Now().Format("2006-01-02")
 	heading := "## " + today
+	if e.Kind != "" {
+		heading += " [" + string(e.Kind) + "]"
+	}
 	if strings.TrimSpace(title) != "" {
 		heading += " - " + title
 	}
@@ -94,6 +244,17 @@ func AppendChangelogEntry(changelogPath string, title string, entry string, file
 	sb.WriteString("\n\n")
 	sb.WriteString(entry)
 	sb.WriteString("\n\n")
+	if len(e.Tasks) > 0 {
+		sb.WriteString("### Related Tasks\n\n")
+		for _, t := range e.Tasks {
+			if text := strings.TrimSpace(t.Text); text != "" {
+				sb.WriteString("- `t:" + t.ID + "` — " + text + "\n")
+			} else {
+				sb.WriteString("- `t:" + t.ID + "`\n")
+			}
+		}
+		sb.WriteString("\n")
+	}
 	if len(files) > 0 {
 		sb.WriteString("### Related Files\n\n")
 		var names []string
@@ -114,11 +275,22 @@ func AppendChangelogEntry(changelogPath string, title string, entry string, file
 
 	fp, err := os.OpenFile(changelogPath, os.O_APPEND|os.O_WRONLY, 0o600)
 	if err != nil {
-		return "", fmt.Errorf("failed to open changelog.md: %w", err)
+		return "", hooks.Payload{}, fmt.Errorf("failed to open changelog.md: %w", err)
 	}
 	defer func() { _ = fp.Close() }()
 	if _, err := fp.WriteString(sb.String()); err != nil {
-		return "", fmt.Errorf("failed to write changelog entry: %w", err)
+		return "", hooks.Payload{}, fmt.Errorf("failed to write changelog entry: %w", err)
+	}
+	ticket := e.Ticket
+	if ticket == "" {
+		ticket = ticketForPath("", changelogPath)
 	}
-	return today, nil
+	return today, hooks.Payload{Event: hooks.EventChangelogAppended, Ticket: ticket, Path: changelogPath, Data: map[string]any{
+		"date":  today,
+		"kind":  string(e.Kind),
+		"title": title,
+		"entry": entry,
+		"tasks": e.Tasks,
+		"files": files,
+	}}, nil
 }
@@ -82,3 +82,43 @@ func TestAppendChangelogEntry_RoundTrip(t *testing.T) {
 		t.Fatal("expected error for empty entry")
 	}
 }
+
+func TestAppendChangelog_KindTasksAndFiles(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "changelog.md")
+	if _, err := AppendChangelog(path, NewChangelogEntry{
+		Title: "Keep REST",
+		Entry: "REST stays under /api/v1.",
+		Kind:  ChangelogKindDecision,
+		Tasks: []ChangelogTaskRef{{ID: "ab12", Text: "Decide on paths"}, {ID: "cd34"}},
+		Files: map[string]string{"pkg/api.go": "routes"},
+	}); err != nil {
+		t.Fatalf("AppendChangelog: %v", err)
+	}
+	if _, err := AppendChangelog(path, NewChangelogEntry{Entry: "x", Kind: "misc"}); err == nil {
+		t.Fatal("expected error for unknown kind")
+	}
+
+	raw, err := os.ReadFile(path) // #nosec G304 -- test fixture path
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	entries := ParseChangelogEntries(string(raw) + "\n## 2026-07-04 [someday] - Not a kind\n\nBody.\n")
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d (%s)", len(entries), string(raw))
+	}
+	e := entries[0]
+	if e.Kind != ChangelogKindDecision || e.Title != "Keep REST" || e.Text() != "REST stays under /api/v1." {
+		t.Fatalf("unexpected entry: %+v (text %q)", e, e.Text())
+	}
+	if len(e.Tasks) != 2 || e.Tasks[0] != (ChangelogTaskRef{ID: "ab12", Text: "Decide on paths"}) || e.Tasks[1].ID != "cd34" {
+		t.Fatalf("unexpected tasks: %+v", e.Tasks)
+	}
+	if len(e.Files) != 1 || e.Files[0] != (ChangelogFileRef{Path: "pkg/api.go", Note: "routes"}) {
+		t.Fatalf("unexpected files: %+v", e.Files)
+	}
+	if entries[1].Kind != "" || entries[1].Title != "[someday] - Not a kind" {
+		t.Fatalf("unknown bracket tag should stay in the title: %+v", entries[1])
+	}
+}
@@ -0,0 +1,315 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/glazed/pkg/cmds"
+	"github.com/go-go-golems/glazed/pkg/cmds/fields"
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+	"github.com/go-go-golems/glazed/pkg/middlewares"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+// ChangelogRollupCommand aggregates changelog entries across tickets.
+type ChangelogRollupCommand struct{ *cmds.CommandDescription }
+
+// ChangelogRollupSettings holds the parameters for the changelog rollup command
+type ChangelogRollupSettings struct {
+	Root   string   `glazed:"root"`
+	Since  string   `glazed:"since"`
+	Until  string   `glazed:"until"`
+	Topics []string `glazed:"topics"`
+	Kinds  []string `glazed:"kind"`
+	Status string   `glazed:"status"`
+}
+
+func NewChangelogRollupCommand() (*ChangelogRollupCommand, error) {
+	cmd := cmds.NewCommandDescription(
+		"rollup",
+		cmds.WithShort("Aggregate changelog entries across tickets into one report"),
+		cmds.WithLong(`Collects the changelog.md entries of all tickets (optionally filtered by date,
+ticket topics, ticket status, and entry kind) into one report, e.g. release notes.
+
+Human output is a markdown report grouped by kind (added, changed, fixed,
+removed, decision, then untyped entries). Use --with-glaze-output for one row
+per entry (JSON, CSV, ...).
+
+Columns:
+  date,kind,ticket,ticket_title,title,text,tasks,files,path
+
+Examples:
+  docmgr changelog rollup --since 2026-09-01
+  docmgr changelog rollup --since 2026-09-01 --until 2026-09-30 --topics api
+  docmgr changelog rollup --since 2026-09-01 --kind decision --with-glaze-output --output json
+`),
+		cmds.WithFlags(
+			fields.New(
+				"root",
+				fields.TypeString,
+				fields.WithHelp("Root directory for docs"),
+				fields.WithDefault("ttmp"),
+			),
+			fields.New(
+				"since",
+				fields.TypeString,
+				fields.WithHelp("Include entries dated on or after this day (YYYY-MM-DD)"),
+				fields.WithDefault(""),
+			),
+			fields.New(
+				"until",
+				fields.TypeString,
+				fields.WithHelp("Include entries dated on or before this day (YYYY-MM-DD)"),
+				fields.WithDefault(""),
+			),
+			fields.New(
+				"topics",
+				fields.TypeStringList,
+				fields.WithHelp("Only tickets with any of these topics"),
+				fields.WithDefault([]string{}),
+			),
+			fields.New(
+				"kind",
+				fields.TypeStringList,
+				fields.WithHelp("Only entries of these kinds (added, changed, fixed, removed, decision)"),
+				fields.WithDefault([]string{}),
+			),
+			fields.New(
+				"status",
+				fields.TypeString,
+				fields.WithHelp("Only tickets with this status"),
+				fields.WithDefault(""),
+			),
+		),
+	)
+	return &ChangelogRollupCommand{CommandDescription: cmd}, nil
+}
+
+// changelogRollupEntry is one changelog entry with its ticket.
+type changelogRollupEntry struct {
+	Ticket      string
+	TicketTitle string
+	Path        string
+	Entry       ChangelogEntry
+}
+
+func (c *ChangelogRollupCommand) RunIntoGlazeProcessor(ctx context.Context, pl *values.Values, gp middlewares.Processor) error {
+	s := &ChangelogRollupSettings{}
+	if err := pl.DecodeSectionInto(schema.DefaultSlug, s); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+	entries, _, err := collectChangelogRollup(ctx, s)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		files := make([]string, 0, len(e.Entry.Files))
+		for _, f := range e.Entry.Files {
+			files = append(files, f.Path)
+		}
+		row := types.NewRow(
+			types.MRP("date", e.Entry.Date),
+			types.MRP("kind", string(e.Entry.Kind)),
+			types.MRP(ColTicket, e.Ticket),
+			types.MRP("ticket_title", e.TicketTitle),
+			types.MRP(ColTitle, e.Entry.Title),
+			types.MRP("text", e.Entry.Text()),
+			types.MRP("tasks", changelogTaskIDs(e.Entry.Tasks)),
+			types.MRP("files", files),
+			types.MRP(ColPath, e.Path),
+		)
+		if err := gp.AddRow(ctx, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ cmds.GlazeCommand = &ChangelogRollupCommand{}
+
+func (c *ChangelogRollupCommand) Run(ctx context.Context, pl *values.Values) error {
+	s := &ChangelogRollupSettings{}
+	if err := pl.DecodeSectionInto(schema.DefaultSlug, s); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+	entries, ticketCount, err := collectChangelogRollup(ctx, s)
+	if err != nil {
+		return err
+	}
+	fmt.Print(renderChangelogRollup(s, entries, ticketCount))
+	return nil
+}
+
+var _ cmds.BareCommand = &ChangelogRollupCommand{}
+
+// collectChangelogRollup returns the matching entries ordered by date, then
+// ticket, then file order, and the number of tickets they come from.
+func collectChangelogRollup(ctx context.Context, s *ChangelogRollupSettings) ([]changelogRollupEntry, int, error) {
+	since, err := parseRollupDate("since", s.Since)
+	if err != nil {
+		return nil, 0, err
+	}
+	until, err := parseRollupDate("until", s.Until)
+	if err != nil {
+		return nil, 0, err
+	}
+	kinds := map[ChangelogKind]bool{}
+	for _, k := range s.Kinds {
+		kind, err := ParseChangelogKind(k)
+		if err != nil {
+			return nil, 0, err
+		}
+		if kind != "" {
+			kinds[kind] = true
+		}
+	}
+
+	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: workspace.ResolveRoot(s.Root)})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to discover workspace: %w", err)
+	}
+	root := ws.Context().Root
+	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
+		return nil, 0, fmt.Errorf("failed to initialize workspace index: %w", err)
+	}
+	res, err := ws.QueryDocs(ctx, workspace.DocQuery{
+		Scope: workspace.Scope{Kind: workspace.ScopeRepo},
+		Filters: workspace.DocFilters{
+			DocType:   "index",
+			Status:    strings.TrimSpace(s.Status),
+			TopicsAny: s.Topics,
+		},
+		Options: workspace.DocQueryOptions{
+			IncludeArchivedPath: true,
+			IncludeScriptsPath:  true,
+			IncludeSourcesPath:  true,
+			IncludeControlDocs:  true,
+			OrderBy:             workspace.OrderByPath,
+		},
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query tickets: %w", err)
+	}
+
+	var out []changelogRollupEntry
+	tickets := map[string]bool{}
+	for _, h := range res.Docs {
+		if h.Doc == nil {
+			continue
+		}
+		changelogPath := filepath.Join(filepath.Dir(filepath.FromSlash(h.Path)), "changelog.md")
+		raw, err := os.ReadFile(changelogPath)
+		if err != nil {
+			continue
+		}
+		rel := changelogPath
+		if r, err := filepath.Rel(root, changelogPath); err == nil {
+			rel = filepath.ToSlash(r)
+		}
+		for _, e := range ParseChangelogEntries(string(raw)) {
+			if len(kinds) > 0 && !kinds[e.Kind] {
+				continue
+			}
+			if (since != "" || until != "") && e.Date == "" {
+				continue
+			}
+			if since != "" && e.Date < since {
+				continue
+			}
+			if until != "" && e.Date > until {
+				continue
+			}
+			out = append(out, changelogRollupEntry{Ticket: h.Doc.Ticket, TicketTitle: h.Doc.Title, Path: rel, Entry: e})
+			tickets[h.Doc.Ticket] = true
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Entry.Date != out[j].Entry.Date {
+			return out[i].Entry.Date < out[j].Entry.Date
+		}
+		return out[i].Ticket < out[j].Ticket
+	})
+	return out, len(tickets), nil
+}
+
+func parseRollupDate(flag string, v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return "", nil
+	}
+	if _, err := time.Parse("2006-01-02", v); err != nil {
+		return "", fmt.Errorf("invalid --%s %q (expected YYYY-MM-DD)", flag, v)
+	}
+	return v, nil
+}
+
+// renderChangelogRollup renders entries as a markdown report grouped by kind.
+func renderChangelogRollup(s *ChangelogRollupSettings, entries []changelogRollupEntry, ticketCount int) string {
+	var b strings.Builder
+	b.WriteString("# Changelog rollup")
+	var scope []string
+	if s.Since != "" {
+		scope = append(scope, "since "+s.Since)
+	}
+	if s.Until != "" {
+		scope = append(scope, "until "+s.Until)
+	}
+	if len(s.Topics) > 0 {
+		scope = append(scope, "topics: "+strings.Join(s.Topics, ", "))
+	}
+	if s.Status != "" {
+		scope = append(scope, "status: "+s.Status)
+	}
+	if len(scope) > 0 {
+		b.WriteString(" (" + strings.Join(scope, "; ") + ")")
+	}
+	b.WriteString("\n\n")
+	if len(entries) == 0 {
+		b.WriteString("No changelog entries.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%d entries from %d tickets.\n", len(entries), ticketCount)
+
+	groups := append(append([]ChangelogKind{}, ChangelogKinds...), "")
+	for _, kind := range groups {
+		var group []changelogRollupEntry
+		for _, e := range entries {
+			if e.Entry.Kind == kind {
+				group = append(group, e)
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+		heading := "Other"
+		if kind != "" {
+			heading = strings.ToUpper(string(kind[:1])) + string(kind[1:])
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", heading)
+		for _, e := range group {
+			text := e.Entry.Text()
+			summary := e.Entry.Title
+			if summary == "" {
+				summary, text, _ = strings.Cut(text, "\n")
+				summary = strings.TrimPrefix(strings.TrimSpace(summary), "- ")
+			}
+			fmt.Fprintf(&b, "- %s **%s** — %s\n", e.Entry.Date, e.Ticket, strings.TrimSpace(summary))
+			for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+				if strings.TrimSpace(line) != "" {
+					b.WriteString("  " + line + "\n")
+				}
+			}
+			if len(e.Entry.Tasks) > 0 {
+				b.WriteString("  Tasks: " + strings.Join(changelogTaskIDs(e.Entry.Tasks), ", ") + "\n")
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCollectChangelogRollup(t *testing.T) {
+	root := t.TempDir()
+	for _, tk := range []struct{ id, topics, changelog string }{
+		{"API-1", "api", "# Changelog\n\n## 2026-08-30 [added] - Too early\n\nOld.\n\n## 2026-09-02 [decision] - Keep REST\n\nREST stays.\n\n## 2026-09-05\n\n- Untyped note\n"},
+		{"API-2", "api, backend", "# Changelog\n\n## 2026-09-03 [fixed] - Reconnect loop\n\nFixed it.\n"},
+		{"CHAT-1", "chat", "# Changelog\n\n## 2026-09-04 [added] - Emoji\n\nAdded emoji.\n"},
+	} {
+		dir := filepath.Join(root, "2026", "09", "01", tk.id+"--t")
+		writeMarkdown(t, filepath.Join(dir, "index.md"), "---\nTitle: "+tk.id+" title\nTicket: "+tk.id+"\nDocType: index\nStatus: active\nTopics: ["+tk.topics+"]\nLastUpdated: 2026-09-01\n---\n\n# T\n")
+		writeMarkdown(t, filepath.Join(dir, "changelog.md"), tk.changelog)
+	}
+	ctx := context.Background()
+
+	entries, tickets, err := collectChangelogRollup(ctx, &ChangelogRollupSettings{Root: root, Since: "2026-09-01", Topics: []string{"api"}})
+	if err != nil {
+		t.Fatalf("rollup: %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Entry.Date+" "+e.Ticket+" "+string(e.Entry.Kind))
+	}
+	want := "2026-09-02 API-1 decision|2026-09-03 API-2 fixed|2026-09-05 API-1 "
+	if strings.Join(got, "|") != want || tickets != 2 {
+		t.Fatalf("got %q (%d tickets), want %q", strings.Join(got, "|"), tickets, want)
+	}
+
+	report := renderChangelogRollup(&ChangelogRollupSettings{Since: "2026-09-01"}, entries, tickets)
+	for _, s := range []string{"## Fixed", "## Decision", "## Other", "- 2026-09-05 **API-1** — Untyped note"} {
+		if !strings.Contains(report, s) {
+			t.Fatalf("report missing %q:\n%s", s, report)
+		}
+	}
+
+	entries, _, err = collectChangelogRollup(ctx, &ChangelogRollupSettings{Root: root, Kinds: []string{"added"}})
+	if err != nil {
+		t.Fatalf("rollup by kind: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Ticket != "API-1" || entries[1].Ticket != "CHAT-1" {
+		t.Fatalf("unexpected added entries: %+v", entries)
+	}
+	if _, _, err := collectChangelogRollup(ctx, &ChangelogRollupSettings{Root: root, Since: "09/01/2026"}); err == nil {
+		t.Fatal("expected an error for a malformed --since")
+	}
+}
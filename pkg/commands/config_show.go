@@ -340,6 +340,17 @@ func (c *ConfigShowCommand) RunIntoGlazeProcessor(
 				return err
 			}
 		}
+
+		if activeConfig.Index.Cache {
+			row = types.NewRow(
+				types.MRP("setting", "index.cache"),
+				types.MRP("value", indexCacheDisplay(activeConfig, activeConfigPath, resolvedRoot)),
+				types.MRP("source", activeConfigPath),
+			)
+			if err := gp.AddRow(ctx, row); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -579,6 +590,9 @@ func (c *ConfigShowCommand) Run(
 		if activeConfig.FilenamePrefixPolicy != "" {
 			fmt.Printf("  filenamePrefixPolicy: %s\n", activeConfig.FilenamePrefixPolicy)
 		}
+		if activeConfig.Index.Cache {
+			fmt.Printf("  index.cache: %s\n", indexCacheDisplay(activeConfig, activeConfigPath, resolvedRoot))
+		}
 	}
 
 	return nil
@@ -586,3 +600,15 @@ func (c *ConfigShowCommand) Run(
 
 var _ cmds.GlazeCommand = &ConfigShowCommand{}
 var _ cmds.BareCommand = &ConfigShowCommand{}
+
+// indexCacheDisplay renders the effective on-disk index cache location.
+func indexCacheDisplay(cfg *workspace.WorkspaceConfig, configPath string, root string) string {
+	p := cfg.Index.CachePath
+	if p == "" {
+		return workspace.DefaultIndexCachePath(root)
+	}
+	if !filepath.IsAbs(p) && configPath != "" {
+		p = filepath.Join(filepath.Dir(configPath), p)
+	}
+	return p
+}
@@ -12,6 +12,7 @@ const (
 	ColLastUpdated = "last_updated"
 	ColTasksOpen   = "tasks_open"
 	ColTasksDone   = "tasks_done"
+	ColBlockedBy   = "blocked_by"
 
 	ColDocType = "doc_type"
 
@@ -25,7 +26,7 @@ const (
 	ColDescription = "description"
 )
 
-var ColumnsTickets = []string{ColTicket, ColTitle, ColStatus, ColTopics, ColTasksOpen, ColTasksDone, ColPath, ColLastUpdated}
+var ColumnsTickets = []string{ColTicket, ColTitle, ColStatus, ColTopics, ColTasksOpen, ColTasksDone, ColBlockedBy, ColPath, ColLastUpdated}
 var ColumnsDocs = []string{ColTicket, ColDocType, ColTitle, ColStatus, ColTopics, ColPath, ColLastUpdated}
 var ColumnsTasksList = []string{ColIndex, ColChecked, ColText}
 var ColumnsVocabList = []string{ColCategory, ColSlug, ColDescription}
@@ -8,7 +8,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-go-golems/docmgr/internal/blueprints"
 	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/hooks"
+	"github.com/go-go-golems/docmgr/internal/tasksmd"
 	"github.com/go-go-golems/docmgr/internal/templates"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/docmgr/pkg/models"
@@ -36,6 +39,7 @@ type CreateTicketSettings struct {
 	Root         string   `glazed:"root"`
 	Force        bool     `glazed:"force"`
 	PathTemplate string   `glazed:"path-template"`
+	Blueprint    string   `glazed:"blueprint"`
 }
 
 type CreateTicketResult struct {
@@ -43,6 +47,7 @@ type CreateTicketResult struct {
 	Title        string
 	Path         string
 	Root         string
+	Blueprint    string
 	Directories  []string
 	FilesCreated []string
 }
@@ -64,6 +69,9 @@ Examples:
   # Create under a custom path template (relative to --root)
   docmgr ticket create --ticket MEN-9999 --title "Scratch ticket for experiments" \
     --root ttmp --path-template "examples/{{TICKET}}--{{SLUG}}"
+
+  # Scaffold docs, seed tasks, and topics from a blueprint (see 'docmgr blueprint list')
+  docmgr ticket create --ticket MEN-4300 --title "Retry budgets" --blueprint feature
 `),
 			cmds.WithFlags(
 				fields.New(
@@ -102,6 +110,12 @@ Examples:
 					fields.WithHelp("Template for ticket directory relative to root (placeholders: {{YYYY}}, {{MM}}, {{DD}}, {{DATE}}, {{TICKET}}, {{SLUG}}, {{TITLE}})"),
 					fields.WithDefault(DefaultTicketPathTemplate),
 				),
+				fields.New(
+					"blueprint",
+					fields.TypeString,
+					fields.WithHelp("Blueprint that adds docs, topics, seed tasks, and the first changelog entry (see 'docmgr blueprint list')"),
+					fields.WithDefault(""),
+				),
 			),
 		),
 	}, nil
@@ -117,7 +131,7 @@ func (c *CreateTicketCommand) RunIntoGlazeProcessor(
 		return fmt.Errorf("failed to parse settings: %w", err)
 	}
 
-	result, err := c.createTicketWorkspace(settings)
+	result, err := c.createTicketWorkspace(ctx, settings)
 	if err != nil {
 		return err
 	}
@@ -126,15 +140,33 @@ func (c *CreateTicketCommand) RunIntoGlazeProcessor(
 		types.MRP("ticket", result.Ticket),
 		types.MRP("path", result.Path),
 		types.MRP("title", result.Title),
+		types.MRP("blueprint", result.Blueprint),
+		types.MRP("files", len(result.FilesCreated)),
 		types.MRP("status", "created"),
 	)
 
 	return gp.AddRow(ctx, row)
 }
 
-func (c *CreateTicketCommand) createTicketWorkspace(settings *CreateTicketSettings) (*CreateTicketResult, error) {
+func (c *CreateTicketCommand) createTicketWorkspace(ctx context.Context, settings *CreateTicketSettings) (*CreateTicketResult, error) {
 	settings.Root = workspace.ResolveRoot(settings.Root)
 
+	var bp *blueprints.Blueprint
+	if name := strings.TrimSpace(settings.Blueprint); name != "" {
+		b, err := blueprints.Get(settings.Root, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load blueprint: %w", err)
+		}
+		b = b.Expand(settings.Ticket, settings.Title)
+		// Check the doc templates up front so a broken one fails the command
+		// before anything is written, not after the ticket is half scaffolded.
+		if err := checkBlueprintTemplates(settings.Root, b); err != nil {
+			return nil, err
+		}
+		bp = &b
+		settings.Topics = mergeTopics(settings.Topics, b.Topics)
+	}
+
 	slug := utils.SlugifyTitleForTicket(settings.Ticket, settings.Title)
 	now := time.Now()
 	ticketPath, err := renderTicketPath(settings.Root, settings.PathTemplate, settings.Ticket, slug, settings.Title, now)
@@ -181,17 +213,15 @@ func (c *CreateTicketCommand) createTicketWorkspace(settings *CreateTicketSettin
 			return []string{}
 		}(),
 		RelatedFiles:    models.RelatedFiles{},
-		ExternalSources: []string{},
+		ExternalSources: models.ExternalSources{},
 		Summary:         "",
 		LastUpdated:     now,
 	}
 
 	indexPath := filepath.Join(ticketPath, "index.md")
 	indexBody := fmt.Sprintf("# %s\n\nDocument workspace for %s.\n", settings.Title, settings.Ticket)
-	if tpl, ok := templates.LoadTemplate(settings.Root, "index"); ok {
-		_, body := templates.ExtractFrontmatterAndBody(tpl)
-		doc.Title = settings.Title
-		indexBody = templates.RenderTemplateBody(body, &doc)
+	if body, ok := renderDocTemplate(ctx, settings.Root, "index", &doc, templates.TicketDataFromDocument(&doc, ticketPath), nil); ok {
+		indexBody = body
 	}
 	if err := documents.WriteDocumentWithFrontmatter(indexPath, &doc, indexBody, settings.Force); err != nil {
 		return nil, fmt.Errorf("failed to write index.md: %w", err)
@@ -236,32 +266,102 @@ Use docmgr commands to manage this workspace:
 ## TODO
 
 `
+	if bp != nil && len(bp.Tasks) > 0 {
+		existing := map[string]struct{}{}
+		var sb strings.Builder
+		sb.WriteString(tasksContent)
+		for _, task := range bp.Tasks {
+			id := tasksmd.NewStableID(existing)
+			existing[id] = struct{}{}
+			sb.WriteString(formatTaskLine(false, strings.TrimSpace(task), id) + "\n")
+		}
+		tasksContent = sb.String()
+	}
 	if err := writeFileIfNotExists(tasksPath, []byte(tasksContent), settings.Force); err != nil {
 		return nil, fmt.Errorf("failed to write tasks.md: %w", err)
 	}
 	files = append(files, tasksPath)
 
 	changelogPath := filepath.Join(ticketPath, "changelog.md")
-	changelogContent := fmt.Sprintf(`# Changelog
-
-## %s
-
-- Initial workspace created
-
-`, now.Format("2006-01-02"))
-	if err := writeFileIfNotExists(changelogPath, []byte(changelogContent), settings.Force); err != nil {
-		return nil, fmt.Errorf("failed to write changelog.md: %w", err)
+	firstEntry := "- Initial workspace created"
+	if bp != nil && strings.TrimSpace(bp.Changelog) != "" {
+		// Blueprint entries are markdown and may span several lines.
+		firstEntry = strings.TrimSpace(bp.Changelog)
+	}
+	if settings.Force {
+		if err := os.Remove(changelogPath); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to replace changelog.md: %w", err)
+		}
+	}
+	var changelogHook *hooks.Payload
+	if _, err := os.Stat(changelogPath); os.IsNotExist(err) {
+		_, payload, err := WriteChangelogEntry(changelogPath, NewChangelogEntry{Ticket: settings.Ticket, Entry: firstEntry})
+		if err != nil {
+			return nil, fmt.Errorf("failed to write changelog.md: %w", err)
+		}
+		changelogHook = &payload
 	}
 	files = append(files, changelogPath)
 
-	return &CreateTicketResult{
+	fireHook(ctx, hooks.EventTicketCreated, settings.Ticket, indexPath, map[string]any{
+		"title":  settings.Title,
+		"topics": settings.Topics,
+		"dir":    ticketPath,
+	})
+	if changelogHook != nil {
+		fireHooks(ctx, *changelogHook)
+	}
+
+	result := &CreateTicketResult{
 		Ticket:       settings.Ticket,
 		Title:        settings.Title,
 		Path:         ticketPath,
 		Root:         settings.Root,
 		Directories:  dirList,
 		FilesCreated: files,
-	}, nil
+	}
+	if bp == nil {
+		return result, nil
+	}
+
+	// Blueprint docs go through 'doc add' so they get numeric prefixes,
+	// templates, and doc.added hooks like any other doc.
+	result.Blueprint = bp.Name
+	add := &AddCommand{}
+	for _, d := range bp.Docs {
+		added, err := add.createDocument(ctx, &AddSettings{
+			Ticket:  settings.Ticket,
+			DocType: d.DocType,
+			Title:   d.Title,
+			Root:    settings.Root,
+			Summary: d.Summary,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create blueprint doc %q: %w", d.Title, err)
+		}
+		result.FilesCreated = append(result.FilesCreated, added.DocPath)
+	}
+	return result, nil
+}
+
+// checkBlueprintTemplates validates the _templates/<docType>.md of each
+// blueprint doc type that has one, like 'docmgr template validate'.
+func checkBlueprintTemplates(root string, bp blueprints.Blueprint) error {
+	seen := map[string]struct{}{}
+	for _, d := range bp.Docs {
+		if _, ok := seen[d.DocType]; ok {
+			continue
+		}
+		seen[d.DocType] = struct{}{}
+		path := filepath.Join(root, "_templates", d.DocType+".md")
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := validateDocTemplate(root, path); err != nil {
+			return fmt.Errorf("blueprint %q: template for %s: %w", bp.Name, d.DocType, err)
+		}
+	}
+	return nil
 }
 
 func (c *CreateTicketCommand) Run(
@@ -273,7 +373,7 @@ func (c *CreateTicketCommand) Run(
 		return fmt.Errorf("failed to parse settings: %w", err)
 	}
 
-	result, err := c.createTicketWorkspace(settings)
+	result, err := c.createTicketWorkspace(ctx, settings)
 	if err != nil {
 		return err
 	}
@@ -329,6 +429,24 @@ var _ cmds.BareCommand = &CreateTicketCommand{}
 var _ cmds.GlazeCommand = &CreateTicketCommand{}
 var _ cmds.BareCommand = &CreateTicketCommand{}
 
+// mergeTopics appends extra topics not already in topics.
+func mergeTopics(topics []string, extra []string) []string {
+	out := append([]string{}, topics...)
+	seen := map[string]bool{}
+	for _, t := range out {
+		seen[strings.ToLower(strings.TrimSpace(t))] = true
+	}
+	for _, t := range extra {
+		key := strings.ToLower(strings.TrimSpace(t))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, strings.TrimSpace(t))
+	}
+	return out
+}
+
 func renderTicketPath(root, templateStr, ticket, slug, title string, now time.Time) (string, error) {
 	if templateStr == "" {
 		templateStr = DefaultTicketPathTemplate
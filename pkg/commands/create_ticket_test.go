@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+)
+
+func TestCreateTicketWorkspace_Blueprint(t *testing.T) {
+	tmp := t.TempDir()
+	root := filepath.Join(tmp, "ttmp")
+	cfgPath := filepath.Join(tmp, ".ttmp.yaml")
+	writeMarkdown(t, cfgPath, "root: ttmp\n")
+	t.Setenv("DOCMGR_CONFIG", cfgPath)
+	writeMarkdown(t, filepath.Join(root, "_blueprints", "incident.yaml"), `description: Incident follow-up
+topics: [ops]
+docs:
+  - docType: playbook
+    title: "{{TITLE}} — Runbook"
+tasks:
+  - Write the timeline for {{TICKET}}
+changelog: |
+  Incident ticket opened.
+
+  - Timeline owner: on-call
+`)
+
+	result, err := (&CreateTicketCommand{}).createTicketWorkspace(context.Background(), &CreateTicketSettings{
+		Ticket:       "INC-7",
+		Title:        "Queue outage",
+		Topics:       []string{"backend"},
+		Root:         root,
+		PathTemplate: DefaultTicketPathTemplate,
+		Blueprint:    "incident",
+	})
+	if err != nil {
+		t.Fatalf("createTicketWorkspace: %v", err)
+	}
+	if result.Blueprint != "incident" {
+		t.Fatalf("Blueprint = %q", result.Blueprint)
+	}
+
+	index, _, err := documents.ReadDocumentWithFrontmatter(filepath.Join(result.Path, "index.md"))
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	if strings.Join(index.Topics, ",") != "backend,ops" {
+		t.Fatalf("Topics = %v, want backend,ops", index.Topics)
+	}
+
+	tasks, err := os.ReadFile(filepath.Join(result.Path, "tasks.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(tasks), "- [ ] Write the timeline for INC-7 <!-- t:") {
+		t.Fatalf("tasks.md missing seeded task:\n%s", tasks)
+	}
+	changelog, err := os.ReadFile(filepath.Join(result.Path, "changelog.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := ParseChangelogEntries(string(changelog))
+	if len(entries) != 1 || entries[0].Body != "Incident ticket opened.\n\n- Timeline owner: on-call" {
+		t.Fatalf("unexpected changelog:\n%s", changelog)
+	}
+
+	runbook := filepath.Join(result.Path, "playbook", "01-queue-outage-runbook.md")
+	doc, _, err := documents.ReadDocumentWithFrontmatter(runbook)
+	if err != nil {
+		t.Fatalf("read blueprint doc: %v", err)
+	}
+	if doc.Title != "Queue outage — Runbook" || doc.DocType != "playbook" || doc.Ticket != "INC-7" {
+		t.Fatalf("unexpected blueprint doc: %+v", doc)
+	}
+}
+
+func TestCreateTicketWorkspace_BlueprintBadTemplateWritesNothing(t *testing.T) {
+	tmp := t.TempDir()
+	root := filepath.Join(tmp, "ttmp")
+	cfgPath := filepath.Join(tmp, ".ttmp.yaml")
+	writeMarkdown(t, cfgPath, "root: ttmp\n")
+	t.Setenv("DOCMGR_CONFIG", cfgPath)
+	writeMarkdown(t, filepath.Join(root, "_templates", "playbook.md"), "# {{ .Doc.Nope }}\n")
+	writeMarkdown(t, filepath.Join(root, "_blueprints", "incident.yaml"), `docs:
+  - docType: playbook
+    title: Runbook
+`)
+
+	_, err := (&CreateTicketCommand{}).createTicketWorkspace(context.Background(), &CreateTicketSettings{
+		Ticket:       "INC-8",
+		Title:        "Disk full",
+		Root:         root,
+		PathTemplate: DefaultTicketPathTemplate,
+		Blueprint:    "incident",
+	})
+	if err == nil || !strings.Contains(err.Error(), "template for playbook") {
+		t.Fatalf("expected template error, got %v", err)
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), "_") {
+			t.Fatalf("ticket scaffolded despite template error: %s", e.Name())
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/docdiff"
+	"github.com/go-go-golems/docmgr/internal/tickets"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/glazed/pkg/cmds"
+	"github.com/go-go-golems/glazed/pkg/cmds/fields"
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+	"github.com/go-go-golems/glazed/pkg/middlewares"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+// DiffCommand compares the docs root between two git revisions.
+type DiffCommand struct {
+	*cmds.CommandDescription
+}
+
+// DiffSettings holds the parameters for the diff command.
+type DiffSettings struct {
+	Root   string   `glazed:"root"`
+	Since  string   `glazed:"since"`
+	Until  string   `glazed:"until"`
+	Ticket string   `glazed:"ticket"`
+	Fields []string `glazed:"field"`
+}
+
+func NewDiffCommand() (*DiffCommand, error) {
+	return &DiffCommand{
+		CommandDescription: cmds.NewCommandDescription(
+			"diff",
+			cmds.WithShort("Show docs added, removed, or with changed frontmatter since a git revision"),
+			cmds.WithLong(`Builds the workspace index from the docs root at --since (read from local git,
+not the working tree) and compares it with --until (default: the working tree).
+Reports added and removed documents and, for documents present on both sides,
+every indexed frontmatter field that changed (Title, Status, Topics, Owners,
+RelatedFiles, ticket links, custom fields, ...). Body-only edits are not
+reported.
+
+Revisions are anything git understands: commits, tags, branches, HEAD~10,
+or a date via the reflog (main@{2.weeks.ago}).
+
+Examples:
+  # What changed in the docs since the last release tag
+  docmgr diff --since v1.4.0
+
+  # Which docs changed status this sprint
+  docmgr diff --since 'main@{2.weeks.ago}' --field Status
+
+  # One ticket between two commits, as JSON
+  docmgr diff --since HEAD~20 --until HEAD --ticket MEN-4242 --with-glaze-output --output json
+`),
+			cmds.WithFlags(
+				fields.New(
+					"root",
+					fields.TypeString,
+					fields.WithHelp("Root directory for docs"),
+					fields.WithDefault("ttmp"),
+				),
+				fields.New(
+					"since",
+					fields.TypeString,
+					fields.WithHelp("Git revision to compare from"),
+					fields.WithRequired(true),
+				),
+				fields.New(
+					"until",
+					fields.TypeString,
+					fields.WithHelp("Git revision to compare to (default: working tree)"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"ticket",
+					fields.TypeString,
+					fields.WithHelp("Limit to a specific ticket"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"field",
+					fields.TypeStringList,
+					fields.WithHelp("Only report modified docs where these frontmatter fields changed (e.g. Status)"),
+					fields.WithDefault([]string{}),
+				),
+			),
+		),
+	}, nil
+}
+
+type diffResult struct {
+	From    string // resolved commit
+	To      string // resolved commit, or "" for the working tree
+	Changes []docdiff.DocChange
+}
+
+func (c *DiffCommand) RunIntoGlazeProcessor(
+	ctx context.Context,
+	parsedValues *values.Values,
+	gp middlewares.Processor,
+) error {
+	settings := &DiffSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	res, err := runDocDiff(ctx, settings)
+	if err != nil {
+		return err
+	}
+	for _, ch := range res.Changes {
+		base := []types.MapRowPair{
+			types.MRP("kind", string(ch.Kind)),
+			types.MRP(ColTicket, ch.Ticket),
+			types.MRP(ColPath, ch.Path),
+			types.MRP(ColTitle, ch.Title),
+		}
+		if len(ch.Fields) == 0 {
+			row := types.NewRow(append(base,
+				types.MRP("field", ""),
+				types.MRP("old", ""),
+				types.MRP("new", ""),
+			)...)
+			if err := gp.AddRow(ctx, row); err != nil {
+				return fmt.Errorf("failed to emit diff row: %w", err)
+			}
+			continue
+		}
+		for _, f := range ch.Fields {
+			row := types.NewRow(append(append([]types.MapRowPair{}, base...),
+				types.MRP("field", f.Field),
+				types.MRP("old", f.Old),
+				types.MRP("new", f.New),
+			)...)
+			if err := gp.AddRow(ctx, row); err != nil {
+				return fmt.Errorf("failed to emit diff row: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _ cmds.GlazeCommand = &DiffCommand{}
+
+// Run implements cmds.BareCommand with a +/-/~ listing per document.
+func (c *DiffCommand) Run(
+	ctx context.Context,
+	parsedValues *values.Values,
+) error {
+	settings := &DiffSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	res, err := runDocDiff(ctx, settings)
+	if err != nil {
+		return err
+	}
+	to := "working tree"
+	if res.To != "" {
+		to = fmt.Sprintf("%s (%s)", shortRev(res.To), settings.Until)
+	}
+	fmt.Printf("Docs diff %s (%s) → %s\n", shortRev(res.From), settings.Since, to)
+	if len(res.Changes) == 0 {
+		fmt.Println("No document changes.")
+		return nil
+	}
+
+	counts := map[docdiff.ChangeKind]int{}
+	for _, ch := range res.Changes {
+		counts[ch.Kind]++
+		marker := "~"
+		switch ch.Kind {
+		case docdiff.Added:
+			marker = "+"
+		case docdiff.Removed:
+			marker = "-"
+		case docdiff.Modified:
+		}
+		fmt.Printf("%s %s %s\n", marker, ch.Ticket, ch.Path)
+		for _, f := range ch.Fields {
+			fmt.Printf("    %s: %s → %s\n", f.Field, diffValue(f.Old), diffValue(f.New))
+		}
+	}
+	fmt.Printf("%d added, %d removed, %d modified\n", counts[docdiff.Added], counts[docdiff.Removed], counts[docdiff.Modified])
+	return nil
+}
+
+var _ cmds.BareCommand = &DiffCommand{}
+
+func runDocDiff(ctx context.Context, settings *DiffSettings) (*diffResult, error) {
+	if strings.TrimSpace(settings.Since) == "" {
+		return nil, fmt.Errorf("--since is required")
+	}
+	settings.Root = workspace.ResolveRoot(settings.Root)
+
+	open := func(rev string) (*workspace.Workspace, error) {
+		ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: settings.Root, Revision: rev})
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover workspace: %w", err)
+		}
+		if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
+			if rev != "" {
+				return nil, fmt.Errorf("failed to index docs at %s: %w", rev, err)
+			}
+			return nil, fmt.Errorf("failed to initialize workspace index: %w", err)
+		}
+		return ws, nil
+	}
+	from, err := open(strings.TrimSpace(settings.Since))
+	if err != nil {
+		return nil, err
+	}
+	to, err := open(strings.TrimSpace(settings.Until))
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := strings.TrimSpace(settings.Ticket)
+	if ticket != "" {
+		// Prefer the canonical ID from the newer side; a ticket that only
+		// exists in the older revision is matched as written.
+		if id, err := tickets.ResolveTicketID(ctx, to, ticket); err == nil {
+			ticket = id
+		} else if id, err := tickets.ResolveTicketID(ctx, from, ticket); err == nil {
+			ticket = id
+		}
+	}
+
+	changes, err := docdiff.Compare(ctx, from, to, docdiff.Options{Ticket: ticket, Fields: settings.Fields})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare revisions: %w", err)
+	}
+	return &diffResult{
+		From:    from.LastIndexStats().Revision,
+		To:      to.LastIndexStats().Revision,
+		Changes: changes,
+	}, nil
+}
+
+func shortRev(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
+
+func diffValue(s string) string {
+	if s == "" {
+		return "(unset)"
+	}
+	return s
+}
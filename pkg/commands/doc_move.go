@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/journal"
 	"github.com/go-go-golems/docmgr/internal/tickets"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/glazed/pkg/cmds"
@@ -37,6 +38,7 @@ type DocMoveResult struct {
 	DestTicket   string
 	SourcePath   string
 	DestPath     string
+	OpID         string
 	CompletedAt  time.Time
 }
 
@@ -128,6 +130,7 @@ func (c *DocMoveCommand) RunIntoGlazeProcessor(
 		types.MRP("source_path", result.SourcePath),
 		types.MRP("dest_path", result.DestPath),
 		types.MRP("status", "moved"),
+		types.MRP("op_id", result.OpID),
 		types.MRP("time", result.CompletedAt.Format(time.RFC3339)),
 	)
 	return gp.AddRow(ctx, row)
@@ -195,18 +198,27 @@ func (c *DocMoveCommand) applyMove(ctx context.Context, settings *DocMoveSetting
 		}
 	}
 
-	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
-		return nil, fmt.Errorf("failed to create destination directory: %w", err)
-	}
+	summary := fmt.Sprintf("move %s to %s", relToRoot(settings.Root, srcPath), settings.DestTicket)
+	opID, err := runJournaled(settings.Root, "doc move", summary, func(tx *journal.Tx) error {
+		if err := tx.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+			return fmt.Errorf("failed to create destination directory: %w", err)
+		}
 
-	// Rewrite Ticket in frontmatter for the destination copy.
-	doc.Ticket = settings.DestTicket
-	if err := documents.WriteDocumentWithFrontmatter(destPath, doc, body, true); err != nil {
-		return nil, fmt.Errorf("failed to write destination document: %w", err)
-	}
+		// Rewrite Ticket in frontmatter for the destination copy.
+		doc.Ticket = settings.DestTicket
+		if err := tx.Write(destPath, func() error {
+			return documents.WriteDocumentWithFrontmatter(destPath, doc, body, true)
+		}); err != nil {
+			return fmt.Errorf("failed to write destination document: %w", err)
+		}
 
-	if err := os.Remove(srcPath); err != nil {
-		return nil, fmt.Errorf("failed to remove source document: %w", err)
+		if err := tx.Remove(srcPath); err != nil {
+			return fmt.Errorf("failed to remove source document: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &DocMoveResult{
@@ -214,6 +226,7 @@ func (c *DocMoveCommand) applyMove(ctx context.Context, settings *DocMoveSetting
 		DestTicket:   settings.DestTicket,
 		SourcePath:   srcPath,
 		DestPath:     destPath,
+		OpID:         opID,
 		CompletedAt:  time.Now(),
 	}, nil
 }
@@ -250,6 +263,9 @@ func (c *DocMoveCommand) Run(
 	}
 
 	fmt.Printf("moved %s -> %s (ticket %s -> %s)\n", result.SourcePath, result.DestPath, result.SourceTicket, result.DestTicket)
+	if result.OpID != "" {
+		fmt.Printf("undo with: docmgr undo %s\n", result.OpID)
+	}
 	return nil
 }
 
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/templates"
+	"github.com/go-go-golems/docmgr/internal/tickets"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/models"
+)
+
+// renderDocTemplate renders <root>/_templates/<docType>.md for doc. ok is
+// false when the root has no template for docType. Bodies that do not
+// render as text/template fall back to the legacy placeholders with a
+// warning, so existing templates keep working.
+func renderDocTemplate(
+	ctx context.Context,
+	root string,
+	docType string,
+	doc *models.Document,
+	ticket *templates.TicketData,
+	vars map[string]string,
+) (string, bool) {
+	tpl, ok := templates.LoadTemplate(root, docType)
+	if !ok {
+		return "", false
+	}
+	_, body := templates.ExtractFrontmatterAndBody(tpl)
+
+	data := &templates.DocData{Doc: doc, Ticket: ticket, Vars: vars}
+	if cfg, err := workspace.LoadWorkspaceConfig(); err == nil && cfg != nil {
+		data.Defaults = templates.Defaults{Owners: cfg.Defaults.Owners, Intent: cfg.Defaults.Intent}
+	}
+	if data.Vars == nil {
+		data.Vars = map[string]string{}
+	}
+	r := templates.Renderer{Root: root, LookupTicket: templateTicketLookup(ctx, root)}
+	out, fallbackErr := r.RenderOrLegacy(docType+".md", body, data)
+	if fallbackErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; rendered legacy placeholders only (see 'docmgr template validate')\n", fallbackErr)
+	}
+	return out, true
+}
+
+// templateTicketLookup backs the ticket template helper with a workspace
+// scan, done on first use.
+func templateTicketLookup(ctx context.Context, root string) templates.TicketLookup {
+	var ws *workspace.Workspace
+	return func(id string) (*templates.TicketData, error) {
+		if ws == nil {
+			w, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: root})
+			if err != nil {
+				return nil, fmt.Errorf("failed to discover workspace: %w", err)
+			}
+			if err := w.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
+				return nil, fmt.Errorf("failed to initialize workspace index: %w", err)
+			}
+			ws = w
+		}
+		res, err := tickets.Resolve(ctx, ws, id)
+		if err != nil {
+			return nil, fmt.Errorf("ticket %q: %w", id, err)
+		}
+		doc, _, err := documents.ReadDocumentWithFrontmatter(res.IndexPathAbs)
+		if err != nil {
+			return nil, fmt.Errorf("ticket %q: %w", id, err)
+		}
+		return templates.TicketDataFromDocument(doc, res.TicketDirAbs), nil
+	}
+}
+
+// parseTemplateVars parses repeated --var key=value flags.
+func parseTemplateVars(raw []string) (map[string]string, error) {
+	vars := map[string]string{}
+	for _, kv := range raw {
+		key, value, ok := strings.Cut(kv, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q (expected key=value)", kv)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
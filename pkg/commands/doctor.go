@@ -11,14 +11,17 @@ import (
 	"time"
 
 	"github.com/charmbracelet/glamour"
-	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/customrules"
 	"github.com/go-go-golems/docmgr/internal/paths"
+	"github.com/go-go-golems/docmgr/internal/stalecode"
+	"github.com/go-go-golems/docmgr/internal/tasksmd"
 	"github.com/go-go-golems/docmgr/internal/templates"
 	"github.com/go-go-golems/docmgr/internal/tickets"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
 	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgr"
 	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgrctx"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/report"
 	"github.com/go-go-golems/docmgr/pkg/models"
 	"github.com/go-go-golems/glazed/pkg/cmds"
 	"github.com/go-go-golems/glazed/pkg/cmds/fields"
@@ -45,10 +48,15 @@ type DoctorSettings struct {
 	StaleAfterDays  int      `glazed:"stale-after"`
 	FailOn          string   `glazed:"fail-on"`
 	DiagnosticsJSON string   `glazed:"diagnostics-json"`
+	Format          string   `glazed:"format"`
+	ReportFile      string   `glazed:"report-file"`
 	Fix             bool     `glazed:"fix"`
 	FixAnchors      bool     `glazed:"fix-anchors"`
+	DryRun          bool     `glazed:"dry-run"`
+	Only            []string `glazed:"only"`
 	Details         bool     `glazed:"details"`
 	IncludeSources  bool     `glazed:"include-sources"`
+	StaleCode       bool     `glazed:"stale-code"`
 	// Schema printing flags (human mode only)
 	PrintTemplateSchema bool   `glazed:"print-template-schema"`
 	SchemaFormat        string `glazed:"schema-format"`
@@ -61,7 +69,7 @@ var (
 	doctorBuiltinDocTypes = []string{
 		"index", "design-doc", "reference", "playbook", "analysis", "til",
 		"skill", "log", "code-review", "script", "task-list", "tutorial",
-		"working-note",
+		"working-note", "source",
 	}
 	doctorBuiltinIntents = []string{
 		"long-term", "ticket-specific", "only-during-ticket", "throwaway", "short-term",
@@ -164,8 +172,23 @@ Common findings (doctor message ⇒ likely cause ⇒ how to fix):
   • unknown_topics / unknown_status — Value not present in vocabulary.yaml. Either add it via
     'docmgr vocab add --category topics --slug your-topic --description "TODO"'
     (valid categories: topics, docTypes, intent, status) or update the doc’s fields.
+  • missing_custom_field / invalid_custom_field — A custom field declared in the workspace
+    schema (schema.yaml) is required but absent, or has the wrong type / a value not in its
+    'values' list. Fix with 'docmgr meta update --field Name --value ...'.
   • stale — No document in the ticket was updated within '--stale-after' days (default 30).
     Review the ticket, make an update, or pass '--stale-after N' for a different cadence.
+  • stale_code (with --stale-code) — A RelatedFiles entry has git commits newer than the doc's
+    LastUpdated (commit count and last author in the message). Review the doc against the code
+    and update it; 'docmgr stale' lists the same findings.
+  • closed_blocker / dependency_cycle / unknown_ticket_link — Ticket links (DependsOn/Blocks
+    in index.md) where a complete/archived ticket still blocks an open one, tickets block each
+    other in a cycle, or the target ticket does not exist. Fix with
+    'docmgr ticket link --ticket T --depends-on OTHER --remove'.
+  • task_overdue / task_assignee_not_owner — An open task in tasks.md is past its
+    'due:YYYY-MM-DD' date, or is assigned ('@name') to someone not in the ticket's Owners.
+    Check it off, move the date, or fix the assignee/Owners.
+  • <rule id> — A rule from the workspace's .docmgr/rules.yaml matched the document (query
+    expression, severity, and message are team-defined; see 'docmgr help how-to-use').
 
 Scope and output:
   • RelatedFiles, vocabulary, and staleness checks run on every document in a ticket,
@@ -174,15 +197,29 @@ Scope and output:
   • Multi-ticket runs print a one-line rollup per ticket; pass '--details' (or '--ticket ID')
     for the full per-issue report.
 
-Tips:
-  • '--fix' applies safe fixes: frontmatter auto-repair (same fixes as
-    'validate frontmatter --auto-fix', with .bak backups) and anchor migration.
-  • '--fix-anchors' migrates only legacy RelatedFiles paths to explicit anchors
+Fixes ('--fix' runs the safe fixers invalid_frontmatter and legacy_related_file;
+'--only a,b' selects fixers by name, including the opt-in ones; '--dry-run' prints
+the unified diffs instead of writing):
+  • invalid_frontmatter — frontmatter auto-repair (same fixes as
+    'validate frontmatter --auto-fix', with .bak backups).
+  • legacy_related_file — migrates legacy RelatedFiles paths to explicit anchors
     (repo://pkg/foo.go, ws://<member>/<rel> for go.work siblings, docs://..., abs:///...).
     Only entries that resolve to an existing file are rewritten; the rest are left
-    as legacy with an 'anchor_migration_skipped' warning.
+    as legacy with an 'anchor_migration_skipped' warning. '--fix-anchors' runs it alone.
+  • missing_related_file (opt-in) — re-points a RelatedFiles entry whose file is gone to the
+    only file in the repository with the same name (a moved file).
+  • unknown_topic / unknown_doc_type / unknown_status / unknown_intent (opt-in) — replaces an
+    unknown value with the vocabulary slug it is an obvious variant of ('API' → 'api',
+    'bakend' → 'backend'). Values without one clear match are left as they are.
+  Findings a fixer can't fix are reported as '<fixer>_not_fixed' (info).
+
+Tips:
   • Use '--fail-on warning' (or 'error') to make CI fail when issues are detected.
   • '--diagnostics-json path' captures rule results as JSON (use '-' for stdout) for CI/automation.
+  • '--format sarif|junit|checkstyle' writes every finding as a CI report instead of the
+    human report: SARIF results carry file locations (frontmatter findings point at the
+    offending field's line), JUnit has one test case per ticket (errors and warnings fail it).
+    Use '--report-file path' to write it to a file and keep the normal output.
   • '--ignore-glob' is handy for suppressing known noisy paths; the command also reads patterns from
     both repository and docs-root .docmgrignore files.
 
@@ -193,17 +230,29 @@ Examples:
   # Validate all tickets (rollup summary; add --details for everything)
   docmgr doctor --all
 
-  # Apply safe fixes (frontmatter auto-repair + anchor migration)
+  # Preview the safe fixes as a patch, then apply them
+  docmgr doctor --ticket MEN-3475 --fix --dry-run
   docmgr doctor --ticket MEN-3475 --fix
 
+  # Opt into the vocabulary and moved-file fixers (review the patch first)
+  docmgr doctor --all --fix --dry-run --only unknown_topic,missing_related_file
+  docmgr doctor --all --fix --only unknown_topic,missing_related_file
+
   # Tighten staleness and fail CI on warnings
   docmgr doctor --all --stale-after 14 --fail-on warning
 
+  # Flag docs whose related code changed in git since they were updated
+  docmgr doctor --all --stale-code
+
   # Ignore multiple dirs/globs (repeat the flags)
   docmgr doctor --all --ignore-dir archive --ignore-glob "*.bak" --ignore-glob "*.tmp"
 
   # Emit diagnostics JSON for scripts/CI
   docmgr doctor --ticket MEN-3475 --diagnostics-json - --with-glaze-output --output json
+
+  # SARIF for code-scanning dashboards, JUnit for CI test reports
+  docmgr doctor --all --format sarif > docmgr.sarif
+  docmgr doctor --all --format junit --report-file reports/docmgr.xml --fail-on error
 `),
 			cmds.WithFlags(
 				fields.New(
@@ -272,10 +321,22 @@ Examples:
 					fields.WithHelp("Write diagnostics rule output to JSON (file path or '-' for stdout)"),
 					fields.WithDefault(""),
 				),
+				fields.New(
+					"format",
+					fields.TypeString,
+					fields.WithHelp("Write findings as a CI report: sarif|junit|checkstyle (stdout unless --report-file)"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"report-file",
+					fields.TypeString,
+					fields.WithHelp("Write the --format report to this file instead of stdout ('-' for stdout)"),
+					fields.WithDefault(""),
+				),
 				fields.New(
 					"fix",
 					fields.TypeBool,
-					fields.WithHelp("Apply safe fixes: frontmatter auto-repair (creates .bak backups) and anchor migration, then re-validate."),
+					fields.WithHelp("Apply the safe doctor fixers (frontmatter auto-repair with .bak backups, anchor migration), then re-validate. Use --only to opt into the moved-file and vocabulary fixers."),
 					fields.WithDefault(false),
 				),
 				fields.New(
@@ -284,6 +345,18 @@ Examples:
 					fields.WithHelp("Migrate legacy RelatedFiles paths to explicit anchors (repo://, ws://, docs://, abs://). Subset of --fix. Entries that don't resolve to an existing file are left as-is with a warning."),
 					fields.WithDefault(false),
 				),
+				fields.New(
+					"dry-run",
+					fields.TypeBool,
+					fields.WithHelp("With --fix/--fix-anchors: print the fixes as unified diffs instead of writing them."),
+					fields.WithDefault(false),
+				),
+				fields.New(
+					"only",
+					fields.TypeStringList,
+					fields.WithHelp("With --fix: run these fixers instead of the default invalid_frontmatter,legacy_related_file (comma-separated or repeated). Opt-in fixers: missing_related_file, unknown_topic, unknown_doc_type, unknown_status, unknown_intent."),
+					fields.WithDefault([]string{}),
+				),
 				fields.New(
 					"details",
 					fields.TypeBool,
@@ -296,6 +369,12 @@ Examples:
 					fields.WithHelp("Also check documents under sources/ (imported material; skipped by default)."),
 					fields.WithDefault(false),
 				),
+				fields.New(
+					"stale-code",
+					fields.TypeBool,
+					fields.WithHelp("Report docs whose related files have git commits newer than the doc's LastUpdated (runs git log)."),
+					fields.WithDefault(false),
+				),
 			),
 		),
 	}, nil
@@ -314,6 +393,18 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 	// Apply config root if present
 	settings.Root = workspace.ResolveRoot(settings.Root)
 
+	var reportFormat report.Format
+	if strings.TrimSpace(settings.Format) != "" {
+		f, err := report.ParseFormat(settings.Format)
+		if err != nil {
+			return err
+		}
+		reportFormat = f
+	}
+	if (settings.DryRun || len(settings.Only) > 0) && !settings.Fix && !settings.FixAnchors {
+		return fmt.Errorf("--dry-run and --only require --fix (or --fix-anchors)")
+	}
+
 	// Diagnostics renderer: collects for JSON output when requested, and
 	// stays text-silent in rollup mode so multi-ticket runs really are
 	// summary-first (the per-finding text otherwise streams to stderr).
@@ -324,6 +415,9 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 	if settings.DiagnosticsJSON != "" {
 		rendererOpts = append(rendererOpts, docmgr.WithCollector())
 	}
+	if reportFormat != "" {
+		rendererOpts = append(rendererOpts, docmgr.WithTaxonomyCollector())
+	}
 	diagRenderer := docmgr.NewRenderer(rendererOpts...)
 	ctx = docmgr.ContextWithRenderer(ctx, diagRenderer)
 
@@ -370,6 +464,11 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 	}
 	dv := newDoctorVocab(vocab)
 
+	fieldSchema, err := LoadFieldSchema()
+	if err != nil {
+		return fmt.Errorf("failed to load field schema: %w", err)
+	}
+
 	// Single-file mode: validate one explicitly requested doc and return. This
 	// intentionally ignores workspace ignore policy: if the user names a file,
 	// doctor validates that file directly.
@@ -378,13 +477,27 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 		if err != nil {
 			return err
 		}
-		sev, err := validateSingleDoc(ctx, docPath, dv, gp)
+		docTicket := ""
+		if doc, parseErr := readDocumentFrontmatter(docPath); parseErr == nil && doc != nil {
+			docTicket = doc.Ticket
+		}
+		custom, err := docCustomRuleFindings(ctx, nil, settings.Root, docPath)
+		if err != nil {
+			return err
+		}
+		sev, err := validateSingleDoc(docmgr.ContextWithTicket(ctx, docTicket), docPath, dv, fieldSchema, custom, gp)
 		highestSeverity = maxInt(highestSeverity, sev)
 		if diagRenderer != nil && settings.DiagnosticsJSON != "" {
 			if err := writeDiagnosticsJSON(diagRenderer, settings.DiagnosticsJSON); err != nil {
 				return err
 			}
 		}
+		if reportFormat != "" {
+			opts := report.Options{BaseDir: doctorReportBaseDir(""), Tickets: []string{docTicket}}
+			if err := writeDoctorReport(diagRenderer, reportFormat, settings.ReportFile, opts); err != nil {
+				return err
+			}
+		}
 		threshold := severityThreshold(settings.FailOn)
 		if threshold >= 0 && highestSeverity >= threshold && threshold > 0 {
 			return fmt.Errorf("doctor failed: severity >= %s", settings.FailOn)
@@ -451,7 +564,7 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 			return fmt.Errorf("failed to emit doctor row (missing_index) for %s: %w", missing, err)
 		}
 		highestSeverity = maxInt(highestSeverity, 2)
-		docmgr.RenderTaxonomy(ctx, docmgrctx.NewWorkspaceMissingIndex(missing))
+		docmgr.RenderTaxonomy(docmgr.ContextWithTicket(ctx, filepath.Base(missing)), docmgrctx.NewWorkspaceMissingIndex(missing))
 	}
 
 	// Determine scope: default is repo-wide unless --ticket is provided. Ticket
@@ -504,109 +617,55 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 		}
 	}
 
+	// Ticket link checks need every ticket's status, so they look at the whole
+	// workspace even for single-ticket runs; findings are reported per ticket.
+	linkGraph, err := tickets.LoadLinkGraph(ctx, ws)
+	if err != nil {
+		return fmt.Errorf("failed to load ticket links: %w", err)
+	}
+	linkIssues := map[string][]tickets.LinkIssue{}
+	for _, issue := range linkGraph.Check() {
+		linkIssues[issue.Ticket] = append(linkIssues[issue.Ticket], issue)
+	}
+
 	// Group by ticket directory inferred from ttmp layout.
 	tickets := groupDoctorDocsByTicket(settings.Root, filtered)
 	if requestedTicket != "" && !settings.All && len(tickets) == 0 {
 		return fmt.Errorf("doctor checked zero documents for ticket %q", requestedTicket)
 	}
 
-	// Safe fixes (--fix / --fix-anchors): rewrite documents before validation,
-	// then rebuild the index so the checks below see the fixed state.
-	// --fix = frontmatter auto-repair + anchor migration; --fix-anchors is the
-	// anchor-only subset (kept as an alias).
+	// Fixes (--fix / --fix-anchors): plan the fixers for the current findings,
+	// then either report the patches (--dry-run) or write them and rebuild
+	// the index so the checks below see the fixed state. --fix-anchors is the
+	// legacy_related_file fixer alone (kept as an alias).
 	if settings.Fix || settings.FixAnchors {
-		migrated := false
-
-		// Frontmatter auto-repair (--fix only): same safe fixes as
-		// 'validate frontmatter --auto-fix', with .bak backups.
-		if settings.Fix {
-			for _, bucket := range tickets {
-				for _, h := range bucket.Docs {
-					if h.ReadErr == nil {
-						continue
-					}
-					fixed, fixes, fixErr := autoFixDocFrontmatter(h.Path)
-					if !fixed && fixErr == nil {
-						continue // no safe fix available; validation will report it
-					}
-					if fixed {
-						// The file changed on disk either way; re-index below.
-						migrated = true
-					}
-					if fixErr != nil {
-						row := types.NewRow(
-							types.MRP("ticket", bucket.TicketID),
-							types.MRP("issue", "frontmatter_fix_failed"),
-							types.MRP("severity", "warning"),
-							types.MRP("message", fmt.Sprintf("auto-fix did not produce parseable frontmatter: %v", fixErr)),
-							types.MRP("path", h.Path),
-						)
-						if err := gp.AddRow(ctx, row); err != nil {
-							return fmt.Errorf("failed to emit doctor row (frontmatter_fix_failed) for %s: %w", h.Path, err)
-						}
-						highestSeverity = maxInt(highestSeverity, 1)
-						continue
-					}
-					migrated = true
-					row := types.NewRow(
-						types.MRP("ticket", bucket.TicketID),
-						types.MRP("issue", "frontmatter_fixed"),
-						types.MRP("severity", "ok"),
-						types.MRP("message", fmt.Sprintf("auto-fixed frontmatter (%s); backup written to %s.bak", strings.Join(fixes, "; "), filepath.Base(h.Path))),
-						types.MRP("path", h.Path),
-					)
-					if err := gp.AddRow(ctx, row); err != nil {
-						return fmt.Errorf("failed to emit doctor row (frontmatter_fixed) for %s: %w", h.Path, err)
-					}
-				}
-			}
+		only := settings.Only
+		if !settings.Fix && len(only) == 0 {
+			only = []string{FixerLegacyRelatedFile}
 		}
-
-		for _, bucket := range tickets {
-			for _, h := range bucket.Docs {
-				if h.ReadErr != nil || h.Doc == nil {
-					continue
-				}
-				changed, skipped, err := migrateDocAnchors(ws, h.Path)
-				if err != nil {
-					return fmt.Errorf("failed to migrate anchors for %s: %w", h.Path, err)
-				}
-				for _, skip := range skipped {
-					row := types.NewRow(
-						types.MRP("ticket", bucket.TicketID),
-						types.MRP("issue", "anchor_migration_skipped"),
-						types.MRP("severity", "warning"),
-						types.MRP("message", fmt.Sprintf("legacy related file left as-is (does not resolve to an existing file): %s", skip)),
-						types.MRP("path", h.Path),
-					)
-					if err := gp.AddRow(ctx, row); err != nil {
-						return fmt.Errorf("failed to emit doctor row (anchor_migration_skipped) for %s: %w", h.Path, err)
-					}
-					highestSeverity = maxInt(highestSeverity, 1)
-				}
-				if changed > 0 {
-					migrated = true
-					row := types.NewRow(
-						types.MRP("ticket", bucket.TicketID),
-						types.MRP("issue", "anchors_migrated"),
-						types.MRP("severity", "ok"),
-						types.MRP("message", fmt.Sprintf("migrated %d related file path(s) to explicit anchors", changed)),
-						types.MRP("path", h.Path),
-					)
-					if err := gp.AddRow(ctx, row); err != nil {
-						return fmt.Errorf("failed to emit doctor row (anchors_migrated) for %s: %w", h.Path, err)
-					}
-				}
+		plan, err := planDoctorFixes(ctx, ws, tickets, only)
+		if err != nil {
+			return err
+		}
+		if !settings.DryRun {
+			if err := ApplyDoctorFixes(ctx, plan); err != nil {
+				return err
 			}
 		}
-		if migrated {
+		sev, err := emitDoctorFixRows(ctx, plan, settings.DryRun, doctorReportBaseDir(ws.Context().RepoRoot), gp)
+		if err != nil {
+			return err
+		}
+		highestSeverity = maxInt(highestSeverity, sev)
+
+		if !settings.DryRun && len(plan.Files) > 0 {
 			// Re-index and re-query so validations reflect the rewritten docs.
 			if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
-				return fmt.Errorf("failed to rebuild workspace index after anchor migration: %w", err)
+				return fmt.Errorf("failed to rebuild workspace index after fixes: %w", err)
 			}
 			qr, err = ws.QueryDocs(ctx, query)
 			if err != nil {
-				return fmt.Errorf("failed to query docs after anchor migration: %w", err)
+				return fmt.Errorf("failed to query docs after fixes: %w", err)
 			}
 			filtered = qr.Docs
 			if len(settings.IgnoreDirs) > 0 || len(settings.IgnoreGlobs) > 0 {
@@ -635,6 +694,38 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 		if err := gp.AddRow(ctx, row); err != nil {
 			return fmt.Errorf("failed to emit doctor row (no_vocabulary): %w", err)
 		}
+		docmgr.RenderTaxonomy(ctx, docmgrctx.NewDoctorFinding("", "no_vocabulary", core.SeverityInfo, doctorNoVocabularyMessage))
+	}
+
+	// Git-aware staleness (--stale-code): related files with commits newer
+	// than the doc's LastUpdated, keyed by doc path.
+	staleCode := map[string][]stalecode.FileChange{}
+	if settings.StaleCode {
+		reports, err := stalecode.Scan(ctx, ws, stalecode.Options{Ticket: scope.TicketID, IncludeArchived: true})
+		if err != nil {
+			return fmt.Errorf("failed to check related files against git: %w", err)
+		}
+		for _, rep := range reports {
+			staleCode[rep.Path] = rep.Files
+		}
+	}
+
+	// Workspace-defined rules (.docmgr/rules.yaml), evaluated on the doctor's
+	// scope and keyed by doc path. A broken rules file fails the run like an
+	// invalid vocabulary does.
+	customRules, err := customrules.Load(settings.Root)
+	if err != nil {
+		return fmt.Errorf("failed to load doctor rules: %w", err)
+	}
+	customFindings := map[string][]customrules.Finding{}
+	if len(customRules) > 0 {
+		findings, err := customrules.Evaluate(ctx, ws, customRules, query)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate doctor rules: %w", err)
+		}
+		for _, f := range findings {
+			customFindings[f.Path] = append(customFindings[f.Path], f)
+		}
 	}
 
 	// Per-ticket validations. RelatedFiles, vocabulary, and staleness checks
@@ -644,16 +735,19 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 	for _, bucket := range tickets {
 		ticketPath := bucket.TicketDir
 		indexPath := filepath.Join(ticketPath, "index.md")
+		ctx := docmgr.ContextWithTicket(ctx, bucket.TicketID)
 
 		hasIssues := false
-		emit := func(issue string, severity string, message string, path string) error {
-			row := types.NewRow(
+		// emitAt adds a finding row; pos (when known) fills the line and
+		// column columns.
+		emitAt := func(issue string, severity string, message string, path string, pos models.Position) error {
+			row := withRowPosition(types.NewRow(
 				types.MRP("ticket", bucket.TicketID),
 				types.MRP("issue", issue),
 				types.MRP("severity", severity),
 				types.MRP("message", message),
 				types.MRP("path", path),
-			)
+			), pos)
 			if err := gp.AddRow(ctx, row); err != nil {
 				return fmt.Errorf("failed to emit doctor row (%s) for %s: %w", issue, path, err)
 			}
@@ -666,6 +760,18 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 			hasIssues = true
 			return nil
 		}
+		emit := func(issue string, severity string, message string, path string) error {
+			return emitAt(issue, severity, message, path, models.Position{})
+		}
+		// emitFinding is emit for checks without a domain diagnostics
+		// context: a generic doctor taxonomy keeps them in --format reports.
+		emitFinding := func(issue string, severity string, message string, path string) error {
+			if err := emit(issue, severity, message, path); err != nil {
+				return err
+			}
+			docmgr.RenderTaxonomy(ctx, docmgrctx.NewDoctorFinding(path, issue, core.Severity(severity), message))
+			return nil
+		}
 
 		// Check for unique index.md (should only be one per ticket root)
 		indexFiles := findIndexFiles(ticketPath, shouldSkipPath)
@@ -683,12 +789,14 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 				return fmt.Errorf("failed to emit doctor row (multiple_index) for %s: %w", bucket.TicketID, err)
 			}
 			highestSeverity = maxInt(highestSeverity, 1)
+			docmgr.RenderTaxonomy(ctx, docmgrctx.NewDoctorFinding(ticketPath, "multiple_index", core.SeverityWarning, getRowString(row, "message")))
 		}
 
 		// Aggregates across all docs in the ticket.
 		unknownVocab := newDoctorVocabAgg()
 		var newestUpdate time.Time
 		haveTimestamps := false
+		var ticketOwners []string
 
 		for _, h := range bucket.Docs {
 			// Only consider markdown files (index builder is md-only, but keep the guard).
@@ -699,11 +807,8 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 			isRootLevel := filepath.Clean(dir) == filepath.Clean(ticketPath)
 			isIndex := isRootLevel && filepath.Base(h.Path) == "index.md"
 			// Skip root-level control files (no frontmatter by design).
-			if isRootLevel && !isIndex {
-				bn := filepath.Base(h.Path)
-				if bn == "README.md" || bn == "tasks.md" || bn == "changelog.md" {
-					continue
-				}
+			if isDoctorControlDoc(ticketPath, h.Path) {
+				continue
 			}
 
 			// invalid frontmatter (index and non-index alike).
@@ -714,7 +819,7 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 				if parseErr != nil {
 					msgErr = parseErr
 				}
-				if err := emit("invalid_frontmatter", "error", fmt.Sprintf("Failed to parse frontmatter: %v", msgErr), h.Path); err != nil {
+				if err := emitAt("invalid_frontmatter", "error", fmt.Sprintf("Failed to parse frontmatter: %v", msgErr), h.Path, errorPosition(msgErr)); err != nil {
 					return err
 				}
 				renderFrontmatterParseTaxonomy(ctx, msgErr, h.Path)
@@ -724,6 +829,14 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 				continue
 			}
 			doc := h.Doc
+			src := &doctorDocSource{path: h.Path}
+
+			for _, f := range customFindings[h.Path] {
+				if err := emit(f.Rule.ID, string(f.Rule.SeverityOrDefault()), f.Message, h.Path); err != nil {
+					return err
+				}
+				docmgr.RenderTaxonomy(ctx, f.Taxonomy())
+			}
 
 			// Staleness input: track the newest update across the ticket.
 			if !doc.LastUpdated.IsZero() {
@@ -735,6 +848,7 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 
 			// Index-only structural checks (required/optional fields).
 			if isIndex {
+				ticketOwners = doc.Owners
 				if err := doc.Validate(); err != nil {
 					if emitErr := emit("missing_required_fields", "error", err.Error(), h.Path); emitErr != nil {
 						return emitErr
@@ -761,10 +875,10 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 					}{field: "Topics", detail: "missing Topics"})
 				}
 				for _, issue := range optionalIssues {
-					if err := emit("missing_field", "warning", issue.detail, h.Path); err != nil {
+					if err := emitAt("missing_field", "warning", issue.detail, h.Path, src.value(issue.field)); err != nil {
 						return err
 					}
-					docmgr.RenderTaxonomy(ctx, docmgrctx.NewFrontmatterSchema(h.Path, issue.field, issue.detail, core.SeverityWarning))
+					docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewFrontmatterSchema(h.Path, issue.field, issue.detail, core.SeverityWarning), src.value(issue.field)))
 				}
 			}
 
@@ -773,29 +887,37 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 				for _, t := range doc.Topics {
 					if _, ok := dv.topicSet[t]; !ok && t != "" {
 						unknownVocab.add("topics", t)
-						docmgr.RenderTaxonomy(ctx, docmgrctx.NewVocabularyUnknown(h.Path, "Topics", t, dv.topicList))
+						docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewVocabularyUnknown(h.Path, "Topics", t, dv.topicList), src.topic(t)))
 					}
 				}
 				if doc.DocType != "" {
 					if _, ok := dv.docTypeSet[doc.DocType]; !ok {
 						unknownVocab.add("docTypes", doc.DocType)
-						docmgr.RenderTaxonomy(ctx, docmgrctx.NewVocabularyUnknown(h.Path, "DocType", doc.DocType, dv.docTypeList))
+						docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewVocabularyUnknown(h.Path, "DocType", doc.DocType, dv.docTypeList), src.value("DocType")))
 					}
 				}
 				if doc.Intent != "" {
 					if _, ok := dv.intentSet[doc.Intent]; !ok {
 						unknownVocab.add("intent", doc.Intent)
-						docmgr.RenderTaxonomy(ctx, docmgrctx.NewVocabularyUnknown(h.Path, "Intent", doc.Intent, dv.intentList))
+						docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewVocabularyUnknown(h.Path, "Intent", doc.Intent, dv.intentList), src.value("Intent")))
 					}
 				}
 				if doc.Status != "" {
 					if _, ok := dv.statusSet[doc.Status]; !ok {
 						unknownVocab.add("status", doc.Status)
-						docmgr.RenderTaxonomy(ctx, docmgrctx.NewVocabularyUnknown(h.Path, "Status", doc.Status, dv.statusList))
+						docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewVocabularyUnknown(h.Path, "Status", doc.Status, dv.statusList), src.value("Status")))
 					}
 				}
 			}
 
+			// Custom field checks (all docs) against the workspace field schema.
+			for _, issue := range checkCustomFields(doc, fieldSchema) {
+				if err := emitAt(issue.issue, "warning", issue.detail, h.Path, src.value(issue.field)); err != nil {
+					return err
+				}
+				docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewFrontmatterSchema(h.Path, issue.field, issue.detail, core.SeverityWarning), src.value(issue.field)))
+			}
+
 			// RelatedFiles checks (all docs) using a doc-anchored resolver (Spec §7.3).
 			resolver := paths.NewResolver(paths.ResolverOptions{
 				DocsRoot:      ws.Context().Root,
@@ -814,14 +936,20 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 				}
 				n := resolver.Resolve(rf.Path)
 				if !n.Exists {
-					if err := emit("missing_related_file", "warning", fmt.Sprintf("related file not found: %s", rf.Path), h.Path); err != nil {
+					if err := emitAt("missing_related_file", "warning", fmt.Sprintf("related file not found: %s", rf.Path), h.Path, src.relatedFile(rf.Path)); err != nil {
 						return err
 					}
-					docmgr.RenderTaxonomy(ctx, docmgrctx.NewRelatedFileMissing(h.Path, rf.Path, rf.Note))
+					docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewRelatedFileMissing(h.Path, rf.Path, rf.Note), src.relatedFile(rf.Path)))
 				}
 			}
 			if len(missingNotes) > 0 {
-				if err := emit("missing_related_file_note", "warning", fmt.Sprintf("%d related file(s) have no Note: %s", len(missingNotes), summarizeList(missingNotes, 5)), h.Path); err != nil {
+				if err := emitFinding("missing_related_file_note", "warning", fmt.Sprintf("%d related file(s) have no Note: %s", len(missingNotes), summarizeList(missingNotes, 5)), h.Path); err != nil {
+					return err
+				}
+			}
+			for _, f := range staleCode[h.Path] {
+				msg := fmt.Sprintf("%s changed after LastUpdated %s: %s", f.Path, doc.LastUpdated.Format("2006-01-02"), describeFileChange(f))
+				if err := emitFinding("stale_code", "warning", msg, h.Path); err != nil {
 					return err
 				}
 			}
@@ -830,7 +958,7 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 			if !isRootLevel {
 				bn := filepath.Base(h.Path)
 				if !prefixRe.MatchString(bn) {
-					if err := emit("missing_numeric_prefix", "warning", "file without numeric prefix", h.Path); err != nil {
+					if err := emitFinding("missing_numeric_prefix", "warning", "file without numeric prefix", h.Path); err != nil {
 						return err
 					}
 				}
@@ -873,6 +1001,27 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 			}
 		}
 
+		// Inline task attributes: overdue open tasks and assignees that are
+		// not ticket owners.
+		tasksPath := filepath.Join(ticketPath, "tasks.md")
+		if lines, err := tasksmd.ReadFile(tasksPath); err == nil {
+			for _, issue := range checkTaskAttrs(parseTasksFromLines(lines), ticketOwners, time.Now()) {
+				if err := emitFinding(issue.issue, "warning", issue.message, tasksPath); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, issue := range linkIssues[bucket.TicketID] {
+			path := issue.Path
+			if path == "" {
+				path = indexPath
+			}
+			if err := emitFinding(issue.Issue, issue.Severity, issue.Message, path); err != nil {
+				return err
+			}
+		}
+
 		if !hasIssues {
 			row := types.NewRow(
 				types.MRP("ticket", bucket.TicketID),
@@ -893,6 +1042,15 @@ func (c *DoctorCommand) RunIntoGlazeProcessor(
 			return err
 		}
 	}
+	if reportFormat != "" {
+		opts := report.Options{BaseDir: doctorReportBaseDir(ws.Context().RepoRoot)}
+		for _, bucket := range tickets {
+			opts.Tickets = append(opts.Tickets, bucket.TicketID)
+		}
+		if err := writeDoctorReport(diagRenderer, reportFormat, settings.ReportFile, opts); err != nil {
+			return err
+		}
+	}
 	threshold := severityThreshold(settings.FailOn)
 	if threshold >= 0 && highestSeverity >= threshold && threshold > 0 {
 		return fmt.Errorf("doctor failed: severity >= %s", settings.FailOn)
@@ -953,92 +1111,60 @@ func summarizeList(items []string, limit int) string {
 	return fmt.Sprintf("%s, … (%d more)", strings.Join(items[:limit], ", "), len(items)-limit)
 }
 
-// autoFixDocFrontmatter applies the same safe frontmatter fixes as
-// 'validate frontmatter --auto-fix' (quoting unsafe scalars, normalizing
-// delimiters, ...), writing a .bak backup first. It returns whether a fix was
-// written, the list of applied fixes, and an error when the fix was applied
-// but the document still fails to parse.
-func autoFixDocFrontmatter(path string) (bool, []string, error) {
-	raw, err := os.ReadFile(path)
-	if err != nil {
-		return false, nil, err
-	}
-	fixes, fixedContent, err := generateFixes(raw)
-	if err != nil || fixedContent == nil {
-		// No safe fix available; leave the finding to validation.
-		return false, nil, nil
-	}
-	if err := applyAutoFix(path, raw, fixedContent); err != nil {
-		return false, nil, err
-	}
-	if _, _, parseErr := documents.ReadDocumentWithFrontmatter(path); parseErr != nil {
-		return true, fixes, fmt.Errorf("re-parse after auto-fix failed: %w", parseErr)
-	}
-	return true, fixes, nil
+// doctorTaskIssue is one finding about a task's inline attributes.
+type doctorTaskIssue struct {
+	issue   string
+	message string
 }
 
-// migrateDocAnchors rewrites legacy (bare-string) RelatedFiles entries of one
-// document into explicit anchored form (repo://, ws://, docs://, abs://) using
-// the tightest-containing-anchor rule. Entries are only migrated when the
-// legacy resolution finds an existing file; ambiguous/missing entries are left
-// as legacy and reported in skipped. Already-anchored entries are untouched.
-func migrateDocAnchors(ws *workspace.Workspace, docPath string) (int, []string, error) {
-	doc, content, err := documents.ReadDocumentWithFrontmatter(docPath)
-	if err != nil {
-		return 0, nil, err
-	}
-
-	resolver := paths.NewResolver(paths.ResolverOptions{
-		DocsRoot:      ws.Context().Root,
-		DocPath:       docPath,
-		ConfigDir:     ws.Context().ConfigDir,
-		RepoRoot:      ws.Context().RepoRoot,
-		WorkspaceRoot: ws.Context().WorkspaceRoot,
-	})
-
-	changed := 0
-	var skipped []string
-	for i, rf := range doc.RelatedFiles {
-		raw := strings.TrimSpace(rf.Path)
-		if raw == "" {
-			continue
-		}
-		if paths.IsAnchored(raw) {
+// checkTaskAttrs reports open tasks whose due date lies before now
+// (task_overdue) and open tasks assigned to someone missing from the ticket's
+// Owners (task_assignee_not_owner). The assignee check is skipped for tickets
+// without owners.
+func checkTaskAttrs(tasks []parsedTask, owners []string, now time.Time) []doctorTaskIssue {
+	var out []doctorTaskIssue
+	for _, t := range tasks {
+		if t.Checked {
 			continue
 		}
-		n := resolver.Resolve(raw)
-		abs := ""
-		if n.Exists {
-			abs = strings.TrimSpace(n.Abs)
-		}
-		if abs == "" && !filepath.IsAbs(raw) {
-			// Rescue pass for historical doc-relative ../ chains that escape
-			// the repo: the legacy resolver rejects them (containment guard),
-			// but for migration a plain doc-relative join is authoritative.
-			joined := filepath.Clean(filepath.Join(filepath.Dir(docPath), filepath.FromSlash(raw)))
-			if info, statErr := os.Stat(joined); statErr == nil && !info.IsDir() {
-				abs = joined
-			}
+		if t.Attrs.Overdue(now) {
+			out = append(out, doctorTaskIssue{
+				issue:   "task_overdue",
+				message: fmt.Sprintf("task %s %q was due %s", t.DisplayID(), t.Text, t.Attrs.Due),
+			})
 		}
-		if abs == "" {
-			skipped = append(skipped, raw)
+		if len(owners) == 0 {
 			continue
 		}
-		anchored := resolver.AnchoredFor(abs).String()
-		if anchored == "" || anchored == raw {
-			continue
+		for _, a := range t.Attrs.Assignees {
+			if !containsStringFold(owners, a) {
+				out = append(out, doctorTaskIssue{
+					issue:   "task_assignee_not_owner",
+					message: fmt.Sprintf("task %s %q is assigned to @%s, who is not an owner of the ticket (owners: %s)", t.DisplayID(), t.Text, a, strings.Join(owners, ", ")),
+				})
+			}
 		}
-		doc.RelatedFiles[i].Path = anchored
-		changed++
 	}
+	return out
+}
 
-	if changed == 0 {
-		return 0, skipped, nil
+func containsStringFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(strings.TrimSpace(v), s) {
+			return true
+		}
 	}
-	if err := documents.WriteDocumentWithFrontmatter(docPath, doc, content, true); err != nil {
-		return 0, skipped, err
+	return false
+}
+
+// isDoctorControlDoc reports root-level README.md, tasks.md and changelog.md
+// of a ticket: control files without frontmatter by design.
+func isDoctorControlDoc(ticketDir string, path string) bool {
+	if filepath.Clean(filepath.Dir(path)) != filepath.Clean(ticketDir) {
+		return false
 	}
-	return changed, skipped, nil
+	bn := filepath.Base(path)
+	return bn == "README.md" || bn == "tasks.md" || bn == "changelog.md"
 }
 
 type doctorTicketBucket struct {
@@ -1249,6 +1375,122 @@ func missingRequiredFields(doc *models.Document) []string {
 	return fields
 }
 
+// doctorDocSource re-reads a document on first use to locate frontmatter
+// fields; documents from the workspace index carry no positions.
+type doctorDocSource struct {
+	path   string
+	loaded bool
+	doc    *models.Document
+}
+
+func (s *doctorDocSource) parsed() *models.Document {
+	if !s.loaded {
+		s.loaded = true
+		if doc, err := readDocumentFrontmatter(s.path); err == nil {
+			s.doc = doc
+		}
+	}
+	return s.doc
+}
+
+// value returns the position of a field's value (zero when absent).
+func (s *doctorDocSource) value(field string) models.Position {
+	doc := s.parsed()
+	if doc == nil {
+		return models.Position{}
+	}
+	return doc.Positions.Value(field)
+}
+
+// topic returns the position of a Topics element.
+func (s *doctorDocSource) topic(value string) models.Position {
+	doc := s.parsed()
+	if doc == nil {
+		return models.Position{}
+	}
+	for i, t := range doc.Topics {
+		if t == value {
+			return doc.Positions.Item("Topics", i)
+		}
+	}
+	return doc.Positions.Value("Topics")
+}
+
+// relatedFile returns the position of the RelatedFiles entry for path.
+func (s *doctorDocSource) relatedFile(path string) models.Position {
+	doc := s.parsed()
+	if doc == nil {
+		return models.Position{}
+	}
+	for i, rf := range doc.RelatedFiles {
+		if rf.Path == path {
+			return doc.Positions.Item("RelatedFiles", i)
+		}
+	}
+	return doc.Positions.Value("RelatedFiles")
+}
+
+// docCustomRuleFindings evaluates the workspace-defined rules of root
+// against one document. When ws is nil the workspace is discovered and
+// indexed, but only if root defines any rules.
+func docCustomRuleFindings(ctx context.Context, ws *workspace.Workspace, root string, docPath string) ([]customrules.Finding, error) {
+	rules, err := customrules.Load(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load doctor rules: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	if ws == nil {
+		ws, err = workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: root})
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover workspace: %w", err)
+		}
+		if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
+			return nil, fmt.Errorf("failed to initialize workspace index: %w", err)
+		}
+	}
+	findings, err := customrules.Evaluate(ctx, ws, rules, workspace.DocQuery{
+		Scope: workspace.Scope{Kind: workspace.ScopeDoc, DocPath: docPath},
+		Options: workspace.DocQueryOptions{
+			IncludeArchivedPath: true,
+			IncludeScriptsPath:  true,
+			IncludeSourcesPath:  true,
+			IncludeControlDocs:  true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate doctor rules: %w", err)
+	}
+	return findings, nil
+}
+
+// withRowPosition adds line and column columns to a doctor row when the
+// position is known.
+func withRowPosition(row types.Row, p models.Position) types.Row {
+	if p.Line > 0 {
+		row.Set("line", p.Line)
+		row.Set("column", p.Column)
+	}
+	return row
+}
+
+// errorPosition returns the position a frontmatter parse error points at.
+func errorPosition(err error) models.Position {
+	if tax, ok := core.AsTaxonomy(err); ok && tax != nil {
+		if p, ok := tax.Context.(docmgrctx.Positioned); ok {
+			_, line, col := p.Location()
+			return models.Position{Line: line, Column: col}
+		}
+	}
+	return models.Position{}
+}
+
+// atPosition points a taxonomy at a frontmatter position, when known.
+func atPosition(tax *core.Taxonomy, p models.Position) *core.Taxonomy {
+	return docmgrctx.AtPosition(tax, p.Line, p.Column)
+}
+
 func renderFrontmatterParseTaxonomy(ctx context.Context, err error, path string) {
 	if err == nil {
 		return
@@ -1289,6 +1531,48 @@ func writeDiagnosticsJSON(renderer *docmgr.Renderer, destination string) error {
 	return nil
 }
 
+// writeDoctorReport renders the collected taxonomies as a --format report to
+// destination ("" or "-" for stdout).
+func writeDoctorReport(renderer *docmgr.Renderer, format report.Format, destination string, opts report.Options) error {
+	if renderer == nil {
+		return nil
+	}
+	findings := []report.Finding{}
+	for _, c := range renderer.Taxonomies() {
+		findings = append(findings, report.FromTaxonomy(c.Ticket, c.Taxonomy))
+	}
+	data, err := report.Render(format, findings, opts)
+	if err != nil {
+		return fmt.Errorf("failed to render %s report: %w", format, err)
+	}
+	data = append(data, '\n')
+	if destination == "" || destination == "-" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s report to stdout: %w", format, err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(destination), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s report directory: %w", format, err)
+	}
+	if err := os.WriteFile(destination, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s report: %w", format, err)
+	}
+	return nil
+}
+
+// doctorReportBaseDir is the directory report paths are relative to: the
+// repository root when known, else the working directory.
+func doctorReportBaseDir(repoRoot string) string {
+	if repoRoot != "" {
+		return repoRoot
+	}
+	if wd, err := os.Getwd(); err == nil {
+		return wd
+	}
+	return ""
+}
+
 func maxInt(a, b int) int {
 	if a > b {
 		return a
@@ -1322,19 +1606,21 @@ func validateSingleDoc(
 	ctx context.Context,
 	docPath string,
 	dv *doctorVocab,
+	fieldSchema *models.FieldSchema,
+	custom []customrules.Finding,
 	gp glazedMiddlewares.Processor,
 ) (int, error) {
 	highestSeverity := 0
 
 	doc, err := readDocumentFrontmatter(docPath)
 	if err != nil {
-		row := types.NewRow(
+		row := withRowPosition(types.NewRow(
 			types.MRP("ticket", ""),
 			types.MRP("issue", "invalid_frontmatter"),
 			types.MRP("severity", "error"),
 			types.MRP("message", fmt.Sprintf("Failed to parse frontmatter: %v", err)),
 			types.MRP("path", docPath),
-		)
+		), errorPosition(err))
 		if err := gp.AddRow(ctx, row); err != nil {
 			return highestSeverity, fmt.Errorf("failed to emit doctor row (invalid_frontmatter) for %s: %w", docPath, err)
 		}
@@ -1342,6 +1628,7 @@ func validateSingleDoc(
 		renderFrontmatterParseTaxonomy(ctx, err, docPath)
 		return highestSeverity, nil
 	}
+	src := &doctorDocSource{path: docPath, loaded: true, doc: doc}
 
 	// Required fields
 	if err := doc.Validate(); err != nil {
@@ -1373,7 +1660,7 @@ func validateSingleDoc(
 		)
 		_ = gp.AddRow(ctx, row)
 		highestSeverity = maxInt(highestSeverity, 1)
-		docmgr.RenderTaxonomy(ctx, docmgrctx.NewFrontmatterSchema(docPath, "Status", "missing Status", core.SeverityWarning))
+		docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewFrontmatterSchema(docPath, "Status", "missing Status", core.SeverityWarning), src.value("Status")))
 	}
 	if len(doc.Topics) == 0 {
 		row := types.NewRow(
@@ -1385,7 +1672,7 @@ func validateSingleDoc(
 		)
 		_ = gp.AddRow(ctx, row)
 		highestSeverity = maxInt(highestSeverity, 1)
-		docmgr.RenderTaxonomy(ctx, docmgrctx.NewFrontmatterSchema(docPath, "Topics", "missing Topics", core.SeverityWarning))
+		docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewFrontmatterSchema(docPath, "Topics", "missing Topics", core.SeverityWarning), src.value("Topics")))
 	}
 
 	// Vocabulary checks. When no vocabulary file exists, emit one info-level
@@ -1408,63 +1695,95 @@ func validateSingleDoc(
 				}
 			}
 			if len(unknownTopics) > 0 {
-				row := types.NewRow(
+				row := withRowPosition(types.NewRow(
 					types.MRP("ticket", doc.Ticket),
 					types.MRP("issue", "unknown_topics"),
 					types.MRP("severity", "warning"),
 					types.MRP("message", fmt.Sprintf("unknown topics: %v; %s", unknownTopics, vocabRemediation("topics"))),
 					types.MRP("path", docPath),
-				)
+				), src.topic(unknownTopics[0]))
 				_ = gp.AddRow(ctx, row)
 				highestSeverity = maxInt(highestSeverity, 1)
-				docmgr.RenderTaxonomy(ctx, docmgrctx.NewVocabularyUnknown(docPath, "Topics", strings.Join(unknownTopics, ","), dv.topicList))
+				docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewVocabularyUnknown(docPath, "Topics", strings.Join(unknownTopics, ","), dv.topicList), src.topic(unknownTopics[0])))
 			}
 		}
 		if doc.DocType != "" {
 			if _, ok := dv.docTypeSet[doc.DocType]; !ok {
-				row := types.NewRow(
+				row := withRowPosition(types.NewRow(
 					types.MRP("ticket", doc.Ticket),
 					types.MRP("issue", "unknown_doc_type"),
 					types.MRP("severity", "warning"),
 					types.MRP("message", fmt.Sprintf("unknown docType: %s; %s", doc.DocType, vocabRemediation("docTypes"))),
 					types.MRP("path", docPath),
-				)
+				), src.value("DocType"))
 				_ = gp.AddRow(ctx, row)
 				highestSeverity = maxInt(highestSeverity, 1)
-				docmgr.RenderTaxonomy(ctx, docmgrctx.NewVocabularyUnknown(docPath, "DocType", doc.DocType, dv.docTypeList))
+				docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewVocabularyUnknown(docPath, "DocType", doc.DocType, dv.docTypeList), src.value("DocType")))
 			}
 		}
 		if doc.Intent != "" {
 			if _, ok := dv.intentSet[doc.Intent]; !ok {
-				row := types.NewRow(
+				row := withRowPosition(types.NewRow(
 					types.MRP("ticket", doc.Ticket),
 					types.MRP("issue", "unknown_intent"),
 					types.MRP("severity", "warning"),
 					types.MRP("message", fmt.Sprintf("unknown intent: %s; %s", doc.Intent, vocabRemediation("intent"))),
 					types.MRP("path", docPath),
-				)
+				), src.value("Intent"))
 				_ = gp.AddRow(ctx, row)
 				highestSeverity = maxInt(highestSeverity, 1)
-				docmgr.RenderTaxonomy(ctx, docmgrctx.NewVocabularyUnknown(docPath, "Intent", doc.Intent, dv.intentList))
+				docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewVocabularyUnknown(docPath, "Intent", doc.Intent, dv.intentList), src.value("Intent")))
 			}
 		}
 		if doc.Status != "" {
 			if _, ok := dv.statusSet[doc.Status]; !ok {
 				statusValidText := strings.Join(dv.statusList, ", ")
-				row := types.NewRow(
+				row := withRowPosition(types.NewRow(
 					types.MRP("ticket", doc.Ticket),
 					types.MRP("issue", "unknown_status"),
 					types.MRP("severity", "warning"),
 					types.MRP("message", fmt.Sprintf("unknown status: %s (valid values: %s; list via 'docmgr vocab list --category status'); %s", doc.Status, statusValidText, vocabRemediation("status"))),
 					types.MRP("path", docPath),
-				)
+				), src.value("Status"))
 				_ = gp.AddRow(ctx, row)
 				highestSeverity = maxInt(highestSeverity, 1)
-				docmgr.RenderTaxonomy(ctx, docmgrctx.NewVocabularyUnknown(docPath, "Status", doc.Status, dv.statusList))
+				docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewVocabularyUnknown(docPath, "Status", doc.Status, dv.statusList), src.value("Status")))
 			}
 		}
 	}
 
+	for _, issue := range checkCustomFields(doc, fieldSchema) {
+		row := withRowPosition(types.NewRow(
+			types.MRP("ticket", doc.Ticket),
+			types.MRP("issue", issue.issue),
+			types.MRP("severity", "warning"),
+			types.MRP("message", issue.detail),
+			types.MRP("path", docPath),
+		), src.value(issue.field))
+		_ = gp.AddRow(ctx, row)
+		highestSeverity = maxInt(highestSeverity, 1)
+		docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewFrontmatterSchema(docPath, issue.field, issue.detail, core.SeverityWarning), src.value(issue.field)))
+	}
+
+	// Workspace-defined rules (.docmgr/rules.yaml) matching this doc.
+	for _, f := range custom {
+		severity := f.Rule.SeverityOrDefault()
+		row := types.NewRow(
+			types.MRP("ticket", doc.Ticket),
+			types.MRP("issue", f.Rule.ID),
+			types.MRP("severity", string(severity)),
+			types.MRP("message", f.Message),
+			types.MRP("path", docPath),
+		)
+		_ = gp.AddRow(ctx, row)
+		if severity == core.SeverityError {
+			highestSeverity = maxInt(highestSeverity, 2)
+		} else if severity == core.SeverityWarning {
+			highestSeverity = maxInt(highestSeverity, 1)
+		}
+		docmgr.RenderTaxonomy(ctx, f.Taxonomy())
+	}
+
 	// Success row
 	if highestSeverity == 0 {
 		row := types.NewRow(
@@ -1530,8 +1849,15 @@ func (c *DoctorCommand) Run(
 	if err := c.RunIntoGlazeProcessor(ctx, parsedValues, collector); err != nil {
 		return err
 	}
+	// A --format report on stdout replaces the human report.
+	if strings.TrimSpace(settings.Format) != "" && (settings.ReportFile == "" || settings.ReportFile == "-") {
+		return nil
+	}
 
 	rows := collector.rows
+	if settings.DryRun {
+		printDoctorFixDiffs(rows)
+	}
 	if len(rows) == 0 {
 		fmt.Println("No tickets checked.")
 		return nil
@@ -1568,7 +1894,7 @@ func (c *DoctorCommand) Run(
 				issue := getRowString(row, "issue")
 				severity := strings.ToUpper(getRowString(row, "severity"))
 				message := getRowString(row, "message")
-				path := getRowString(row, "path")
+				path := docmgrctx.FormatLocation(getRowString(row, "path"), getRowInt(row, "line"), getRowInt(row, "column"))
 
 				if issue == "none" && severity == "OK" {
 					fmt.Fprintf(&b, "- ✅ %s\n", message)
@@ -1776,4 +2102,13 @@ func getRowString(row types.Row, field string) string {
 	return ""
 }
 
+func getRowInt(row types.Row, field string) int {
+	if val, ok := row.Get(field); ok {
+		if n, ok := val.(int); ok {
+			return n
+		}
+	}
+	return 0
+}
+
 var _ cmds.BareCommand = &DoctorCommand{}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/customrules"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgr"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgrctx"
+	"github.com/go-go-golems/docmgr/pkg/models"
+)
+
+// DoctorDocTaxonomies runs the doctor checks for a single document and returns
+// their diagnostics instead of printing them: the `doctor --doc` checks
+// (frontmatter, required fields, vocabulary, custom fields, and the
+// workspace rules in .docmgr/rules.yaml) plus the RelatedFiles existence
+// check doctor runs for ticket docs. ws supplies the index the rules query
+// and the roots for resolving related files; when nil both are skipped.
+//
+// It is the entry point for editors (`docmgr lsp` publishes the result on save).
+func DoctorDocTaxonomies(ctx context.Context, ws *workspace.Workspace, docPath string) ([]*core.Taxonomy, error) {
+	vocab, err := LoadVocabulary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vocabulary: %w", err)
+	}
+	fieldSchema, err := LoadFieldSchema()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load field schema: %w", err)
+	}
+	var custom []customrules.Finding
+	if ws != nil {
+		custom, err = docCustomRuleFindings(ctx, ws, ws.Context().Root, docPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return doctorDocTaxonomies(ctx, ws, docPath, newDoctorVocab(vocab), fieldSchema, custom)
+}
+
+// doctorDocTaxonomies is DoctorDocTaxonomies with the vocabulary, schema and
+// rule findings already loaded (the fix planner runs it for every doc).
+func doctorDocTaxonomies(ctx context.Context, ws *workspace.Workspace, docPath string, dv *doctorVocab, fieldSchema *models.FieldSchema, custom []customrules.Finding) ([]*core.Taxonomy, error) {
+	renderer := docmgr.NewRenderer(docmgr.WithoutText(), docmgr.WithTaxonomyCollector())
+	ctx = docmgr.ContextWithRenderer(ctx, renderer)
+	if _, err := validateSingleDoc(ctx, docPath, dv, fieldSchema, custom, &doctorRowCollector{}); err != nil {
+		return nil, err
+	}
+
+	if ws != nil {
+		if doc, err := readDocumentFrontmatter(docPath); err == nil {
+			src := &doctorDocSource{path: docPath, loaded: true, doc: doc}
+			resolver := doctorResolver(ws, docPath)
+			for _, rf := range doc.RelatedFiles {
+				if strings.TrimSpace(rf.Path) == "" || resolver.Resolve(rf.Path).Exists {
+					continue
+				}
+				docmgr.RenderTaxonomy(ctx, atPosition(docmgrctx.NewRelatedFileMissing(docPath, rf.Path, rf.Note), src.relatedFile(rf.Path)))
+			}
+		}
+	}
+
+	out := make([]*core.Taxonomy, 0, len(renderer.Taxonomies()))
+	for _, c := range renderer.Taxonomies() {
+		out = append(out, c.Taxonomy)
+	}
+	return out, nil
+}
@@ -0,0 +1,579 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/hooks"
+	"github.com/go-go-golems/docmgr/internal/paths"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgr"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgrctx"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/fixes"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/rules"
+	"github.com/go-go-golems/docmgr/pkg/models"
+	glazedMiddlewares "github.com/go-go-golems/glazed/pkg/middlewares"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+// Doctor fixer names, as accepted by 'docmgr doctor --fix --only'.
+const (
+	FixerInvalidFrontmatter = "invalid_frontmatter"
+	FixerLegacyRelatedFile  = "legacy_related_file"
+	FixerMissingRelatedFile = "missing_related_file"
+	FixerUnknownTopic       = "unknown_topic"
+	FixerUnknownDocType     = "unknown_doc_type"
+	FixerUnknownStatus      = "unknown_status"
+	FixerUnknownIntent      = "unknown_intent"
+)
+
+// DefaultDoctorFixers names the fixers plain 'doctor --fix' runs: repairs
+// that keep the document's meaning. The moved-file and vocabulary fixers
+// guess at what the author meant and run only when named with --only.
+func DefaultDoctorFixers() []string {
+	return []string{FixerInvalidFrontmatter, FixerLegacyRelatedFile}
+}
+
+// doctorFixIssues keeps the row issues doctor reported for its original
+// inline fixes (frontmatter repair, anchor migration); other fixers report
+// <fixer>_fixed and <fixer>_not_fixed.
+var doctorFixIssues = map[string]struct {
+	fixed, skipped string
+	skipSeverity   core.Severity
+}{
+	FixerInvalidFrontmatter: {"frontmatter_fixed", "frontmatter_fix_failed", core.SeverityWarning},
+	FixerLegacyRelatedFile:  {"anchors_migrated", "anchor_migration_skipped", core.SeverityWarning},
+}
+
+func doctorFixedIssue(fixer string) string {
+	if issues, ok := doctorFixIssues[fixer]; ok {
+		return issues.fixed
+	}
+	return fixer + "_fixed"
+}
+
+func doctorFixSkippedIssue(fixer string) (string, core.Severity) {
+	if issues, ok := doctorFixIssues[fixer]; ok {
+		return issues.skipped, issues.skipSeverity
+	}
+	return fixer + "_not_fixed", core.SeverityInfo
+}
+
+// DoctorFixOptions scopes PlanDoctorFixes.
+type DoctorFixOptions struct {
+	// TicketID limits the plan to one ticket; empty plans every ticket.
+	TicketID string
+	// Only selects fixers by name; empty selects DefaultDoctorFixers.
+	Only []string
+	// IncludeSources also fixes documents under sources/.
+	IncludeSources bool
+}
+
+// DoctorFixers returns the doctor's fixers for a workspace, in the order
+// they are tried for a finding.
+func DoctorFixers(ws *workspace.Workspace) *rules.Registry {
+	reg := rules.NewRegistry()
+	reg.RegisterFixer(frontmatterFixer{})
+	reg.RegisterFixer(&legacyRelatedFileFixer{ws: ws})
+	reg.RegisterFixer(&missingRelatedFileFixer{ws: ws})
+	reg.RegisterFixer(vocabularyFixer{name: FixerUnknownTopic, field: "Topics", category: "topics"})
+	reg.RegisterFixer(vocabularyFixer{name: FixerUnknownDocType, field: "DocType", category: "docTypes"})
+	reg.RegisterFixer(vocabularyFixer{name: FixerUnknownStatus, field: "Status", category: "status"})
+	reg.RegisterFixer(vocabularyFixer{name: FixerUnknownIntent, field: "Intent", category: "intent"})
+	return reg
+}
+
+// PlanDoctorFixes runs the doctor checks on the indexed docs in scope and
+// plans the fixes for their findings. Nothing is written; call Apply on the
+// plan (or fixes.Apply per file) to write it.
+func PlanDoctorFixes(ctx context.Context, ws *workspace.Workspace, opts DoctorFixOptions) (*fixes.Plan, error) {
+	scope := workspace.Scope{Kind: workspace.ScopeRepo}
+	if opts.TicketID != "" {
+		scope = workspace.Scope{Kind: workspace.ScopeTicket, TicketID: opts.TicketID}
+	}
+	qr, err := ws.QueryDocs(ctx, workspace.DocQuery{
+		Scope: scope,
+		Options: workspace.DocQueryOptions{
+			IncludeErrors:       true,
+			IncludeArchivedPath: true,
+			IncludeScriptsPath:  true,
+			IncludeSourcesPath:  opts.IncludeSources,
+			IncludeControlDocs:  true,
+			OrderBy:             workspace.OrderByPath,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query docs: %w", err)
+	}
+	return planDoctorFixes(ctx, ws, groupDoctorDocsByTicket(ws.Context().Root, qr.Docs), opts.Only)
+}
+
+// ApplyDoctorFixes writes a plan file by file and fires the doc.updated hook
+// for each rewritten document.
+func ApplyDoctorFixes(ctx context.Context, plan *fixes.Plan) error {
+	for _, f := range plan.Files {
+		if err := fixes.Apply(f); err != nil {
+			return err
+		}
+		fireHook(ctx, hooks.EventDocUpdated, f.Ticket, f.Path, map[string]any{"fixers": f.Fixers()})
+	}
+	return nil
+}
+
+// planDoctorFixes collects the findings of every doc in the buckets and
+// plans the selected fixers for them. Legacy RelatedFiles paths are not a
+// doctor finding on their own; they are added here for anchor migration.
+func planDoctorFixes(ctx context.Context, ws *workspace.Workspace, buckets []doctorTicketBucket, only []string) (*fixes.Plan, error) {
+	if len(only) == 0 {
+		only = DefaultDoctorFixers()
+	}
+	selected, err := fixes.Select(DoctorFixers(ws).Fixers(), only)
+	if err != nil {
+		return nil, err
+	}
+	vocab, err := LoadVocabulary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vocabulary: %w", err)
+	}
+	fieldSchema, err := LoadFieldSchema()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load field schema: %w", err)
+	}
+	dv := newDoctorVocab(vocab)
+
+	var found []docmgr.Collected
+	for _, bucket := range buckets {
+		for _, h := range bucket.Docs {
+			if isDoctorControlDoc(bucket.TicketDir, h.Path) {
+				continue
+			}
+			if h.ReadErr == nil && h.Doc != nil {
+				resolver := doctorResolver(ws, h.Path)
+				for _, rf := range h.Doc.RelatedFiles {
+					raw := strings.TrimSpace(rf.Path)
+					if raw == "" || paths.IsAnchored(raw) {
+						continue
+					}
+					tax := docmgrctx.NewRelatedFileLegacy(h.Path, raw, rf.Note, resolver.Resolve(raw).Exists)
+					found = append(found, docmgr.Collected{Ticket: bucket.TicketID, Taxonomy: tax})
+				}
+			}
+			taxes, err := doctorDocTaxonomies(ctx, ws, h.Path, dv, fieldSchema, nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range taxes {
+				found = append(found, docmgr.Collected{Ticket: bucket.TicketID, Taxonomy: t})
+			}
+		}
+	}
+	return fixes.Build(ctx, selected, found)
+}
+
+func doctorResolver(ws *workspace.Workspace, docPath string) *paths.Resolver {
+	return paths.NewResolver(paths.ResolverOptions{
+		DocsRoot:      ws.Context().Root,
+		DocPath:       docPath,
+		ConfigDir:     ws.Context().ConfigDir,
+		RepoRoot:      ws.Context().RepoRoot,
+		WorkspaceRoot: ws.Context().WorkspaceRoot,
+	})
+}
+
+// rewriteFrontmatter parses content, lets edit change the document, and
+// renders it back in docmgr's canonical frontmatter form.
+func rewriteFrontmatter(file string, content []byte, edit func(doc *models.Document) (string, error)) (*rules.Fix, error) {
+	doc, body, err := documents.ParseDocumentWithFrontmatter(file, content)
+	if err != nil {
+		return nil, rules.NoFix("frontmatter does not parse: %v", err)
+	}
+	// The parsed body keeps the blank line after the closing delimiter, which
+	// rendering adds back.
+	body = strings.TrimPrefix(body, "\n")
+	summary, err := edit(doc)
+	if err != nil || summary == "" {
+		return nil, err
+	}
+	out, err := documents.RenderDocumentWithFrontmatter(doc, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render frontmatter: %w", err)
+	}
+	return &rules.Fix{Content: out, Summary: summary}, nil
+}
+
+// frontmatterFixer applies the 'validate frontmatter --auto-fix' repairs
+// (quoting unsafe scalars, normalizing delimiters, ...) with a .bak backup.
+type frontmatterFixer struct{}
+
+func (frontmatterFixer) Name() string { return FixerInvalidFrontmatter }
+
+func (frontmatterFixer) Match(t *core.Taxonomy) bool {
+	return t.Stage == docmgrctx.StageFrontmatterParse && t.Symptom == docmgrctx.SymptomYAMLSyntax
+}
+
+func (frontmatterFixer) Fix(_ context.Context, _ *core.Taxonomy, file string, content []byte) (*rules.Fix, error) {
+	applied, fixed, err := generateFixes(content)
+	if err != nil || fixed == nil {
+		// No safe fix available; validation reports the finding.
+		return nil, nil
+	}
+	if _, _, parseErr := documents.ParseDocumentWithFrontmatter(file, fixed); parseErr != nil {
+		return nil, rules.NoFix("auto-fix did not produce parseable frontmatter: %v", parseErr)
+	}
+	return &rules.Fix{Content: fixed, Summary: strings.Join(applied, "; "), Backup: true}, nil
+}
+
+// legacyRelatedFileFixer migrates a legacy RelatedFiles path to an explicit
+// anchor (repo://, ws://, docs://, abs://) using the tightest containing
+// anchor. Only entries that resolve to an existing file are rewritten.
+type legacyRelatedFileFixer struct {
+	ws *workspace.Workspace
+}
+
+func (f *legacyRelatedFileFixer) Name() string { return FixerLegacyRelatedFile }
+
+func (f *legacyRelatedFileFixer) Match(t *core.Taxonomy) bool {
+	return t.Stage == docmgrctx.StageDocLink && t.Symptom == docmgrctx.SymptomLegacyPath
+}
+
+func (f *legacyRelatedFileFixer) Fix(_ context.Context, t *core.Taxonomy, file string, content []byte) (*rules.Fix, error) {
+	c, ok := t.Context.(*docmgrctx.RelatedFileContext)
+	if !ok {
+		return nil, nil
+	}
+	resolver := doctorResolver(f.ws, file)
+	return rewriteFrontmatter(file, content, func(doc *models.Document) (string, error) {
+		for i, rf := range doc.RelatedFiles {
+			raw := strings.TrimSpace(rf.Path)
+			if raw != c.FilePath {
+				continue
+			}
+			abs := ""
+			if n := resolver.Resolve(raw); n.Exists {
+				abs = strings.TrimSpace(n.Abs)
+			}
+			if abs == "" && !filepath.IsAbs(raw) {
+				// Rescue pass for historical doc-relative ../ chains that
+				// escape the repo: the legacy resolver rejects them
+				// (containment guard), but for migration a plain
+				// doc-relative join is authoritative.
+				joined := filepath.Clean(filepath.Join(filepath.Dir(file), filepath.FromSlash(raw)))
+				if info, statErr := os.Stat(joined); statErr == nil && !info.IsDir() {
+					abs = joined
+				}
+			}
+			if abs == "" {
+				return "", rules.NoFix("legacy related file left as-is (does not resolve to an existing file): %s", raw)
+			}
+			anchored := resolver.AnchoredFor(abs).String()
+			if anchored == "" || anchored == raw {
+				return "", nil
+			}
+			doc.RelatedFiles[i].Path = anchored
+			return fmt.Sprintf("%s -> %s", raw, anchored), nil
+		}
+		return "", nil
+	})
+}
+
+// missingRelatedFileFixer re-points a RelatedFiles entry whose file is gone
+// to the only file in the repository with the same name (the usual result of
+// a move). Entries with no or several candidates are left for a human.
+type missingRelatedFileFixer struct {
+	ws *workspace.Workspace
+	// byName indexes repository files by base name; built on first use.
+	byName map[string][]string
+}
+
+func (f *missingRelatedFileFixer) Name() string { return FixerMissingRelatedFile }
+
+func (f *missingRelatedFileFixer) Match(t *core.Taxonomy) bool {
+	return t.Stage == docmgrctx.StageDocLink && t.Symptom == docmgrctx.SymptomMissingFile
+}
+
+func (f *missingRelatedFileFixer) Fix(_ context.Context, t *core.Taxonomy, file string, content []byte) (*rules.Fix, error) {
+	c, ok := t.Context.(*docmgrctx.RelatedFileContext)
+	if !ok {
+		return nil, nil
+	}
+	name := path.Base(strings.TrimSpace(c.FilePath))
+	if name == "" || name == "." || name == "/" {
+		return nil, nil
+	}
+	candidates, err := f.filesNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, rules.NoFix("no file named %s in the repository; restore it or remove the entry with 'docmgr doc relate --remove-files'", name)
+	}
+	if len(candidates) > 1 {
+		return nil, rules.NoFix("%d files named %s in the repository; update the entry with 'docmgr doc relate'", len(candidates), name)
+	}
+
+	anchored := doctorResolver(f.ws, file).AnchoredFor(candidates[0]).String()
+	if anchored == "" {
+		return nil, nil
+	}
+	return rewriteFrontmatter(file, content, func(doc *models.Document) (string, error) {
+		for i, rf := range doc.RelatedFiles {
+			if strings.TrimSpace(rf.Path) == c.FilePath {
+				doc.RelatedFiles[i].Path = anchored
+				return fmt.Sprintf("%s -> %s", c.FilePath, anchored), nil
+			}
+		}
+		return "", nil
+	})
+}
+
+// filesNamed returns the repository files with the given base name, skipping
+// hidden, vendor and node_modules directories.
+func (f *missingRelatedFileFixer) filesNamed(name string) ([]string, error) {
+	if f.byName == nil {
+		root := f.ws.Context().RepoRoot
+		if root == "" {
+			return nil, rules.NoFix("repository root unknown; cannot search for a moved file")
+		}
+		f.byName = map[string][]string{}
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			base := d.Name()
+			if d.IsDir() {
+				if p != root && (strings.HasPrefix(base, ".") || base == "vendor" || base == "node_modules") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			f.byName[base] = append(f.byName[base], p)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan repository for moved files: %w", err)
+		}
+	}
+	return f.byName[name], nil
+}
+
+// vocabularyFixer replaces an unknown vocabulary value with the known slug it
+// is an obvious variant of: the same slug in another case or separator
+// style, or a typo a couple of characters away. Values without one clear
+// match are left for 'docmgr vocab add'.
+type vocabularyFixer struct {
+	name     string
+	field    string
+	category string
+}
+
+func (f vocabularyFixer) Name() string { return f.name }
+
+func (f vocabularyFixer) Match(t *core.Taxonomy) bool {
+	if t.Stage != docmgrctx.StageVocabulary || t.Symptom != docmgrctx.SymptomUnknownValue {
+		return false
+	}
+	c, ok := t.Context.(*docmgrctx.VocabularyContext)
+	return ok && strings.EqualFold(c.Field, f.field)
+}
+
+func (f vocabularyFixer) Fix(_ context.Context, t *core.Taxonomy, file string, content []byte) (*rules.Fix, error) {
+	c, ok := t.Context.(*docmgrctx.VocabularyContext)
+	if !ok {
+		return nil, nil
+	}
+	replacements := map[string]string{}
+	var summaries, unmatched []string
+	for _, value := range strings.Split(c.Value, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if slug, ok := closestVocabSlug(value, c.Known); ok {
+			replacements[value] = slug
+			summaries = append(summaries, fmt.Sprintf("%s: %s -> %s", f.field, value, slug))
+		} else {
+			unmatched = append(unmatched, value)
+		}
+	}
+	if len(replacements) == 0 {
+		return nil, rules.NoFix("no close %s match for %s; %s", f.category, strings.Join(unmatched, ", "), vocabRemediation(f.category))
+	}
+
+	return rewriteFrontmatter(file, content, func(doc *models.Document) (string, error) {
+		if f.field == "Topics" {
+			var topics []string
+			for _, topic := range doc.Topics {
+				if slug, ok := replacements[topic]; ok {
+					topic = slug
+				}
+				if !containsString(topics, topic) {
+					topics = append(topics, topic)
+				}
+			}
+			doc.Topics = topics
+		} else if f.field == "DocType" {
+			doc.DocType = replaceValue(doc.DocType, replacements)
+		} else if f.field == "Status" {
+			doc.Status = replaceValue(doc.Status, replacements)
+		} else if f.field == "Intent" {
+			doc.Intent = replaceValue(doc.Intent, replacements)
+		}
+		return strings.Join(summaries, "; "), nil
+	})
+}
+
+func replaceValue(value string, replacements map[string]string) string {
+	if slug, ok := replacements[value]; ok {
+		return slug
+	}
+	return value
+}
+
+// closestVocabSlug returns the known slug value is an obvious variant of:
+// equal after normalizing case and separators, or within a small edit
+// distance (1 for values of 4-5 characters, 2 from 6) of exactly one slug.
+func closestVocabSlug(value string, known []string) (string, bool) {
+	norm := normalizeVocabSlug(value)
+	best, bestDist, tie := "", -1, false
+	for _, slug := range known {
+		candidate := normalizeVocabSlug(slug)
+		if candidate == norm {
+			return slug, true
+		}
+		d := editDistance(norm, candidate)
+		if bestDist < 0 || d < bestDist {
+			best, bestDist, tie = slug, d, false
+		} else if d == bestDist {
+			tie = true
+		}
+	}
+	limit := 0
+	if len(norm) >= 6 {
+		limit = 2
+	} else if len(norm) >= 4 {
+		limit = 1
+	}
+	if bestDist < 0 || bestDist > limit || tie {
+		return "", false
+	}
+	return best, true
+}
+
+func normalizeVocabSlug(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.NewReplacer("_", "-", " ", "-").Replace(s)
+}
+
+// editDistance is the Levenshtein distance between a and b (bytes).
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// emitDoctorFixRows reports a fix plan as doctor rows: one row per file and
+// fixer that changed it (one fix_available row per file, with its unified
+// diff, on --dry-run) and one row per finding a fixer could not fix. It
+// returns the highest severity emitted.
+func emitDoctorFixRows(ctx context.Context, plan *fixes.Plan, dryRun bool, baseDir string, gp glazedMiddlewares.Processor) (int, error) {
+	highestSeverity := 0
+	for _, f := range plan.Files {
+		if dryRun {
+			var summaries []string
+			for _, c := range f.Changes {
+				summaries = append(summaries, c.Summary)
+			}
+			row := types.NewRow(
+				types.MRP("ticket", f.Ticket),
+				types.MRP("issue", "fix_available"),
+				types.MRP("severity", "info"),
+				types.MRP("message", fmt.Sprintf("would apply %s: %s", strings.Join(f.Fixers(), ", "), strings.Join(summaries, "; "))),
+				types.MRP("path", f.Path),
+				types.MRP("fixer", strings.Join(f.Fixers(), ",")),
+				types.MRP("diff", f.Diff(baseDir)),
+			)
+			if err := gp.AddRow(ctx, row); err != nil {
+				return highestSeverity, fmt.Errorf("failed to emit doctor row (fix_available) for %s: %w", f.Path, err)
+			}
+			continue
+		}
+		for _, fixer := range f.Fixers() {
+			message := strings.Join(f.Summaries(fixer), "; ")
+			if f.Backup && fixer == FixerInvalidFrontmatter {
+				message = fmt.Sprintf("auto-fixed frontmatter (%s); backup written to %s.bak", message, filepath.Base(f.Path))
+			}
+			issue := doctorFixedIssue(fixer)
+			row := types.NewRow(
+				types.MRP("ticket", f.Ticket),
+				types.MRP("issue", issue),
+				types.MRP("severity", "ok"),
+				types.MRP("message", message),
+				types.MRP("path", f.Path),
+				types.MRP("fixer", fixer),
+			)
+			if err := gp.AddRow(ctx, row); err != nil {
+				return highestSeverity, fmt.Errorf("failed to emit doctor row (%s) for %s: %w", issue, f.Path, err)
+			}
+		}
+	}
+
+	for _, s := range plan.Skipped {
+		issue, severity := doctorFixSkippedIssue(s.Fixer)
+		row := types.NewRow(
+			types.MRP("ticket", s.Ticket),
+			types.MRP("issue", issue),
+			types.MRP("severity", string(severity)),
+			types.MRP("message", s.Reason),
+			types.MRP("path", s.Path),
+			types.MRP("fixer", s.Fixer),
+		)
+		if err := gp.AddRow(ctx, row); err != nil {
+			return highestSeverity, fmt.Errorf("failed to emit doctor row (%s) for %s: %w", issue, s.Path, err)
+		}
+		if severity == core.SeverityWarning {
+			highestSeverity = maxInt(highestSeverity, 1)
+		}
+		docmgr.RenderTaxonomy(docmgr.ContextWithTicket(ctx, s.Ticket), docmgrctx.NewDoctorFinding(s.Path, issue, severity, s.Reason))
+	}
+	return highestSeverity, nil
+}
+
+// printDoctorFixDiffs prints the patches of --fix --dry-run rows.
+func printDoctorFixDiffs(rows []types.Row) {
+	var diffs []string
+	for _, row := range rows {
+		if getRowString(row, "issue") == "fix_available" {
+			diffs = append(diffs, getRowString(row, "diff"))
+		}
+	}
+	if len(diffs) == 0 {
+		fmt.Println("No fixes to apply.")
+		fmt.Println()
+		return
+	}
+	for _, d := range diffs {
+		fmt.Print(d)
+	}
+	fmt.Printf("\n%d file(s) would change; re-run without --dry-run to apply.\n\n", len(diffs))
+}
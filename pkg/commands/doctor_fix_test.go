@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+const doctorFixIndex = `---
+Title: Fixes
+Ticket: FIX-1
+Status: active
+Topics:
+    - Backend
+    - websockets
+    - frontend
+DocType: index
+Intent: long-term
+Owners: []
+RelatedFiles:
+    - Path: repo://old/server.go
+      Note: moved
+LastUpdated: 2026-01-05T00:00:00Z
+---
+
+# Fixes
+`
+
+// setupDoctorFixRepo builds a repo with one ticket whose index has fixable
+// topics and a related file that moved, and chdirs into it. Tests using it
+// must not call t.Parallel.
+func setupDoctorFixRepo(t *testing.T) string {
+	t.Helper()
+	repo := t.TempDir()
+	writeDoctorTestFile(t, filepath.Join(repo, "go.mod"), "module example.com/fixtest\n\ngo 1.23\n")
+	writeDoctorTestFile(t, filepath.Join(repo, ".ttmp.yaml"), "root: ttmp\n")
+	writeDoctorTestFile(t, filepath.Join(repo, "backend", "api", "server.go"), "package api\n")
+	writeDoctorTestFile(t, filepath.Join(repo, "ttmp", "vocabulary.yaml"), `topics:
+    - slug: backend
+      description: Backend
+    - slug: websocket
+      description: WebSocket
+status:
+    - slug: active
+      description: Active
+`)
+	index := filepath.Join(repo, "ttmp", "2026", "01", "05", "FIX-1--fixes", "index.md")
+	writeDoctorTestFile(t, index, doctorFixIndex)
+
+	oldCwd, _ := os.Getwd()
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir repo: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldCwd) })
+	return index
+}
+
+func runDoctorFixForTest(t *testing.T, dryRun bool, only []string) []types.Row {
+	t.Helper()
+	cmd, err := NewDoctorCommand()
+	if err != nil {
+		t.Fatalf("NewDoctorCommand: %v", err)
+	}
+	section, ok := cmd.GetDefaultSection()
+	if !ok {
+		t.Fatal("doctor command missing default section")
+	}
+	sectionValues, err := values.NewSectionValues(
+		section,
+		values.WithFieldValue("ticket", "FIX-1"),
+		values.WithFieldValue("root", "ttmp"),
+		values.WithFieldValue("stale-after", 100000),
+		values.WithFieldValue("fix", true),
+		values.WithFieldValue("dry-run", dryRun),
+		values.WithFieldValue("only", only),
+	)
+	if err != nil {
+		t.Fatalf("NewSectionValues: %v", err)
+	}
+	parsed := values.New()
+	parsed.Set(schema.DefaultSlug, sectionValues)
+
+	collector := &doctorRowCollector{}
+	if err := cmd.RunIntoGlazeProcessor(context.Background(), parsed, collector); err != nil {
+		t.Fatalf("doctor failed: %v", err)
+	}
+	return collector.rows
+}
+
+func rowsWithIssue(rows []types.Row, issue string) []types.Row {
+	var out []types.Row
+	for _, row := range rows {
+		if getRowString(row, "issue") == issue {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+func TestDoctorFixDryRunPrintsPatchWithoutWriting(t *testing.T) {
+	index := setupDoctorFixRepo(t)
+
+	rows := runDoctorFixForTest(t, true, []string{"unknown_topic,missing_related_file"})
+	available := rowsWithIssue(rows, "fix_available")
+	if len(available) != 1 {
+		t.Fatalf("expected one fix_available row, got %d: %v", len(available), rows)
+	}
+	diff := getRowString(available[0], "diff")
+	for _, want := range []string{
+		"--- a/ttmp/2026/01/05/FIX-1--fixes/index.md\n",
+		"-    - Backend\n-    - websockets\n+    - backend\n+    - websocket\n",
+		"-    - Path: repo://old/server.go\n+    - Path: repo://backend/api/server.go\n",
+	} {
+		if !strings.Contains(diff, want) {
+			t.Fatalf("diff missing %q:\n%s", want, diff)
+		}
+	}
+	if got := getRowString(available[0], "fixer"); got != "unknown_topic,missing_related_file" {
+		t.Fatalf("unexpected fixers %q", got)
+	}
+
+	content, err := os.ReadFile(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != doctorFixIndex {
+		t.Fatalf("dry run rewrote the doc:\n%s", content)
+	}
+}
+
+func TestDoctorFixDefaultSkipsOptInFixers(t *testing.T) {
+	index := setupDoctorFixRepo(t)
+
+	// Plain --fix only repairs frontmatter and migrates legacy anchors; the
+	// near-miss topics and the moved file need --only.
+	rows := runDoctorFixForTest(t, false, nil)
+	for _, row := range rows {
+		if issue := getRowString(row, "issue"); strings.HasSuffix(issue, "_fixed") || issue == "anchors_migrated" {
+			t.Fatalf("unexpected fix row %v", row)
+		}
+	}
+	content, err := os.ReadFile(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != doctorFixIndex {
+		t.Fatalf("default --fix rewrote the doc:\n%s", content)
+	}
+}
+
+func TestDoctorFixOnlyAppliesSelectedFixers(t *testing.T) {
+	index := setupDoctorFixRepo(t)
+
+	rows := runDoctorFixForTest(t, false, []string{"unknown_topic"})
+	if len(rowsWithIssue(rows, "unknown_topic_fixed")) != 1 || len(rowsWithIssue(rows, "missing_related_file_fixed")) != 0 {
+		t.Fatalf("expected only the topic fix, got %v", rows)
+	}
+	// frontend has no close match and stays for 'docmgr vocab add'.
+	if len(rowsWithIssue(rows, "unknown_topics")) != 1 {
+		t.Fatalf("expected the remaining unknown topic to be reported, got %v", rows)
+	}
+
+	content, err := os.ReadFile(index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(content)
+	if !strings.Contains(got, "    - backend\n    - websocket\n    - frontend\n") || !strings.Contains(got, "repo://old/server.go") {
+		t.Fatalf("unexpected doc after fix:\n%s", got)
+	}
+	if !strings.Contains(got, "---\n\n# Fixes\n") || strings.Contains(got, "---\n\n\n") {
+		t.Fatalf("fix changed the body spacing:\n%s", got)
+	}
+	if _, err := os.Stat(index + ".bak"); !os.IsNotExist(err) {
+		t.Fatalf("vocabulary fixes should not leave a backup: %v", err)
+	}
+}
+
+func TestClosestVocabSlug(t *testing.T) {
+	known := []string{"api", "backend", "websocket", "design-doc", "chat", "chart"}
+	cases := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"API", "api", true},
+		{"design_doc", "design-doc", true},
+		{"bakend", "backend", true},
+		{"websockets", "websocket", true},
+		{"frontend", "", false},
+		{"ui", "", false},
+		{"chatt", "", false}, // one edit from both chat and chart
+	}
+	for _, tc := range cases {
+		got, ok := closestVocabSlug(tc.value, known)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("closestVocabSlug(%q) = %q, %v; want %q, %v", tc.value, got, ok, tc.want, tc.ok)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-go-golems/glazed/pkg/cmds/schema"
 	"github.com/go-go-golems/glazed/pkg/cmds/values"
@@ -101,3 +102,113 @@ func TestDoctorReturnsErrorForInvalidVocabulary(t *testing.T) {
 		t.Fatalf("expected vocabulary error, got: %v", err)
 	}
 }
+
+func TestCheckTaskAttrs(t *testing.T) {
+	tasks := parseTasksFromLines([]string{
+		"- [ ] Late @alice due:2026-10-01 <!-- t:aa11 -->",
+		"- [x] Done late @mallory due:2026-09-01",
+		"- [ ] On time @Bob due:2026-11-01",
+		"- [ ] Outsider @mallory",
+	})
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	issues := checkTaskAttrs(tasks, []string{"alice", "bob"}, now)
+	var got []string
+	for _, is := range issues {
+		got = append(got, is.issue)
+	}
+	want := []string{"task_overdue", "task_assignee_not_owner"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("got %v, want %v (%+v)", got, want, issues)
+	}
+	if !strings.Contains(issues[0].message, "aa11") || !strings.Contains(issues[1].message, "@mallory") {
+		t.Fatalf("unexpected messages: %+v", issues)
+	}
+
+	// Tickets without owners only get due-date findings.
+	if issues := checkTaskAttrs(tasks, nil, now); len(issues) != 1 || issues[0].issue != "task_overdue" {
+		t.Fatalf("expected only task_overdue without owners, got %+v", issues)
+	}
+}
+
+func TestDoctorRowsCarryFrontmatterPositions(t *testing.T) {
+	setupDoctorFixRepo(t)
+
+	cmd, err := NewDoctorCommand()
+	if err != nil {
+		t.Fatalf("NewDoctorCommand: %v", err)
+	}
+	section, ok := cmd.GetDefaultSection()
+	if !ok {
+		t.Fatal("doctor command missing default section")
+	}
+	sectionValues, err := values.NewSectionValues(
+		section,
+		values.WithFieldValue("ticket", "FIX-1"),
+		values.WithFieldValue("root", "ttmp"),
+		values.WithFieldValue("stale-after", 100000),
+	)
+	if err != nil {
+		t.Fatalf("NewSectionValues: %v", err)
+	}
+	parsed := values.New()
+	parsed.Set(schema.DefaultSlug, sectionValues)
+	collector := &doctorRowCollector{}
+	if err := cmd.RunIntoGlazeProcessor(context.Background(), parsed, collector); err != nil {
+		t.Fatalf("doctor failed: %v", err)
+	}
+
+	missing := rowsWithIssue(collector.rows, "missing_related_file")
+	if len(missing) != 1 {
+		t.Fatalf("expected one missing_related_file row, got %v", collector.rows)
+	}
+	// "    - Path: repo://old/server.go" is line 13 of the fixture.
+	if line, col := getRowInt(missing[0], "line"), getRowInt(missing[0], "column"); line != 13 || col != 7 {
+		t.Fatalf("missing_related_file at %d:%d, want 13:7", line, col)
+	}
+	// Ticket-level aggregates have no single position.
+	for _, row := range rowsWithIssue(collector.rows, "unknown_topics") {
+		if _, ok := row.Get("line"); ok {
+			t.Fatalf("aggregated row has a position: %v", row)
+		}
+	}
+}
+
+func TestDoctorDocEvaluatesCustomRules(t *testing.T) {
+	index := setupDoctorFixRepo(t)
+	writeDoctorTestFile(t, filepath.Join("ttmp", ".docmgr", "rules.yaml"), `rules:
+  - id: index-owner
+    where: doctype:index AND NOT owner:*
+    severity: error
+    message: "{{.Title}} has no Owners"
+`)
+
+	cmd, err := NewDoctorCommand()
+	if err != nil {
+		t.Fatalf("NewDoctorCommand: %v", err)
+	}
+	section, ok := cmd.GetDefaultSection()
+	if !ok {
+		t.Fatal("doctor command missing default section")
+	}
+	sectionValues, err := values.NewSectionValues(
+		section,
+		values.WithFieldValue("doc", index),
+		values.WithFieldValue("root", "ttmp"),
+		values.WithFieldValue("fail-on", "error"),
+	)
+	if err != nil {
+		t.Fatalf("NewSectionValues: %v", err)
+	}
+	parsed := values.New()
+	parsed.Set(schema.DefaultSlug, sectionValues)
+	collector := &doctorRowCollector{}
+	err = cmd.RunIntoGlazeProcessor(context.Background(), parsed, collector)
+	if err == nil || !strings.Contains(err.Error(), "severity >= error") {
+		t.Fatalf("expected --fail-on error to trip on the team rule, got %v", err)
+	}
+	rows := rowsWithIssue(collector.rows, "index-owner")
+	if len(rows) != 1 || getRowString(rows[0], "message") != "Fixes has no Owners" || getRowString(rows[0], "severity") != "error" {
+		t.Fatalf("expected one index-owner row, got %v", collector.rows)
+	}
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFieldSchema loads the custom frontmatter field schema.
+// Resolution order:
+// - .ttmp.yaml 'schema' path (relative to config dir if not absolute)
+// - <root>/schema.yaml, where root is from .ttmp.yaml (default 'ttmp')
+// - fallback search for 'ttmp/schema.yaml' upwards
+//
+// A missing schema file yields an empty schema (no custom fields declared).
+func LoadFieldSchema() (*models.FieldSchema, error) {
+	if path, err := workspace.ResolveFieldSchemaPath(); err == nil {
+		if _, err2 := os.Stat(path); err2 == nil {
+			return loadFieldSchemaFromFile(path)
+		}
+	}
+	return &models.FieldSchema{}, nil
+}
+
+func loadFieldSchemaFromFile(path string) (*models.FieldSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read field schema file: %w", err)
+	}
+
+	var s models.FieldSchema
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse field schema file %s: %w", path, err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid field schema %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// parseFieldFilters turns repeated --field Name=Value flags into DocFilters.Fields.
+// Values for the same field are ORed; a bare Name matches documents that set it.
+func parseFieldFilters(raw []string) ([]workspace.FieldFilter, error) {
+	var out []workspace.FieldFilter
+	index := map[string]int{}
+	for _, item := range raw {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, hasValue := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid --field %q: expected Name=Value or Name", item)
+		}
+		key := strings.ToLower(name)
+		i, ok := index[key]
+		if !ok {
+			i = len(out)
+			index[key] = i
+			out = append(out, workspace.FieldFilter{Name: name})
+		}
+		if hasValue && strings.TrimSpace(value) != "" {
+			out[i].Values = append(out[i].Values, strings.TrimSpace(value))
+		}
+	}
+	return out, nil
+}
+
+// customFieldIssue is one doctor finding for a custom frontmatter field.
+type customFieldIssue struct {
+	issue  string // missing_custom_field | invalid_custom_field
+	field  string
+	detail string
+}
+
+// checkCustomFields validates doc.Extra against the declared custom fields.
+// Undeclared extra keys are not reported.
+func checkCustomFields(doc *models.Document, fieldSchema *models.FieldSchema) []customFieldIssue {
+	if doc == nil || fieldSchema == nil {
+		return nil
+	}
+	var out []customFieldIssue
+	for _, def := range fieldSchema.Fields {
+		_, v, found := doc.ExtraField(def.Name)
+		if values, _ := models.FieldValueStrings(v); !found || len(values) == 0 {
+			if def.Required && def.AppliesTo(doc.DocType) {
+				out = append(out, customFieldIssue{issue: "missing_custom_field", field: def.Name, detail: fmt.Sprintf("missing %s (required by workspace schema)", def.Name)})
+			}
+			continue
+		}
+		if err := def.CheckValue(v); err != nil {
+			out = append(out, customFieldIssue{issue: "invalid_custom_field", field: def.Name, detail: err.Error()})
+		}
+	}
+	return out
+}
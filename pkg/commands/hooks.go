@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/hooks"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+)
+
+// fireHook runs the hooks configured in .ttmp.yaml for a workspace write.
+func fireHook(ctx context.Context, event hooks.Event, ticket string, path string, data map[string]any) {
+	fireHooks(ctx, hooks.Payload{Event: event, Ticket: ticket, Path: path, Data: data})
+}
+
+// fireHooks runs the hooks for payloads returned by the write primitives
+// (WriteDocumentField, WriteRelatedFilesUpdate, WriteChangelogEntry). Callers
+// holding a workspace lock fire them after releasing it.
+func fireHooks(ctx context.Context, payloads ...hooks.Payload) {
+	if hooks.Disabled() {
+		return
+	}
+	for _, p := range payloads {
+		hooks.Fire(ctx, p)
+	}
+}
+
+// ticketForPath returns the Ticket of the nearest index.md (DocType index)
+// in or above the directory of path, without leaving the docs root (resolved
+// from root like --root). Paths outside the docs root have no ticket.
+func ticketForPath(root string, path string) string {
+	if path == "" {
+		return ""
+	}
+	root, err := filepath.Abs(workspace.ResolveRoot(root))
+	if err != nil {
+		return ""
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	for dir := filepath.Dir(abs); ; dir = filepath.Dir(dir) {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return ""
+		}
+		indexPath := filepath.Join(dir, "index.md")
+		if _, err := os.Stat(indexPath); err == nil {
+			if doc, _, err := documents.ReadDocumentWithFrontmatter(indexPath); err == nil && doc.DocType == "index" {
+				return doc.Ticket
+			}
+		}
+		if rel == "." {
+			return ""
+		}
+	}
+}
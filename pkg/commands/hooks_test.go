@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTicketForPathStopsAtDocsRoot(t *testing.T) {
+	tmp := t.TempDir()
+	root := filepath.Join(tmp, "ttmp")
+	ticketDir := filepath.Join(root, "2026", "01", "02", "HOOK-1--demo")
+	for _, dir := range []string{filepath.Join(ticketDir, "design"), filepath.Join(root, "loose")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	index := "---\nTitle: Hook\nTicket: HOOK-1\nDocType: index\n---\n"
+	if err := os.WriteFile(filepath.Join(ticketDir, "index.md"), []byte(index), 0o644); err != nil {
+		t.Fatalf("write index: %v", err)
+	}
+	// An index.md above the docs root must not claim files inside it.
+	outer := "---\nTitle: Outer\nTicket: OUTER-9\nDocType: index\n---\n"
+	if err := os.WriteFile(filepath.Join(tmp, "index.md"), []byte(outer), 0o644); err != nil {
+		t.Fatalf("write outer index: %v", err)
+	}
+
+	for path, want := range map[string]string{
+		filepath.Join(ticketDir, "tasks.md"):            "HOOK-1",
+		filepath.Join(ticketDir, "design", "01-api.md"): "HOOK-1",
+		filepath.Join(root, "loose", "notes.md"):        "",
+		filepath.Join(tmp, "elsewhere.md"):              "",
+	} {
+		if got := ticketForPath(root, path); got != want {
+			t.Errorf("ticketForPath(%s) = %q, want %q", path, got, want)
+		}
+	}
+}
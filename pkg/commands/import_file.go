@@ -4,19 +4,14 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
-	"time"
+	"strings"
 
-	"github.com/go-go-golems/docmgr/internal/documents"
-	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/docmgr/pkg/models"
 	"github.com/go-go-golems/glazed/pkg/cmds"
 	"github.com/go-go-golems/glazed/pkg/cmds/fields"
 	"github.com/go-go-golems/glazed/pkg/cmds/schema"
 	"github.com/go-go-golems/glazed/pkg/cmds/values"
 	"github.com/go-go-golems/glazed/pkg/middlewares"
-	"github.com/go-go-golems/glazed/pkg/types"
-	"gopkg.in/yaml.v3"
 )
 
 // ImportFileCommand imports a file into the document workspace
@@ -30,14 +25,9 @@ type ImportFileSettings struct {
 	FilePath string `glazed:"file"`
 	Root     string `glazed:"root"`
 	Name     string `glazed:"name"`
-}
-
-type ImportFileResult struct {
-	Ticket      string
-	SourceFile  string
-	Destination string
-	MetaPath    string
-	IndexPath   string
+	URL      string `glazed:"url"`
+	Refresh  bool   `glazed:"refresh"`
+	Extract  bool   `glazed:"extract"`
 }
 
 func NewImportFileCommand() (*ImportFileCommand, error) {
@@ -47,10 +37,26 @@ func NewImportFileCommand() (*ImportFileCommand, error) {
 			cmds.WithShort("Import a file into the document workspace"),
 			cmds.WithLong(`Imports a local file into the sources directory of a document workspace.
 
+The file is copied to sources/local/ and recorded in the ticket's index.md
+ExternalSources (type, origin path, local copy, SHA-256, fetch time). HTML pages
+and PDFs additionally get a markdown rendition of their text written next to
+the copy (DocType: source), so the content shows up in 'docmgr search'.
+PDF text follows the page tree; PDFs that keep it in compressed object streams
+are read in file order. Encrypted and scanned PDFs get no rendition.
+
+Re-importing the same file updates its entry; with --refresh the copy is only
+rewritten when the file's SHA changed since the last import.
+
 Examples:
   docmgr import file --ticket MEN-3475 --file /path/to/doc.md
   docmgr import file --ticket MEN-3475 --file /path/to/spec.pdf --name "API Spec"
 
+  # A page saved from the browser, remembering where it came from
+  docmgr import file --ticket MEN-3475 --file ~/Downloads/rfc.html --url https://example.com/rfc
+
+  # Re-import only if the file changed
+  docmgr import file --ticket MEN-3475 --file /path/to/spec.pdf --refresh
+
   # Scriptable output (JSON)
   docmgr import file --ticket MEN-3475 --file /path/to/spec.pdf --with-glaze-output --output json
 `),
@@ -79,6 +85,24 @@ Examples:
 					fields.WithHelp("Optional name for the imported file"),
 					fields.WithDefault(""),
 				),
+				fields.New(
+					"url",
+					fields.TypeString,
+					fields.WithHelp("URL the file was saved from (recorded in ExternalSources)"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"refresh",
+					fields.TypeBool,
+					fields.WithHelp("Only re-import when the source SHA differs from the recorded one"),
+					fields.WithDefault(false),
+				),
+				fields.New(
+					"extract",
+					fields.TypeBool,
+					fields.WithHelp("Extract markdown text from HTML and PDF sources"),
+					fields.WithDefault(true),
+				),
 			),
 		),
 	}, nil
@@ -102,13 +126,7 @@ func (c *ImportFileCommand) RunIntoGlazeProcessor(
 		return err
 	}
 
-	row := types.NewRow(
-		types.MRP("ticket", result.Ticket),
-		types.MRP("source_file", result.SourceFile),
-		types.MRP("destination", result.Destination),
-		types.MRP("type", "local"),
-		types.MRP("status", "imported"),
-	)
+	row := importResultRow(result)
 
 	if err := gp.AddRow(ctx, row); err != nil {
 		return fmt.Errorf("failed to add import row for %s: %w", result.SourceFile, err)
@@ -116,123 +134,34 @@ func (c *ImportFileCommand) RunIntoGlazeProcessor(
 	return nil
 }
 
-func appendSourceMetadata(path string, source *models.ExternalSource) error {
-	var sources []models.ExternalSource
-
-	// Read existing sources if file exists
-	if _, err := os.Stat(path); err == nil {
-		data, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("failed to read external sources file %s: %w", path, err)
-		}
-		if err := yaml.Unmarshal(data, &sources); err != nil {
-			return fmt.Errorf("failed to parse external sources file %s: %w", path, err)
-		}
-	}
-
-	sources = append(sources, *source)
-
-	data, err := yaml.Marshal(sources)
-	if err != nil {
-		return fmt.Errorf("failed to encode external sources for %s: %w", path, err)
-	}
-
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return fmt.Errorf("failed to write external sources file %s: %w", path, err)
-	}
-	return nil
-}
-
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
-}
-
 var _ cmds.GlazeCommand = &ImportFileCommand{}
 
 func (c *ImportFileCommand) importFile(ctx context.Context, settings *ImportFileSettings) (*ImportFileResult, error) {
 	if ctx == nil {
 		return nil, fmt.Errorf("nil context")
 	}
-	settings.Root = workspace.ResolveRoot(settings.Root)
-
-	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: settings.Root})
-	if err != nil {
-		return nil, fmt.Errorf("failed to discover workspace: %w", err)
-	}
-	settings.Root = ws.Context().Root
-	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
-		return nil, fmt.Errorf("failed to initialize workspace index: %w", err)
-	}
-
-	ticketDir, err := resolveTicketDirViaWorkspace(ctx, ws, settings.Ticket)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find ticket directory: %w", err)
-	}
-
 	if _, err := os.Stat(settings.FilePath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("source file does not exist: %s", settings.FilePath)
 	}
-
-	sourcesDir := filepath.Join(ticketDir, "sources", "local")
-	if err := os.MkdirAll(sourcesDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create sources directory: %w", err)
-	}
-
-	destName := filepath.Base(settings.FilePath)
-	if settings.Name != "" {
-		ext := filepath.Ext(settings.FilePath)
-		destName = settings.Name + ext
-	}
-	destPath := filepath.Join(sourcesDir, destName)
-
 	input, err := os.ReadFile(settings.FilePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read source file: %w", err)
 	}
 
-	if err := os.WriteFile(destPath, input, 0644); err != nil {
-		return nil, fmt.Errorf("failed to write destination file: %w", err)
-	}
-
-	source := models.ExternalSource{
-		Type:        "local",
-		Path:        settings.FilePath,
-		LastFetched: time.Now(),
-	}
-
-	metaPath := filepath.Join(ticketDir, ".meta", "sources.yaml")
-	if err := appendSourceMetadata(metaPath, &source); err != nil {
-		return nil, fmt.Errorf("failed to write metadata: %w", err)
-	}
-
-	indexPath := filepath.Join(ticketDir, "index.md")
-	doc, body, err := documents.ReadDocumentWithFrontmatter(indexPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read index.md: %w", err)
-	}
-
-	sourceRef := fmt.Sprintf("local:%s", destName)
-	if !contains(doc.ExternalSources, sourceRef) {
-		doc.ExternalSources = append(doc.ExternalSources, sourceRef)
-		doc.LastUpdated = time.Now()
-
-		if err := documents.WriteDocumentWithFrontmatter(indexPath, doc, body, true); err != nil {
-			return nil, fmt.Errorf("failed to update index.md: %w", err)
-		}
-	}
-
-	return &ImportFileResult{
-		Ticket:      settings.Ticket,
-		SourceFile:  settings.FilePath,
-		Destination: destPath,
-		MetaPath:    metaPath,
-		IndexPath:   indexPath,
-	}, nil
+	return importSource(ctx, &sourceImport{
+		Root:     settings.Root,
+		Ticket:   settings.Ticket,
+		Name:     settings.Name,
+		FileName: settings.FilePath,
+		Data:     input,
+		Origin: models.ExternalSource{
+			Path: settings.FilePath,
+			URL:  strings.TrimSpace(settings.URL),
+		},
+		SubDir:  "local",
+		Refresh: settings.Refresh,
+		Extract: settings.Extract,
+	})
 }
 
 func (c *ImportFileCommand) Run(
@@ -252,11 +181,7 @@ func (c *ImportFileCommand) Run(
 		return err
 	}
 
-	fmt.Printf("File imported into %s\n", filepath.Dir(result.Destination))
-	fmt.Printf("- Source: %s\n", result.SourceFile)
-	fmt.Printf("- Destination: %s\n", result.Destination)
-	fmt.Printf("- Metadata: %s\n", result.MetaPath)
-	fmt.Printf("- Index updated: %s\n", result.IndexPath)
+	printImportResult(result)
 
 	return nil
 }
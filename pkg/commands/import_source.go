@@ -0,0 +1,302 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/sources"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/models"
+	"github.com/go-go-golems/glazed/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// sourceDocType is the DocType of markdown files extracted from imported sources.
+const sourceDocType = "source"
+
+// Import statuses reported by import file/url.
+const (
+	importStatusImported  = "imported"
+	importStatusUpdated   = "updated"
+	importStatusUnchanged = "unchanged"
+)
+
+// sourceImport describes one artifact to copy into a ticket's sources/ directory.
+type sourceImport struct {
+	Root   string
+	Ticket string
+	// Name overrides the base name of the copy (the extension is kept).
+	Name string
+	// FileName is the original file name, used for the copy and kind detection.
+	FileName    string
+	ContentType string
+	Data        []byte
+	// Origin identifies where the bytes came from (Path and/or URL).
+	Origin models.ExternalSource
+	// SubDir is the directory under sources/ that receives the copy.
+	SubDir  string
+	Refresh bool
+	Extract bool
+}
+
+// ImportFileResult describes an imported source.
+type ImportFileResult struct {
+	Ticket      string
+	SourceFile  string
+	Destination string
+	// Extracted is the markdown rendition of an HTML/PDF source ("" if none).
+	Extracted string
+	Source    models.ExternalSource
+	Status    string
+	// Warning explains why text extraction was skipped, if it was.
+	Warning   string
+	MetaPath  string
+	IndexPath string
+}
+
+// importSource copies the artifact into sources/, extracts markdown from HTML
+// and PDF content next to it, and records a structured ExternalSource in the
+// ticket's index.md and .meta/sources.yaml. With Refresh set, a source whose
+// SHA matches the recorded one is left alone.
+func importSource(ctx context.Context, imp *sourceImport) (*ImportFileResult, error) {
+	imp.Root = workspace.ResolveRoot(imp.Root)
+	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: imp.Root})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover workspace: %w", err)
+	}
+	imp.Root = ws.Context().Root
+	if imp.Origin.Path != "" {
+		imp.Origin.Path = sourceOriginPath(ws.Context().RepoRoot, imp.Origin.Path)
+	}
+	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
+		return nil, fmt.Errorf("failed to initialize workspace index: %w", err)
+	}
+	ticketDir, err := resolveTicketDirViaWorkspace(ctx, ws, imp.Ticket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find ticket directory: %w", err)
+	}
+
+	indexPath := filepath.Join(ticketDir, "index.md")
+	ticketDoc, ticketBody, err := documents.ReadDocumentWithFrontmatter(indexPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index.md: %w", err)
+	}
+
+	sha := sources.SHA256(imp.Data)
+	existing := findExternalSource(ticketDoc.ExternalSources, imp.Origin)
+
+	relCopy := filepath.ToSlash(filepath.Join("sources", imp.SubDir, importDestName(imp)))
+	if existing >= 0 && imp.Name == "" && ticketDoc.ExternalSources[existing].LocalCopy != "" {
+		// Re-imports keep writing to the recorded copy.
+		relCopy = ticketDoc.ExternalSources[existing].LocalCopy
+	}
+	destPath := filepath.Join(ticketDir, filepath.FromSlash(relCopy))
+	metaPath := filepath.Join(ticketDir, ".meta", "sources.yaml")
+
+	result := &ImportFileResult{
+		Ticket:      imp.Ticket,
+		SourceFile:  imp.Origin.String(),
+		Destination: destPath,
+		Status:      importStatusImported,
+		MetaPath:    metaPath,
+		IndexPath:   indexPath,
+	}
+	if existing >= 0 {
+		result.Status = importStatusUpdated
+		if imp.Refresh && ticketDoc.ExternalSources[existing].SHA == sha {
+			if _, err := os.Stat(destPath); err == nil {
+				result.Source = ticketDoc.ExternalSources[existing]
+				result.Status = importStatusUnchanged
+				return result, nil
+			}
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create sources directory: %w", err)
+	}
+	if err := os.WriteFile(destPath, imp.Data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write destination file: %w", err)
+	}
+
+	kind := sources.DetectKind(imp.FileName, imp.Data, imp.ContentType)
+	source := imp.Origin
+	source.Type = kind
+	if source.Type == "" {
+		source.Type = "local"
+		if source.URL != "" {
+			source.Type = "url"
+		}
+	}
+	source.LocalCopy = relCopy
+	source.SHA = sha
+	source.LastFetched = time.Now()
+	result.Source = source
+
+	if imp.Extract && kind != "" {
+		mdPath := strings.TrimSuffix(destPath, filepath.Ext(destPath)) + ".md"
+		if err := writeExtractedSource(mdPath, kind, imp.Data, ticketDoc, source); err != nil {
+			result.Warning = fmt.Sprintf("text extraction skipped: %v", err)
+		} else {
+			result.Extracted = mdPath
+		}
+	}
+
+	if err := upsertSourceMetadata(metaPath, source); err != nil {
+		return nil, fmt.Errorf("failed to write metadata: %w", err)
+	}
+
+	if existing >= 0 {
+		ticketDoc.ExternalSources[existing] = source
+	} else {
+		ticketDoc.ExternalSources = append(ticketDoc.ExternalSources, source)
+	}
+	ticketDoc.LastUpdated = time.Now()
+	if err := documents.WriteDocumentWithFrontmatter(indexPath, ticketDoc, ticketBody, true); err != nil {
+		return nil, fmt.Errorf("failed to update index.md: %w", err)
+	}
+	return result, nil
+}
+
+func importDestName(imp *sourceImport) string {
+	name := filepath.Base(imp.FileName)
+	if imp.Name != "" {
+		name = imp.Name + filepath.Ext(imp.FileName)
+	}
+	return name
+}
+
+// writeExtractedSource writes the markdown rendition of an HTML/PDF source as a
+// "source" document so that its text is indexed and searchable.
+func writeExtractedSource(path, kind string, data []byte, ticketDoc *models.Document, source models.ExternalSource) error {
+	ex, err := sources.Extract(kind, data)
+	if err != nil {
+		return err
+	}
+	title := strings.TrimSpace(ex.Title)
+	if title == "" {
+		title = filepath.Base(source.LocalCopy)
+	}
+	doc := &models.Document{
+		Title:           title,
+		Ticket:          ticketDoc.Ticket,
+		Status:          "active",
+		Topics:          ticketDoc.Topics,
+		DocType:         sourceDocType,
+		Intent:          "ticket-specific",
+		ExternalSources: models.ExternalSources{source},
+		Summary:         fmt.Sprintf("Text extracted from %s (%s).", filepath.Base(source.LocalCopy), kind),
+		LastUpdated:     time.Now(),
+	}
+	return documents.WriteDocumentWithFrontmatter(path, doc, ex.Markdown, true)
+}
+
+// findExternalSource returns the index of the entry with the same origin
+// (URL, or Path when there is no URL), or -1.
+func findExternalSource(list models.ExternalSources, origin models.ExternalSource) int {
+	for i, es := range list {
+		switch {
+		case origin.URL != "":
+			if es.URL == origin.URL {
+				return i
+			}
+		case origin.Path != "":
+			if es.Path == origin.Path {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// upsertSourceMetadata records source in .meta/sources.yaml, replacing an
+// entry with the same origin.
+func upsertSourceMetadata(path string, source models.ExternalSource) error {
+	var list models.ExternalSources
+
+	if _, err := os.Stat(path); err == nil {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read external sources file %s: %w", path, err)
+		}
+		if err := yaml.Unmarshal(data, &list); err != nil {
+			return fmt.Errorf("failed to parse external sources file %s: %w", path, err)
+		}
+	}
+
+	if i := findExternalSource(list, source); i >= 0 {
+		list[i] = source
+	} else {
+		list = append(list, source)
+	}
+
+	data, err := yaml.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("failed to encode external sources for %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write external sources file %s: %w", path, err)
+	}
+	return nil
+}
+
+// sourceOriginPath returns path relative to the repository root when it lies
+// inside it, so that recorded origins stay valid across checkouts.
+func sourceOriginPath(repoRoot, path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if repoRoot != "" {
+		if rel, err := filepath.Rel(repoRoot, abs); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return abs
+}
+
+func importResultRow(result *ImportFileResult) types.Row {
+	return types.NewRow(
+		types.MRP("ticket", result.Ticket),
+		types.MRP("source_file", result.SourceFile),
+		types.MRP("destination", result.Destination),
+		types.MRP("type", result.Source.Type),
+		types.MRP("sha", result.Source.SHA),
+		types.MRP("extracted", result.Extracted),
+		types.MRP("status", result.Status),
+		types.MRP("warning", result.Warning),
+	)
+}
+
+func printImportResult(result *ImportFileResult) {
+	if result.Status == importStatusUnchanged {
+		fmt.Printf("Source unchanged (sha256 %s): %s\n", shortSHA(result.Source.SHA), result.Destination)
+		return
+	}
+	fmt.Printf("File %s into %s\n", result.Status, filepath.Dir(result.Destination))
+	fmt.Printf("- Source: %s\n", result.SourceFile)
+	fmt.Printf("- Destination: %s\n", result.Destination)
+	if result.Extracted != "" {
+		fmt.Printf("- Extracted text: %s\n", result.Extracted)
+	}
+	if result.Warning != "" {
+		fmt.Printf("- Warning: %s\n", result.Warning)
+	}
+	fmt.Printf("- Metadata: %s\n", result.MetaPath)
+	fmt.Printf("- Index updated: %s\n", result.IndexPath)
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
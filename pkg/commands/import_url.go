@@ -0,0 +1,233 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-go-golems/docmgr/pkg/models"
+	"github.com/go-go-golems/glazed/pkg/cmds"
+	"github.com/go-go-golems/glazed/pkg/cmds/fields"
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+	"github.com/go-go-golems/glazed/pkg/middlewares"
+)
+
+// maxImportURLBytes bounds the size of a fetched source.
+const maxImportURLBytes = 50 << 20
+
+// ImportURLCommand fetches a web page or document into a ticket's sources/.
+type ImportURLCommand struct {
+	*cmds.CommandDescription
+}
+
+// ImportURLSettings holds the parameters for the import url command.
+type ImportURLSettings struct {
+	Ticket  string `glazed:"ticket"`
+	URL     string `glazed:"url"`
+	Root    string `glazed:"root"`
+	Name    string `glazed:"name"`
+	Refresh bool   `glazed:"refresh"`
+	Extract bool   `glazed:"extract"`
+	Timeout int    `glazed:"timeout"`
+}
+
+func NewImportURLCommand() (*ImportURLCommand, error) {
+	return &ImportURLCommand{
+		CommandDescription: cmds.NewCommandDescription(
+			"url",
+			cmds.WithShort("Fetch a URL into the document workspace"),
+			cmds.WithLong(`Downloads a URL into sources/url/ of a ticket workspace and records it in
+the ticket's ExternalSources (URL, local copy, SHA-256, fetch time).
+
+HTML pages and PDFs get a markdown rendition of their text written next to the
+copy (DocType: source), so the content shows up in 'docmgr search' (see
+'docmgr import file --help' for PDF limits). With
+--refresh the URL is fetched again and the copy only rewritten when the
+content SHA changed.
+
+Examples:
+  docmgr import url --ticket MEN-3475 --url https://example.com/rfc/retry-budgets
+  docmgr import url --ticket MEN-3475 --url https://example.com/spec.pdf --name api-spec
+
+  # Re-fetch and update only if the page changed
+  docmgr import url --ticket MEN-3475 --url https://example.com/rfc/retry-budgets --refresh
+`),
+			cmds.WithFlags(
+				fields.New(
+					"ticket",
+					fields.TypeString,
+					fields.WithHelp("Ticket identifier"),
+					fields.WithRequired(true),
+				),
+				fields.New(
+					"url",
+					fields.TypeString,
+					fields.WithHelp("http(s) URL to fetch"),
+					fields.WithRequired(true),
+				),
+				fields.New(
+					"root",
+					fields.TypeString,
+					fields.WithHelp("Root directory for docs"),
+					fields.WithDefault("ttmp"),
+				),
+				fields.New(
+					"name",
+					fields.TypeString,
+					fields.WithHelp("Optional name for the downloaded file"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"refresh",
+					fields.TypeBool,
+					fields.WithHelp("Only update the copy when the content SHA differs from the recorded one"),
+					fields.WithDefault(false),
+				),
+				fields.New(
+					"extract",
+					fields.TypeBool,
+					fields.WithHelp("Extract markdown text from HTML and PDF sources"),
+					fields.WithDefault(true),
+				),
+				fields.New(
+					"timeout",
+					fields.TypeInteger,
+					fields.WithHelp("HTTP timeout in seconds"),
+					fields.WithDefault(30),
+				),
+			),
+		),
+	}, nil
+}
+
+func (c *ImportURLCommand) RunIntoGlazeProcessor(
+	ctx context.Context,
+	parsedValues *values.Values,
+	gp middlewares.Processor,
+) error {
+	settings := &ImportURLSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	result, err := importURL(ctx, settings)
+	if err != nil {
+		return err
+	}
+	if err := gp.AddRow(ctx, importResultRow(result)); err != nil {
+		return fmt.Errorf("failed to add import row for %s: %w", result.SourceFile, err)
+	}
+	return nil
+}
+
+var _ cmds.GlazeCommand = &ImportURLCommand{}
+
+func (c *ImportURLCommand) Run(
+	ctx context.Context,
+	parsedValues *values.Values,
+) error {
+	settings := &ImportURLSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	result, err := importURL(ctx, settings)
+	if err != nil {
+		return err
+	}
+	printImportResult(result)
+	return nil
+}
+
+var _ cmds.BareCommand = &ImportURLCommand{}
+
+func importURL(ctx context.Context, settings *ImportURLSettings) (*ImportFileResult, error) {
+	rawURL := strings.TrimSpace(settings.URL)
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q: only http(s) URLs can be imported", settings.URL)
+	}
+
+	timeout := time.Duration(settings.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	data, contentType, err := fetchURL(ctx, &http.Client{Timeout: timeout}, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return importSource(ctx, &sourceImport{
+		Root:        settings.Root,
+		Ticket:      settings.Ticket,
+		Name:        settings.Name,
+		FileName:    urlFileName(u, contentType),
+		ContentType: contentType,
+		Data:        data,
+		Origin:      models.ExternalSource{URL: rawURL},
+		SubDir:      "url",
+		Refresh:     settings.Refresh,
+		Extract:     settings.Extract,
+	})
+}
+
+func fetchURL(ctx context.Context, client *http.Client, rawURL string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to build request for %s: %w", rawURL, err)
+	}
+	req.Header.Set("User-Agent", "docmgr-import")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch %s: %w", rawURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, "", fmt.Errorf("failed to fetch %s: %s", rawURL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImportURLBytes+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", rawURL, err)
+	}
+	if len(data) > maxImportURLBytes {
+		return nil, "", fmt.Errorf("%s is larger than %d MiB", rawURL, maxImportURLBytes>>20)
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+// urlFileName derives a file name for a downloaded URL from its last path
+// segment (or host), adding an extension that matches the content type.
+func urlFileName(u *url.URL, contentType string) string {
+	name := path.Base(u.Path)
+	if name == "." || name == "/" || name == "" {
+		name = u.Host
+	}
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '-'
+	}, name)
+	if path.Ext(name) == "" || strings.HasPrefix(name, u.Host) {
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		switch mediaType {
+		case "text/html", "application/xhtml+xml":
+			name += ".html"
+		case "application/pdf":
+			name += ".pdf"
+		case "text/plain":
+			name += ".txt"
+		case "text/markdown":
+			name += ".md"
+		}
+	}
+	return name
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+)
+
+func TestImportURL_ExtractsAndRefreshes(t *testing.T) {
+	tmp := t.TempDir()
+	root := filepath.Join(tmp, "ttmp")
+	ticketDir := filepath.Join(root, "2026", "10", "18", "MEN-77--retries")
+	writeMarkdown(t, filepath.Join(ticketDir, "index.md"), strings.TrimSpace(`---
+Title: Retries
+Ticket: MEN-77
+DocType: index
+Status: active
+Topics: [backend]
+ExternalSources:
+  - local:notes.txt
+LastUpdated: 2026-10-18
+---
+
+# Retries
+`))
+
+	page := `<html><head><title>Retry Budgets</title></head><body><h1>Retry budgets</h1><p>Cap retries at <b>10%</b>.</p></body></html>`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(page))
+	}))
+	defer srv.Close()
+	pageURL := srv.URL + "/rfc/retry-budgets"
+
+	ctx := context.Background()
+	settings := func(refresh bool) *ImportURLSettings {
+		return &ImportURLSettings{Ticket: "MEN-77", URL: pageURL, Root: root, Refresh: refresh, Extract: true, Timeout: 5}
+	}
+
+	result, err := importURL(ctx, settings(false))
+	if err != nil {
+		t.Fatalf("import url: %v", err)
+	}
+	if result.Status != importStatusImported || result.Source.Type != "html" {
+		t.Fatalf("unexpected result: status=%s type=%s", result.Status, result.Source.Type)
+	}
+	wantCopy := filepath.Join(ticketDir, "sources", "url", "retry-budgets.html")
+	if result.Destination != wantCopy {
+		t.Fatalf("destination = %s, want %s", result.Destination, wantCopy)
+	}
+
+	extracted, body, err := documents.ReadDocumentWithFrontmatter(filepath.Join(ticketDir, "sources", "url", "retry-budgets.md"))
+	if err != nil {
+		t.Fatalf("read extracted doc: %v", err)
+	}
+	if extracted.DocType != sourceDocType || extracted.Title != "Retry Budgets" || extracted.Ticket != "MEN-77" {
+		t.Fatalf("unexpected extracted frontmatter: %+v", extracted)
+	}
+	if !strings.Contains(body, "Cap retries at **10%**.") {
+		t.Fatalf("extracted body missing text:\n%s", body)
+	}
+
+	index, _, err := documents.ReadDocumentWithFrontmatter(filepath.Join(ticketDir, "index.md"))
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	if len(index.ExternalSources) != 2 {
+		t.Fatalf("ExternalSources = %+v, want legacy entry plus the URL", index.ExternalSources)
+	}
+	if legacy := index.ExternalSources[0]; legacy.Type != "local" || legacy.Path != "notes.txt" {
+		t.Fatalf("legacy entry not preserved: %+v", legacy)
+	}
+	es := index.ExternalSources[1]
+	if es.URL != pageURL || es.LocalCopy != "sources/url/retry-budgets.html" || len(es.SHA) != 64 || es.LastFetched.IsZero() {
+		t.Fatalf("unexpected ExternalSource: %+v", es)
+	}
+
+	// Same content: --refresh leaves everything alone.
+	result, err = importURL(ctx, settings(true))
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if result.Status != importStatusUnchanged {
+		t.Fatalf("status = %s, want unchanged", result.Status)
+	}
+
+	// Changed content: --refresh rewrites the copy and the recorded SHA.
+	page = strings.Replace(page, "10%", "20%", 1)
+	result, err = importURL(ctx, settings(true))
+	if err != nil {
+		t.Fatalf("refresh after change: %v", err)
+	}
+	if result.Status != importStatusUpdated || result.Source.SHA == es.SHA {
+		t.Fatalf("expected an update with a new SHA, got status=%s sha=%s", result.Status, result.Source.SHA)
+	}
+	copyData, err := os.ReadFile(wantCopy)
+	if err != nil || !strings.Contains(string(copyData), "20%") {
+		t.Fatalf("copy not refreshed: %v", err)
+	}
+	index, _, err = documents.ReadDocumentWithFrontmatter(filepath.Join(ticketDir, "index.md"))
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	if len(index.ExternalSources) != 2 || index.ExternalSources[1].SHA != result.Source.SHA {
+		t.Fatalf("index not updated in place: %+v", index.ExternalSources)
+	}
+}
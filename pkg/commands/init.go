@@ -48,6 +48,7 @@ What this does:
 - Creates the docs root if missing (defaults to 'ttmp' or .ttmp.yaml root)
 - Creates an empty 'vocabulary.yaml' if missing
 - Scaffolds '_templates/' and '_guidelines/' with default files (respecting existing ones unless --force)
+- Scaffolds '_blueprints/' with the built-in ticket blueprints (bugfix, feature, spike)
 
 Examples:
   # Initialize default root (ttmp) next to the nearest .ttmp.yaml or from CWD
@@ -128,7 +129,7 @@ func (c *InitCommand) initializeWorkspace(settings *InitSettings) (*InitResult,
 	}
 
 	ignorePath := filepath.Join(settings.Root, ".docmgrignore")
-	ignoreContent := "# Default ignores for docmgr\n.git/\n_templates/\n_guidelines/\n"
+	ignoreContent := "# Default ignores for docmgr\n.git/\n_templates/\n_guidelines/\n_blueprints/\n"
 	if err := writeFileIfNotExists(ignorePath, []byte(ignoreContent), settings.Force); err != nil {
 		return nil, fmt.Errorf("failed to write .docmgrignore: %w", err)
 	}
@@ -147,6 +148,9 @@ func (c *InitCommand) initializeWorkspace(settings *InitSettings) (*InitResult,
 	if err := scaffoldTemplatesAndGuidelines(settings.Root, settings.Force); err != nil {
 		return nil, fmt.Errorf("failed to scaffold templates and guidelines: %w", err)
 	}
+	if err := scaffoldBlueprints(settings.Root, settings.Force); err != nil {
+		return nil, fmt.Errorf("failed to scaffold blueprints: %w", err)
+	}
 
 	status := "initialized"
 	configPath := cfgPath
@@ -245,6 +249,7 @@ func seedDefaultVocabulary() error {
 	addItem(&vocab.DocTypes, "playbook", "Operational procedures and QA/Smoke steps")
 	addItem(&vocab.DocTypes, "index", "Ticket landing page")
 	addItem(&vocab.DocTypes, "skill", "Skill documentation (what it's for and when to use it)")
+	addItem(&vocab.DocTypes, "source", "Text extracted from an imported web page or PDF")
 
 	// Intent
 	addItem(&vocab.Intent, "long-term", "Likely to persist")
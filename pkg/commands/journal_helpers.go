@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/go-go-golems/docmgr/internal/journal"
+)
+
+// runJournaled runs fn inside a journaled operation under root: on success the
+// operation is committed (and can be reverted with `docmgr undo`), on error every
+// step fn performed through tx is rolled back. It returns the operation ID, or
+// "" when fn changed nothing.
+func runJournaled(root, command, summary string, fn func(tx *journal.Tx) error) (string, error) {
+	tx, err := journal.Begin(root, command, summary)
+	if err != nil {
+		return "", fmt.Errorf("failed to start operation journal: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return "", fmt.Errorf("%w (%v)", err, rbErr)
+		}
+		return "", fmt.Errorf("%w (changes rolled back)", err)
+	}
+	empty := tx.Empty()
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit operation journal: %w", err)
+	}
+	if empty {
+		return "", nil
+	}
+	return tx.ID(), nil
+}
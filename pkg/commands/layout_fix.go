@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/journal"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/glazed/pkg/cmds"
 	"github.com/go-go-golems/glazed/pkg/cmds/fields"
@@ -92,7 +93,7 @@ func (c *LayoutFixCommand) RunIntoGlazeProcessor(
 		return fmt.Errorf("failed to parse settings: %w", err)
 	}
 
-	moves, err := c.applyLayoutFix(ctx, settings)
+	moves, opID, err := c.applyLayoutFix(ctx, settings)
 	if err != nil {
 		return err
 	}
@@ -103,6 +104,7 @@ func (c *LayoutFixCommand) RunIntoGlazeProcessor(
 			types.MRP("from", move.From),
 			types.MRP("to", move.To),
 			types.MRP("status", move.Status),
+			types.MRP("op_id", opID),
 		)
 		if err := gp.AddRow(ctx, row); err != nil {
 			return fmt.Errorf("failed to emit layout-fix row: %w", err)
@@ -112,25 +114,28 @@ func (c *LayoutFixCommand) RunIntoGlazeProcessor(
 	return nil
 }
 
-func (c *LayoutFixCommand) applyLayoutFix(ctx context.Context, settings *LayoutFixSettings) ([]LayoutFixMove, error) {
+// applyLayoutFix moves misplaced docs of all selected tickets as one journaled
+// operation and returns the moves plus the operation ID ("" for dry runs or
+// when nothing moved).
+func (c *LayoutFixCommand) applyLayoutFix(ctx context.Context, settings *LayoutFixSettings) ([]LayoutFixMove, string, error) {
 	if ctx == nil {
-		return nil, fmt.Errorf("nil context")
+		return nil, "", fmt.Errorf("nil context")
 	}
 	settings.Root = workspace.ResolveRoot(settings.Root)
 	var ticketDirs []string
 	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: settings.Root})
 	if err != nil {
-		return nil, fmt.Errorf("failed to discover workspace: %w", err)
+		return nil, "", fmt.Errorf("failed to discover workspace: %w", err)
 	}
 	settings.Root = ws.Context().Root
 	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
-		return nil, fmt.Errorf("failed to initialize workspace index: %w", err)
+		return nil, "", fmt.Errorf("failed to initialize workspace index: %w", err)
 	}
 
 	if strings.TrimSpace(settings.Ticket) != "" {
 		td, err := resolveTicketDirViaWorkspace(ctx, ws, settings.Ticket)
 		if err != nil {
-			return nil, fmt.Errorf("failed to find ticket directory: %w", err)
+			return nil, "", fmt.Errorf("failed to find ticket directory: %w", err)
 		}
 		ticketDirs = append(ticketDirs, td)
 	} else {
@@ -149,7 +154,7 @@ func (c *LayoutFixCommand) applyLayoutFix(ctx context.Context, settings *LayoutF
 			},
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to query ticket index docs: %w", err)
+			return nil, "", fmt.Errorf("failed to query ticket index docs: %w", err)
 		}
 		seen := map[string]bool{}
 		for _, h := range res.Docs {
@@ -171,7 +176,28 @@ func (c *LayoutFixCommand) applyLayoutFix(ctx context.Context, settings *LayoutF
 	}
 
 	moves := []LayoutFixMove{}
+	if settings.DryRun {
+		if err := layoutFixTickets(nil, ticketDirs, true, &moves); err != nil {
+			return nil, "", err
+		}
+		return moves, "", nil
+	}
+	summary := fmt.Sprintf("layout-fix %d ticket(s)", len(ticketDirs))
+	if strings.TrimSpace(settings.Ticket) != "" {
+		summary = fmt.Sprintf("layout-fix %s", settings.Ticket)
+	}
+	opID, err := runJournaled(settings.Root, "doc layout-fix", summary, func(tx *journal.Tx) error {
+		return layoutFixTickets(tx, ticketDirs, false, &moves)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return moves, opID, nil
+}
 
+// layoutFixTickets moves docs whose top-level directory differs from their
+// DocType and rewrites intra-ticket references. With dryRun, tx is unused.
+func layoutFixTickets(tx *journal.Tx, ticketDirs []string, dryRun bool, moves *[]LayoutFixMove) error {
 	for _, ticketDir := range ticketDirs {
 		ticketName := filepath.Base(ticketDir)
 		renameMap := map[string]string{}
@@ -218,8 +244,8 @@ func (c *LayoutFixCommand) applyLayoutFix(ctx context.Context, settings *LayoutF
 			newRel := filepath.Join(expected, filepath.Base(path))
 			newAbs := filepath.Join(ticketDir, newRel)
 			oldRel := filepath.ToSlash(rel)
-			if settings.DryRun {
-				moves = append(moves, LayoutFixMove{
+			if dryRun {
+				*moves = append(*moves, LayoutFixMove{
 					Ticket: ticketName,
 					From:   oldRel,
 					To:     filepath.ToSlash(newRel),
@@ -228,14 +254,14 @@ func (c *LayoutFixCommand) applyLayoutFix(ctx context.Context, settings *LayoutF
 				return nil
 			}
 
-			if err := os.MkdirAll(filepath.Dir(newAbs), 0755); err != nil {
+			if err := tx.MkdirAll(filepath.Dir(newAbs), 0755); err != nil {
 				return fmt.Errorf("failed to ensure target directory for %s: %w", newAbs, err)
 			}
-			if err := os.Rename(path, newAbs); err != nil {
+			if err := tx.Rename(path, newAbs); err != nil {
 				return fmt.Errorf("rename %s -> %s failed: %w", path, newAbs, err)
 			}
 			renameMap[oldRel] = filepath.ToSlash(newRel)
-			moves = append(moves, LayoutFixMove{
+			*moves = append(*moves, LayoutFixMove{
 				Ticket: ticketName,
 				From:   oldRel,
 				To:     filepath.ToSlash(newRel),
@@ -244,16 +270,15 @@ func (c *LayoutFixCommand) applyLayoutFix(ctx context.Context, settings *LayoutF
 			return nil
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to walk ticket directory %s: %w", ticketDir, err)
+			return fmt.Errorf("failed to walk ticket directory %s: %w", ticketDir, err)
 		}
-		if !settings.DryRun && len(renameMap) > 0 {
-			if err := updateTicketReferences(ticketDir, renameMap); err != nil {
-				return nil, fmt.Errorf("failed to update references in %s: %w", ticketDir, err)
+		if !dryRun && len(renameMap) > 0 {
+			if err := updateTicketReferences(tx, ticketDir, renameMap); err != nil {
+				return fmt.Errorf("failed to update references in %s: %w", ticketDir, err)
 			}
 		}
 	}
-
-	return moves, nil
+	return nil
 }
 
 func (c *LayoutFixCommand) Run(
@@ -268,7 +293,7 @@ func (c *LayoutFixCommand) Run(
 		return fmt.Errorf("failed to parse settings: %w", err)
 	}
 
-	moves, err := c.applyLayoutFix(ctx, settings)
+	moves, opID, err := c.applyLayoutFix(ctx, settings)
 	if err != nil {
 		return err
 	}
@@ -287,6 +312,9 @@ func (c *LayoutFixCommand) Run(
 	for _, move := range moves {
 		fmt.Printf("- %s: %s → %s (%s)\n", move.Ticket, move.From, move.To, move.Status)
 	}
+	if opID != "" {
+		fmt.Printf("\nUndo with: docmgr undo %s\n", opID)
+	}
 
 	return nil
 }
@@ -34,6 +34,9 @@ type ListDocsSettings struct {
 	Status  string   `glazed:"status"`
 	DocType string   `glazed:"doc-type"`
 	Topics  []string `glazed:"topics"`
+	Fields  []string `glazed:"field"`
+	Where   string   `glazed:"where"`
+	View    string   `glazed:"view"`
 	// Schema printing flags (human mode only)
 	PrintTemplateSchema bool   `glazed:"print-template-schema"`
 	SchemaFormat        string `glazed:"schema-format"`
@@ -58,6 +61,17 @@ Examples:
   docmgr list docs --topics chat,backend
   docmgr list docs --topics chat,backend,websocket --status active
 
+  # Custom frontmatter fields (repeat --field; same name = any value, different names = all)
+  docmgr list docs --field Priority=P0 --field Priority=P1 --field Epic=AUTH
+  docmgr list docs --field DueDate
+
+  # Query expressions (AND/OR/NOT, parentheses, key:value terms)
+  docmgr list docs --where 'status:active AND topic:api AND NOT owner:bob AND updated>2026-01-01'
+  docmgr list docs --where 'priority:P0 OR (priority:P1 updated>=14d)'
+
+  # Saved views (docmgr view save ...)
+  docmgr list docs --view my-active-api
+
   # Scriptable (paths only)
   docmgr list docs --ticket MEN-3475 --with-glaze-output --select path
 
@@ -107,6 +121,24 @@ Examples:
 					fields.WithHelp("Filter by topics (comma-separated, matches any)"),
 					fields.WithDefault([]string{}),
 				),
+				fields.New(
+					"field",
+					fields.TypeStringList,
+					fields.WithHelp("Filter by custom frontmatter field: Name=Value, or Name to require the field (repeatable)"),
+					fields.WithDefault([]string{}),
+				),
+				fields.New(
+					"view",
+					fields.TypeString,
+					fields.WithHelp("Apply a saved view (see 'docmgr view list'); flags given alongside override it"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"where",
+					fields.TypeString,
+					fields.WithHelp("Query expression, e.g. 'status:active AND topic:api AND NOT owner:bob' (see 'docmgr help how-to-use')"),
+					fields.WithDefault(""),
+				),
 			),
 		),
 	}, nil
@@ -174,14 +206,13 @@ func (c *ListDocsCommand) RunIntoGlazeProcessor(
 		return fmt.Errorf("failed to initialize workspace index: %w", err)
 	}
 
+	filters, orderBy, err := settings.docFilters()
+	if err != nil {
+		return err
+	}
 	res, err := ws.QueryDocs(ctx, workspace.DocQuery{
-		Scope: workspace.Scope{Kind: workspace.ScopeRepo},
-		Filters: workspace.DocFilters{
-			Ticket:    settings.Ticket,
-			Status:    settings.Status,
-			DocType:   settings.DocType,
-			TopicsAny: settings.Topics,
-		},
+		Scope:   workspace.Scope{Kind: workspace.ScopeRepo},
+		Filters: filters,
 		Options: workspace.DocQueryOptions{
 			IncludeErrors:       false,
 			IncludeDiagnostics:  true,
@@ -189,7 +220,7 @@ func (c *ListDocsCommand) RunIntoGlazeProcessor(
 			IncludeScriptsPath:  true,
 			IncludeSourcesPath:  true,
 			IncludeControlDocs:  true,
-			OrderBy:             workspace.OrderByPath,
+			OrderBy:             orderBy,
 			Reverse:             false,
 			IncludeBody:         false,
 		},
@@ -310,14 +341,13 @@ func (c *ListDocsCommand) Run(
 	}
 
 	var entries []docEntry
+	filters, orderBy, err := settings.docFilters()
+	if err != nil {
+		return err
+	}
 	res, err := ws.QueryDocs(ctx, workspace.DocQuery{
-		Scope: workspace.Scope{Kind: workspace.ScopeRepo},
-		Filters: workspace.DocFilters{
-			Ticket:    settings.Ticket,
-			Status:    settings.Status,
-			DocType:   settings.DocType,
-			TopicsAny: settings.Topics,
-		},
+		Scope:   workspace.Scope{Kind: workspace.ScopeRepo},
+		Filters: filters,
 		Options: workspace.DocQueryOptions{
 			IncludeErrors:       false,
 			IncludeDiagnostics:  false, // keep human mode quiet (matches previous behavior)
@@ -325,7 +355,7 @@ func (c *ListDocsCommand) Run(
 			IncludeScriptsPath:  true,
 			IncludeSourcesPath:  true,
 			IncludeControlDocs:  true,
-			OrderBy:             workspace.OrderByPath,
+			OrderBy:             orderBy,
 			Reverse:             false,
 			IncludeBody:         false,
 		},
@@ -392,12 +422,15 @@ func (c *ListDocsCommand) Run(
 	fmt.Fprintf(&b, "## Documents (%d)\n\n", len(entries))
 	for _, ticket := range order {
 		docs := grouped[ticket]
-		sort.SliceStable(docs, func(i, j int) bool {
-			if docs[i].docType == docs[j].docType {
-				return docs[i].title < docs[j].title
-			}
-			return docs[i].docType < docs[j].docType
-		})
+		// A view's orderBy keeps query order within each ticket.
+		if orderBy == workspace.OrderByPath {
+			sort.SliceStable(docs, func(i, j int) bool {
+				if docs[i].docType == docs[j].docType {
+					return docs[i].title < docs[j].title
+				}
+				return docs[i].docType < docs[j].docType
+			})
+		}
 		fmt.Fprintf(&b, "### %s (%d docs)\n\n", ticket, len(docs))
 		for _, entry := range docs {
 			topics := "—"
@@ -11,6 +11,7 @@ import (
 
 	"github.com/charmbracelet/glamour"
 	"github.com/go-go-golems/docmgr/internal/templates"
+	"github.com/go-go-golems/docmgr/internal/tickets"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/glazed/pkg/cmds"
 	"github.com/go-go-golems/glazed/pkg/cmds/fields"
@@ -180,6 +181,7 @@ func (c *ListTicketsCommand) RunIntoGlazeProcessor(
 			types.MRP(ColTopics, strings.Join(t.Topics, ", ")),
 			types.MRP(ColTasksOpen, t.TasksOpen),
 			types.MRP(ColTasksDone, t.TasksDone),
+			types.MRP(ColBlockedBy, strings.Join(t.BlockedBy, ", ")),
 			types.MRP(ColPath, t.Path),
 			types.MRP(ColLastUpdated, t.LastUpdated.Format("2006-01-02 15:04")),
 		)
@@ -274,6 +276,9 @@ func (c *ListTicketsCommand) Run(
 		fmt.Fprintf(&b, "- Status: **%s**\n", t.Status)
 		fmt.Fprintf(&b, "- Topics: %s\n", topics)
 		fmt.Fprintf(&b, "- Tasks: %d open / %d done\n", t.TasksOpen, t.TasksDone)
+		if len(t.BlockedBy) > 0 {
+			fmt.Fprintf(&b, "- Blocked by: %s\n", strings.Join(t.BlockedBy, ", "))
+		}
 		fmt.Fprintf(&b, "- Updated: %s\n", t.LastUpdated.Format("2006-01-02 15:04"))
 		fmt.Fprintf(&b, "- Path: `%s`\n\n", t.Path)
 	}
@@ -377,6 +382,7 @@ type ticketIndexDoc struct {
 	LastUpdated time.Time
 	TasksOpen   int
 	TasksDone   int
+	BlockedBy   []string // open tickets this ticket depends on (DependsOn/Blocks links)
 }
 
 func queryTicketIndexDocs(ctx context.Context, rootOverride string, ticketFilter string, statusFilter string) (string, []ticketIndexDoc, error) {
@@ -412,6 +418,10 @@ func queryTicketIndexDocs(ctx context.Context, rootOverride string, ticketFilter
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to query docs: %w", err)
 	}
+	links, err := tickets.LoadLinkGraph(ctx, ws)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load ticket links: %w", err)
+	}
 
 	out := make([]ticketIndexDoc, 0, len(res.Docs))
 	for _, h := range res.Docs {
@@ -437,6 +447,7 @@ func queryTicketIndexDocs(ctx context.Context, rootOverride string, ticketFilter
 			LastUpdated: h.Doc.LastUpdated,
 			TasksOpen:   open,
 			TasksDone:   done,
+			BlockedBy:   links.BlockedBy(h.Doc.Ticket),
 		})
 	}
 
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/hooks"
 	"github.com/go-go-golems/docmgr/internal/tickets"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/docmgr/pkg/models"
@@ -76,6 +77,9 @@ Examples:
 
   # Update all design-docs under a ticket
   docmgr meta update --ticket MEN-1234 --doc-type design-doc --field Topics --value chat,backend
+
+  # Set a custom field declared in the workspace schema (empty value removes it)
+  docmgr meta update --ticket MEN-1234 --field Priority --value P1
 `),
 			cmds.WithFlags(
 				fields.New(
@@ -99,7 +103,7 @@ Examples:
 				fields.New(
 					"field",
 					fields.TypeString,
-					fields.WithHelp("Field name to update (Title, Ticket, Status, Topics, DocType, Intent, Owners, RelatedFiles, ExternalSources, Summary)"),
+					fields.WithHelp("Field name to update (Title, Ticket, Status, Topics, DocType, Intent, Owners, RelatedFiles, ExternalSources, Summary, or a custom field declared in the workspace schema)"),
 					fields.WithRequired(true),
 				),
 				fields.New(
@@ -324,14 +328,31 @@ func (c *MetaUpdateCommand) Run(
 // frontmatter field is not one of the supported field names.
 var ErrUnknownMetaField = errors.New("unknown field")
 
-// UpdateDocumentField updates a specific field in a document's frontmatter.
-// It is the shared write primitive behind 'docmgr meta update' and the HTTP
-// API's POST /docs/meta endpoint.
+// ErrInvalidMetaValue is returned by UpdateDocumentField when a value does not
+// match the type or allowed values of a custom field declared in the schema.
+var ErrInvalidMetaValue = errors.New("invalid value")
+
+// UpdateDocumentField updates a specific field in a document's frontmatter
+// and runs the doc.updated (and status.changed) hooks.
 func UpdateDocumentField(filePath string, fieldName string, value string) error {
-	doc, content, err := documents.ReadDocumentWithFrontmatter(filePath)
+	payloads, err := WriteDocumentField(filePath, fieldName, value)
 	if err != nil {
 		return err
 	}
+	fireHooks(context.Background(), payloads...)
+	return nil
+}
+
+// WriteDocumentField updates a specific field in a document's frontmatter and
+// returns the hook payloads for the write without running them. It is the
+// shared write primitive behind 'docmgr meta update' and the HTTP API's
+// POST /docs/meta endpoint.
+func WriteDocumentField(filePath string, fieldName string, value string) ([]hooks.Payload, error) {
+	doc, content, err := documents.ReadDocumentWithFrontmatter(filePath)
+	if err != nil {
+		return nil, err
+	}
+	oldStatus := doc.Status
 
 	// Update field based on field name
 	// Map field names to struct fields (case-insensitive)
@@ -342,6 +363,9 @@ func UpdateDocumentField(filePath string, fieldName string, value string) error
 	case "ticket":
 		doc.Ticket = value
 	case "status":
+		if err := checkTicketTransition(filePath, doc, value); err != nil {
+			return nil, err
+		}
 		doc.Status = value
 	case "topics":
 		// Parse comma-separated values
@@ -379,25 +403,65 @@ func UpdateDocumentField(filePath string, fieldName string, value string) error
 		doc.RelatedFiles = rfs
 	case "externalsources":
 		// Parse comma-separated values
-		sources := []string{}
+		sources := models.ExternalSources{}
 		for _, source := range strings.Split(value, ",") {
 			source = strings.TrimSpace(source)
 			if source != "" {
-				sources = append(sources, source)
+				sources = append(sources, models.ParseExternalSource(source))
 			}
 		}
 		doc.ExternalSources = sources
 	case "summary":
 		doc.Summary = value
 	default:
-		return fmt.Errorf("%w: %s", ErrUnknownMetaField, fieldName)
+		if err := updateCustomField(doc, fieldName, value); err != nil {
+			return nil, err
+		}
 	}
 
 	// Update LastUpdated
 	doc.LastUpdated = time.Now()
 
 	// Write back to file
-	return documents.WriteDocumentWithFrontmatter(filePath, doc, content, true)
+	if err := documents.WriteDocumentWithFrontmatter(filePath, doc, content, true); err != nil {
+		return nil, err
+	}
+
+	payloads := []hooks.Payload{{Event: hooks.EventDocUpdated, Ticket: doc.Ticket, Path: filePath, Data: map[string]any{"field": fieldName, "value": value}}}
+	if doc.Status != oldStatus {
+		payloads = append(payloads, hooks.Payload{Event: hooks.EventStatusChanged, Ticket: doc.Ticket, Path: filePath, Data: map[string]any{"from": oldStatus, "to": doc.Status}})
+	}
+	return payloads, nil
+}
+
+// updateCustomField sets (or, for an empty value, removes) a custom field
+// declared in the workspace field schema.
+func updateCustomField(doc *models.Document, fieldName string, value string) error {
+	fieldSchema, err := LoadFieldSchema()
+	if err != nil {
+		return err
+	}
+	def, ok := fieldSchema.Lookup(fieldName)
+	if !ok {
+		return fmt.Errorf("%w: %s (custom fields must be declared in the workspace schema, see 'docmgr help how-to-setup')", ErrUnknownMetaField, fieldName)
+	}
+
+	// Replace any existing key that differs only in case.
+	if existing, _, found := doc.ExtraField(def.Name); found {
+		delete(doc.Extra, existing)
+	}
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	v, err := def.ParseValue(value)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMetaValue, err)
+	}
+	if doc.Extra == nil {
+		doc.Extra = map[string]any{}
+	}
+	doc.Extra[def.Name] = v
+	return nil
 }
 
 var _ cmds.GlazeCommand = &MetaUpdateCommand{}
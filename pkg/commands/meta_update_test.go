@@ -75,3 +75,67 @@ Status: draft
 		t.Fatalf("expected success, got: %v", err)
 	}
 }
+
+func TestMetaUpdateRunSetsSchemaDeclaredCustomField(t *testing.T) {
+	tmp := t.TempDir()
+	repo := filepath.Join(tmp, "repo")
+	root := filepath.Join(repo, "ttmp")
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatalf("mkdir root: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".ttmp.yaml"), []byte("root: ttmp\n"), 0o644); err != nil {
+		t.Fatalf("write .ttmp.yaml: %v", err)
+	}
+	schemaYAML := "fields:\n  - name: Priority\n    type: enum\n    values: [P0, P1, P2]\n  - name: Reviewers\n    type: list\n"
+	if err := os.WriteFile(filepath.Join(root, "schema.yaml"), []byte(schemaYAML), 0o644); err != nil {
+		t.Fatalf("write schema.yaml: %v", err)
+	}
+	docPath := filepath.Join(root, "doc.md")
+	content := `---
+Title: Test doc
+Status: draft
+Sponsor: carol
+---
+
+# Test doc
+`
+	if err := os.WriteFile(docPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("write doc: %v", err)
+	}
+
+	oldCwd, _ := os.Getwd()
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("chdir repo: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(oldCwd) })
+
+	cmd, err := NewMetaUpdateCommand()
+	if err != nil {
+		t.Fatalf("NewMetaUpdateCommand: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := cmd.Run(ctx, newMetaUpdateValues(t, cmd, docPath, "priority", "P1")); err != nil {
+		t.Fatalf("set Priority: %v", err)
+	}
+	if err := cmd.Run(ctx, newMetaUpdateValues(t, cmd, docPath, "Reviewers", "alice, bob")); err != nil {
+		t.Fatalf("set Reviewers: %v", err)
+	}
+	if err := cmd.Run(ctx, newMetaUpdateValues(t, cmd, docPath, "Priority", "P9")); err == nil {
+		t.Fatal("expected error for a value outside the enum")
+	}
+	if err := cmd.Run(ctx, newMetaUpdateValues(t, cmd, docPath, "Sponsor", "dave")); err == nil {
+		t.Fatal("expected error for an undeclared custom field")
+	}
+
+	data, err := os.ReadFile(docPath)
+	if err != nil {
+		t.Fatalf("read doc: %v", err)
+	}
+	got := string(data)
+	for _, want := range []string{"Priority: P1", "- alice", "- bob", "Sponsor: carol"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in rewritten doc:\n%s", want, got)
+		}
+	}
+}
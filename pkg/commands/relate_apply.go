@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/hooks"
 	"github.com/go-go-golems/docmgr/internal/paths"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/docmgr/pkg/models"
@@ -36,15 +37,17 @@ type RelatedFilesUpdateResult struct {
 // ws://..., docs://..., abs://...).
 //
 // When no effective change results, the document is left untouched and the
-// returned result has Changed=false. This is the shared write primitive
-// behind the HTTP API's POST /docs/relate endpoint.
-func ApplyRelatedFilesUpdate(ws *workspace.Workspace, targetDocPath string, add []RelatedFileChange, remove []string) (*RelatedFilesUpdateResult, error) {
+// returned result has Changed=false and no hook payload. Otherwise the
+// doc.updated payload is returned for the caller to fire once it no longer
+// holds workspace locks. This is the shared write primitive behind the HTTP
+// API's POST /docs/relate endpoint.
+func ApplyRelatedFilesUpdate(ws *workspace.Workspace, targetDocPath string, add []RelatedFileChange, remove []string) (*RelatedFilesUpdateResult, []hooks.Payload, error) {
 	if ws == nil {
-		return nil, fmt.Errorf("nil workspace")
+		return nil, nil, fmt.Errorf("nil workspace")
 	}
 	targetDocPath = filepath.Clean(strings.TrimSpace(targetDocPath))
 	if targetDocPath == "" {
-		return nil, fmt.Errorf("empty target document path")
+		return nil, nil, fmt.Errorf("empty target document path")
 	}
 
 	resolver := paths.NewResolver(paths.ResolverOptions{
@@ -57,7 +60,7 @@ func ApplyRelatedFilesUpdate(ws *workspace.Workspace, targetDocPath string, add
 
 	doc, content, err := documents.ReadDocumentWithFrontmatter(targetDocPath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Existing entries keyed by resolved absolute path so anchored, legacy and
@@ -133,13 +136,18 @@ func ApplyRelatedFilesUpdate(ws *workspace.Workspace, targetDocPath string, add
 	res.Total = len(out)
 
 	if res.Added == 0 && res.Updated == 0 && res.Removed == 0 {
-		return res, nil
+		return res, nil, nil
 	}
 
 	doc.RelatedFiles = out
 	if err := documents.WriteDocumentWithFrontmatter(targetDocPath, doc, content, true); err != nil {
-		return nil, fmt.Errorf("failed to write document: %w", err)
+		return nil, nil, fmt.Errorf("failed to write document: %w", err)
 	}
 	res.Changed = true
-	return res, nil
+	return res, []hooks.Payload{{Event: hooks.EventDocUpdated, Ticket: doc.Ticket, Path: targetDocPath, Data: map[string]any{
+		"field":   "RelatedFiles",
+		"added":   res.Added,
+		"updated": res.Updated,
+		"removed": res.Removed,
+	}}}, nil
 }
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/journal"
 	"github.com/go-go-golems/docmgr/internal/tickets"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/docmgr/pkg/models"
@@ -74,38 +75,79 @@ Examples:
 	}, nil
 }
 
+type RenameTicketResult struct {
+	Ticket    string
+	NewTicket string
+	From      string
+	To        string
+	Updated   int
+	DryRun    bool
+	OpID      string
+}
+
 func (c *RenameTicketCommand) RunIntoGlazeProcessor(
 	ctx context.Context,
 	parsedValues *values.Values,
 	gp middlewares.Processor,
 ) error {
-	if ctx == nil {
-		return fmt.Errorf("nil context")
-	}
 	settings := &RenameTicketSettings{}
 	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
 		return fmt.Errorf("failed to parse settings: %w", err)
 	}
 
+	result, err := c.applyRename(ctx, settings)
+	if err != nil {
+		return err
+	}
+
+	if result.DryRun {
+		row := types.NewRow(
+			types.MRP("ticket_old", result.Ticket),
+			types.MRP("ticket_new", result.NewTicket),
+			types.MRP("from", result.From),
+			types.MRP("to", result.To),
+			types.MRP("status", "dry-run"),
+		)
+		return gp.AddRow(ctx, row)
+	}
+
+	row := types.NewRow(
+		types.MRP("ticket_old", result.Ticket),
+		types.MRP("ticket_new", result.NewTicket),
+		types.MRP("from", result.From),
+		types.MRP("to", result.To),
+		types.MRP("updated_docs", result.Updated),
+		types.MRP("status", "renamed"),
+		types.MRP("op_id", result.OpID),
+		types.MRP("time", time.Now().Format(time.RFC3339)),
+	)
+	return gp.AddRow(ctx, row)
+}
+
+func (c *RenameTicketCommand) applyRename(ctx context.Context, settings *RenameTicketSettings) (*RenameTicketResult, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("nil context")
+	}
+
 	// Resolve workspace root from config/ENV/git
 	settings.Root = workspace.ResolveRoot(settings.Root)
 	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: settings.Root})
 	if err != nil {
-		return fmt.Errorf("failed to discover workspace: %w", err)
+		return nil, fmt.Errorf("failed to discover workspace: %w", err)
 	}
 	settings.Root = ws.Context().Root
 	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
-		return fmt.Errorf("failed to initialize workspace index: %w", err)
+		return nil, fmt.Errorf("failed to initialize workspace index: %w", err)
 	}
 
 	if settings.Ticket == settings.NewTicket {
-		return fmt.Errorf("new ticket is identical to current ticket")
+		return nil, fmt.Errorf("new ticket is identical to current ticket")
 	}
 
 	// Locate current ticket directory (forgiving ticket reference resolution).
 	ticketRes, err := tickets.Resolve(ctx, ws, settings.Ticket)
 	if err != nil {
-		return fmt.Errorf("failed to find ticket directory: %w", err)
+		return nil, fmt.Errorf("failed to find ticket directory: %w", err)
 	}
 	settings.Ticket = ticketRes.TicketID
 	oldDir := ticketRes.TicketDirAbs
@@ -121,47 +163,45 @@ func (c *RenameTicketCommand) RunIntoGlazeProcessor(
 
 	workspace.VerboseLog("rename-ticket: oldDir=%s newDir=%s", oldDir, newDir)
 
-	if settings.DryRun {
-		row := types.NewRow(
-			types.MRP("ticket_old", settings.Ticket),
-			types.MRP("ticket_new", settings.NewTicket),
-			types.MRP("from", oldDir),
-			types.MRP("to", newDir),
-			types.MRP("status", "dry-run"),
-		)
-		return gp.AddRow(ctx, row)
+	result := &RenameTicketResult{
+		Ticket:    settings.Ticket,
+		NewTicket: settings.NewTicket,
+		From:      oldDir,
+		To:        newDir,
+		DryRun:    settings.DryRun,
 	}
-
-	// Update frontmatter Ticket fields across all markdown files that contain frontmatter
-	updated, err := updateTicketFrontmatter(oldDir, settings.NewTicket)
-	if err != nil {
-		return fmt.Errorf("failed to update ticket in frontmatter: %w", err)
+	if settings.DryRun {
+		return result, nil
 	}
 
 	// Ensure target doesn't exist
 	if _, err := os.Stat(newDir); err == nil {
-		return fmt.Errorf("target directory already exists: %s", newDir)
+		return nil, fmt.Errorf("target directory already exists: %s", newDir)
 	}
 
-	// Perform directory rename
-	if err := os.Rename(oldDir, newDir); err != nil {
-		return fmt.Errorf("failed to rename directory %s -> %s: %w", oldDir, newDir, err)
-	}
+	summary := fmt.Sprintf("rename %s -> %s", settings.Ticket, settings.NewTicket)
+	result.OpID, err = runJournaled(settings.Root, "ticket rename", summary, func(tx *journal.Tx) error {
+		// Update frontmatter Ticket fields across all markdown files that contain frontmatter
+		updated, err := updateTicketFrontmatter(tx, oldDir, settings.NewTicket)
+		if err != nil {
+			return fmt.Errorf("failed to update ticket in frontmatter: %w", err)
+		}
+		result.Updated = updated
 
-	row := types.NewRow(
-		types.MRP("ticket_old", settings.Ticket),
-		types.MRP("ticket_new", settings.NewTicket),
-		types.MRP("from", oldDir),
-		types.MRP("to", newDir),
-		types.MRP("updated_docs", updated),
-		types.MRP("status", "renamed"),
-		types.MRP("time", time.Now().Format(time.RFC3339)),
-	)
-	return gp.AddRow(ctx, row)
+		// Perform directory rename
+		if err := tx.Rename(oldDir, newDir); err != nil {
+			return fmt.Errorf("failed to rename directory %s -> %s: %w", oldDir, newDir, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // updateTicketFrontmatter walks a directory and updates the Ticket field in frontmatter-capable markdown files.
-func updateTicketFrontmatter(root string, newTicket string) (int, error) {
+func updateTicketFrontmatter(tx *journal.Tx, root string, newTicket string) (int, error) {
 	updated := 0
 	err := documents.WalkDocuments(root, func(path string, doc *models.Document, body string, readErr error) error {
 		if readErr != nil || doc == nil {
@@ -169,7 +209,9 @@ func updateTicketFrontmatter(root string, newTicket string) (int, error) {
 		}
 		doc.Ticket = newTicket
 		doc.LastUpdated = time.Now()
-		if err := documents.WriteDocumentWithFrontmatter(path, doc, body, true); err != nil {
+		if err := tx.Write(path, func() error {
+			return documents.WriteDocumentWithFrontmatter(path, doc, body, true)
+		}); err != nil {
 			return fmt.Errorf("writing updated frontmatter failed for %s: %w", path, err)
 		}
 		updated++
@@ -185,61 +227,26 @@ func (c *RenameTicketCommand) Run(
 	ctx context.Context,
 	parsedValues *values.Values,
 ) error {
-	if ctx == nil {
-		return fmt.Errorf("nil context")
-	}
 	settings := &RenameTicketSettings{}
 	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
 		return fmt.Errorf("failed to parse settings: %w", err)
 	}
 
-	settings.Root = workspace.ResolveRoot(settings.Root)
-	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: settings.Root})
-	if err != nil {
-		return fmt.Errorf("failed to discover workspace: %w", err)
-	}
-	settings.Root = ws.Context().Root
-	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
-		return fmt.Errorf("failed to initialize workspace index: %w", err)
-	}
-
-	if settings.Ticket == settings.NewTicket {
-		return fmt.Errorf("new ticket is identical to current ticket")
-	}
-
-	ticketRes, err := tickets.Resolve(ctx, ws, settings.Ticket)
-	if err != nil {
-		return fmt.Errorf("failed to find ticket directory: %w", err)
-	}
-	settings.Ticket = ticketRes.TicketID
-	oldDir := ticketRes.TicketDirAbs
-	base := filepath.Base(oldDir)
-	remainder := ""
-	if strings.HasPrefix(base, settings.Ticket) {
-		remainder = strings.TrimPrefix(base, settings.Ticket)
-	}
-	newBase := settings.NewTicket + remainder
-	newDir := filepath.Join(filepath.Dir(oldDir), newBase)
-
-	if settings.DryRun {
-		fmt.Printf("Would rename ticket %s -> %s: %s -> %s\n", settings.Ticket, settings.NewTicket, oldDir, newDir)
-		return nil
-	}
-
-	updated, err := updateTicketFrontmatter(oldDir, settings.NewTicket)
+	result, err := c.applyRename(ctx, settings)
 	if err != nil {
 		return err
 	}
 
-	if _, err := os.Stat(newDir); err == nil {
-		return fmt.Errorf("target directory already exists: %s", newDir)
-	}
-	if err := os.Rename(oldDir, newDir); err != nil {
-		return fmt.Errorf("failed to rename directory %s -> %s: %w", oldDir, newDir, err)
+	if result.DryRun {
+		fmt.Printf("Would rename ticket %s -> %s: %s -> %s\n", result.Ticket, result.NewTicket, result.From, result.To)
+		return nil
 	}
 
 	fmt.Printf("renamed %s -> %s (%d docs updated) at %s\n",
-		settings.Ticket, settings.NewTicket, updated, newDir)
+		result.Ticket, result.NewTicket, result.Updated, result.To)
+	if result.OpID != "" {
+		fmt.Printf("undo with: docmgr undo %s\n", result.OpID)
+	}
 	return nil
 }
 
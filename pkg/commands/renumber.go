@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-go-golems/docmgr/internal/journal"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/glazed/pkg/cmds"
 	"github.com/go-go-golems/glazed/pkg/cmds/fields"
@@ -33,6 +34,7 @@ type RenumberResult struct {
 	Ticket      string
 	Renamed     int
 	Path        string
+	OpID        string
 	CompletedAt time.Time
 }
 
@@ -90,12 +92,13 @@ func (c *RenumberCommand) RunIntoGlazeProcessor(
 		types.MRP("renamed", result.Renamed),
 		types.MRP("status", "completed"),
 		types.MRP("path", result.Path),
+		types.MRP("op_id", result.OpID),
 		types.MRP("time", result.CompletedAt.Format(time.RFC3339)),
 	)
 	return gp.AddRow(ctx, row)
 }
 
-func updateTicketReferences(ticketDir string, renameMap map[string]string) error {
+func updateTicketReferences(tx *journal.Tx, ticketDir string, renameMap map[string]string) error {
 	// Walk all .md files under ticketDir and replace oldRel with newRel
 	return filepath.WalkDir(ticketDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -118,7 +121,7 @@ func updateTicketReferences(ticketDir string, renameMap map[string]string) error
 			updated = strings.ReplaceAll(updated, oldRel, newRel)
 		}
 		if updated != content {
-			if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+			if err := tx.WriteFile(path, []byte(updated), 0644); err != nil {
 				return err
 			}
 		}
@@ -155,7 +158,27 @@ func (c *RenumberCommand) applyRenumber(ctx context.Context, settings *RenumberS
 	}
 
 	renameMap := map[string]string{}
+	summary := fmt.Sprintf("renumber %s", settings.Ticket)
+	opID, err := runJournaled(settings.Root, "doc renumber", summary, func(tx *journal.Tx) error {
+		return renumberTicketDocs(tx, ticketDir, entries, renameMap)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenumberResult{
+		Ticket:      settings.Ticket,
+		Renamed:     len(renameMap),
+		Path:        ticketDir,
+		OpID:        opID,
+		CompletedAt: time.Now(),
+	}, nil
+}
 
+// renumberTicketDocs renames docs in each subdirectory of ticketDir to sequential
+// prefixes, recording old -> new ticket-relative paths in renameMap, and rewrites
+// references to them.
+func renumberTicketDocs(tx *journal.Tx, ticketDir string, entries []os.DirEntry, renameMap map[string]string) error {
 	for _, e := range entries {
 		if !e.IsDir() {
 			continue
@@ -167,7 +190,7 @@ func (c *RenumberCommand) applyRenumber(ctx context.Context, settings *RenumberS
 
 		subdir := filepath.Join(ticketDir, name)
 		var files []string
-		err = filepath.WalkDir(subdir, func(path string, d fs.DirEntry, err error) error {
+		err := filepath.WalkDir(subdir, func(path string, d fs.DirEntry, err error) error {
 			if err != nil {
 				return nil
 			}
@@ -180,7 +203,7 @@ func (c *RenumberCommand) applyRenumber(ctx context.Context, settings *RenumberS
 			return nil
 		})
 		if err != nil {
-			return nil, fmt.Errorf("walk failed: %w", err)
+			return fmt.Errorf("walk failed: %w", err)
 		}
 
 		sort.Slice(files, func(i, j int) bool {
@@ -203,8 +226,8 @@ func (c *RenumberCommand) applyRenumber(ctx context.Context, settings *RenumberS
 				continue
 			}
 			newPath := filepath.Join(filepath.Dir(oldPath), newBase)
-			if err := os.Rename(oldPath, newPath); err != nil {
-				return nil, fmt.Errorf("failed to rename %s -> %s: %w", oldPath, newPath, err)
+			if err := tx.Rename(oldPath, newPath); err != nil {
+				return fmt.Errorf("failed to rename %s -> %s: %w", oldPath, newPath, err)
 			}
 			oldRel, _ := filepath.Rel(ticketDir, oldPath)
 			newRel, _ := filepath.Rel(ticketDir, newPath)
@@ -213,17 +236,11 @@ func (c *RenumberCommand) applyRenumber(ctx context.Context, settings *RenumberS
 	}
 
 	if len(renameMap) > 0 {
-		if err := updateTicketReferences(ticketDir, renameMap); err != nil {
-			return nil, fmt.Errorf("failed to update references: %w", err)
+		if err := updateTicketReferences(tx, ticketDir, renameMap); err != nil {
+			return fmt.Errorf("failed to update references: %w", err)
 		}
 	}
-
-	return &RenumberResult{
-		Ticket:      settings.Ticket,
-		Renamed:     len(renameMap),
-		Path:        ticketDir,
-		CompletedAt: time.Now(),
-	}, nil
+	return nil
 }
 
 func (c *RenumberCommand) Run(
@@ -244,6 +261,9 @@ func (c *RenumberCommand) Run(
 	fmt.Printf("- Files renamed: %d\n", result.Renamed)
 	fmt.Printf("- Path: %s\n", result.Path)
 	fmt.Printf("- Completed: %s\n", result.CompletedAt.Format(time.RFC3339))
+	if result.OpID != "" {
+		fmt.Printf("- Undo: docmgr undo %s\n", result.OpID)
+	}
 
 	return nil
 }
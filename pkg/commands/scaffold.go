@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/go-go-golems/docmgr/internal/blueprints"
 	"github.com/go-go-golems/docmgr/internal/templates"
 )
 
@@ -90,3 +91,22 @@ func scaffoldTemplatesAndGuidelines(root string, force bool) error {
 
 	return nil
 }
+
+// scaffoldBlueprints copies the built-in ticket blueprints into _blueprints/
+// so they can be customized per docs root.
+func scaffoldBlueprints(root string, force bool) error {
+	dir := blueprints.Dir(root)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create blueprints directory: %w", err)
+	}
+	builtin, err := blueprints.Builtin()
+	if err != nil {
+		return fmt.Errorf("failed to list built-in blueprints: %w", err)
+	}
+	for name, data := range builtin {
+		if err := writeFileIfNotExists(filepath.Join(dir, name+".yaml"), data, force); err != nil {
+			return fmt.Errorf("failed to write blueprint %s: %w", name, err)
+		}
+	}
+	return nil
+}
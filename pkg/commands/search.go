@@ -35,6 +35,8 @@ type SearchSettings struct {
 	Files               bool     `glazed:"files"`
 	File                string   `glazed:"file"`
 	Dir                 string   `glazed:"dir"`
+	Where               string   `glazed:"where"`
+	View                string   `glazed:"view"`
 	ExternalSource      string   `glazed:"external-source"`
 	Since               string   `glazed:"since"`
 	Until               string   `glazed:"until"`
@@ -57,6 +59,7 @@ The search command supports:
 - Metadata filtering (ticket, topics, doc-type, status)
 - File suggestions using heuristics (--files flag)
 - Reverse lookup: find docs for a file/directory (--file, --dir)
+- Structured query expressions (--where)
 - External source search (--external-source)
 - Date range filtering (--since, --until, --created-since, --updated-since)
 
@@ -72,6 +75,14 @@ Examples:
   docmgr search --file pkg/commands/add.go
   docmgr search --dir pkg/commands/
 
+  # Query expressions (AND/OR/NOT, parentheses, key:value terms)
+  docmgr search --where 'status:active AND topic:api AND NOT owner:bob AND updated>2026-01-01'
+  docmgr search --where '(doctype:design-doc OR doctype:reference) title:*websocket*'
+
+  # Saved views (docmgr view save ...)
+  docmgr search --view my-active-api
+  docmgr search --view my-active-api --status review
+
   # Time-based filters (relative or absolute)
   docmgr search --updated-since "2 weeks ago"
   docmgr search --created-since "2025-01-01" --until "2025-01-31"
@@ -131,10 +142,22 @@ Examples:
 					fields.WithHelp("Find documents in this directory or referencing files in it"),
 					fields.WithDefault(""),
 				),
+				fields.New(
+					"where",
+					fields.TypeString,
+					fields.WithHelp("Query expression, e.g. 'status:active AND topic:api AND NOT owner:bob AND updated>2026-01-01' (see 'docmgr help how-to-use')"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"view",
+					fields.TypeString,
+					fields.WithHelp("Run a saved view (see 'docmgr view list'); flags given alongside override it"),
+					fields.WithDefault(""),
+				),
 				fields.New(
 					"external-source",
 					fields.TypeString,
-					fields.WithHelp("Find documents that reference this external source URL"),
+					fields.WithHelp("Find documents with an external source whose type, path, URL, local copy or SHA contains this text"),
 					fields.WithDefault(""),
 				),
 				fields.New(
@@ -193,9 +216,17 @@ func (c *SearchCommand) RunIntoGlazeProcessor(
 	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
 		return fmt.Errorf("failed to parse settings: %w", err)
 	}
+	clearDefaulted(parsedValues, "order-by", &settings.OrderBy)
+	return c.searchIntoGlaze(ctx, settings, gp)
+}
 
+// searchIntoGlaze runs a search for already-decoded settings (also used by `view run`).
+func (c *SearchCommand) searchIntoGlaze(ctx context.Context, settings *SearchSettings, gp middlewares.Processor) error {
 	// Apply config root if present
 	settings.Root = workspace.ResolveRoot(settings.Root)
+	if err := applySearchView(settings); err != nil {
+		return err
+	}
 
 	// If only printing template schema, skip all other processing and output
 	if settings.PrintTemplateSchema {
@@ -234,7 +265,7 @@ func (c *SearchCommand) RunIntoGlazeProcessor(
 
 	// Validate that we have at least a query or some filters
 	if settings.Query == "" && settings.Ticket == "" && len(settings.Topics) == 0 && settings.DocType == "" && settings.Status == "" &&
-		settings.File == "" && settings.Dir == "" && settings.ExternalSource == "" &&
+		settings.File == "" && settings.Dir == "" && settings.Where == "" && settings.ExternalSource == "" &&
 		settings.Since == "" && settings.Until == "" && settings.CreatedSince == "" && settings.UpdatedSince == "" {
 		return fmt.Errorf("must provide at least a query or filter")
 	}
@@ -267,6 +298,7 @@ func (c *SearchCommand) RunIntoGlazeProcessor(
 		Status:              strings.TrimSpace(settings.Status),
 		File:                strings.TrimSpace(settings.File),
 		Dir:                 strings.TrimSpace(settings.Dir),
+		Where:               strings.TrimSpace(settings.Where),
 		ExternalSource:      strings.TrimSpace(settings.ExternalSource),
 		Since:               strings.TrimSpace(settings.Since),
 		Until:               strings.TrimSpace(settings.Until),
@@ -364,7 +396,16 @@ func (c *SearchCommand) Run(
 	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
 		return fmt.Errorf("failed to parse settings: %w", err)
 	}
+	clearDefaulted(parsedValues, "order-by", &settings.OrderBy)
+	return c.searchHuman(ctx, settings)
+}
+
+// searchHuman runs a search for already-decoded settings and prints human output.
+func (c *SearchCommand) searchHuman(ctx context.Context, settings *SearchSettings) error {
 	settings.Root = workspace.ResolveRoot(settings.Root)
+	if err := applySearchView(settings); err != nil {
+		return err
+	}
 
 	// If only printing template schema, skip all other processing and output
 	if settings.PrintTemplateSchema {
@@ -447,6 +488,7 @@ func (c *SearchCommand) Run(
 		Status:              strings.TrimSpace(settings.Status),
 		File:                strings.TrimSpace(settings.File),
 		Dir:                 strings.TrimSpace(settings.Dir),
+		Where:               strings.TrimSpace(settings.Where),
 		ExternalSource:      strings.TrimSpace(settings.ExternalSource),
 		Since:               strings.TrimSpace(settings.Since),
 		Until:               strings.TrimSpace(settings.Until),
@@ -0,0 +1,217 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/sources"
+	"github.com/go-go-golems/docmgr/internal/tickets"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/glazed/pkg/cmds"
+	"github.com/go-go-golems/glazed/pkg/cmds/fields"
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+	"github.com/go-go-golems/glazed/pkg/middlewares"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+// SourcesCheckCommand verifies local source copies against their recorded SHAs.
+type SourcesCheckCommand struct {
+	*cmds.CommandDescription
+}
+
+// SourcesCheckSettings holds the parameters for the sources check command.
+type SourcesCheckSettings struct {
+	Root        string `glazed:"root"`
+	Ticket      string `glazed:"ticket"`
+	All         bool   `glazed:"all"`
+	FailOnDrift bool   `glazed:"fail-on-drift"`
+}
+
+func NewSourcesCheckCommand() (*SourcesCheckCommand, error) {
+	return &SourcesCheckCommand{
+		CommandDescription: cmds.NewCommandDescription(
+			"check",
+			cmds.WithShort("Recompute SHAs of local source copies and report drift"),
+			cmds.WithLong(`Recomputes the SHA-256 of every local source copy recorded in ExternalSources
+(see 'docmgr import file' / 'docmgr import url') and compares it with the
+recorded SHA.
+
+Statuses:
+  modified    the copy under sources/ was changed after it was imported
+  missing     the copy under sources/ no longer exists
+  stale       the copy is intact, but the original file it was imported from
+              changed since (re-import with --refresh)
+  unrecorded  the entry has no SHA to compare against (legacy string entries)
+  ok          the copy matches its recorded SHA
+
+Only entries with a problem are listed unless --all is given. Sources without
+a local copy (plain URLs) are not checked.
+
+Examples:
+  docmgr sources check
+  docmgr sources check --ticket MEN-4242 --all
+
+  # Fail CI when a source copy was modified or deleted
+  docmgr sources check --fail-on-drift
+`),
+			cmds.WithFlags(
+				fields.New(
+					"root",
+					fields.TypeString,
+					fields.WithHelp("Root directory for docs"),
+					fields.WithDefault("ttmp"),
+				),
+				fields.New(
+					"ticket",
+					fields.TypeString,
+					fields.WithHelp("Limit to a specific ticket"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"all",
+					fields.TypeBool,
+					fields.WithHelp("Also list sources that match their recorded SHA"),
+					fields.WithDefault(false),
+				),
+				fields.New(
+					"fail-on-drift",
+					fields.TypeBool,
+					fields.WithHelp("Exit with an error when a copy is modified or missing"),
+					fields.WithDefault(false),
+				),
+			),
+		),
+	}, nil
+}
+
+func (c *SourcesCheckCommand) RunIntoGlazeProcessor(
+	ctx context.Context,
+	parsedValues *values.Values,
+	gp middlewares.Processor,
+) error {
+	settings := &SourcesCheckSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	drifts, root, err := checkSources(ctx, settings)
+	if err != nil {
+		return err
+	}
+	for _, d := range drifts {
+		row := types.NewRow(
+			types.MRP(ColTicket, d.Ticket),
+			types.MRP("source", d.Source.String()),
+			types.MRP("local_copy", relToRoot(root, d.CopyPath)),
+			types.MRP("status", string(d.Status)),
+			types.MRP("recorded_sha", d.Source.SHA),
+			types.MRP("current_sha", d.CurrentSHA),
+			types.MRP("origin_sha", d.OriginSHA),
+		)
+		if err := gp.AddRow(ctx, row); err != nil {
+			return fmt.Errorf("failed to emit sources check row: %w", err)
+		}
+	}
+	return sourcesDriftError(settings, drifts)
+}
+
+var _ cmds.GlazeCommand = &SourcesCheckCommand{}
+
+// Run implements cmds.BareCommand.
+func (c *SourcesCheckCommand) Run(
+	ctx context.Context,
+	parsedValues *values.Values,
+) error {
+	settings := &SourcesCheckSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	drifts, root, err := checkSources(ctx, settings)
+	if err != nil {
+		return err
+	}
+	if len(drifts) == 0 {
+		fmt.Println("No source drift: all local source copies match their recorded SHA.")
+		return nil
+	}
+	for _, d := range drifts {
+		fmt.Printf("%-10s %s %s (%s)\n", d.Status, d.Ticket, relToRoot(root, d.CopyPath), describeDrift(d))
+	}
+	return sourcesDriftError(settings, drifts)
+}
+
+var _ cmds.BareCommand = &SourcesCheckCommand{}
+
+// checkSources runs sources.Check and drops "ok" entries unless --all is set.
+func checkSources(ctx context.Context, settings *SourcesCheckSettings) ([]sources.Drift, string, error) {
+	settings.Root = workspace.ResolveRoot(settings.Root)
+	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: settings.Root})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to discover workspace: %w", err)
+	}
+	settings.Root = ws.Context().Root
+	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
+		return nil, "", fmt.Errorf("failed to initialize workspace index: %w", err)
+	}
+
+	ticket := ""
+	if strings.TrimSpace(settings.Ticket) != "" {
+		ticket, err = tickets.ResolveTicketID(ctx, ws, settings.Ticket)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to resolve ticket %q: %w", settings.Ticket, err)
+		}
+	}
+	drifts, err := sources.Check(ctx, ws, sources.CheckOptions{Ticket: ticket})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to check sources: %w", err)
+	}
+	if settings.All {
+		return drifts, settings.Root, nil
+	}
+	out := drifts[:0]
+	for _, d := range drifts {
+		if d.Status != sources.DriftOK {
+			out = append(out, d)
+		}
+	}
+	return out, settings.Root, nil
+}
+
+func describeDrift(d sources.Drift) string {
+	switch d.Status {
+	case sources.DriftOK:
+		return "matches sha256 " + shortSHA(d.CurrentSHA)
+	case sources.DriftModified:
+		return fmt.Sprintf("recorded sha256 %s, now %s", shortSHA(d.Source.SHA), shortSHA(d.CurrentSHA))
+	case sources.DriftMissing:
+		return "local copy not found"
+	case sources.DriftStale:
+		return fmt.Sprintf("origin %s changed (sha256 %s); re-import with --refresh", d.Source.Path, shortSHA(d.OriginSHA))
+	case sources.DriftUnrecorded:
+		return "no SHA recorded; re-import to record one"
+	}
+	return string(d.Status)
+}
+
+// sourcesDriftError fails the command for modified/missing copies with --fail-on-drift.
+func sourcesDriftError(settings *SourcesCheckSettings, drifts []sources.Drift) error {
+	if !settings.FailOnDrift {
+		return nil
+	}
+	n := 0
+	for _, d := range drifts {
+		if d.Status == sources.DriftModified || d.Status == sources.DriftMissing {
+			n++
+		}
+	}
+	switch {
+	case n == 1:
+		return fmt.Errorf("1 source copy drifted from its recorded SHA")
+	case n > 1:
+		return fmt.Errorf("%d source copies drifted from their recorded SHA", n)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/stalecode"
+	"github.com/go-go-golems/docmgr/internal/tickets"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/glazed/pkg/cmds"
+	"github.com/go-go-golems/glazed/pkg/cmds/fields"
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+	"github.com/go-go-golems/glazed/pkg/middlewares"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+// StaleCommand lists documents whose related files changed in git since the doc was last updated.
+type StaleCommand struct {
+	*cmds.CommandDescription
+}
+
+// StaleSettings holds the parameters for the stale command.
+type StaleSettings struct {
+	Root            string `glazed:"root"`
+	Ticket          string `glazed:"ticket"`
+	IncludeArchived bool   `glazed:"include-archived"`
+}
+
+func NewStaleCommand() (*StaleCommand, error) {
+	return &StaleCommand{
+		CommandDescription: cmds.NewCommandDescription(
+			"stale",
+			cmds.WithShort("List docs whose related files changed in git after the doc's LastUpdated"),
+			cmds.WithLong(`Compares each document's LastUpdated with the git history of its RelatedFiles
+and lists the files that have commits newer than the document, with the commit
+count and the last author per file.
+
+Files outside a git repository and documents without LastUpdated are skipped.
+Refresh a doc after reviewing it (e.g. 'docmgr meta update' or any edit through
+docmgr) to clear it. 'docmgr doctor --stale-code' reports the same findings as
+stale_code warnings.
+
+Examples:
+  docmgr stale
+  docmgr stale --ticket MEN-4242
+  docmgr stale --with-glaze-output --output json
+`),
+			cmds.WithFlags(
+				fields.New(
+					"root",
+					fields.TypeString,
+					fields.WithHelp("Root directory for docs"),
+					fields.WithDefault("ttmp"),
+				),
+				fields.New(
+					"ticket",
+					fields.TypeString,
+					fields.WithHelp("Limit to a specific ticket"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"include-archived",
+					fields.TypeBool,
+					fields.WithHelp("Include documents under archive/"),
+					fields.WithDefault(false),
+				),
+			),
+		),
+	}, nil
+}
+
+func (c *StaleCommand) RunIntoGlazeProcessor(
+	ctx context.Context,
+	parsedValues *values.Values,
+	gp middlewares.Processor,
+) error {
+	settings := &StaleSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	reports, root, err := scanStaleCode(ctx, settings)
+	if err != nil {
+		return err
+	}
+	for _, rep := range reports {
+		for _, f := range rep.Files {
+			row := types.NewRow(
+				types.MRP(ColTicket, rep.Ticket),
+				types.MRP(ColPath, relToRoot(root, rep.Path)),
+				types.MRP(ColLastUpdated, rep.LastUpdated.Format("2006-01-02 15:04")),
+				types.MRP("file", f.Path),
+				types.MRP("commits", f.Commits),
+				types.MRP("last_author", f.LastAuthor),
+				types.MRP("last_commit", f.LastCommit),
+				types.MRP("last_commit_at", f.LastCommitAt.Format("2006-01-02 15:04")),
+			)
+			if err := gp.AddRow(ctx, row); err != nil {
+				return fmt.Errorf("failed to emit stale row: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+var _ cmds.GlazeCommand = &StaleCommand{}
+
+// Run implements cmds.BareCommand, grouping changed files under each doc.
+func (c *StaleCommand) Run(
+	ctx context.Context,
+	parsedValues *values.Values,
+) error {
+	settings := &StaleSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	reports, root, err := scanStaleCode(ctx, settings)
+	if err != nil {
+		return err
+	}
+	if len(reports) == 0 {
+		fmt.Println("No stale documents: no related file changed after its doc's LastUpdated.")
+		return nil
+	}
+	for i, rep := range reports {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s %s (LastUpdated %s)\n", rep.Ticket, relToRoot(root, rep.Path), rep.LastUpdated.Format("2006-01-02"))
+		for _, f := range rep.Files {
+			fmt.Printf("  - %s: %s\n", f.Path, describeFileChange(f))
+		}
+	}
+	return nil
+}
+
+var _ cmds.BareCommand = &StaleCommand{}
+
+func scanStaleCode(ctx context.Context, settings *StaleSettings) ([]stalecode.DocReport, string, error) {
+	settings.Root = workspace.ResolveRoot(settings.Root)
+	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: settings.Root})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to discover workspace: %w", err)
+	}
+	settings.Root = ws.Context().Root
+	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
+		return nil, "", fmt.Errorf("failed to initialize workspace index: %w", err)
+	}
+
+	ticket := ""
+	if strings.TrimSpace(settings.Ticket) != "" {
+		ticket, err = tickets.ResolveTicketID(ctx, ws, settings.Ticket)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to resolve ticket %q: %w", settings.Ticket, err)
+		}
+	}
+	reports, err := stalecode.Scan(ctx, ws, stalecode.Options{Ticket: ticket, IncludeArchived: settings.IncludeArchived})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to scan related files: %w", err)
+	}
+	return reports, settings.Root, nil
+}
+
+// describeFileChange renders "3 commit(s) since, last by Alice on 2026-02-01 (abc1234)".
+func describeFileChange(f stalecode.FileChange) string {
+	return fmt.Sprintf("%d commit(s) since, last by %s on %s (%s)", f.Commits, f.LastAuthor, f.LastCommitAt.Format("2006-01-02"), f.LastCommit)
+}
+
+func relToRoot(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
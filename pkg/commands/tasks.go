@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/go-go-golems/docmgr/internal/hooks"
 	"github.com/go-go-golems/docmgr/internal/tasksmd"
 	"github.com/go-go-golems/docmgr/internal/templates"
 	"github.com/go-go-golems/docmgr/internal/workspace"
@@ -26,10 +27,17 @@ type parsedTask struct {
 	TaskIndex int
 	LineIndex int
 	Checked   bool
-	Text      string
+	// Text is the task text without the stable-ID marker and the trailing
+	// inline attributes.
+	Text string
 	// StableID is the invisible "<!-- t:xxxx -->" marker ID, empty for
 	// unmarked (legacy) tasks that are addressed by position only.
 	StableID string
+	// Attrs are the inline attributes (@assignee, due:, !priority, #tag).
+	Attrs tasksmd.Attrs
+	// FullText is the text as written (attributes included, marker removed);
+	// rewrites that only touch the checkbox or marker use it verbatim.
+	FullText string
 }
 
 // DisplayID returns the identifier shown to users: the stable ID when the
@@ -41,6 +49,11 @@ func (t parsedTask) DisplayID() string {
 	return strconv.Itoa(t.TaskIndex)
 }
 
+// attrSuffix returns the attribute tokens as written after the task text.
+func (t parsedTask) attrSuffix() string {
+	return strings.TrimSpace(strings.TrimPrefix(t.FullText, t.Text))
+}
+
 // removed unused regex to satisfy linter; parsing handled in code below
 
 func loadTasksFile(ctx context.Context, root string, ticket string, tasksFile string) (string, []string, []parsedTask, error) {
@@ -124,9 +137,10 @@ func parseTasksFromLines(lines []string) []parsedTask {
 			if pos >= 0 {
 				text = strings.TrimSpace(trimmed[pos+1:])
 			}
-			stableID, cleanText := tasksmd.ExtractStableID(text)
+			stableID, fullText := tasksmd.ExtractStableID(text)
+			cleanText, attrs := tasksmd.ExtractAttrs(fullText)
 			idx++
-			tasks = append(tasks, parsedTask{TaskIndex: idx, LineIndex: i, Checked: checked, Text: cleanText, StableID: stableID})
+			tasks = append(tasks, parsedTask{TaskIndex: idx, LineIndex: i, Checked: checked, Text: cleanText, StableID: stableID, Attrs: attrs, FullText: fullText})
 		}
 	}
 	return tasks
@@ -245,12 +259,16 @@ func NewTasksListCommand() (*TasksListCommand, error) {
 		cmds.WithLong(`List checkbox tasks found in the ticket's tasks.md.
 
 Columns:
-  id,index,checked,text
+  id,index,checked,text,assignees,due,priority,tags
 
 'id' is the stable task ID (from the invisible '<!-- t:xxxx -->' marker) when
 present, else the 1-based position. Stamp markers onto old task files with
 'docmgr task migrate --ticket <ID>'.
 
+'text' excludes the inline attributes written at the end of a task line
+(@assignee, due:YYYY-MM-DD, !high|!medium|!low, #tag); they have their own
+columns.
+
 Examples:
   # Human output
   docmgr task list --ticket MEN-4242
@@ -281,10 +299,14 @@ func (c *TasksListCommand) RunIntoGlazeProcessor(ctx context.Context, pl *values
 	// If only printing template schema, skip all other processing and output
 	if s.PrintTemplateSchema {
 		type TaskInfo struct {
-			ID      string
-			Index   int
-			Checked bool
-			Text    string
+			ID        string
+			Index     int
+			Checked   bool
+			Text      string
+			Assignees []string
+			Due       string
+			Priority  string
+			Tags      []string
 		}
 		templateData := map[string]interface{}{
 			"TotalTasks": 0,
@@ -293,10 +315,14 @@ func (c *TasksListCommand) RunIntoGlazeProcessor(ctx context.Context, pl *values
 			"TasksFile":  "",
 			"Tasks": []TaskInfo{
 				{
-					ID:      "",
-					Index:   0,
-					Checked: false,
-					Text:    "",
+					ID:        "",
+					Index:     0,
+					Checked:   false,
+					Text:      "",
+					Assignees: []string{},
+					Due:       "",
+					Priority:  "",
+					Tags:      []string{},
 				},
 			},
 		}
@@ -314,6 +340,10 @@ func (c *TasksListCommand) RunIntoGlazeProcessor(ctx context.Context, pl *values
 			types.MRP(ColIndex, t.TaskIndex),
 			types.MRP(ColChecked, t.Checked),
 			types.MRP(ColText, t.Text),
+			types.MRP("assignees", t.Attrs.Assignees),
+			types.MRP("due", t.Attrs.Due),
+			types.MRP("priority", t.Attrs.Priority),
+			types.MRP("tags", t.Attrs.Tags),
 		)
 		if err := gp.AddRow(ctx, row); err != nil {
 			return fmt.Errorf("failed to emit tasks list row %d: %w", t.TaskIndex, err)
@@ -337,10 +367,14 @@ func (c *TasksListCommand) Run(ctx context.Context, pl *values.Values) error {
 	// If only printing template schema, skip all other processing and output
 	if s.PrintTemplateSchema {
 		type TaskInfo struct {
-			ID      string
-			Index   int
-			Checked bool
-			Text    string
+			ID        string
+			Index     int
+			Checked   bool
+			Text      string
+			Assignees []string
+			Due       string
+			Priority  string
+			Tags      []string
 		}
 		templateData := map[string]interface{}{
 			"TotalTasks": 0,
@@ -349,10 +383,14 @@ func (c *TasksListCommand) Run(ctx context.Context, pl *values.Values) error {
 			"TasksFile":  "",
 			"Tasks": []TaskInfo{
 				{
-					ID:      "",
-					Index:   0,
-					Checked: false,
-					Text:    "",
+					ID:        "",
+					Index:     0,
+					Checked:   false,
+					Text:      "",
+					Assignees: []string{},
+					Due:       "",
+					Priority:  "",
+					Tags:      []string{},
 				},
 			},
 		}
@@ -376,16 +414,20 @@ func (c *TasksListCommand) Run(ctx context.Context, pl *values.Values) error {
 		if t.Checked {
 			mark = "x"
 		}
-		fmt.Printf("[%s] [%s] %s\n", t.DisplayID(), mark, t.Text)
+		fmt.Printf("[%s] [%s] %s\n", t.DisplayID(), mark, tasksmd.JoinAttrs(t.Text, t.Attrs))
 	}
 
 	// Render postfix template if it exists
 	// Build template data struct
 	type TaskInfo struct {
-		ID      string
-		Index   int
-		Checked bool
-		Text    string
+		ID        string
+		Index     int
+		Checked   bool
+		Text      string
+		Assignees []string
+		Due       string
+		Priority  string
+		Tags      []string
 	}
 
 	taskInfos := make([]TaskInfo, 0, len(tasks))
@@ -393,10 +435,14 @@ func (c *TasksListCommand) Run(ctx context.Context, pl *values.Values) error {
 	doneTasks := 0
 	for _, t := range tasks {
 		taskInfos = append(taskInfos, TaskInfo{
-			ID:      t.DisplayID(),
-			Index:   t.TaskIndex,
-			Checked: t.Checked,
-			Text:    t.Text,
+			ID:        t.DisplayID(),
+			Index:     t.TaskIndex,
+			Checked:   t.Checked,
+			Text:      t.Text,
+			Assignees: t.Attrs.Assignees,
+			Due:       t.Attrs.Due,
+			Priority:  t.Attrs.Priority,
+			Tags:      t.Attrs.Tags,
 		})
 		if t.Checked {
 			doneTasks++
@@ -437,11 +483,15 @@ var _ cmds.BareCommand = &TasksListCommand{}
 type TasksAddCommand struct{ *cmds.CommandDescription }
 
 type TasksAddSettings struct {
-	Ticket    string `glazed:"ticket"`
-	Root      string `glazed:"root"`
-	TasksFile string `glazed:"tasks-file"`
-	Text      string `glazed:"text"`
-	After     int    `glazed:"after"`
+	Ticket    string   `glazed:"ticket"`
+	Root      string   `glazed:"root"`
+	TasksFile string   `glazed:"tasks-file"`
+	Text      string   `glazed:"text"`
+	After     int      `glazed:"after"`
+	Assignees []string `glazed:"assignee"`
+	Due       string   `glazed:"due"`
+	Priority  string   `glazed:"priority"`
+	Tags      []string `glazed:"tag"`
 }
 
 func NewTasksAddCommand() (*TasksAddCommand, error) {
@@ -456,6 +506,12 @@ Examples:
 
   # Insert after an existing task index
   docmgr task add --ticket MEN-4242 --text "Add tests" --after 1
+
+  # Assign, schedule and prioritize (written inline: "@alice due:2026-11-01 !high")
+  docmgr task add --ticket MEN-4242 --text "Design routes" --assignee alice --due 2026-11-01 --priority high
+
+Inline attributes can also be typed at the end of --text:
+  @name (assignee), due:YYYY-MM-DD, !high|!medium|!low (priority), #tag
 `),
 		cmds.WithFlags(
 			fields.New("ticket", fields.TypeString, fields.WithHelp("Ticket identifier (if --tasks-file not set)"), fields.WithDefault("")),
@@ -463,6 +519,10 @@ Examples:
 			fields.New("tasks-file", fields.TypeString, fields.WithHelp("Path to tasks.md (overrides --ticket)"), fields.WithDefault("")),
 			fields.New("text", fields.TypeString, fields.WithHelp("Task text to add"), fields.WithRequired(true)),
 			fields.New("after", fields.TypeInteger, fields.WithHelp("Insert after given task index (0=append)"), fields.WithDefault(0)),
+			fields.New("assignee", fields.TypeStringList, fields.WithHelp("Assignees (written as @name)"), fields.WithDefault([]string{})),
+			fields.New("due", fields.TypeString, fields.WithHelp("Due date YYYY-MM-DD (written as due:YYYY-MM-DD)"), fields.WithDefault("")),
+			fields.New("priority", fields.TypeString, fields.WithHelp("Priority: high, medium or low (written as !priority)"), fields.WithDefault("")),
+			fields.New("tag", fields.TypeStringList, fields.WithHelp("Tags (written as #tag)"), fields.WithDefault([]string{})),
 		),
 	)
 	return &TasksAddCommand{CommandDescription: cmd}, nil
@@ -475,8 +535,13 @@ func (c *TasksAddCommand) applyTaskAdd(ctx context.Context, s *TasksAddSettings)
 	if err != nil {
 		return "", 0, "", fmt.Errorf("failed to load tasks file: %w", err)
 	}
+	flagAttrs, err := tasksmd.NormalizeAttrs(tasksmd.Attrs{Assignees: s.Assignees, Due: s.Due, Priority: s.Priority, Tags: s.Tags})
+	if err != nil {
+		return "", 0, "", err
+	}
+	text, attrs := tasksmd.ExtractAttrs(s.Text)
 	stableID := tasksmd.NewStableID(existingStableIDs(tasks))
-	newLine := formatTaskLine(false, strings.TrimSpace(s.Text), stableID)
+	newLine := formatTaskLine(false, tasksmd.JoinAttrs(text, attrs.Merge(flagAttrs)), stableID)
 	if s.After <= 0 || len(tasks) == 0 {
 		lines = append(lines, newLine)
 	} else {
@@ -501,6 +566,7 @@ func (c *TasksAddCommand) applyTaskAdd(ctx context.Context, s *TasksAddSettings)
 			newIndex = t.TaskIndex
 		}
 	}
+	fireHook(ctx, hooks.EventTaskAdded, ticketForPath(s.Root, path), path, map[string]any{"id": stableID, "text": strings.TrimSpace(s.Text)})
 	return path, newIndex, stableID, nil
 }
 
@@ -601,11 +667,16 @@ func applyTaskMark(ctx context.Context, root string, ticket string, tasksFile st
 		return "", nil, nil, fmt.Errorf("no target task specified")
 	}
 	for _, t := range targets {
-		lines[t.LineIndex] = formatTaskLine(checked, t.Text, t.StableID)
+		lines[t.LineIndex] = formatTaskLine(checked, t.FullText, t.StableID)
 	}
 	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
 		return "", nil, nil, fmt.Errorf("failed to write tasks file %s: %w", path, err)
 	}
+	event := hooks.EventTaskUnchecked
+	if checked {
+		event = hooks.EventTaskChecked
+	}
+	fireHook(ctx, event, ticketForPath(root, path), path, taskHookData(targets))
 	return path, targets, parseTasksFromLines(lines), nil
 }
 
@@ -786,7 +857,8 @@ func NewTasksEditCommand() (*TasksEditCommand, error) {
 		cmds.WithLong(`Edit the text of a checkbox task in tasks.md.
 
 --id accepts a stable task ID (e.g. ab12, shown by 'task list') or a 1-based
-position.
+position. Inline attributes (@assignee, due:, !priority, #tag) are kept unless
+the new text ends with attributes of its own.
 
 Examples:
   docmgr task edit --ticket MEN-4242 --id ab12 --text "Updated task text"
@@ -846,13 +918,32 @@ func editTaskLine(ctx context.Context, s *TasksEditSettings) (string, error) {
 		return "", err
 	}
 	target := targets[0]
-	lines[target.LineIndex] = formatTaskLine(target.Checked, strings.TrimSpace(s.Text), target.StableID)
+	// New text without attributes of its own keeps the task's existing ones.
+	newText := strings.TrimSpace(s.Text)
+	if _, attrs := tasksmd.ExtractAttrs(newText); attrs.IsZero() && target.attrSuffix() != "" {
+		newText += " " + target.attrSuffix()
+	}
+	lines[target.LineIndex] = formatTaskLine(target.Checked, newText, target.StableID)
 	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
 		return "", fmt.Errorf("failed to write tasks file %s: %w", path, err)
 	}
+	fireHook(ctx, hooks.EventTaskEdited, ticketForPath(s.Root, path), path, map[string]any{
+		"id":      target.DisplayID(),
+		"oldText": target.Text,
+		"text":    strings.TrimSpace(s.Text),
+	})
 	return path, nil
 }
 
+// taskHookData describes the tasks affected by a write for hook payloads.
+func taskHookData(tasks []parsedTask) map[string]any {
+	items := make([]map[string]any, 0, len(tasks))
+	for _, t := range tasks {
+		items = append(items, map[string]any{"id": t.DisplayID(), "text": t.Text})
+	}
+	return map[string]any{"tasks": items}
+}
+
 // tasks remove
 type TasksRemoveCommand struct{ *cmds.CommandDescription }
 
@@ -912,6 +1003,7 @@ func (c *TasksRemoveCommand) applyTaskRemove(ctx context.Context, s *TasksRemove
 	if err := os.WriteFile(path, []byte(strings.Join(newLines, "\n")+"\n"), 0644); err != nil {
 		return "", nil, nil, fmt.Errorf("failed to write tasks file %s: %w", path, err)
 	}
+	fireHook(ctx, hooks.EventTaskRemoved, ticketForPath(s.Root, path), path, taskHookData(targets))
 	return path, targets, parseTasksFromLines(newLines), nil
 }
 
@@ -1000,7 +1092,7 @@ func applyTaskMigrate(ctx context.Context, root string, ticket string, tasksFile
 		}
 		id := tasksmd.NewStableID(existing)
 		existing[id] = struct{}{}
-		lines[t.LineIndex] = formatTaskLine(t.Checked, t.Text, id)
+		lines[t.LineIndex] = formatTaskLine(t.Checked, t.FullText, id)
 		stamped++
 	}
 	if stamped > 0 {
@@ -0,0 +1,251 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/tasksmd"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/glazed/pkg/cmds"
+	"github.com/go-go-golems/glazed/pkg/cmds/fields"
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+	"github.com/go-go-golems/glazed/pkg/middlewares"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+// tasks query
+type TasksQueryCommand struct{ *cmds.CommandDescription }
+
+type TasksQuerySettings struct {
+	Root            string   `glazed:"root"`
+	Ticket          string   `glazed:"ticket"`
+	Unchecked       bool     `glazed:"unchecked"`
+	Checked         bool     `glazed:"checked"`
+	Topics          []string `glazed:"topics"`
+	Owners          []string `glazed:"owner"`
+	Status          string   `glazed:"status"`
+	Section         string   `glazed:"section"`
+	Match           string   `glazed:"match"`
+	Assignee        string   `glazed:"assignee"`
+	Priority        string   `glazed:"priority"`
+	IncludeArchived bool     `glazed:"include-archived"`
+}
+
+func NewTasksQueryCommand() (*TasksQueryCommand, error) {
+	cmd := cmds.NewCommandDescription(
+		"query",
+		cmds.WithShort("Query tasks across all tickets"),
+		cmds.WithLong(`Lists the checkbox tasks of every ticket's tasks.md from the workspace index.
+
+--topics, --owner and --status filter on the ticket (its index.md); --section,
+--match, --assignee and --priority filter on the task itself (--assignee and
+--priority match the inline @name and !priority attributes). Tasks under
+archive/ directories are skipped unless --include-archived is set.
+
+Columns:
+  ticket,id,index,checked,section,text,assignees,due,priority,tags,ticket_title,ticket_status,line,path
+
+Examples:
+  # Open tasks of all api tickets owned by alice
+  docmgr task query --unchecked --topics api --owner alice
+
+  # Open tasks assigned to bob
+  docmgr task query --unchecked --assignee bob
+
+  # Everything still in the TODO section of active tickets, as JSON
+  docmgr task query --status active --section TODO --with-glaze-output --output json
+`),
+		cmds.WithFlags(
+			fields.New(
+				"root",
+				fields.TypeString,
+				fields.WithHelp("Root directory for docs"),
+				fields.WithDefault("ttmp"),
+			),
+			fields.New(
+				"ticket",
+				fields.TypeString,
+				fields.WithHelp("Only tasks of this ticket"),
+				fields.WithDefault(""),
+			),
+			fields.New(
+				"unchecked",
+				fields.TypeBool,
+				fields.WithHelp("Only open tasks"),
+				fields.WithDefault(false),
+			),
+			fields.New(
+				"checked",
+				fields.TypeBool,
+				fields.WithHelp("Only done tasks"),
+				fields.WithDefault(false),
+			),
+			fields.New(
+				"topics",
+				fields.TypeStringList,
+				fields.WithHelp("Only tickets with any of these topics"),
+				fields.WithDefault([]string{}),
+			),
+			fields.New(
+				"owner",
+				fields.TypeStringList,
+				fields.WithHelp("Only tickets with any of these owners"),
+				fields.WithDefault([]string{}),
+			),
+			fields.New(
+				"status",
+				fields.TypeString,
+				fields.WithHelp("Only tickets with this status"),
+				fields.WithDefault(""),
+			),
+			fields.New(
+				"section",
+				fields.TypeString,
+				fields.WithHelp("Only tasks under this tasks.md heading (e.g. TODO)"),
+				fields.WithDefault(""),
+			),
+			fields.New(
+				"match",
+				fields.TypeString,
+				fields.WithHelp("Only tasks whose text contains this substring (case-insensitive)"),
+				fields.WithDefault(""),
+			),
+			fields.New(
+				"assignee",
+				fields.TypeString,
+				fields.WithHelp("Only tasks assigned to this person (@name attribute)"),
+				fields.WithDefault(""),
+			),
+			fields.New(
+				"priority",
+				fields.TypeString,
+				fields.WithHelp("Only tasks with this priority (high, medium, low)"),
+				fields.WithDefault(""),
+			),
+			fields.New(
+				"include-archived",
+				fields.TypeBool,
+				fields.WithHelp("Include tasks.md files under archive/ directories"),
+				fields.WithDefault(false),
+			),
+		),
+	)
+	return &TasksQueryCommand{CommandDescription: cmd}, nil
+}
+
+func (c *TasksQueryCommand) RunIntoGlazeProcessor(ctx context.Context, pl *values.Values, gp middlewares.Processor) error {
+	s := &TasksQuerySettings{}
+	if err := pl.DecodeSectionInto(schema.DefaultSlug, s); err != nil {
+		return fmt.Errorf("failed to parse tasks query settings: %w", err)
+	}
+	root, tasks, err := queryTasks(ctx, s)
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		row := types.NewRow(
+			types.MRP(ColTicket, t.Ticket),
+			types.MRP("id", t.DisplayID()),
+			types.MRP(ColIndex, t.Position),
+			types.MRP(ColChecked, t.Checked),
+			types.MRP("section", t.Section),
+			types.MRP(ColText, t.Text),
+			types.MRP("assignees", t.Attrs.Assignees),
+			types.MRP("due", t.Attrs.Due),
+			types.MRP("priority", t.Attrs.Priority),
+			types.MRP("tags", t.Attrs.Tags),
+			types.MRP("ticket_title", t.TicketTitle),
+			types.MRP("ticket_status", t.TicketStatus),
+			types.MRP("line", t.Line),
+			types.MRP(ColPath, relToRoot(root, filepath.FromSlash(t.Path))),
+		)
+		if err := gp.AddRow(ctx, row); err != nil {
+			return fmt.Errorf("failed to emit tasks query row: %w", err)
+		}
+	}
+	return nil
+}
+
+var _ cmds.GlazeCommand = &TasksQueryCommand{}
+
+func (c *TasksQueryCommand) Run(ctx context.Context, pl *values.Values) error {
+	s := &TasksQuerySettings{}
+	if err := pl.DecodeSectionInto(schema.DefaultSlug, s); err != nil {
+		return fmt.Errorf("failed to parse tasks query settings: %w", err)
+	}
+	_, tasks, err := queryTasks(ctx, s)
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		fmt.Println("No matching tasks.")
+		return nil
+	}
+	open := 0
+	lastTicket := ""
+	for _, t := range tasks {
+		if t.Ticket != lastTicket {
+			if lastTicket != "" {
+				fmt.Println()
+			}
+			header := t.Ticket
+			if t.TicketTitle != "" {
+				header += " — " + t.TicketTitle
+			}
+			if t.TicketStatus != "" {
+				header += " (" + t.TicketStatus + ")"
+			}
+			fmt.Println(header)
+			lastTicket = t.Ticket
+		}
+		mark := " "
+		if t.Checked {
+			mark = "x"
+		} else {
+			open++
+		}
+		fmt.Printf("  [%s] [%s] %s  (%s)\n", t.DisplayID(), mark, tasksmd.JoinAttrs(t.Text, t.Attrs), t.Section)
+	}
+	fmt.Printf("\n%d tasks (%d open)\n", len(tasks), open)
+	return nil
+}
+
+var _ cmds.BareCommand = &TasksQueryCommand{}
+
+// queryTasks discovers the workspace, indexes it, and runs the task query.
+// It returns the resolved docs root with the matching tasks.
+func queryTasks(ctx context.Context, s *TasksQuerySettings) (string, []workspace.TaskHandle, error) {
+	if s.Checked && s.Unchecked {
+		return "", nil, fmt.Errorf("--checked and --unchecked are mutually exclusive")
+	}
+	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: workspace.ResolveRoot(s.Root)})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to discover workspace: %w", err)
+	}
+	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
+		return "", nil, fmt.Errorf("failed to initialize workspace index: %w", err)
+	}
+	f := workspace.TaskFilters{
+		Ticket:              strings.TrimSpace(s.Ticket),
+		Status:              s.Status,
+		TopicsAny:           s.Topics,
+		OwnersAny:           s.Owners,
+		Section:             s.Section,
+		TextContains:        s.Match,
+		Assignee:            s.Assignee,
+		Priority:            s.Priority,
+		IncludeArchivedPath: s.IncludeArchived,
+	}
+	if s.Checked || s.Unchecked {
+		checked := s.Checked
+		f.Checked = &checked
+	}
+	tasks, err := ws.QueryTasks(ctx, f)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to query tasks: %w", err)
+	}
+	return ws.Context().Root, tasks, nil
+}
@@ -36,13 +36,21 @@ func NewTemplateValidateCommand() (*TemplateValidateCommand, error) {
 		CommandDescription: cmds.NewCommandDescription(
 			"validate",
 			cmds.WithShort("Validate template syntax"),
-			cmds.WithLong(`Validates template files for syntax errors before runtime.
+			cmds.WithLong(`Validates template files for errors before runtime.
 
-Validates one or more template files by parsing them with Go's text/template engine.
-Reports syntax errors, undefined functions, and other template issues.
+Validates two kinds of templates, both parsed with Go's text/template engine:
 
-If --path is specified, validates only that template file.
-Otherwise, scans all templates in <root>/templates/ directory.
+  - Verb output templates (<root>/templates/**/*.templ): syntax and helpers.
+  - Document templates (<root>/_templates/*.md, used by 'docmgr doc add'):
+    syntax and helpers, plus field references type-checked against the
+    document data model (.Doc, .Ticket, .Defaults, .Vars, .Root, .Now) and
+    include calls checked against <root>/_templates/_partials/.
+
+Reports syntax errors, undefined functions, unknown fields, and missing partials
+with file:line:col positions.
+
+If --path is specified, validates only that template file (.md files are
+checked as document templates). Otherwise, scans both directories.
 
 Examples:
   # Validate all templates
@@ -50,6 +58,7 @@ Examples:
 
   # Validate a specific template
   docmgr template validate --path templates/status.templ
+  docmgr template validate --path _templates/design-doc.md
 
   # Verbose output showing all validated templates
   docmgr template validate --verbose
@@ -90,6 +99,7 @@ func (c *TemplateValidateCommand) Run(
 	// Resolve root to absolute path
 	root := workspace.ResolveRoot(settings.Root)
 	templatesDir := filepath.Join(root, "templates")
+	docTemplatesDir := filepath.Join(root, "_templates")
 
 	// Get FuncMap for validation (same as used in rendering)
 	funcMap := getTemplateFuncMap()
@@ -109,38 +119,53 @@ func (c *TemplateValidateCommand) Run(
 				templatePath = filepath.Join(templatesDir, settings.Path)
 			}
 		}
+		if _, err := os.Stat(templatePath); err != nil {
+			return fmt.Errorf("template file not found: %s", settings.Path)
+		}
 		templatePaths = []string{templatePath}
 	} else {
 		// Scan all templates
-		if _, err := os.Stat(templatesDir); os.IsNotExist(err) {
-			return fmt.Errorf("templates directory does not exist: %s", templatesDir)
+		_, verbErr := os.Stat(templatesDir)
+		_, docErr := os.Stat(docTemplatesDir)
+		if os.IsNotExist(verbErr) && os.IsNotExist(docErr) {
+			return fmt.Errorf("no templates directory exists: %s or %s", templatesDir, docTemplatesDir)
 		}
-		err := filepath.Walk(templatesDir, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
+		for _, scan := range []struct {
+			dir string
+			ext string
+		}{{templatesDir, ".templ"}, {docTemplatesDir, ".md"}} {
+			if _, err := os.Stat(scan.dir); os.IsNotExist(err) {
+				continue
 			}
-			if !info.IsDir() && strings.HasSuffix(path, ".templ") {
-				templatePaths = append(templatePaths, path)
+			err := filepath.Walk(scan.dir, func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if !info.IsDir() && strings.HasSuffix(path, scan.ext) {
+					templatePaths = append(templatePaths, path)
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to scan templates directory: %w", err)
 			}
-			return nil
-		})
-		if err != nil {
-			return fmt.Errorf("failed to scan templates directory: %w", err)
 		}
 	}
 
 	if len(templatePaths) == 0 {
-		if settings.Path != "" {
-			return fmt.Errorf("template file not found: %s", settings.Path)
-		}
-		fmt.Fprintf(os.Stderr, "No templates found in %s\n", templatesDir)
+		fmt.Fprintf(os.Stderr, "No templates found in %s or %s\n", templatesDir, docTemplatesDir)
 		return nil
 	}
 
 	// Validate each template
 	errors := 0
 	for _, templatePath := range templatePaths {
-		err := validateTemplate(templatePath, funcMap, settings.Verbose)
+		var err error
+		if strings.HasSuffix(templatePath, ".md") {
+			err = validateDocTemplate(root, templatePath)
+		} else {
+			err = validateTemplate(templatePath, funcMap, settings.Verbose)
+		}
 		if err != nil {
 			errors++
 			fmt.Fprintf(os.Stderr, "ERROR: %s: %v\n", templatePath, err)
@@ -190,6 +215,28 @@ func validateTemplate(templatePath string, funcMap template.FuncMap, verbose boo
 	return nil
 }
 
+// validateDocTemplate parses and type-checks a document template (or a
+// partial under _templates/_partials) against the document data model.
+func validateDocTemplate(root string, templatePath string) error {
+	body, offset, err := templates.LoadDocTemplateBody(templatePath)
+	if err != nil {
+		return err
+	}
+	name := filepath.Base(templatePath)
+	var problems []string
+	if filepath.Base(filepath.Dir(templatePath)) == templates.PartialsDir {
+		problems = templates.CheckPartial(root, name, body)
+	} else {
+		problems = templates.CheckDocTemplate(root, name, body, offset)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	msg := strings.Join(problems, "\n  ")
+	tax := docmgrctx.NewTemplateParse(templatePath, msg, nil)
+	return core.WrapWithCause(fmt.Errorf("%s", msg), tax)
+}
+
 // getTemplateFuncMap returns the same FuncMap used in template rendering
 // We use the exported function from templates package
 func getTemplateFuncMap() template.FuncMap {
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/hooks"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/glazed/pkg/cmds"
 	"github.com/go-go-golems/glazed/pkg/cmds/fields"
@@ -30,6 +31,7 @@ type TicketCloseSettings struct {
 	Status         string `glazed:"status"`
 	Intent         string `glazed:"intent"`
 	ChangelogEntry string `glazed:"changelog-entry"`
+	Force          bool   `glazed:"force"`
 }
 
 func NewTicketCloseCommand() (*TicketCloseCommand, error) {
@@ -44,6 +46,9 @@ func NewTicketCloseCommand() (*TicketCloseCommand, error) {
   • Updating LastUpdated timestamp
 
 The command checks if all tasks are done and warns if not, but does not fail.
+When .ttmp.yaml declares a workflow, the status change must be an allowed
+transition whose preconditions hold (e.g. tasks done before "complete");
+--force closes anyway and only warns.
 
 Examples:
   # Close with defaults
@@ -89,6 +94,12 @@ Examples:
 					fields.WithHelp("Changelog entry message (default: 'Ticket closed')"),
 					fields.WithDefault("Ticket closed"),
 				),
+				fields.New(
+					"force",
+					fields.TypeBool,
+					fields.WithHelp("Close even if the workflow does not allow the status transition"),
+					fields.WithDefault(false),
+				),
 			),
 		),
 	}, nil
@@ -135,6 +146,13 @@ func (c *TicketCloseCommand) RunIntoGlazeProcessor(
 	if err != nil {
 		return fmt.Errorf("failed to read ticket index: %w", err)
 	}
+	if settings.Status != "" {
+		if err := enforceTicketTransition(indexPath, doc, settings.Status, settings.Force); err != nil {
+			return err
+		}
+	}
+
+	oldStatus := doc.Status
 
 	// Track what was updated
 	operations := map[string]bool{
@@ -162,6 +180,9 @@ func (c *TicketCloseCommand) RunIntoGlazeProcessor(
 	if err := documents.WriteDocumentWithFrontmatter(indexPath, doc, content, true); err != nil {
 		return fmt.Errorf("failed to write ticket index: %w", err)
 	}
+	if doc.Status != oldStatus {
+		fireHook(ctx, hooks.EventStatusChanged, doc.Ticket, indexPath, map[string]any{"from": oldStatus, "to": doc.Status})
+	}
 
 	// Update changelog
 	changelogPath := filepath.Join(ticketDir, "changelog.md")
@@ -170,22 +191,9 @@ func (c *TicketCloseCommand) RunIntoGlazeProcessor(
 		changelogEntry = "Ticket closed"
 	}
 
-	// Ensure changelog exists
-	if _, err := os.Stat(changelogPath); os.IsNotExist(err) {
-		_ = os.MkdirAll(filepath.Dir(changelogPath), 0755)
-		_ = os.WriteFile(changelogPath, []byte("# Changelog\n\n"), 0644)
-	}
-
-	// Append changelog entry
-	today := time.Now().Format("2006-01-02")
-	entryText := fmt.Sprintf("\n## %s\n\n%s\n\n", today, changelogEntry)
-	fp, err := os.OpenFile(changelogPath, os.O_APPEND|os.O_WRONLY, 0o600)
-	if err != nil {
-		return fmt.Errorf("failed to open changelog: %w", err)
-	}
-	defer func() { _ = fp.Close() }()
-	if _, err := fp.WriteString(entryText); err != nil {
-		return fmt.Errorf("failed to write changelog entry: %w", err)
+	// Append changelog entry (creates changelog.md when missing)
+	if _, err := AppendChangelogEntry(changelogPath, "", changelogEntry, nil); err != nil {
+		return err
 	}
 	operations["changelog_updated"] = true
 
@@ -242,17 +250,22 @@ func (c *TicketCloseCommand) Run(
 	openTasks, doneTasks := countTasksInTicket(ticketDir)
 	allTasksDone := openTasks == 0 && (openTasks+doneTasks) > 0
 
-	// Warn if not all tasks are done
-	if !allTasksDone && (openTasks+doneTasks) > 0 {
-		fmt.Fprintf(os.Stderr, "Warning: Not all tasks are done (%d open, %d done). Closing anyway.\n", openTasks, doneTasks)
-	}
-
 	// Read current index.md
 	indexPath := filepath.Join(ticketDir, "index.md")
 	doc, content, err := documents.ReadDocumentWithFrontmatter(indexPath)
 	if err != nil {
 		return fmt.Errorf("failed to read ticket index: %w", err)
 	}
+	if settings.Status != "" {
+		if err := enforceTicketTransition(indexPath, doc, settings.Status, settings.Force); err != nil {
+			return err
+		}
+	}
+
+	// Warn if not all tasks are done
+	if !allTasksDone && (openTasks+doneTasks) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: Not all tasks are done (%d open, %d done). Closing anyway.\n", openTasks, doneTasks)
+	}
 
 	oldStatus := doc.Status
 	oldIntent := doc.Intent
@@ -274,6 +287,9 @@ func (c *TicketCloseCommand) Run(
 	if err := documents.WriteDocumentWithFrontmatter(indexPath, doc, content, true); err != nil {
 		return fmt.Errorf("failed to write ticket index: %w", err)
 	}
+	if doc.Status != oldStatus {
+		fireHook(ctx, hooks.EventStatusChanged, doc.Ticket, indexPath, map[string]any{"from": oldStatus, "to": doc.Status})
+	}
 
 	// Update changelog
 	changelogPath := filepath.Join(ticketDir, "changelog.md")
@@ -282,22 +298,9 @@ func (c *TicketCloseCommand) Run(
 		changelogEntry = "Ticket closed"
 	}
 
-	// Ensure changelog exists
-	if _, err := os.Stat(changelogPath); os.IsNotExist(err) {
-		_ = os.MkdirAll(filepath.Dir(changelogPath), 0755)
-		_ = os.WriteFile(changelogPath, []byte("# Changelog\n\n"), 0644)
-	}
-
-	// Append changelog entry
-	today := time.Now().Format("2006-01-02")
-	entryText := fmt.Sprintf("\n## %s\n\n%s\n\n", today, changelogEntry)
-	fp, err := os.OpenFile(changelogPath, os.O_APPEND|os.O_WRONLY, 0o600)
-	if err != nil {
-		return fmt.Errorf("failed to open changelog: %w", err)
-	}
-	defer func() { _ = fp.Close() }()
-	if _, err := fp.WriteString(entryText); err != nil {
-		return fmt.Errorf("failed to write changelog entry: %w", err)
+	// Append changelog entry (creates changelog.md when missing)
+	if _, err := AppendChangelogEntry(changelogPath, "", changelogEntry, nil); err != nil {
+		return err
 	}
 
 	// Print human-friendly output
@@ -24,32 +24,42 @@ type TicketGraphCommand struct {
 }
 
 type TicketGraphSettings struct {
-	Ticket             string `glazed:"ticket"`
-	Root               string `glazed:"root"`
-	Format             string `glazed:"format"`
-	Direction          string `glazed:"direction"`
-	Label              string `glazed:"label"`
-	EdgeNotes          string `glazed:"edge-notes"`
-	Depth              int    `glazed:"depth"`
-	Scope              string `glazed:"scope"`
-	ExpandFiles        bool   `glazed:"expand-files"`
-	MaxNodes           int    `glazed:"max-nodes"`
-	MaxEdges           int    `glazed:"max-edges"`
-	BatchSize          int    `glazed:"batch-size"`
-	IncludeControlDocs bool   `glazed:"include-control-docs"`
-	IncludeArchived    bool   `glazed:"include-archived"`
-	IncludeScriptsPath bool   `glazed:"include-scripts-path"`
+	Ticket             string   `glazed:"ticket"`
+	All                bool     `glazed:"all"`
+	Topics             []string `glazed:"topics"`
+	Status             []string `glazed:"status"`
+	IncludeDocs        bool     `glazed:"include-docs"`
+	Root               string   `glazed:"root"`
+	Format             string   `glazed:"format"`
+	Direction          string   `glazed:"direction"`
+	Label              string   `glazed:"label"`
+	EdgeNotes          string   `glazed:"edge-notes"`
+	Depth              int      `glazed:"depth"`
+	Scope              string   `glazed:"scope"`
+	ExpandFiles        bool     `glazed:"expand-files"`
+	MaxNodes           int      `glazed:"max-nodes"`
+	MaxEdges           int      `glazed:"max-edges"`
+	BatchSize          int      `glazed:"batch-size"`
+	IncludeControlDocs bool     `glazed:"include-control-docs"`
+	IncludeArchived    bool     `glazed:"include-archived"`
+	IncludeScriptsPath bool     `glazed:"include-scripts-path"`
 }
 
 func NewTicketGraphCommand() (*TicketGraphCommand, error) {
 	return &TicketGraphCommand{
 		CommandDescription: cmds.NewCommandDescription(
 			"graph",
-			cmds.WithShort("Render a Mermaid graph for a ticket (docs ↔ related files)"),
+			cmds.WithShort("Render a graph for a ticket (docs ↔ related files) or for the whole workspace (--all)"),
 			cmds.WithLong(`Render a Mermaid graph for a ticket showing:
 - all markdown docs in the ticket workspace, and
 - the code files referenced via frontmatter RelatedFiles.
 
+With --all, render the workspace graph instead: one node per ticket (narrow it
+with --topics/--status), DependsOn/Blocks/Supersedes/RelatesTo links (see
+'docmgr ticket link'), and an edge between tickets whose docs relate the same
+files. --include-docs adds each ticket's docs and their related files. The
+workspace graph can also be rendered as Graphviz DOT or JSON (nodes/edges).
+
 With --scope repo and --depth > 0, the command expands the graph transitively:
   docs -> related files -> other docs that reference those files -> ...
 
@@ -67,13 +77,46 @@ Examples:
 
   # Repo-wide transitive expansion (depth 1), do not expand file frontier
   docmgr ticket graph --ticket MEN-4242 --scope repo --depth 1 --expand-files=false
+
+  # Ticket graph across the workspace
+  docmgr ticket graph --all --direction LR
+
+  # Active backend tickets with their docs, as Graphviz
+  docmgr ticket graph --all --topics backend --status active --include-docs --format dot | dot -Tsvg > graph.svg
+
+  # Nodes/edges for other tooling
+  docmgr ticket graph --all --format json
 `),
 			cmds.WithFlags(
 				fields.New(
 					"ticket",
 					fields.TypeString,
-					fields.WithHelp("Ticket identifier"),
-					fields.WithRequired(true),
+					fields.WithHelp("Ticket identifier (required unless --all)"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"all",
+					fields.TypeBool,
+					fields.WithHelp("Render the workspace graph (all tickets) instead of one ticket's docs"),
+					fields.WithDefault(false),
+				),
+				fields.New(
+					"topics",
+					fields.TypeStringList,
+					fields.WithHelp("With --all: only tickets with any of these topics"),
+					fields.WithDefault([]string{}),
+				),
+				fields.New(
+					"status",
+					fields.TypeStringList,
+					fields.WithHelp("With --all: only tickets with one of these statuses"),
+					fields.WithDefault([]string{}),
+				),
+				fields.New(
+					"include-docs",
+					fields.TypeBool,
+					fields.WithHelp("With --all: also include docs and their related files"),
+					fields.WithDefault(false),
 				),
 				fields.New(
 					"root",
@@ -84,7 +127,7 @@ Examples:
 				fields.New(
 					"format",
 					fields.TypeString,
-					fields.WithHelp("Output format: markdown|mermaid"),
+					fields.WithHelp("Output format: markdown|mermaid (with --all also dot|json)"),
 					fields.WithDefault("markdown"),
 				),
 				fields.New(
@@ -173,6 +216,9 @@ func (c *TicketGraphCommand) RunIntoGlazeProcessor(
 	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
 		return fmt.Errorf("failed to parse settings: %w", err)
 	}
+	if settings.All {
+		return emitWorkspaceGraphRows(ctx, settings, gp)
+	}
 
 	graph, err := buildTicketGraph(ctx, settings)
 	if err != nil {
@@ -206,6 +252,9 @@ func (c *TicketGraphCommand) Run(
 	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
 		return fmt.Errorf("failed to parse settings: %w", err)
 	}
+	if settings.All {
+		return printWorkspaceGraph(ctx, settings)
+	}
 
 	graph, err := buildTicketGraph(ctx, settings)
 	if err != nil {
@@ -254,7 +303,7 @@ type ticketGraphBuilder struct {
 
 func buildTicketGraph(ctx context.Context, settings *TicketGraphSettings) (*ticketGraph, error) {
 	if strings.TrimSpace(settings.Ticket) == "" {
-		return nil, fmt.Errorf("missing --ticket")
+		return nil, fmt.Errorf("missing --ticket (or use --all for the workspace graph)")
 	}
 
 	if settings.Depth < 0 {
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/ticketgraph"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/glazed/pkg/middlewares"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+// loadWorkspaceGraph builds the workspace-wide graph for `ticket graph --all`.
+func loadWorkspaceGraph(ctx context.Context, settings *TicketGraphSettings) (*ticketgraph.Graph, error) {
+	if strings.TrimSpace(settings.Ticket) != "" {
+		return nil, fmt.Errorf("--all and --ticket are mutually exclusive")
+	}
+	settings.Root = workspace.ResolveRoot(settings.Root)
+	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: settings.Root})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover workspace: %w", err)
+	}
+	settings.Root = ws.Context().Root
+	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
+		return nil, fmt.Errorf("failed to initialize workspace index: %w", err)
+	}
+	g, err := ticketgraph.BuildWorkspace(ctx, ws, ticketgraph.WorkspaceOptions{
+		Topics:             settings.Topics,
+		Statuses:           settings.Status,
+		IncludeDocs:        settings.IncludeDocs,
+		IncludeArchived:    settings.IncludeArchived,
+		IncludeScripts:     settings.IncludeScriptsPath,
+		IncludeControlDocs: settings.IncludeControlDocs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build workspace graph: %w", err)
+	}
+	if g.Stats.Nodes > settings.MaxNodes {
+		return nil, fmt.Errorf("graph has %d nodes (exceeds --max-nodes %d); narrow it with --topics/--status", g.Stats.Nodes, settings.MaxNodes)
+	}
+	if g.Stats.Edges > settings.MaxEdges {
+		return nil, fmt.Errorf("graph has %d edges (exceeds --max-edges %d); narrow it with --topics/--status", g.Stats.Edges, settings.MaxEdges)
+	}
+	return g, nil
+}
+
+func emitWorkspaceGraphRows(ctx context.Context, settings *TicketGraphSettings, gp middlewares.Processor) error {
+	g, err := loadWorkspaceGraph(ctx, settings)
+	if err != nil {
+		return err
+	}
+	nodes := make(map[string]ticketgraph.GraphNode, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes[n.ID] = n
+	}
+	for _, e := range g.Edges {
+		from, to := nodes[e.From], nodes[e.To]
+		row := types.NewRow(
+			types.MRP("from_type", from.Kind),
+			types.MRP("from_ticket", from.Ticket),
+			types.MRP("from_label", from.Label),
+			types.MRP("kind", e.Kind),
+			types.MRP("to_type", to.Kind),
+			types.MRP("to_ticket", to.Ticket),
+			types.MRP("to_label", to.Label),
+			types.MRP("to_missing", to.Missing),
+			types.MRP("label", e.Label),
+			types.MRP("weight", e.Weight),
+		)
+		if err := gp.AddRow(ctx, row); err != nil {
+			return fmt.Errorf("failed to emit edge row: %w", err)
+		}
+	}
+	return nil
+}
+
+func printWorkspaceGraph(ctx context.Context, settings *TicketGraphSettings) error {
+	format := strings.ToLower(strings.TrimSpace(settings.Format))
+	if format == "" {
+		format = "markdown"
+	}
+	if format != "markdown" && format != "mermaid" && format != "dot" && format != "json" {
+		return fmt.Errorf("invalid --format %q (expected markdown, mermaid, dot or json)", format)
+	}
+
+	g, err := loadWorkspaceGraph(ctx, settings)
+	if err != nil {
+		return err
+	}
+
+	var out string
+	switch format {
+	case "json":
+		b, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode graph: %w", err)
+		}
+		out = string(b) + "\n"
+	case "dot":
+		out, err = g.RenderDOT(settings.Direction)
+	default:
+		out, err = g.RenderMermaid(settings.Direction)
+		if err == nil && format == "markdown" {
+			out = "```mermaid\n" + out + "```\n"
+		}
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+	return nil
+}
@@ -0,0 +1,251 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/tickets"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/models"
+	"github.com/go-go-golems/glazed/pkg/cmds"
+	"github.com/go-go-golems/glazed/pkg/cmds/fields"
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+	"github.com/go-go-golems/glazed/pkg/middlewares"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+// TicketLinkCommand adds or removes ticket-to-ticket relations on a ticket's index.md.
+type TicketLinkCommand struct {
+	*cmds.CommandDescription
+}
+
+// TicketLinkSettings holds the parameters for the ticket link command.
+type TicketLinkSettings struct {
+	Ticket     string   `glazed:"ticket"`
+	Root       string   `glazed:"root"`
+	DependsOn  []string `glazed:"depends-on"`
+	Blocks     []string `glazed:"blocks"`
+	Supersedes []string `glazed:"supersedes"`
+	RelatesTo  []string `glazed:"relates-to"`
+	Remove     bool     `glazed:"remove"`
+}
+
+func NewTicketLinkCommand() (*TicketLinkCommand, error) {
+	return &TicketLinkCommand{
+		CommandDescription: cmds.NewCommandDescription(
+			"link",
+			cmds.WithShort("Link a ticket to other tickets (depends-on, blocks, supersedes, relates-to)"),
+			cmds.WithLong(`Records ticket-to-ticket relations in the ticket's index.md frontmatter
+(DependsOn, Blocks, Supersedes, RelatesTo).
+
+Targets are resolved like --ticket (exact ID, unique prefix, or directory name)
+and must exist. DependsOn and Blocks are the two directions of the same
+ordering relation: 'A --depends-on B' means B blocks A. 'docmgr ticket list'
+shows open blockers, 'docmgr ticket graph --all' draws the relations, and
+'docmgr doctor' reports closed tickets still blocking open ones and
+dependency cycles.
+
+Examples:
+  # MEN-4300 cannot finish before MEN-4242
+  docmgr ticket link --ticket MEN-4300 --depends-on MEN-4242
+
+  # Several relations at once
+  docmgr ticket link --ticket MEN-4300 --supersedes MEN-3900 --relates-to MEN-4100,MEN-4101
+
+  # Remove a relation
+  docmgr ticket link --ticket MEN-4300 --depends-on MEN-4242 --remove
+`),
+			cmds.WithFlags(
+				fields.New(
+					"ticket",
+					fields.TypeString,
+					fields.WithHelp("Ticket to link from (its index.md is updated)"),
+					fields.WithRequired(true),
+				),
+				fields.New(
+					"root",
+					fields.TypeString,
+					fields.WithHelp("Root directory for docs"),
+					fields.WithDefault("ttmp"),
+				),
+				fields.New(
+					"depends-on",
+					fields.TypeStringList,
+					fields.WithHelp("Tickets this ticket depends on (comma-separated)"),
+					fields.WithDefault([]string{}),
+				),
+				fields.New(
+					"blocks",
+					fields.TypeStringList,
+					fields.WithHelp("Tickets this ticket blocks (comma-separated)"),
+					fields.WithDefault([]string{}),
+				),
+				fields.New(
+					"supersedes",
+					fields.TypeStringList,
+					fields.WithHelp("Tickets this ticket supersedes (comma-separated)"),
+					fields.WithDefault([]string{}),
+				),
+				fields.New(
+					"relates-to",
+					fields.TypeStringList,
+					fields.WithHelp("Related tickets (comma-separated)"),
+					fields.WithDefault([]string{}),
+				),
+				fields.New(
+					"remove",
+					fields.TypeBool,
+					fields.WithHelp("Remove the given relations instead of adding them"),
+					fields.WithDefault(false),
+				),
+			),
+		),
+	}, nil
+}
+
+type ticketLinkChange struct {
+	Kind   models.TicketLinkKind
+	Target string
+	Action string // added | removed | unchanged
+}
+
+type ticketLinkResult struct {
+	Ticket    string
+	IndexPath string
+	Changes   []ticketLinkChange
+}
+
+func (c *TicketLinkCommand) RunIntoGlazeProcessor(
+	ctx context.Context,
+	parsedValues *values.Values,
+	gp middlewares.Processor,
+) error {
+	settings := &TicketLinkSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	result, err := c.applyLinks(ctx, settings)
+	if err != nil {
+		return err
+	}
+	for _, ch := range result.Changes {
+		row := types.NewRow(
+			types.MRP("ticket", result.Ticket),
+			types.MRP("kind", string(ch.Kind)),
+			types.MRP("target", ch.Target),
+			types.MRP("action", ch.Action),
+			types.MRP("index_path", result.IndexPath),
+		)
+		if err := gp.AddRow(ctx, row); err != nil {
+			return fmt.Errorf("failed to emit link row: %w", err)
+		}
+	}
+	return nil
+}
+
+var _ cmds.GlazeCommand = &TicketLinkCommand{}
+
+// Run implements cmds.BareCommand with one line per relation.
+func (c *TicketLinkCommand) Run(
+	ctx context.Context,
+	parsedValues *values.Values,
+) error {
+	settings := &TicketLinkSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	result, err := c.applyLinks(ctx, settings)
+	if err != nil {
+		return err
+	}
+	for _, ch := range result.Changes {
+		fmt.Printf("%s %s %s (%s)\n", result.Ticket, ch.Kind, ch.Target, ch.Action)
+	}
+	return nil
+}
+
+var _ cmds.BareCommand = &TicketLinkCommand{}
+
+func (c *TicketLinkCommand) applyLinks(ctx context.Context, settings *TicketLinkSettings) (*ticketLinkResult, error) {
+	requested := map[models.TicketLinkKind][]string{
+		models.TicketLinkDependsOn:  settings.DependsOn,
+		models.TicketLinkBlocks:     settings.Blocks,
+		models.TicketLinkSupersedes: settings.Supersedes,
+		models.TicketLinkRelatesTo:  settings.RelatesTo,
+	}
+	total := 0
+	for _, targets := range requested {
+		total += len(targets)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("nothing to link: pass --depends-on, --blocks, --supersedes or --relates-to")
+	}
+
+	settings.Root = workspace.ResolveRoot(settings.Root)
+	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: settings.Root})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover workspace: %w", err)
+	}
+	settings.Root = ws.Context().Root
+	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
+		return nil, fmt.Errorf("failed to initialize workspace index: %w", err)
+	}
+
+	res, err := tickets.Resolve(ctx, ws, settings.Ticket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve ticket %q: %w", settings.Ticket, err)
+	}
+	doc, content, err := documents.ReadDocumentWithFrontmatter(res.IndexPathAbs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ticket index: %w", err)
+	}
+
+	result := &ticketLinkResult{Ticket: res.TicketID, IndexPath: res.IndexPathAbs}
+	changed := false
+	for _, kind := range models.TicketLinkKinds {
+		for _, raw := range requested[kind] {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			target, err := tickets.ResolveTicketID(ctx, ws, raw)
+			if err != nil {
+				if !settings.Remove {
+					return nil, fmt.Errorf("--%s %s: %w", kind.FlagName(), raw, err)
+				}
+				// Removing a link to a ticket that no longer exists is how dangling links get cleaned up.
+				target = raw
+			}
+			if target == res.TicketID {
+				return nil, fmt.Errorf("--%s %s: a ticket cannot be linked to itself", kind.FlagName(), raw)
+			}
+
+			action := "unchanged"
+			if settings.Remove {
+				if doc.RemoveTicketLink(kind, target) || doc.RemoveTicketLink(kind, raw) {
+					action = "removed"
+				}
+			} else if doc.AddTicketLink(kind, target) {
+				action = "added"
+			}
+			if action != "unchanged" {
+				changed = true
+			}
+			result.Changes = append(result.Changes, ticketLinkChange{Kind: kind, Target: target, Action: action})
+		}
+	}
+
+	if changed {
+		doc.LastUpdated = time.Now()
+		if err := documents.WriteDocumentWithFrontmatter(res.IndexPathAbs, doc, content, true); err != nil {
+			return nil, fmt.Errorf("failed to write ticket index: %w", err)
+		}
+	}
+	return result, nil
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/journal"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/docmgr/pkg/utils"
 	"github.com/go-go-golems/glazed/pkg/cmds"
@@ -35,6 +36,7 @@ type TicketMoveResult struct {
 	Ticket      string
 	SourcePath  string
 	DestPath    string
+	OpID        string
 	CompletedAt time.Time
 }
 
@@ -114,6 +116,7 @@ func (c *TicketMoveCommand) RunIntoGlazeProcessor(
 		types.MRP("source_path", result.SourcePath),
 		types.MRP("dest_path", result.DestPath),
 		types.MRP("status", "moved"),
+		types.MRP("op_id", result.OpID),
 		types.MRP("time", result.CompletedAt.Format(time.RFC3339)),
 	)
 	return gp.AddRow(ctx, row)
@@ -168,25 +171,34 @@ func (c *TicketMoveCommand) applyMove(ctx context.Context, settings *TicketMoveS
 		}
 	}
 
-	if err := os.MkdirAll(filepath.Dir(destDir), 0o755); err != nil {
-		return nil, fmt.Errorf("failed to create destination parent: %w", err)
-	}
-
-	if err := os.Rename(srcDir, destDir); err != nil {
-		return nil, fmt.Errorf("failed to move ticket directory: %w", err)
-	}
+	summary := fmt.Sprintf("move %s to %s", settings.Ticket, relToRoot(settings.Root, destDir))
+	opID, err := runJournaled(settings.Root, "ticket move", summary, func(tx *journal.Tx) error {
+		if err := tx.MkdirAll(filepath.Dir(destDir), 0o755); err != nil {
+			return fmt.Errorf("failed to create destination parent: %w", err)
+		}
+		if err := tx.Rename(srcDir, destDir); err != nil {
+			return fmt.Errorf("failed to move ticket directory: %w", err)
+		}
 
-	// Best effort: touch LastUpdated in index.md if present.
-	destIndexPath := filepath.Join(destDir, "index.md")
-	if doc, body, err := readDocumentWithContent(destIndexPath); err == nil && doc != nil {
-		doc.LastUpdated = now
-		_ = documents.WriteDocumentWithFrontmatter(destIndexPath, doc, body, true)
+		// Best effort: touch LastUpdated in index.md if present.
+		destIndexPath := filepath.Join(destDir, "index.md")
+		if doc, body, err := readDocumentWithContent(destIndexPath); err == nil && doc != nil {
+			doc.LastUpdated = now
+			_ = tx.Write(destIndexPath, func() error {
+				return documents.WriteDocumentWithFrontmatter(destIndexPath, doc, body, true)
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &TicketMoveResult{
 		Ticket:      settings.Ticket,
 		SourcePath:  srcDir,
 		DestPath:    destDir,
+		OpID:        opID,
 		CompletedAt: time.Now(),
 	}, nil
 }
@@ -207,6 +219,9 @@ func (c *TicketMoveCommand) Run(
 	}
 
 	fmt.Printf("moved %s: %s -> %s\n", result.Ticket, result.SourcePath, result.DestPath)
+	if result.OpID != "" {
+		fmt.Printf("undo with: docmgr undo %s\n", result.OpID)
+	}
 	return nil
 }
 
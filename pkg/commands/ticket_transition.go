@@ -0,0 +1,236 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/hooks"
+	"github.com/go-go-golems/docmgr/internal/tickets"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/glazed/pkg/cmds"
+	"github.com/go-go-golems/glazed/pkg/cmds/fields"
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+	"github.com/go-go-golems/glazed/pkg/middlewares"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+// TicketTransitionCommand moves a ticket to another status through the workflow.
+type TicketTransitionCommand struct {
+	*cmds.CommandDescription
+}
+
+// TicketTransitionSettings holds the parameters for the ticket transition command.
+type TicketTransitionSettings struct {
+	Ticket         string `glazed:"ticket"`
+	Root           string `glazed:"root"`
+	To             string `glazed:"to"`
+	ChangelogEntry string `glazed:"changelog-entry"`
+	Force          bool   `glazed:"force"`
+}
+
+func NewTicketTransitionCommand() (*TicketTransitionCommand, error) {
+	return &TicketTransitionCommand{
+		CommandDescription: cmds.NewCommandDescription(
+			"transition",
+			cmds.WithShort("Move a ticket to another status, enforcing the workflow"),
+			cmds.WithLong(`Changes the Status of a ticket's index.md and records the transition in
+changelog.md.
+
+When .ttmp.yaml declares a workflow section, the transition must be listed
+under workflow.transitions and the preconditions of the target status under
+workflow.require must hold (tasksDone, minOwners, fields). Without a workflow
+section any status is accepted. --force applies a disallowed transition and
+only warns.
+
+Example .ttmp.yaml:
+
+  workflow:
+    transitions:
+      draft: [active]
+      active: [review, draft]
+      review: [complete, active]
+      complete: [archived, active]
+    require:
+      review: {minOwners: 1}
+      complete: {tasksDone: true}
+
+Examples:
+  docmgr ticket transition --ticket MEN-4242 --to review
+  docmgr ticket transition --ticket MEN-4242 --to active --changelog-entry "Back to implementation after review"
+  docmgr ticket transition --ticket MEN-4242 --to complete --force
+`),
+			cmds.WithFlags(
+				fields.New(
+					"ticket",
+					fields.TypeString,
+					fields.WithHelp("Ticket identifier"),
+					fields.WithRequired(true),
+				),
+				fields.New(
+					"to",
+					fields.TypeString,
+					fields.WithHelp("Target status"),
+					fields.WithRequired(true),
+				),
+				fields.New(
+					"root",
+					fields.TypeString,
+					fields.WithHelp("Root directory for docs"),
+					fields.WithDefault("ttmp"),
+				),
+				fields.New(
+					"changelog-entry",
+					fields.TypeString,
+					fields.WithHelp("Changelog entry message (default: 'Status: <from> → <to>')"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"force",
+					fields.TypeBool,
+					fields.WithHelp("Apply the transition even if the workflow does not allow it"),
+					fields.WithDefault(false),
+				),
+			),
+		),
+	}, nil
+}
+
+type ticketTransitionResult struct {
+	Ticket        string
+	From          string
+	To            string
+	Changed       bool
+	IndexPath     string
+	ChangelogPath string
+	Next          []string
+}
+
+func (c *TicketTransitionCommand) RunIntoGlazeProcessor(
+	ctx context.Context,
+	parsedValues *values.Values,
+	gp middlewares.Processor,
+) error {
+	settings := &TicketTransitionSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	result, err := transitionTicket(ctx, settings)
+	if err != nil {
+		return err
+	}
+	row := types.NewRow(
+		types.MRP(ColTicket, result.Ticket),
+		types.MRP("from", result.From),
+		types.MRP("to", result.To),
+		types.MRP("changed", result.Changed),
+		types.MRP("next", result.Next),
+		types.MRP("index_path", result.IndexPath),
+		types.MRP("changelog_path", result.ChangelogPath),
+	)
+	return gp.AddRow(ctx, row)
+}
+
+var _ cmds.GlazeCommand = &TicketTransitionCommand{}
+
+// Run implements cmds.BareCommand.
+func (c *TicketTransitionCommand) Run(
+	ctx context.Context,
+	parsedValues *values.Values,
+) error {
+	settings := &TicketTransitionSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	result, err := transitionTicket(ctx, settings)
+	if err != nil {
+		return err
+	}
+	if !result.Changed {
+		fmt.Printf("Ticket %s is already %s.\n", result.Ticket, result.To)
+		return nil
+	}
+	fmt.Printf("Ticket %s: %s → %s\n", result.Ticket, displayStatus(result.From), result.To)
+	if len(result.Next) > 0 {
+		fmt.Printf("Next: %s\n", strings.Join(result.Next, ", "))
+	}
+	fmt.Printf("Changelog: %s\n", result.ChangelogPath)
+	return nil
+}
+
+var _ cmds.BareCommand = &TicketTransitionCommand{}
+
+func transitionTicket(ctx context.Context, settings *TicketTransitionSettings) (*ticketTransitionResult, error) {
+	to := strings.TrimSpace(settings.To)
+	if to == "" {
+		return nil, fmt.Errorf("--to must not be empty")
+	}
+
+	settings.Root = workspace.ResolveRoot(settings.Root)
+	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: settings.Root})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover workspace: %w", err)
+	}
+	settings.Root = ws.Context().Root
+	if err := ws.InitIndex(ctx, workspace.BuildIndexOptions{IncludeBody: false}); err != nil {
+		return nil, fmt.Errorf("failed to initialize workspace index: %w", err)
+	}
+
+	res, err := tickets.Resolve(ctx, ws, settings.Ticket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve ticket %q: %w", settings.Ticket, err)
+	}
+	doc, content, err := documents.ReadDocumentWithFrontmatter(res.IndexPathAbs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ticket index: %w", err)
+	}
+
+	wf, err := LoadWorkflow()
+	if err != nil {
+		return nil, err
+	}
+	result := &ticketTransitionResult{
+		Ticket:        res.TicketID,
+		From:          doc.Status,
+		To:            to,
+		IndexPath:     res.IndexPathAbs,
+		ChangelogPath: filepath.Join(res.TicketDirAbs, "changelog.md"),
+		Next:          wf.Next(to),
+	}
+	if strings.EqualFold(strings.TrimSpace(doc.Status), to) {
+		return result, nil
+	}
+	if err := enforceTicketTransition(res.IndexPathAbs, doc, to, settings.Force); err != nil {
+		return nil, err
+	}
+
+	doc.Status = to
+	doc.LastUpdated = time.Now()
+	if err := documents.WriteDocumentWithFrontmatter(res.IndexPathAbs, doc, content, true); err != nil {
+		return nil, fmt.Errorf("failed to write ticket index: %w", err)
+	}
+	result.Changed = true
+	fireHook(ctx, hooks.EventStatusChanged, res.TicketID, res.IndexPathAbs, map[string]any{"from": result.From, "to": to})
+
+	entry := strings.TrimSpace(settings.ChangelogEntry)
+	if entry == "" {
+		entry = fmt.Sprintf("Status: %s → %s", displayStatus(result.From), to)
+	}
+	if _, err := AppendChangelogEntry(result.ChangelogPath, "", entry, nil); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func displayStatus(status string) string {
+	if strings.TrimSpace(status) == "" {
+		return "(none)"
+	}
+	return status
+}
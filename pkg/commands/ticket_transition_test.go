@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/internal/workflow"
+)
+
+func TestTicketTransition_EnforcesWorkflow(t *testing.T) {
+	tmp := t.TempDir()
+	root := filepath.Join(tmp, "ttmp")
+	ticketDir := filepath.Join(root, "2026", "10", "18", "WF-1--workflow")
+	writeMarkdown(t, filepath.Join(ticketDir, "index.md"), strings.TrimSpace(`---
+Title: Workflow
+Ticket: WF-1
+DocType: index
+Status: active
+Topics: [backend]
+LastUpdated: 2026-10-18
+---
+
+# Workflow
+`))
+	writeMarkdown(t, filepath.Join(ticketDir, "tasks.md"), "# Tasks\n\n- [x] Design\n- [ ] Ship\n")
+	cfgPath := filepath.Join(tmp, ".ttmp.yaml")
+	writeMarkdown(t, cfgPath, `root: ttmp
+workflow:
+  transitions:
+    active: [review]
+    review: [complete, active]
+  require:
+    complete: {tasksDone: true}
+`)
+	t.Setenv("DOCMGR_CONFIG", cfgPath)
+
+	ctx := context.Background()
+	transition := func(to string, force bool) (*ticketTransitionResult, error) {
+		return transitionTicket(ctx, &TicketTransitionSettings{Ticket: "WF-1", Root: root, To: to, Force: force})
+	}
+
+	if _, err := transition("complete", false); !errors.Is(err, workflow.ErrTransitionNotAllowed) {
+		t.Fatalf("active → complete: expected ErrTransitionNotAllowed, got %v", err)
+	}
+	result, err := transition("review", false)
+	if err != nil {
+		t.Fatalf("active → review: %v", err)
+	}
+	if !result.Changed || result.From != "active" || strings.Join(result.Next, ",") != "complete,active" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if _, err := transition("complete", false); err == nil || !strings.Contains(err.Error(), "1 open task(s)") {
+		t.Fatalf("review → complete with open tasks: expected a tasksDone error, got %v", err)
+	}
+	if _, err := transition("complete", true); err != nil {
+		t.Fatalf("forced review → complete: %v", err)
+	}
+
+	doc, _, err := documents.ReadDocumentWithFrontmatter(filepath.Join(ticketDir, "index.md"))
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	if doc.Status != "complete" {
+		t.Fatalf("Status = %q, want complete", doc.Status)
+	}
+	changelog, err := os.ReadFile(filepath.Join(ticketDir, "changelog.md"))
+	if err != nil {
+		t.Fatalf("read changelog: %v", err)
+	}
+	if !strings.Contains(string(changelog), "Status: active → review") || !strings.Contains(string(changelog), "Status: review → complete") {
+		t.Fatalf("changelog missing transitions:\n%s", changelog)
+	}
+}
@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-go-golems/docmgr/internal/journal"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/glazed/pkg/cmds"
+	"github.com/go-go-golems/glazed/pkg/cmds/fields"
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+	"github.com/go-go-golems/glazed/pkg/middlewares"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+// UndoCommand reverts a journaled multi-file operation.
+type UndoCommand struct {
+	*cmds.CommandDescription
+}
+
+// UndoSettings holds the parameters for the undo command.
+type UndoSettings struct {
+	Root  string `glazed:"root"`
+	OpID  string `glazed:"op-id"`
+	List  bool   `glazed:"list"`
+	Force bool   `glazed:"force"`
+}
+
+func NewUndoCommand() (*UndoCommand, error) {
+	return &UndoCommand{
+		CommandDescription: cmds.NewCommandDescription(
+			"undo",
+			cmds.WithShort("Revert the last ticket rename/move, doc move, renumber or layout-fix"),
+			cmds.WithLong(`Reverts an operation recorded in the operation journal
+(<docs-root>/.docmgr/journal/). 'ticket rename', 'ticket move', 'doc move',
+'doc renumber' and 'doc layout-fix' journal every file write and move; if one of
+them fails halfway its changes are rolled back automatically, and once it
+succeeds it can be reverted here.
+
+Without an operation ID the most recent operation that was not undone yet is
+reverted. Undo refuses when a file written by the operation was edited since;
+use --force to restore the journaled content anyway.
+
+Examples:
+  # Show the journal (newest first)
+  docmgr undo --list
+
+  # Revert the last operation
+  docmgr undo
+
+  # Revert a specific operation
+  docmgr undo 20261018T153012.042Z-3f9a
+`),
+			cmds.WithArguments(
+				fields.New(
+					"op-id",
+					fields.TypeString,
+					fields.WithHelp("Operation ID to revert (default: the most recent one)"),
+					fields.WithDefault(""),
+				),
+			),
+			cmds.WithFlags(
+				fields.New(
+					"root",
+					fields.TypeString,
+					fields.WithHelp("Root directory for docs"),
+					fields.WithDefault("ttmp"),
+				),
+				fields.New(
+					"list",
+					fields.TypeBool,
+					fields.WithHelp("List journaled operations instead of reverting"),
+					fields.WithDefault(false),
+				),
+				fields.New(
+					"force",
+					fields.TypeBool,
+					fields.WithHelp("Revert even if files were changed after the operation"),
+					fields.WithDefault(false),
+				),
+			),
+		),
+	}, nil
+}
+
+func (c *UndoCommand) RunIntoGlazeProcessor(
+	ctx context.Context,
+	parsedValues *values.Values,
+	gp middlewares.Processor,
+) error {
+	settings := &UndoSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	ops, err := runUndo(ctx, settings)
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		row := types.NewRow(
+			types.MRP("op_id", op.ID),
+			types.MRP("command", op.Command),
+			types.MRP("summary", op.Summary),
+			types.MRP("status", string(op.Status)),
+			types.MRP("steps", len(op.Entries)),
+			types.MRP("started", op.StartedAt.Format(time.RFC3339)),
+		)
+		if err := gp.AddRow(ctx, row); err != nil {
+			return fmt.Errorf("failed to emit undo row: %w", err)
+		}
+	}
+	return nil
+}
+
+var _ cmds.GlazeCommand = &UndoCommand{}
+
+// Run implements cmds.BareCommand.
+func (c *UndoCommand) Run(
+	ctx context.Context,
+	parsedValues *values.Values,
+) error {
+	settings := &UndoSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+
+	ops, err := runUndo(ctx, settings)
+	if err != nil {
+		return err
+	}
+	if settings.List {
+		if len(ops) == 0 {
+			fmt.Println("Operation journal is empty.")
+			return nil
+		}
+		for _, op := range ops {
+			fmt.Printf("%s  %-11s  %-15s %s (%d steps)\n", op.ID, op.Status, op.Command, op.Summary, len(op.Entries))
+		}
+		return nil
+	}
+	op := ops[0]
+	fmt.Printf("undid %s [%s] %s (%d steps reverted)\n", op.ID, op.Command, op.Summary, len(op.Entries))
+	return nil
+}
+
+var _ cmds.BareCommand = &UndoCommand{}
+
+// runUndo lists the journal (--list) or reverts one operation and returns it.
+func runUndo(ctx context.Context, settings *UndoSettings) ([]journal.Op, error) {
+	settings.Root = workspace.ResolveRoot(settings.Root)
+	ws, err := workspace.DiscoverWorkspace(ctx, workspace.DiscoverOptions{RootOverride: settings.Root})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover workspace: %w", err)
+	}
+	root := ws.Context().Root
+
+	if settings.List {
+		ops, err := journal.List(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read operation journal: %w", err)
+		}
+		return ops, nil
+	}
+	op, err := journal.Undo(root, strings.TrimSpace(settings.OpID), settings.Force)
+	if err != nil {
+		return nil, err
+	}
+	return []journal.Op{*op}, nil
+}
@@ -0,0 +1,554 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/views"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/glazed/pkg/cmds"
+	"github.com/go-go-golems/glazed/pkg/cmds/fields"
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+	"github.com/go-go-golems/glazed/pkg/middlewares"
+	"github.com/go-go-golems/glazed/pkg/types"
+)
+
+// applySearchView merges the saved view named by settings.View into settings.
+// Flags set on the command line win; --where is ANDed with the view's expression.
+func applySearchView(settings *SearchSettings) error {
+	if strings.TrimSpace(settings.View) == "" {
+		return nil
+	}
+	v, err := views.Get(settings.Root, strings.TrimSpace(settings.View))
+	if err != nil {
+		return fmt.Errorf("failed to load view: %w", err)
+	}
+	settings.Where = views.CombineWhere(v.Where, settings.Where)
+	setIfEmpty(&settings.Query, v.Query)
+	setIfEmpty(&settings.Ticket, v.Ticket)
+	setIfEmpty(&settings.DocType, v.DocType)
+	setIfEmpty(&settings.Status, v.Status)
+	setIfEmpty(&settings.File, v.File)
+	setIfEmpty(&settings.Dir, v.Dir)
+	if len(settings.Topics) == 0 {
+		settings.Topics = v.Topics
+	}
+	// rank needs a text query; without one the view keeps the default order.
+	if workspace.OrderBy(strings.TrimSpace(v.OrderBy)) != workspace.OrderByRank || strings.TrimSpace(settings.Query) != "" {
+		setIfEmpty(&settings.OrderBy, v.OrderBy)
+	}
+	return nil
+}
+
+// clearDefaulted empties dst when the flag key kept its declared default, so
+// a saved view can fill it in. Values from the command line, env or config
+// count as explicitly set.
+func clearDefaulted(parsedValues *values.Values, key string, dst *string) {
+	fv, ok := parsedValues.GetField(schema.DefaultSlug, key)
+	if !ok {
+		return
+	}
+	for _, step := range fv.Log {
+		if step.Source != fields.SourceDefaults {
+			return
+		}
+	}
+	*dst = ""
+}
+
+// docFilters builds the list docs query filters and ordering, applying
+// --view if set. settings.Root must already be the resolved docs root.
+func (s *ListDocsSettings) docFilters() (workspace.DocFilters, workspace.OrderBy, error) {
+	filters := workspace.DocFilters{
+		Ticket:    s.Ticket,
+		Status:    s.Status,
+		DocType:   s.DocType,
+		TopicsAny: s.Topics,
+	}
+	orderBy := workspace.OrderByPath
+	where := s.Where
+	if name := strings.TrimSpace(s.View); name != "" {
+		v, err := views.Get(s.Root, name)
+		if err != nil {
+			return workspace.DocFilters{}, "", fmt.Errorf("failed to load view: %w", err)
+		}
+		where = views.CombineWhere(v.Where, where)
+		setIfEmpty(&filters.Ticket, v.Ticket)
+		setIfEmpty(&filters.Status, v.Status)
+		setIfEmpty(&filters.DocType, v.DocType)
+		if len(filters.TopicsAny) == 0 {
+			filters.TopicsAny = v.Topics
+		}
+		filters.TextQuery = strings.TrimSpace(v.Query)
+		if strings.TrimSpace(v.File) != "" {
+			filters.RelatedFile = []string{strings.TrimSpace(v.File)}
+		}
+		if strings.TrimSpace(v.Dir) != "" {
+			filters.RelatedDir = []string{strings.TrimSpace(v.Dir)}
+		}
+		// rank needs a text query; without one the view keeps path order.
+		if o := workspace.OrderBy(v.OrderBy); o != "" && (o != workspace.OrderByRank || filters.TextQuery != "") {
+			orderBy = o
+		}
+	}
+
+	fieldFilters, err := parseFieldFilters(s.Fields)
+	if err != nil {
+		return workspace.DocFilters{}, "", err
+	}
+	filters.Fields = fieldFilters
+	whereExpr, err := workspace.ParseDocExpr(where)
+	if err != nil {
+		return workspace.DocFilters{}, "", err
+	}
+	filters.Where = whereExpr
+	return filters, orderBy, nil
+}
+
+func setIfEmpty(dst *string, v string) {
+	if strings.TrimSpace(*dst) == "" {
+		*dst = strings.TrimSpace(v)
+	}
+}
+
+// describeView renders a view's filters as a compact one-line summary.
+func describeView(v views.View) string {
+	var parts []string
+	add := func(k, val string) {
+		if strings.TrimSpace(val) != "" {
+			parts = append(parts, k+"="+strings.TrimSpace(val))
+		}
+	}
+	add("query", v.Query)
+	add("ticket", v.Ticket)
+	add("topics", strings.Join(v.Topics, ","))
+	add("doc-type", v.DocType)
+	add("status", v.Status)
+	add("file", v.File)
+	add("dir", v.Dir)
+	add("order-by", v.OrderBy)
+	if strings.TrimSpace(v.Where) != "" {
+		parts = append(parts, fmt.Sprintf("where=%q", strings.TrimSpace(v.Where)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// ---- view save ----
+
+// ViewSaveCommand stores a named search in <root>/views.yaml.
+type ViewSaveCommand struct {
+	*cmds.CommandDescription
+}
+
+// ViewSaveSettings holds the parameters for the view save command
+type ViewSaveSettings struct {
+	Name        string   `glazed:"name"`
+	Description string   `glazed:"description"`
+	Query       string   `glazed:"query"`
+	Where       string   `glazed:"where"`
+	Ticket      string   `glazed:"ticket"`
+	Topics      []string `glazed:"topics"`
+	DocType     string   `glazed:"doc-type"`
+	Status      string   `glazed:"status"`
+	File        string   `glazed:"file"`
+	Dir         string   `glazed:"dir"`
+	OrderBy     string   `glazed:"order-by"`
+	Force       bool     `glazed:"force"`
+	Root        string   `glazed:"root"`
+}
+
+func NewViewSaveCommand() (*ViewSaveCommand, error) {
+	return &ViewSaveCommand{
+		CommandDescription: cmds.NewCommandDescription(
+			"save",
+			cmds.WithShort("Save a named search"),
+			cmds.WithLong(`Saves a named combination of search filters to '<root>/views.yaml'.
+
+Saved views can be run with 'docmgr view run NAME', 'docmgr search --view NAME'
+and 'docmgr list docs --view NAME', and are listed in the web UI sidebar.
+Commit views.yaml to share views with your team.
+
+Examples:
+  docmgr view save my-active-api --topics api --status active --where 'NOT owner:bob'
+  docmgr view save recent-designs --doc-type design-doc --where 'updated>=14d' --order-by last_updated
+  docmgr view save my-active-api --topics api,backend --status active --force
+`),
+			cmds.WithArguments(
+				fields.New(
+					"name",
+					fields.TypeString,
+					fields.WithHelp("View name (letters, digits, '.', '_' and '-')"),
+					fields.WithRequired(true),
+				),
+			),
+			cmds.WithFlags(
+				fields.New(
+					"description",
+					fields.TypeString,
+					fields.WithHelp("Short description shown in 'view list' and the web UI"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"query",
+					fields.TypeString,
+					fields.WithHelp("Full-text query (FTS5)"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"where",
+					fields.TypeString,
+					fields.WithHelp("Query expression (see 'docmgr help how-to-use')"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"ticket",
+					fields.TypeString,
+					fields.WithHelp("Filter by ticket identifier"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"topics",
+					fields.TypeStringList,
+					fields.WithHelp("Filter by topics (comma-separated, matches any)"),
+					fields.WithDefault([]string{}),
+				),
+				fields.New(
+					"doc-type",
+					fields.TypeString,
+					fields.WithHelp("Filter by document type"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"status",
+					fields.TypeString,
+					fields.WithHelp("Filter by status"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"file",
+					fields.TypeString,
+					fields.WithHelp("Documents that reference this file path"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"dir",
+					fields.TypeString,
+					fields.WithHelp("Documents that reference files in this directory"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"order-by",
+					fields.TypeString,
+					fields.WithHelp("Order results by: path|last_updated|rank"),
+					fields.WithDefault(""),
+				),
+				fields.New(
+					"force",
+					fields.TypeBool,
+					fields.WithHelp("Overwrite an existing view with the same name"),
+					fields.WithDefault(false),
+				),
+				fields.New(
+					"root",
+					fields.TypeString,
+					fields.WithHelp("Root directory for docs"),
+					fields.WithDefault("ttmp"),
+				),
+			),
+		),
+	}, nil
+}
+
+func (c *ViewSaveCommand) save(settings *ViewSaveSettings) (views.View, string, error) {
+	root := workspace.ResolveRoot(settings.Root)
+	v := views.View{
+		Name:        strings.TrimSpace(settings.Name),
+		Description: strings.TrimSpace(settings.Description),
+		Query:       strings.TrimSpace(settings.Query),
+		Where:       strings.TrimSpace(settings.Where),
+		Ticket:      strings.TrimSpace(settings.Ticket),
+		Topics:      settings.Topics,
+		DocType:     strings.TrimSpace(settings.DocType),
+		Status:      strings.TrimSpace(settings.Status),
+		File:        strings.TrimSpace(settings.File),
+		Dir:         strings.TrimSpace(settings.Dir),
+		OrderBy:     strings.TrimSpace(settings.OrderBy),
+	}
+	if err := views.Put(root, v, settings.Force); err != nil {
+		return views.View{}, "", err
+	}
+	return v, views.Path(root), nil
+}
+
+func (c *ViewSaveCommand) RunIntoGlazeProcessor(ctx context.Context, parsedValues *values.Values, gp middlewares.Processor) error {
+	settings := &ViewSaveSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+	v, path, err := c.save(settings)
+	if err != nil {
+		return err
+	}
+	return gp.AddRow(ctx, types.NewRow(
+		types.MRP("name", v.Name),
+		types.MRP("definition", describeView(v)),
+		types.MRP("views_path", path),
+		types.MRP("status", "saved"),
+	))
+}
+
+func (c *ViewSaveCommand) Run(ctx context.Context, parsedValues *values.Values) error {
+	settings := &ViewSaveSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+	v, path, err := c.save(settings)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("saved view %s (%s) in %s\n", v.Name, describeView(v), path)
+	return nil
+}
+
+var _ cmds.GlazeCommand = &ViewSaveCommand{}
+var _ cmds.BareCommand = &ViewSaveCommand{}
+
+// ---- view list ----
+
+// ViewListCommand lists saved views.
+type ViewListCommand struct {
+	*cmds.CommandDescription
+}
+
+// ViewListSettings holds the parameters for the view list command
+type ViewListSettings struct {
+	Root string `glazed:"root"`
+}
+
+func NewViewListCommand() (*ViewListCommand, error) {
+	return &ViewListCommand{
+		CommandDescription: cmds.NewCommandDescription(
+			"list",
+			cmds.WithShort("List saved views"),
+			cmds.WithLong(`Lists the saved views in '<root>/views.yaml'.
+
+Columns:
+  name,description,definition
+
+Examples:
+  docmgr view list
+  docmgr view list --with-glaze-output --output json
+`),
+			cmds.WithFlags(
+				fields.New(
+					"root",
+					fields.TypeString,
+					fields.WithHelp("Root directory for docs"),
+					fields.WithDefault("ttmp"),
+				),
+			),
+		),
+	}, nil
+}
+
+func (c *ViewListCommand) RunIntoGlazeProcessor(ctx context.Context, parsedValues *values.Values, gp middlewares.Processor) error {
+	settings := &ViewListSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+	all, err := views.Load(workspace.ResolveRoot(settings.Root))
+	if err != nil {
+		return err
+	}
+	for _, v := range all {
+		if err := gp.AddRow(ctx, types.NewRow(
+			types.MRP("name", v.Name),
+			types.MRP("description", v.Description),
+			types.MRP("definition", describeView(v)),
+		)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ViewListCommand) Run(ctx context.Context, parsedValues *values.Values) error {
+	settings := &ViewListSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return fmt.Errorf("failed to parse settings: %w", err)
+	}
+	root := workspace.ResolveRoot(settings.Root)
+	all, err := views.Load(root)
+	if err != nil {
+		return err
+	}
+	if len(all) == 0 {
+		fmt.Printf("No saved views in %s (create one with 'docmgr view save NAME ...').\n", views.Path(root))
+		return nil
+	}
+	fmt.Printf("## Saved views (%d)\n\n", len(all))
+	for _, v := range all {
+		if v.Description != "" {
+			fmt.Printf("- **%s** — %s\n  %s\n", v.Name, v.Description, describeView(v))
+		} else {
+			fmt.Printf("- **%s** — %s\n", v.Name, describeView(v))
+		}
+	}
+	return nil
+}
+
+var _ cmds.GlazeCommand = &ViewListCommand{}
+var _ cmds.BareCommand = &ViewListCommand{}
+
+// ---- view run ----
+
+// ViewRunCommand runs a saved view through `docmgr search`.
+type ViewRunCommand struct {
+	*cmds.CommandDescription
+}
+
+// ViewRunSettings holds the parameters for the view run command
+type ViewRunSettings struct {
+	Name string `glazed:"name"`
+	Root string `glazed:"root"`
+}
+
+func NewViewRunCommand() (*ViewRunCommand, error) {
+	return &ViewRunCommand{
+		CommandDescription: cmds.NewCommandDescription(
+			"run",
+			cmds.WithShort("Run a saved view"),
+			cmds.WithLong(`Runs a saved view; equivalent to 'docmgr search --view NAME'.
+
+Use 'docmgr search --view NAME [flags]' to override individual filters.
+
+Examples:
+  docmgr view run my-active-api
+  docmgr view run my-active-api --with-glaze-output --output json
+`),
+			cmds.WithArguments(
+				fields.New(
+					"name",
+					fields.TypeString,
+					fields.WithHelp("View name"),
+					fields.WithRequired(true),
+				),
+			),
+			cmds.WithFlags(
+				fields.New(
+					"root",
+					fields.TypeString,
+					fields.WithHelp("Root directory for docs"),
+					fields.WithDefault("ttmp"),
+				),
+			),
+		),
+	}, nil
+}
+
+func (c *ViewRunCommand) searchSettings(parsedValues *values.Values) (*SearchSettings, error) {
+	settings := &ViewRunSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+	return &SearchSettings{View: settings.Name, Root: settings.Root}, nil
+}
+
+func (c *ViewRunCommand) RunIntoGlazeProcessor(ctx context.Context, parsedValues *values.Values, gp middlewares.Processor) error {
+	settings, err := c.searchSettings(parsedValues)
+	if err != nil {
+		return err
+	}
+	return (&SearchCommand{}).searchIntoGlaze(ctx, settings, gp)
+}
+
+func (c *ViewRunCommand) Run(ctx context.Context, parsedValues *values.Values) error {
+	settings, err := c.searchSettings(parsedValues)
+	if err != nil {
+		return err
+	}
+	return (&SearchCommand{}).searchHuman(ctx, settings)
+}
+
+var _ cmds.GlazeCommand = &ViewRunCommand{}
+var _ cmds.BareCommand = &ViewRunCommand{}
+
+// ---- view delete ----
+
+// ViewDeleteCommand removes a saved view.
+type ViewDeleteCommand struct {
+	*cmds.CommandDescription
+}
+
+// ViewDeleteSettings holds the parameters for the view delete command
+type ViewDeleteSettings struct {
+	Name string `glazed:"name"`
+	Root string `glazed:"root"`
+}
+
+func NewViewDeleteCommand() (*ViewDeleteCommand, error) {
+	return &ViewDeleteCommand{
+		CommandDescription: cmds.NewCommandDescription(
+			"delete",
+			cmds.WithShort("Delete a saved view"),
+			cmds.WithLong(`Removes a saved view from '<root>/views.yaml'.
+
+Examples:
+  docmgr view delete my-active-api
+`),
+			cmds.WithArguments(
+				fields.New(
+					"name",
+					fields.TypeString,
+					fields.WithHelp("View name"),
+					fields.WithRequired(true),
+				),
+			),
+			cmds.WithFlags(
+				fields.New(
+					"root",
+					fields.TypeString,
+					fields.WithHelp("Root directory for docs"),
+					fields.WithDefault("ttmp"),
+				),
+			),
+		),
+	}, nil
+}
+
+func (c *ViewDeleteCommand) delete(parsedValues *values.Values) (string, error) {
+	settings := &ViewDeleteSettings{}
+	if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+		return "", fmt.Errorf("failed to parse settings: %w", err)
+	}
+	name := strings.TrimSpace(settings.Name)
+	if err := views.Delete(workspace.ResolveRoot(settings.Root), name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+func (c *ViewDeleteCommand) RunIntoGlazeProcessor(ctx context.Context, parsedValues *values.Values, gp middlewares.Processor) error {
+	name, err := c.delete(parsedValues)
+	if err != nil {
+		return err
+	}
+	return gp.AddRow(ctx, types.NewRow(
+		types.MRP("name", name),
+		types.MRP("status", "deleted"),
+	))
+}
+
+func (c *ViewDeleteCommand) Run(ctx context.Context, parsedValues *values.Values) error {
+	name, err := c.delete(parsedValues)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("deleted view %s\n", name)
+	return nil
+}
+
+var _ cmds.GlazeCommand = &ViewDeleteCommand{}
+var _ cmds.BareCommand = &ViewDeleteCommand{}
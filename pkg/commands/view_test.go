@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/go-go-golems/docmgr/internal/views"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/glazed/pkg/cmds/fields"
+	"github.com/go-go-golems/glazed/pkg/cmds/schema"
+	"github.com/go-go-golems/glazed/pkg/cmds/values"
+)
+
+func newSearchOrderValues(t *testing.T, orderBy, source string) *values.Values {
+	t.Helper()
+	cmd, err := NewSearchCommand()
+	if err != nil {
+		t.Fatalf("NewSearchCommand: %v", err)
+	}
+	defaultSection, ok := cmd.GetDefaultSection()
+	if !ok {
+		t.Fatal("search command missing default section")
+	}
+	sectionValues, err := values.NewSectionValues(
+		defaultSection,
+		values.WithFieldValue("order-by", orderBy, fields.WithSource(source)),
+	)
+	if err != nil {
+		t.Fatalf("NewSectionValues: %v", err)
+	}
+	parsedValues := values.New()
+	parsedValues.Set(schema.DefaultSlug, sectionValues)
+	return parsedValues
+}
+
+func TestSearchViewOrderByYieldsOnlyToExplicitFlag(t *testing.T) {
+	root := t.TempDir()
+	if err := views.Put(root, views.View{Name: "recent", Status: "active", OrderBy: "last_updated"}, false); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	for _, tc := range []struct {
+		source string
+		want   string
+	}{
+		{source: fields.SourceDefaults, want: "last_updated"},
+		{source: "cobra", want: "path"},
+	} {
+		parsedValues := newSearchOrderValues(t, "path", tc.source)
+		settings := &SearchSettings{}
+		if err := parsedValues.DecodeSectionInto(schema.DefaultSlug, settings); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		clearDefaulted(parsedValues, "order-by", &settings.OrderBy)
+		settings.Root = root
+		settings.View = "recent"
+		if err := applySearchView(settings); err != nil {
+			t.Fatalf("applySearchView: %v", err)
+		}
+		if settings.OrderBy != tc.want {
+			t.Fatalf("order-by from %s: got %q, want %q", tc.source, settings.OrderBy, tc.want)
+		}
+	}
+}
+
+func TestSearchViewRankNeedsQuery(t *testing.T) {
+	root := t.TempDir()
+	if err := views.Put(root, views.View{Name: "ranked", Status: "active", OrderBy: "rank"}, false); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	for query, want := range map[string]string{"": "", "latency": "rank"} {
+		settings := &SearchSettings{Root: root, View: "ranked", Query: query}
+		if err := applySearchView(settings); err != nil {
+			t.Fatalf("applySearchView: %v", err)
+		}
+		if settings.OrderBy != want {
+			t.Fatalf("query %q: got order-by %q, want %q", query, settings.OrderBy, want)
+		}
+	}
+}
+
+func TestListDocsHonorsViewOrderBy(t *testing.T) {
+	root := t.TempDir()
+	if err := views.Put(root, views.View{Name: "recent", Status: "active", OrderBy: "last_updated"}, false); err != nil {
+		t.Fatalf("Put recent: %v", err)
+	}
+	if err := views.Put(root, views.View{Name: "ranked", Status: "active", OrderBy: "rank"}, false); err != nil {
+		t.Fatalf("Put ranked: %v", err)
+	}
+
+	for name, want := range map[string]workspace.OrderBy{
+		"":       workspace.OrderByPath,
+		"recent": workspace.OrderByLastUpdated,
+		"ranked": workspace.OrderByPath, // rank without a text query
+	} {
+		_, got, err := (&ListDocsSettings{Root: root, View: name}).docFilters()
+		if err != nil {
+			t.Fatalf("docFilters(%q): %v", name, err)
+		}
+		if got != want {
+			t.Fatalf("view %q: got order %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/workflow"
+	"github.com/go-go-golems/docmgr/internal/workspace"
+	"github.com/go-go-golems/docmgr/pkg/models"
+)
+
+// LoadWorkflow loads the ticket workflow from the workflow section of
+// .ttmp.yaml. Without a config (or section) the returned workflow allows
+// every status change.
+func LoadWorkflow() (*workflow.Workflow, error) {
+	cfg, err := workspace.LoadWorkspaceConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workspace config: %w", err)
+	}
+	if cfg == nil {
+		return workflow.New(workspace.WorkflowConfig{})
+	}
+	w, err := workflow.New(cfg.Workflow)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workflow config: %w", err)
+	}
+	return w, nil
+}
+
+// checkTicketTransition enforces the workflow when the Status of a ticket's
+// index.md changes to status to. Other documents keep a free-form Status.
+func checkTicketTransition(indexPath string, doc *models.Document, to string) error {
+	if doc == nil || doc.DocType != "index" || filepath.Base(indexPath) != "index.md" {
+		return nil
+	}
+	if strings.EqualFold(strings.TrimSpace(doc.Status), strings.TrimSpace(to)) {
+		return nil
+	}
+	wf, err := LoadWorkflow()
+	if err != nil {
+		return err
+	}
+	if !wf.Enabled() {
+		return nil
+	}
+	open, done := countTasksInTicket(filepath.Dir(indexPath))
+	return wf.Check(doc.Status, to, workflow.Ticket{Doc: doc, OpenTasks: open, DoneTasks: done})
+}
+
+// enforceTicketTransition is checkTicketTransition for commands with --force:
+// a forced transition only prints the violated rules to stderr.
+func enforceTicketTransition(indexPath string, doc *models.Document, to string, force bool) error {
+	err := checkTicketTransition(indexPath, doc, to)
+	if err == nil || !force || !errors.Is(err, workflow.ErrTransitionNotAllowed) {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Warning: forcing %v\n", err)
+	return nil
+}
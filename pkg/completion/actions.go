@@ -10,6 +10,8 @@ import (
 	"strings"
 
 	"github.com/carapace-sh/carapace"
+	"github.com/go-go-golems/docmgr/internal/blueprints"
+	"github.com/go-go-golems/docmgr/internal/views"
 	"github.com/go-go-golems/docmgr/internal/workspace"
 	"github.com/go-go-golems/docmgr/pkg/commands"
 )
@@ -129,6 +131,44 @@ func ActionTopics() carapace.Action {
 	})
 }
 
+// ActionViews completes saved view names from <root>/views.yaml.
+func ActionViews() carapace.Action {
+	return carapace.ActionCallback(func(c carapace.Context) carapace.Action {
+		root := parseFlags(c.Args)["root"]
+		if root == "" {
+			root = "ttmp"
+		}
+		all, err := views.Load(workspace.ResolveRoot(root))
+		if err != nil {
+			return carapace.ActionMessage(fmt.Sprintf("failed to load views: %v", err))
+		}
+		vals := make([]string, 0, len(all)*2)
+		for _, v := range all {
+			vals = append(vals, v.Name, v.Description)
+		}
+		return carapace.ActionValuesDescribed(vals...)
+	})
+}
+
+// ActionBlueprints completes ticket blueprint names.
+func ActionBlueprints() carapace.Action {
+	return carapace.ActionCallback(func(c carapace.Context) carapace.Action {
+		root := parseFlags(c.Args)["root"]
+		if root == "" {
+			root = "ttmp"
+		}
+		all, err := blueprints.List(workspace.ResolveRoot(root))
+		if err != nil {
+			return carapace.ActionMessage(fmt.Sprintf("failed to load blueprints: %v", err))
+		}
+		vals := make([]string, 0, len(all)*2)
+		for _, b := range all {
+			vals = append(vals, b.Name, b.Description)
+		}
+		return carapace.ActionValuesDescribed(vals...)
+	})
+}
+
 // ActionDocTypes completes document types.
 func ActionDocTypes() carapace.Action {
 	return ActionVocab("docTypes")
@@ -15,14 +15,24 @@ import (
 
 type rendererKey struct{}
 
+type ticketKey struct{}
+
 // Renderer renders diagnostics and can optionally collect results for JSON output.
 type Renderer struct {
-	registry   *rules.Registry
-	writer     io.Writer
-	renderText bool
-	collect    bool
-	results    []*rules.RuleResult
-	nextNumber int
+	registry          *rules.Registry
+	writer            io.Writer
+	renderText        bool
+	collect           bool
+	collectTaxonomies bool
+	results           []*rules.RuleResult
+	taxonomies        []Collected
+	nextNumber        int
+}
+
+// Collected is a rendered taxonomy with the ticket it was reported for.
+type Collected struct {
+	Ticket   string
+	Taxonomy *core.Taxonomy
 }
 
 type Option func(*Renderer)
@@ -70,6 +80,14 @@ func WithCollector() Option {
 	}
 }
 
+// WithTaxonomyCollector keeps every rendered taxonomy, whether or not a rule
+// matches it (see Taxonomies), e.g. for SARIF/JUnit reports.
+func WithTaxonomyCollector() Option {
+	return func(r *Renderer) {
+		r.collectTaxonomies = true
+	}
+}
+
 // WithoutText disables text rendering (useful for tests/CI-only JSON).
 func WithoutText() Option {
 	return func(r *Renderer) {
@@ -94,6 +112,22 @@ func ContextWithRenderer(ctx context.Context, r *Renderer) context.Context {
 	return context.WithValue(ctx, rendererKey{}, r)
 }
 
+// ContextWithTicket tags taxonomies rendered under ctx with a ticket ID.
+func ContextWithTicket(ctx context.Context, ticket string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, ticketKey{}, ticket)
+}
+
+func ticketFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	ticket, _ := ctx.Value(ticketKey{}).(string)
+	return ticket
+}
+
 func rendererFromContext(ctx context.Context) *Renderer {
 	if ctx == nil {
 		return nil
@@ -109,6 +143,9 @@ func (r *Renderer) Render(ctx context.Context, tax *core.Taxonomy) {
 	if tax == nil || r == nil {
 		return
 	}
+	if r.collectTaxonomies {
+		r.taxonomies = append(r.taxonomies, Collected{Ticket: ticketFromContext(ctx), Taxonomy: tax})
+	}
 	reg := r.registry
 	if reg == nil {
 		reg = docmgrrules.DefaultRegistry()
@@ -134,6 +171,11 @@ func (r *Renderer) Results() []*rules.RuleResult {
 	return r.results
 }
 
+// Taxonomies returns the taxonomies kept by WithTaxonomyCollector, in render order.
+func (r *Renderer) Taxonomies() []Collected {
+	return r.taxonomies
+}
+
 // JSON renders collected results into pretty JSON.
 func (r *Renderer) JSON() ([]byte, error) {
 	return render.RenderToJSON(r.results)
@@ -28,3 +28,25 @@ func TestRendererCollectsAndOutputsJSON(t *testing.T) {
 		t.Fatalf("expected JSON to mention field name, got: %s", string(data))
 	}
 }
+
+func TestRendererCollectsTaxonomiesWithTicket(t *testing.T) {
+	renderer := NewRenderer(WithTaxonomyCollector(), WithoutText())
+	ctx := ContextWithRenderer(context.Background(), renderer)
+
+	RenderTaxonomy(ContextWithTicket(ctx, "MEN-1"), docmgrctx.NewDoctorFinding("tasks.md", "task_overdue", "warning", "task 1 is overdue"))
+	RenderTaxonomy(ctx, docmgrctx.NewVocabularyUnknownTaxonomy("doc.md", "Topics", "custom", []string{"chat"}))
+
+	collected := renderer.Taxonomies()
+	if len(collected) != 2 {
+		t.Fatalf("expected 2 collected taxonomies, got %d", len(collected))
+	}
+	if collected[0].Ticket != "MEN-1" || collected[0].Taxonomy.Symptom != "task_overdue" {
+		t.Fatalf("unexpected first entry: %+v", collected[0])
+	}
+	if collected[1].Ticket != "" {
+		t.Fatalf("expected no ticket on second entry, got %q", collected[1].Ticket)
+	}
+	if len(renderer.Results()) != 0 {
+		t.Fatalf("rule results are only kept with WithCollector")
+	}
+}
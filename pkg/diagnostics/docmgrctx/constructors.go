@@ -12,6 +12,10 @@ func NewRelatedFileMissing(docPath, filePath, note string) *core.Taxonomy {
 	return NewRelatedFileMissingTaxonomy(docPath, filePath, note)
 }
 
+func NewRelatedFileLegacy(docPath, filePath, note string, exists bool) *core.Taxonomy {
+	return NewRelatedFileLegacyTaxonomy(docPath, filePath, note, exists)
+}
+
 func NewFrontmatterParse(file string, line, col int, snippet, problem string, cause error) *core.Taxonomy {
 	return NewFrontmatterParseTaxonomy(file, line, col, snippet, problem, cause)
 }
@@ -32,6 +36,14 @@ func NewWorkspaceMissingIndex(path string) *core.Taxonomy {
 	return NewMissingIndexTaxonomy(path)
 }
 
+func NewCustomRule(file, ruleID string, stage core.StageCode, symptom core.SymptomCode, severity core.Severity, message, hint string) *core.Taxonomy {
+	return NewCustomRuleTaxonomy(file, ruleID, stage, symptom, severity, message, hint)
+}
+
+func NewDoctorFinding(path, issue string, severity core.Severity, message string) *core.Taxonomy {
+	return NewDoctorFindingTaxonomy(path, issue, severity, message)
+}
+
 func NewWorkspaceStale(file string, lastUpdatedTime interface{}, threshold int) *core.Taxonomy {
 	// lastUpdatedTime kept as interface{} to allow easy calling from various contexts.
 	switch v := lastUpdatedTime.(type) {
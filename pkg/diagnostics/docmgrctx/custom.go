@@ -0,0 +1,55 @@
+package docmgrctx
+
+import (
+	"fmt"
+
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+)
+
+// StageCustom is the default stage of findings from workspace-defined rules
+// (.docmgr/rules.yaml); rules may set their own stage code.
+const StageCustom core.StageCode = "docmgr.custom"
+
+// CustomRuleContext captures a document matched by a workspace-defined rule.
+type CustomRuleContext struct {
+	RuleID    string
+	RuleStage core.StageCode
+	File      string
+	Message   string
+	Hint      string
+}
+
+func (c *CustomRuleContext) Stage() core.StageCode {
+	if c.RuleStage == "" {
+		return StageCustom
+	}
+	return c.RuleStage
+}
+func (c *CustomRuleContext) Summary() string {
+	return fmt.Sprintf("%s: %s (rule %s)", c.File, c.Message, c.RuleID)
+}
+
+// NewCustomRuleTaxonomy builds a taxonomy for a workspace-defined rule
+// finding. Empty stage/symptom default to StageCustom and the rule ID.
+func NewCustomRuleTaxonomy(file, ruleID string, stage core.StageCode, symptom core.SymptomCode, severity core.Severity, message, hint string) *core.Taxonomy {
+	if stage == "" {
+		stage = StageCustom
+	}
+	if symptom == "" {
+		symptom = core.SymptomCode(ruleID)
+	}
+	return &core.Taxonomy{
+		Tool:     "docmgr",
+		Stage:    stage,
+		Symptom:  symptom,
+		Path:     file,
+		Severity: severity,
+		Context: &CustomRuleContext{
+			RuleID:    ruleID,
+			RuleStage: stage,
+			File:      file,
+			Message:   message,
+			Hint:      hint,
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package docmgrctx
+
+import (
+	"fmt"
+
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+)
+
+// StageDoctor is the stage of doctor checks that have no domain-specific
+// context (task attributes, ticket links, naming policy, ...). The symptom is
+// the doctor issue name.
+const StageDoctor core.StageCode = "docmgr.doctor"
+
+// DoctorFindingContext captures a doctor finding as reported in its table row.
+type DoctorFindingContext struct {
+	Issue   string
+	Path    string
+	Message string
+}
+
+func (c *DoctorFindingContext) Stage() core.StageCode { return StageDoctor }
+func (c *DoctorFindingContext) Summary() string {
+	if c.Path == "" {
+		return c.Message
+	}
+	return fmt.Sprintf("%s: %s", c.Path, c.Message)
+}
+
+// NewDoctorFindingTaxonomy builds a taxonomy for a generic doctor finding.
+func NewDoctorFindingTaxonomy(path, issue string, severity core.Severity, message string) *core.Taxonomy {
+	return &core.Taxonomy{
+		Tool:     "docmgr",
+		Stage:    StageDoctor,
+		Symptom:  core.SymptomCode(issue),
+		Path:     path,
+		Severity: severity,
+		Context: &DoctorFindingContext{
+			Issue:   issue,
+			Path:    path,
+			Message: message,
+		},
+	}
+}
@@ -24,15 +24,9 @@ type FrontmatterParseContext struct {
 
 func (c *FrontmatterParseContext) Stage() core.StageCode { return StageFrontmatterParse }
 func (c *FrontmatterParseContext) Summary() string {
-	loc := ""
-	if c.Line > 0 {
-		loc = fmt.Sprintf(":%d", c.Line)
-		if c.Column > 0 {
-			loc += fmt.Sprintf(":%d", c.Column)
-		}
-	}
-	return fmt.Sprintf("%s%s %s", c.File, loc, c.Problem)
+	return fmt.Sprintf("%s %s", FormatLocation(c.File, c.Line, c.Column), c.Problem)
 }
+func (c *FrontmatterParseContext) Location() (string, int, int) { return c.File, c.Line, c.Column }
 
 // NewFrontmatterParseTaxonomy builds a taxonomy for YAML/frontmatter syntax errors.
 func NewFrontmatterParseTaxonomy(file string, line, col int, snippet, problem string, cause error) *core.Taxonomy {
@@ -58,12 +52,16 @@ type FrontmatterSchemaContext struct {
 	File   string
 	Field  string
 	Detail string
+	// Line and Column locate the field's value (0 when the field is absent).
+	Line   int
+	Column int
 }
 
 func (c *FrontmatterSchemaContext) Stage() core.StageCode { return StageFrontmatterParse }
 func (c *FrontmatterSchemaContext) Summary() string {
-	return fmt.Sprintf("%s: %s (%s)", c.File, c.Field, c.Detail)
+	return fmt.Sprintf("%s: %s (%s)", FormatLocation(c.File, c.Line, c.Column), c.Field, c.Detail)
 }
+func (c *FrontmatterSchemaContext) Location() (string, int, int) { return c.File, c.Line, c.Column }
 
 // NewFrontmatterSchemaTaxonomy builds a taxonomy for schema validation issues.
 func NewFrontmatterSchemaTaxonomy(file, field, detail string, severity core.Severity) *core.Taxonomy {
@@ -0,0 +1,42 @@
+package docmgrctx
+
+import (
+	"fmt"
+
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+)
+
+// Positioned is implemented by context payloads that point at a line and
+// column (1-based; 0 when unknown) in a markdown file.
+type Positioned interface {
+	Location() (file string, line, col int)
+}
+
+// FormatLocation renders "file:line:col", leaving out unknown parts.
+func FormatLocation(file string, line, col int) string {
+	if line <= 0 {
+		return file
+	}
+	if col <= 0 {
+		return fmt.Sprintf("%s:%d", file, line)
+	}
+	return fmt.Sprintf("%s:%d:%d", file, line, col)
+}
+
+// AtPosition sets the line and column on a taxonomy whose payload can carry a
+// position (frontmatter, vocabulary, related-file contexts) and returns it.
+func AtPosition(t *core.Taxonomy, line, col int) *core.Taxonomy {
+	if t == nil || line <= 0 {
+		return t
+	}
+	if c, ok := t.Context.(*FrontmatterParseContext); ok {
+		c.Line, c.Column = line, col
+	} else if c, ok := t.Context.(*FrontmatterSchemaContext); ok {
+		c.Line, c.Column = line, col
+	} else if c, ok := t.Context.(*VocabularyContext); ok {
+		c.Line, c.Column = line, col
+	} else if c, ok := t.Context.(*RelatedFileContext); ok {
+		c.Line, c.Column = line, col
+	}
+	return t
+}
@@ -11,6 +11,7 @@ const (
 	SymptomMissingFile     core.SymptomCode = "missing_file"
 	SymptomMissingNote     core.SymptomCode = "missing_note"
 	SymptomInvalidFilePath core.SymptomCode = "invalid_file_path"
+	SymptomLegacyPath      core.SymptomCode = "legacy_path"
 )
 
 // RelatedFileContext captures issues with RelatedFiles entries.
@@ -19,6 +20,9 @@ type RelatedFileContext struct {
 	FilePath string // related file path entry
 	Note     string // optional note attached to the entry
 	Exists   bool   // whether file exists on disk
+	// Line and Column locate the RelatedFiles entry in DocPath.
+	Line   int
+	Column int
 }
 
 func (c *RelatedFileContext) Stage() core.StageCode { return StageDocLink }
@@ -27,8 +31,9 @@ func (c *RelatedFileContext) Summary() string {
 	if c.Exists {
 		status = "present"
 	}
-	return fmt.Sprintf("%s: related file %q (%s)", c.DocPath, c.FilePath, status)
+	return fmt.Sprintf("%s: related file %q (%s)", FormatLocation(c.DocPath, c.Line, c.Column), c.FilePath, status)
 }
+func (c *RelatedFileContext) Location() (string, int, int) { return c.DocPath, c.Line, c.Column }
 
 // NewRelatedFileMissingTaxonomy builds a taxonomy for missing related files.
 func NewRelatedFileMissingTaxonomy(docPath, filePath, note string) *core.Taxonomy {
@@ -46,3 +51,21 @@ func NewRelatedFileMissingTaxonomy(docPath, filePath, note string) *core.Taxonom
 		},
 	}
 }
+
+// NewRelatedFileLegacyTaxonomy builds a taxonomy for a RelatedFiles entry that
+// still uses a legacy (unanchored) path; anchor migration fixes it.
+func NewRelatedFileLegacyTaxonomy(docPath, filePath, note string, exists bool) *core.Taxonomy {
+	return &core.Taxonomy{
+		Tool:     "docmgr",
+		Stage:    StageDocLink,
+		Symptom:  SymptomLegacyPath,
+		Path:     filePath,
+		Severity: core.SeverityInfo,
+		Context: &RelatedFileContext{
+			DocPath:  docPath,
+			FilePath: filePath,
+			Note:     note,
+			Exists:   exists,
+		},
+	}
+}
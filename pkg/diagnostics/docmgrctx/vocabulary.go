@@ -18,12 +18,16 @@ type VocabularyContext struct {
 	Field string   // frontmatter field name
 	Value string   // offending value
 	Known []string // known values in vocabulary
+	// Line and Column locate the offending value (list element for Topics).
+	Line   int
+	Column int
 }
 
 func (c *VocabularyContext) Stage() core.StageCode { return StageVocabulary }
 func (c *VocabularyContext) Summary() string {
-	return fmt.Sprintf("%s: field %s unknown value %q", c.File, c.Field, c.Value)
+	return fmt.Sprintf("%s: field %s unknown value %q", FormatLocation(c.File, c.Line, c.Column), c.Field, c.Value)
 }
+func (c *VocabularyContext) Location() (string, int, int) { return c.File, c.Line, c.Column }
 
 // NewVocabularyUnknownTaxonomy builds a taxonomy for unknown vocabulary values.
 func NewVocabularyUnknownTaxonomy(file, field, value string, known []string) *core.Taxonomy {
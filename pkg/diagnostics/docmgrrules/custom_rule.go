@@ -0,0 +1,39 @@
+package docmgrrules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgrctx"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/rules"
+)
+
+// CustomRule renders findings of workspace-defined rules (.docmgr/rules.yaml).
+// It matches on the context type because such rules pick their own stage and
+// symptom codes.
+type CustomRule struct{}
+
+func (r *CustomRule) Match(t *core.Taxonomy) (bool, int) {
+	if t == nil {
+		return false, 0
+	}
+	payload, ok := t.Context.(*docmgrctx.CustomRuleContext)
+	return ok && payload != nil, 60
+}
+
+func (r *CustomRule) Render(ctx context.Context, t *core.Taxonomy) (*rules.RuleResult, error) {
+	payload, ok := t.Context.(*docmgrctx.CustomRuleContext)
+	if !ok || payload == nil {
+		return nil, fmt.Errorf("custom rule: expected CustomRuleContext")
+	}
+	body := fmt.Sprintf("File: %s\nRule: %s (%s/%s)\n", payload.File, payload.RuleID, t.Stage, t.Symptom)
+	if payload.Hint != "" {
+		body += fmt.Sprintf("Hint: %s\n", payload.Hint)
+	}
+	return &rules.RuleResult{
+		Headline: payload.Message,
+		Body:     body,
+		Severity: t.Severity,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package docmgrrules
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgrctx"
+)
+
+func TestCustomRule_MatchAndRender(t *testing.T) {
+	rule := &CustomRule{}
+	tax := docmgrctx.NewCustomRuleTaxonomy("docs/design.md", "design-needs-owner", "team.review", "", core.SeverityError, "design doc has no owner", "add Owners")
+	if tax.Stage != "team.review" || tax.Symptom != "design-needs-owner" {
+		t.Fatalf("unexpected codes: %s/%s", tax.Stage, tax.Symptom)
+	}
+
+	ok, score := rule.Match(tax)
+	if !ok || score <= 0 {
+		t.Fatalf("expected match with positive score, got %v %d", ok, score)
+	}
+	res, err := rule.Render(context.Background(), tax)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if res.Headline != "design doc has no owner" || res.Severity != core.SeverityError || !strings.Contains(res.Body, "Hint: add Owners") {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+
+	// Only the registry's custom rule renders it.
+	results, err := DefaultRegistry().RenderAll(context.Background(), tax)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("expected one rendered result, got %d (%v)", len(results), err)
+	}
+}
+
+func TestCustomRule_NoMatch(t *testing.T) {
+	rule := &CustomRule{}
+	tax := &core.Taxonomy{
+		Stage:   docmgrctx.StageVocabulary,
+		Symptom: docmgrctx.SymptomUnknownValue,
+	}
+	if ok, _ := rule.Match(tax); ok {
+		t.Fatalf("expected no match")
+	}
+}
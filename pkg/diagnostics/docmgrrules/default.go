@@ -13,5 +13,6 @@ func DefaultRegistry() *rules.Registry {
 	reg.Register(&FrontmatterSchemaRule{})
 	reg.Register(&ListingSkipRule{})
 	reg.Register(&WorkspaceRule{})
+	reg.Register(&CustomRule{})
 	return reg
 }
@@ -22,11 +22,9 @@ func (r *FrontmatterSyntaxRule) Render(ctx context.Context, t *core.Taxonomy) (*
 	if !ok || payload == nil {
 		return nil, fmt.Errorf("frontmatter syntax rule: unexpected context type")
 	}
+	location := docmgrctx.FormatLocation(payload.File, payload.Line, payload.Column)
 	var b strings.Builder
-	fmt.Fprintf(&b, "File: %s\n", payload.File)
-	if payload.Line > 0 {
-		fmt.Fprintf(&b, "Line: %d Col: %d\n", payload.Line, payload.Column)
-	}
+	fmt.Fprintf(&b, "File: %s\n", location)
 	if payload.Problem != "" {
 		fmt.Fprintf(&b, "Problem: %s\n", payload.Problem)
 	}
@@ -49,6 +47,7 @@ func (r *FrontmatterSyntaxRule) Render(ctx context.Context, t *core.Taxonomy) (*
 		Headline: "YAML/frontmatter syntax error",
 		Body:     b.String(),
 		Severity: core.SeverityError,
+		Location: location,
 		Actions:  actions,
 	}, nil
 }
@@ -65,7 +64,8 @@ func (r *FrontmatterSchemaRule) Render(ctx context.Context, t *core.Taxonomy) (*
 	if !ok || payload == nil {
 		return nil, fmt.Errorf("frontmatter schema rule: unexpected context type")
 	}
-	body := fmt.Sprintf("File: %s\nField: %s\nIssue: %s\n", payload.File, payload.Field, payload.Detail)
+	location := docmgrctx.FormatLocation(payload.File, payload.Line, payload.Column)
+	body := fmt.Sprintf("File: %s\nField: %s\nIssue: %s\n", location, payload.Field, payload.Detail)
 	actions := []rules.Action{
 		{
 			Label:   "Update field",
@@ -82,6 +82,7 @@ func (r *FrontmatterSchemaRule) Render(ctx context.Context, t *core.Taxonomy) (*
 		Headline: fmt.Sprintf("Frontmatter validation: %s", payload.Field),
 		Body:     body,
 		Severity: t.Severity,
+		Location: location,
 		Actions:  actions,
 	}, nil
 }
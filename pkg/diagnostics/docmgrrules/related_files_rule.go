@@ -25,7 +25,8 @@ func (r *RelatedFileMissingRule) Render(ctx context.Context, t *core.Taxonomy) (
 		return nil, fmt.Errorf("related-file rule: unexpected context type")
 	}
 
-	body := fmt.Sprintf("Doc: %s\nRelated file: %s\nNote: %s\nStatus: missing on disk\n", payload.DocPath, payload.FilePath, payload.Note)
+	location := docmgrctx.FormatLocation(payload.DocPath, payload.Line, payload.Column)
+	body := fmt.Sprintf("Doc: %s\nRelated file: %s\nNote: %s\nStatus: missing on disk\n", location, payload.FilePath, payload.Note)
 	actions := []rules.Action{
 		{
 			Label:   "Remove invalid entry",
@@ -43,6 +44,7 @@ func (r *RelatedFileMissingRule) Render(ctx context.Context, t *core.Taxonomy) (
 		Headline: "Missing related file entry",
 		Body:     body,
 		Severity: core.SeverityWarning,
+		Location: location,
 		Actions:  actions,
 	}, nil
 }
@@ -2,6 +2,7 @@ package docmgrrules
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
@@ -44,6 +45,20 @@ func TestRelatedFileMissingRule_MatchAndRender(t *testing.T) {
 	}
 }
 
+func TestRelatedFileMissingRule_RendersPosition(t *testing.T) {
+	tax := docmgrctx.AtPosition(docmgrctx.NewRelatedFileMissing("docs/index.md", "missing.go", ""), 12, 7)
+	res, err := (&RelatedFileMissingRule{}).Render(context.Background(), tax)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if res.Location != "docs/index.md:12:7" || !strings.Contains(res.Body, "Doc: docs/index.md:12:7") {
+		t.Fatalf("expected path:line:col in result, got location %q body %q", res.Location, res.Body)
+	}
+	if !strings.HasPrefix(tax.ContextSummary(), "docs/index.md:12:7:") {
+		t.Fatalf("expected position in summary, got %q", tax.ContextSummary())
+	}
+}
+
 func TestRelatedFileMissingRule_NoMatch(t *testing.T) {
 	rule := &RelatedFileMissingRule{}
 	tax := &core.Taxonomy{
@@ -26,7 +26,8 @@ func (r *VocabularySuggestionRule) Render(ctx context.Context, t *core.Taxonomy)
 		return nil, fmt.Errorf("vocabulary rule: unexpected context type")
 	}
 
-	body := fmt.Sprintf("File: %s\nField: %s\nValue: %q\n", payload.File, payload.Field, payload.Value)
+	location := docmgrctx.FormatLocation(payload.File, payload.Line, payload.Column)
+	body := fmt.Sprintf("File: %s\nField: %s\nValue: %q\n", location, payload.Field, payload.Value)
 	if len(payload.Known) > 0 {
 		body += fmt.Sprintf("Known values: %s\n", strings.Join(payload.Known, ", "))
 	}
@@ -48,6 +49,7 @@ func (r *VocabularySuggestionRule) Render(ctx context.Context, t *core.Taxonomy)
 		Headline: fmt.Sprintf("Unknown vocabulary value for %s", payload.Field),
 		Body:     body,
 		Severity: t.Severity,
+		Location: location,
 		Actions:  actions,
 	}, nil
 }
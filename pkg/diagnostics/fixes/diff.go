@@ -0,0 +1,189 @@
+package fixes
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// maxLCSCells bounds the line-diff table; larger changed regions are shown as
+// one delete/insert block instead.
+const maxLCSCells = 4_000_000
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type diffOp struct {
+	kind opKind
+	line string
+	// a and b are the 0-based indexes of the next line in each file.
+	a, b int
+}
+
+// UnifiedDiff renders the change from a to b as a unified diff (the format of
+// `diff -u` / `git diff`, three lines of context). It returns "" when the
+// contents are equal.
+func UnifiedDiff(aName, bName string, a, b []byte) string {
+	if bytes.Equal(a, b) {
+		return ""
+	}
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", aName, bName)
+	for start := 0; start < len(ops); {
+		first := nextChange(ops, start)
+		if first < 0 {
+			break
+		}
+		// Extend the hunk while the next change is close enough for the
+		// context blocks to touch.
+		last := first
+		for {
+			next := nextChange(ops, last+1)
+			if next < 0 || next-last-1 > 2*diffContext {
+				break
+			}
+			last = next
+		}
+		from := max(first-diffContext, start)
+		to := min(last+1+diffContext, len(ops))
+		writeHunk(&out, ops[from:to])
+		start = to
+	}
+	return out.String()
+}
+
+func nextChange(ops []diffOp, from int) int {
+	for i := from; i < len(ops); i++ {
+		if ops[i].kind != opEqual {
+			return i
+		}
+	}
+	return -1
+}
+
+func writeHunk(out *strings.Builder, ops []diffOp) {
+	aCount, bCount := 0, 0
+	for _, op := range ops {
+		if op.kind != opInsert {
+			aCount++
+		}
+		if op.kind != opDelete {
+			bCount++
+		}
+	}
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(ops[0].a, aCount), hunkRange(ops[0].b, bCount))
+	for _, op := range ops {
+		out.WriteByte(byte(op.kind))
+		out.WriteString(op.line)
+		if !strings.HasSuffix(op.line, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats a hunk side: "start,count", "start" for one line, and the
+// line before the hunk for an empty side.
+func hunkRange(index, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", index)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", index+1)
+	}
+	return fmt.Sprintf("%d,%d", index+1, count)
+}
+
+// splitLines splits content after each newline; the last line has no
+// newline when the file does not end with one.
+func splitLines(content []byte) []string {
+	var lines []string
+	for len(content) > 0 {
+		i := bytes.IndexByte(content, '\n')
+		if i < 0 {
+			lines = append(lines, string(content))
+			break
+		}
+		lines = append(lines, string(content[:i+1]))
+		content = content[i+1:]
+	}
+	return lines
+}
+
+// diffLines computes a line edit script: the common prefix and suffix are
+// kept as-is and the region between them is aligned by longest common
+// subsequence.
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, diffOp{kind: opEqual, line: a[i], a: i, b: i})
+	}
+	ops = append(ops, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], prefix, prefix)...)
+	for i := 0; i < suffix; i++ {
+		ai, bi := len(a)-suffix+i, len(b)-suffix+i
+		ops = append(ops, diffOp{kind: opEqual, line: a[ai], a: ai, b: bi})
+	}
+	return ops
+}
+
+func diffMiddle(a, b []string, aOff, bOff int) []diffOp {
+	var ops []diffOp
+	if len(a)*len(b) > maxLCSCells {
+		for i, line := range a {
+			ops = append(ops, diffOp{kind: opDelete, line: line, a: aOff + i, b: bOff})
+		}
+		for j, line := range b {
+			ops = append(ops, diffOp{kind: opInsert, line: line, a: aOff + len(a), b: bOff + j})
+		}
+		return ops
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		if i < len(a) && j < len(b) && a[i] == b[j] {
+			ops = append(ops, diffOp{kind: opEqual, line: a[i], a: aOff + i, b: bOff + j})
+			i++
+			j++
+		} else if j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]) {
+			ops = append(ops, diffOp{kind: opDelete, line: a[i], a: aOff + i, b: bOff + j})
+			i++
+		} else {
+			ops = append(ops, diffOp{kind: opInsert, line: b[j], a: aOff + i, b: bOff + j})
+			j++
+		}
+	}
+	return ops
+}
@@ -0,0 +1,269 @@
+// Package fixes applies rules.Fixer fixes to the files diagnostics point at.
+// Fixes are planned in memory first, so they can be previewed as unified
+// diffs (doctor --fix --dry-run) before anything is written.
+package fixes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgr"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgrctx"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/rules"
+)
+
+// Change is one fix applied to a file.
+type Change struct {
+	Fixer   string
+	Summary string
+}
+
+// FileFix is the combined result of every fix planned for one file.
+type FileFix struct {
+	Ticket  string
+	Path    string
+	Before  []byte
+	After   []byte
+	Changes []Change
+	// Backup is set when a fixer asked for the original to be kept as <file>.bak.
+	Backup bool
+}
+
+// Fixers returns the names of the fixers that changed the file, in order.
+func (f *FileFix) Fixers() []string {
+	var out []string
+	for _, c := range f.Changes {
+		if !containsString(out, c.Fixer) {
+			out = append(out, c.Fixer)
+		}
+	}
+	return out
+}
+
+// Summaries returns the change summaries of one fixer.
+func (f *FileFix) Summaries(fixer string) []string {
+	var out []string
+	for _, c := range f.Changes {
+		if c.Fixer == fixer {
+			out = append(out, c.Summary)
+		}
+	}
+	return out
+}
+
+// Diff renders the fix as a unified diff with paths relative to baseDir.
+func (f *FileFix) Diff(baseDir string) string {
+	name := f.Path
+	if baseDir != "" {
+		if rel, err := filepath.Rel(baseDir, f.Path); err == nil && !strings.HasPrefix(rel, "..") {
+			name = rel
+		}
+	}
+	name = filepath.ToSlash(name)
+	return UnifiedDiff("a/"+name, "b/"+name, f.Before, f.After)
+}
+
+// Skip is a finding a fixer matched but could not fix.
+type Skip struct {
+	Ticket string
+	Path   string
+	Fixer  string
+	Reason string
+}
+
+// Plan is the set of planned fixes, in the order the findings were reported.
+type Plan struct {
+	Files   []*FileFix
+	Skipped []Skip
+}
+
+// Build runs the first matching fixer for every finding against the file the
+// finding points at. Files are read once and fixes to the same file are
+// chained; nothing is written.
+func Build(ctx context.Context, fixers []rules.Fixer, found []docmgr.Collected) (*Plan, error) {
+	plan := &Plan{}
+	files := map[string]*FileFix{}
+	var order []string
+
+	for _, c := range found {
+		fixer := matchFixer(fixers, c.Taxonomy)
+		if fixer == nil {
+			continue
+		}
+		path := targetFile(c.Taxonomy)
+		if path == "" {
+			continue
+		}
+		ff, ok := files[path]
+		if !ok {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			ff = &FileFix{Ticket: c.Ticket, Path: path, Before: content, After: content}
+			files[path] = ff
+			order = append(order, path)
+		}
+
+		fix, err := fixer.Fix(ctx, c.Taxonomy, path, ff.After)
+		if err != nil {
+			var noFix *rules.NoFixError
+			if errors.As(err, &noFix) {
+				plan.addSkip(Skip{Ticket: c.Ticket, Path: path, Fixer: fixer.Name(), Reason: noFix.Reason})
+				continue
+			}
+			return nil, fmt.Errorf("fixer %s failed for %s: %w", fixer.Name(), path, err)
+		}
+		if fix == nil || bytes.Equal(fix.Content, ff.After) {
+			continue
+		}
+		ff.After = fix.Content
+		ff.Changes = append(ff.Changes, Change{Fixer: fixer.Name(), Summary: fix.Summary})
+		ff.Backup = ff.Backup || fix.Backup
+	}
+
+	for _, path := range order {
+		if ff := files[path]; !bytes.Equal(ff.Before, ff.After) {
+			plan.Files = append(plan.Files, ff)
+		}
+	}
+	return plan, nil
+}
+
+func (p *Plan) addSkip(s Skip) {
+	for _, existing := range p.Skipped {
+		if existing == s {
+			return
+		}
+	}
+	p.Skipped = append(p.Skipped, s)
+}
+
+// Apply writes every planned file (see Apply for a single file).
+func (p *Plan) Apply() error {
+	for _, f := range p.Files {
+		if err := Apply(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply writes the fixed content of one file, keeping a .bak copy of the
+// original when requested. It refuses to overwrite a file that changed on
+// disk since the fix was planned.
+func Apply(f *FileFix) error {
+	info, err := os.Stat(f.Path)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", f.Path, err)
+	}
+	current, err := os.ReadFile(f.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", f.Path, err)
+	}
+	if !bytes.Equal(current, f.Before) {
+		return fmt.Errorf("%s changed since the fix was planned; re-run the fix", f.Path)
+	}
+	if f.Backup {
+		bak := f.Path + ".bak"
+		if err := os.WriteFile(bak, f.Before, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write backup %s: %w", bak, err)
+		}
+	}
+	if err := os.WriteFile(f.Path, f.After, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.Path, err)
+	}
+	return nil
+}
+
+// Select filters fixers by name; an empty list selects all of them. Unknown
+// names are an error that lists the available fixers.
+func Select(fixers []rules.Fixer, only []string) ([]rules.Fixer, error) {
+	var names []string
+	for _, n := range only {
+		for _, part := range strings.Split(n, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				names = append(names, part)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return fixers, nil
+	}
+
+	var out []rules.Fixer
+	for _, name := range names {
+		var match rules.Fixer
+		for _, f := range fixers {
+			if strings.EqualFold(f.Name(), name) {
+				match = f
+				break
+			}
+		}
+		if match == nil {
+			return nil, fmt.Errorf("unknown fixer %q (available: %s)", name, strings.Join(Names(fixers), ", "))
+		}
+		if !containsFixer(out, match) {
+			out = append(out, match)
+		}
+	}
+	return out, nil
+}
+
+// Names returns the sorted fixer names.
+func Names(fixers []rules.Fixer) []string {
+	out := make([]string, 0, len(fixers))
+	for _, f := range fixers {
+		out = append(out, f.Name())
+	}
+	sort.Strings(out)
+	return out
+}
+
+func matchFixer(fixers []rules.Fixer, t *core.Taxonomy) rules.Fixer {
+	if t == nil {
+		return nil
+	}
+	for _, f := range fixers {
+		if f.Match(t) {
+			return f
+		}
+	}
+	return nil
+}
+
+// targetFile is the file a taxonomy points at; the taxonomy Path is a field
+// name for frontmatter and vocabulary findings, so positioned contexts win.
+func targetFile(t *core.Taxonomy) string {
+	if p, ok := t.Context.(docmgrctx.Positioned); ok {
+		if path, _, _ := p.Location(); path != "" {
+			return path
+		}
+	}
+	return t.Path
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFixer(list []rules.Fixer, f rules.Fixer) bool {
+	for _, v := range list {
+		if v.Name() == f.Name() {
+			return true
+		}
+	}
+	return false
+}
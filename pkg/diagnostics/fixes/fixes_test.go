@@ -0,0 +1,152 @@
+package fixes
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgr"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgrctx"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/rules"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "one line changed",
+			a:    "a\nb\nc\n",
+			b:    "a\nB\nc\n",
+			want: "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name: "insert at start",
+			a:    "x\n",
+			b:    "new\nx\n",
+			want: "--- a/f\n+++ b/f\n@@ -1 +1,2 @@\n+new\n x\n",
+		},
+		{
+			name: "everything removed",
+			a:    "x\n",
+			b:    "",
+			want: "--- a/f\n+++ b/f\n@@ -1 +0,0 @@\n-x\n",
+		},
+		{
+			name: "missing newline at end",
+			a:    "a\nb",
+			b:    "a\nb\n",
+			want: "--- a/f\n+++ b/f\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			name: "equal",
+			a:    "same\n",
+			b:    "same\n",
+			want: "",
+		},
+	}
+	for _, tc := range cases {
+		if got := UnifiedDiff("a/f", "b/f", []byte(tc.a), []byte(tc.b)); got != tc.want {
+			t.Errorf("%s:\ngot:\n%s\nwant:\n%s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestUnifiedDiffSplitsDistantChanges(t *testing.T) {
+	var a, b strings.Builder
+	for i := 0; i < 20; i++ {
+		line := string(rune('a'+i)) + "\n"
+		a.WriteString(line)
+		if i == 1 || i == 18 {
+			line = strings.ToUpper(line)
+		}
+		b.WriteString(line)
+	}
+	got := UnifiedDiff("a/f", "b/f", []byte(a.String()), []byte(b.String()))
+	if n := strings.Count(got, "@@ -"); n != 2 {
+		t.Fatalf("expected two hunks, got %d:\n%s", n, got)
+	}
+	if !strings.Contains(got, "@@ -1,5 +1,5 @@\n") || !strings.Contains(got, "@@ -16,5 +16,5 @@\n") {
+		t.Fatalf("unexpected hunk ranges:\n%s", got)
+	}
+}
+
+// upperFixer upper-cases the vocabulary value it is given; it cannot fix "skip".
+type upperFixer struct{}
+
+func (upperFixer) Name() string { return "upper" }
+
+func (upperFixer) Match(t *core.Taxonomy) bool { return t.Stage == docmgrctx.StageVocabulary }
+
+func (upperFixer) Fix(_ context.Context, t *core.Taxonomy, _ string, content []byte) (*rules.Fix, error) {
+	value := t.Context.(*docmgrctx.VocabularyContext).Value
+	if value == "skip" {
+		return nil, rules.NoFix("cannot fix %s", value)
+	}
+	out := bytes.ReplaceAll(content, []byte(value), []byte(strings.ToUpper(value)))
+	return &rules.Fix{Content: out, Summary: value}, nil
+}
+
+func TestBuildChainsFixesPerFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "doc.md")
+	if err := os.WriteFile(path, []byte("Topics: [api, chat, skip]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	found := []docmgr.Collected{
+		{Ticket: "T-1", Taxonomy: docmgrctx.NewVocabularyUnknown(path, "Topics", "api", nil)},
+		{Ticket: "T-1", Taxonomy: docmgrctx.NewVocabularyUnknown(path, "Topics", "chat", nil)},
+		{Ticket: "T-1", Taxonomy: docmgrctx.NewVocabularyUnknown(path, "Topics", "skip", nil)},
+		{Ticket: "T-1", Taxonomy: docmgrctx.NewWorkspaceMissingIndex(dir)},
+	}
+
+	plan, err := Build(context.Background(), []rules.Fixer{upperFixer{}}, found)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Files) != 1 || string(plan.Files[0].After) != "Topics: [API, CHAT, skip]\n" {
+		t.Fatalf("unexpected plan: %+v", plan.Files)
+	}
+	if got := plan.Files[0].Summaries("upper"); strings.Join(got, ",") != "api,chat" {
+		t.Fatalf("unexpected summaries %v", got)
+	}
+	if len(plan.Skipped) != 1 || plan.Skipped[0].Reason != "cannot fix skip" || plan.Skipped[0].Ticket != "T-1" {
+		t.Fatalf("unexpected skips %+v", plan.Skipped)
+	}
+	if diff := plan.Files[0].Diff(dir); !strings.HasPrefix(diff, "--- a/doc.md\n+++ b/doc.md\n") {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+
+	if err := plan.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "Topics: [API, CHAT, skip]\n" {
+		t.Fatalf("file not written: %q", got)
+	}
+	if _, err := os.Stat(path + ".bak"); !os.IsNotExist(err) {
+		t.Fatalf("unexpected backup: %v", err)
+	}
+	// The plan is stale now: applying it again must not clobber the file.
+	if err := plan.Apply(); err == nil {
+		t.Fatal("expected an error applying a stale plan")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	fixers := []rules.Fixer{upperFixer{}}
+	if got, err := Select(fixers, nil); err != nil || len(got) != 1 {
+		t.Fatalf("empty selection: %v %v", got, err)
+	}
+	if got, err := Select(fixers, []string{"upper, UPPER"}); err != nil || len(got) != 1 {
+		t.Fatalf("named selection: %v %v", got, err)
+	}
+	if _, err := Select(fixers, []string{"lower"}); err == nil || !strings.Contains(err.Error(), "available: upper") {
+		t.Fatalf("expected unknown fixer error, got %v", err)
+	}
+}
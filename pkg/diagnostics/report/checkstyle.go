@@ -0,0 +1,67 @@
+package report
+
+import (
+	"encoding/xml"
+
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+)
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// renderCheckstyle writes findings grouped by file, in first-seen order.
+// Findings without a path are listed under ".".
+func renderCheckstyle(findings []Finding, opts Options) ([]byte, error) {
+	rep := checkstyleReport{Version: "4.3", Files: []checkstyleFile{}}
+	index := map[string]int{}
+	for _, f := range findings {
+		name := relPath(opts.BaseDir, f.Path)
+		if name == "" {
+			name = "."
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(rep.Files)
+			index[name] = i
+			rep.Files = append(rep.Files, checkstyleFile{Name: name})
+		}
+		rep.Files[i].Errors = append(rep.Files[i].Errors, checkstyleError{
+			Line:     f.Line,
+			Column:   f.Column,
+			Severity: checkstyleSeverity(f.Severity),
+			Message:  f.text(opts.BaseDir),
+			Source:   string(f.Stage) + "." + string(f.Symptom),
+		})
+	}
+	out, err := xml.MarshalIndent(rep, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+func checkstyleSeverity(sev core.Severity) string {
+	if sev == core.SeverityError {
+		return "error"
+	}
+	if sev == core.SeverityWarning {
+		return "warning"
+	}
+	return "info"
+}
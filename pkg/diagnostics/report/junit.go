@@ -0,0 +1,113 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+)
+
+// workspaceCase names the JUnit test case of findings without a ticket.
+const workspaceCase = "(workspace)"
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// renderJUnit writes one test case per ticket. Error and warning findings
+// fail the case; info findings go to its system-out.
+func renderJUnit(findings []Finding, opts Options) ([]byte, error) {
+	order := []string{}
+	byTicket := map[string][]Finding{}
+	seen := map[string]bool{}
+	addTicket := func(ticket string) {
+		if !seen[ticket] {
+			seen[ticket] = true
+			order = append(order, ticket)
+		}
+	}
+	for _, t := range opts.Tickets {
+		addTicket(t)
+	}
+	for _, f := range findings {
+		addTicket(f.Ticket)
+		byTicket[f.Ticket] = append(byTicket[f.Ticket], f)
+	}
+
+	suite := junitSuite{Name: "docmgr doctor"}
+	for _, ticket := range order {
+		name := ticket
+		if name == "" {
+			name = workspaceCase
+		}
+		tc := junitCase{ClassName: "docmgr.doctor", Name: name}
+		var failures, infos []string
+		errorCount, warningCount := 0, 0
+		for _, f := range byTicket[ticket] {
+			line := fmt.Sprintf("[%s] %s %s: %s", f.Severity, f.RuleID(), f.location(opts.BaseDir), f.text(opts.BaseDir))
+			if f.Severity == core.SeverityError {
+				errorCount++
+				failures = append(failures, line)
+			} else if f.Severity == core.SeverityWarning {
+				warningCount++
+				failures = append(failures, line)
+			} else {
+				infos = append(infos, line)
+			}
+		}
+		if len(failures) > 0 {
+			failType := string(core.SeverityWarning)
+			if errorCount > 0 {
+				failType = string(core.SeverityError)
+			}
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d error(s), %d warning(s)", errorCount, warningCount),
+				Type:    failType,
+				Body:    strings.Join(failures, "\n"),
+			}
+			suite.Failures++
+		}
+		if len(infos) > 0 {
+			tc.SystemOut = strings.Join(infos, "\n")
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Tests = len(suite.Cases)
+
+	out, err := xml.MarshalIndent(junitSuites{
+		Name:     suite.Name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitSuite{suite},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
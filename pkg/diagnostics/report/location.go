@@ -0,0 +1,77 @@
+package report
+
+import (
+	"os"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/internal/documents"
+	"github.com/go-go-golems/docmgr/pkg/models"
+)
+
+// frontmatterLocation returns the 1-based line and column of a frontmatter
+// field in file, narrowed to the list element matching value (a topic, a
+// RelatedFiles path) when value is set. Positions come from the frontmatter
+// parser, so columns count runes like every other recorded position. A field
+// that is absent, an empty field name, or frontmatter that does not parse
+// resolves to the opening '---'. Files that cannot be read or have no
+// frontmatter yield 0, 0.
+func frontmatterLocation(file, field, value string) (int, int) {
+	if file == "" {
+		return 0, 0
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return 0, 0
+	}
+	first, _, _ := strings.Cut(string(data), "\n")
+	if strings.TrimSpace(strings.TrimPrefix(first, "\ufeff")) != "---" {
+		return 0, 0
+	}
+	if field == "" {
+		return 1, 1
+	}
+	doc, _, err := documents.ReadDocumentWithFrontmatter(file)
+	if err != nil {
+		return 1, 1
+	}
+	fp, ok := doc.Positions.Field(field)
+	if !ok {
+		return 1, 1
+	}
+	pos := fp.Key
+	if value != "" {
+		if i := listIndex(doc, field, value); i >= 0 {
+			pos = doc.Positions.Item(field, i)
+		} else if !fp.Value.IsZero() {
+			pos = fp.Value
+		}
+	}
+	if pos.IsZero() {
+		return 1, 1
+	}
+	return pos.Line, pos.Column
+}
+
+// listIndex returns the index of value in a list field, or -1. A
+// comma-joined value (several unknown topics in one finding) matches its
+// first element.
+func listIndex(doc *models.Document, field, value string) int {
+	value, _, _ = strings.Cut(value, ",")
+	value = strings.TrimSpace(value)
+	var items []string
+	if strings.EqualFold(field, "Topics") {
+		items = doc.Topics
+	} else if strings.EqualFold(field, "Owners") {
+		items = doc.Owners
+	} else if strings.EqualFold(field, "RelatedFiles") {
+		for _, rf := range doc.RelatedFiles {
+			items = append(items, rf.Path)
+		}
+	}
+	for i, item := range items {
+		if item == value {
+			return i
+		}
+	}
+	return -1
+}
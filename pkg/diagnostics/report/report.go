@@ -0,0 +1,162 @@
+// Package report renders docmgr diagnostics (core.Taxonomy) in CI report
+// formats: SARIF 2.1.0 for code-scanning dashboards, JUnit XML with one test
+// case per ticket, and checkstyle XML.
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgrctx"
+)
+
+// Format is a report format name.
+type Format string
+
+const (
+	FormatSARIF      Format = "sarif"
+	FormatJUnit      Format = "junit"
+	FormatCheckstyle Format = "checkstyle"
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{FormatSARIF, FormatJUnit, FormatCheckstyle}
+
+// ParseFormat validates a format name (case-insensitive).
+func ParseFormat(s string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range Formats {
+		if f == known {
+			return f, nil
+		}
+	}
+	names := make([]string, 0, len(Formats))
+	for _, known := range Formats {
+		names = append(names, string(known))
+	}
+	return "", fmt.Errorf("unknown report format %q (expected one of: %s)", s, strings.Join(names, ", "))
+}
+
+// Finding is one diagnostic with its resolved file location.
+type Finding struct {
+	// Ticket groups findings into JUnit test cases; empty for workspace-level findings.
+	Ticket   string
+	Stage    core.StageCode
+	Symptom  core.SymptomCode
+	Severity core.Severity
+	// Message is the taxonomy's context summary.
+	Message string
+	// Path is the file (or directory) the finding points at.
+	Path string
+	// Line and Column are 1-based; 0 when unknown.
+	Line   int
+	Column int
+}
+
+// RuleID identifies the check that produced the finding: "<stage>/<symptom>".
+func (f Finding) RuleID() string {
+	return string(f.Stage) + "/" + string(f.Symptom)
+}
+
+// FromTaxonomy converts a taxonomy into a finding. The file comes from the
+// context payload (the taxonomy Path is a field name for frontmatter and
+// vocabulary findings). Payloads without a recorded position are located
+// through the positions the frontmatter parser records for the file.
+func FromTaxonomy(ticket string, t *core.Taxonomy) Finding {
+	f := Finding{
+		Ticket:   ticket,
+		Stage:    t.Stage,
+		Symptom:  t.Symptom,
+		Severity: t.Severity,
+		Message:  t.ContextSummary(),
+		Path:     t.Path,
+	}
+	if f.Message == "" {
+		f.Message = f.RuleID()
+	}
+	if f.Severity == "" {
+		f.Severity = core.SeverityError
+	}
+	if p, ok := t.Context.(docmgrctx.Positioned); ok {
+		f.Path, f.Line, f.Column = p.Location()
+		if f.Line > 0 {
+			return f
+		}
+	}
+
+	if c, ok := t.Context.(*docmgrctx.FrontmatterParseContext); ok {
+		f.Line, f.Column = frontmatterLocation(c.File, "", "")
+	} else if c, ok := t.Context.(*docmgrctx.FrontmatterSchemaContext); ok {
+		f.Line, f.Column = frontmatterLocation(c.File, c.Field, "")
+	} else if c, ok := t.Context.(*docmgrctx.VocabularyContext); ok {
+		f.Line, f.Column = frontmatterLocation(c.File, c.Field, c.Value)
+	} else if c, ok := t.Context.(*docmgrctx.RelatedFileContext); ok {
+		f.Line, f.Column = frontmatterLocation(c.DocPath, "RelatedFiles", c.FilePath)
+	} else if c, ok := t.Context.(*docmgrctx.StalenessContext); ok {
+		f.Path = c.File
+		f.Line, f.Column = frontmatterLocation(c.File, "LastUpdated", "")
+	} else if c, ok := t.Context.(*docmgrctx.CustomRuleContext); ok {
+		f.Path = c.File
+		f.Line, f.Column = frontmatterLocation(c.File, "", "")
+	}
+	return f
+}
+
+// Options control report rendering.
+type Options struct {
+	// BaseDir makes paths relative (SARIF artifact URIs, checkstyle and JUnit
+	// file names); usually the repository root.
+	BaseDir string
+	// Tickets lists every checked ticket so tickets without findings appear
+	// as passing JUnit test cases.
+	Tickets []string
+}
+
+// Render writes findings in the given format.
+func Render(format Format, findings []Finding, opts Options) ([]byte, error) {
+	if format == FormatSARIF {
+		return renderSARIF(findings, opts)
+	}
+	if format == FormatJUnit {
+		return renderJUnit(findings, opts)
+	}
+	if format == FormatCheckstyle {
+		return renderCheckstyle(findings, opts)
+	}
+	return nil, fmt.Errorf("unknown report format %q", format)
+}
+
+// relPath returns path relative to base with forward slashes; paths outside
+// base stay absolute.
+func relPath(base, path string) string {
+	if path == "" || base == "" || !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(base, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// text returns the message with paths under base made relative.
+func (f Finding) text(base string) string {
+	if base == "" {
+		return f.Message
+	}
+	return strings.ReplaceAll(f.Message, filepath.Clean(base)+string(filepath.Separator), "")
+}
+
+// location formats "path:line:col" for plain-text report bodies.
+func (f Finding) location(base string) string {
+	loc := relPath(base, f.Path)
+	if f.Line > 0 {
+		loc += fmt.Sprintf(":%d", f.Line)
+		if f.Column > 0 {
+			loc += fmt.Sprintf(":%d", f.Column)
+		}
+	}
+	return loc
+}
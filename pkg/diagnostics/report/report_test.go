@@ -0,0 +1,171 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/docmgrctx"
+)
+
+const testDoc = `---
+Title: Routes
+Ticket: MEN-1
+Topics:
+    - api
+    - bogus
+RelatedFiles:
+    - Path: pkg/missing.go
+      Note: handler
+---
+
+# Routes
+`
+
+func writeTestDoc(t *testing.T) (string, string) {
+	t.Helper()
+	base := t.TempDir()
+	path := filepath.Join(base, "ttmp", "MEN-1", "design", "01-routes.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(testDoc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return base, path
+}
+
+func TestFromTaxonomyCountsColumnsInRunes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "doc.md")
+	if err := os.WriteFile(path, []byte("---\nTitle: Café\nTopics: [café, bogus]\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// "bogus" is the 17th byte but the 16th rune of its line.
+	f := FromTaxonomy("", docmgrctx.NewVocabularyUnknown(path, "Topics", "bogus", nil))
+	if f.Line != 3 || f.Column != 16 {
+		t.Fatalf("got %d:%d, want 3:16", f.Line, f.Column)
+	}
+}
+
+func TestFromTaxonomyLocatesFrontmatterFields(t *testing.T) {
+	_, path := writeTestDoc(t)
+	cases := []struct {
+		name      string
+		tax       *core.Taxonomy
+		line, col int
+	}{
+		{"schema field", docmgrctx.NewFrontmatterSchema(path, "Ticket", "bad ticket", core.SeverityWarning), 3, 1},
+		{"missing field", docmgrctx.NewFrontmatterSchema(path, "Summary", "missing Summary", core.SeverityWarning), 1, 1},
+		{"vocabulary value", docmgrctx.NewVocabularyUnknown(path, "Topics", "bogus", []string{"api"}), 6, 7},
+		{"related file", docmgrctx.NewRelatedFileMissing(path, "pkg/missing.go", "handler"), 8, 7},
+		{"parse position", docmgrctx.NewFrontmatterParse(path, 4, 2, "", "bad indent", nil), 4, 2},
+		{"recorded position", docmgrctx.AtPosition(docmgrctx.NewVocabularyUnknown(path, "Topics", "bogus", nil), 9, 3), 9, 3},
+	}
+	for _, tc := range cases {
+		f := FromTaxonomy("MEN-1", tc.tax)
+		if f.Path != path || f.Line != tc.line || f.Column != tc.col {
+			t.Errorf("%s: got %s:%d:%d, want line %d col %d", tc.name, f.Path, f.Line, f.Column, tc.line, tc.col)
+		}
+	}
+}
+
+func TestRenderSARIF(t *testing.T) {
+	base, path := writeTestDoc(t)
+	findings := []Finding{
+		FromTaxonomy("MEN-1", docmgrctx.NewVocabularyUnknown(path, "Topics", "bogus", []string{"api"})),
+		FromTaxonomy("MEN-1", docmgrctx.NewDoctorFinding(filepath.Join(base, "ttmp", "MEN-1"), "multiple_index", core.SeverityWarning, "two index files")),
+		FromTaxonomy("", docmgrctx.NewDoctorFinding("", "no_vocabulary", core.SeverityInfo, "no vocabulary")),
+	}
+	data, err := Render(FormatSARIF, findings, Options{BaseDir: base})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v", err)
+	}
+	run := log.Runs[0]
+	if log.Version != "2.1.0" || len(run.Tool.Driver.Rules) != 3 || len(run.Results) != 3 {
+		t.Fatalf("unexpected SARIF log: %s", data)
+	}
+	res := run.Results[0]
+	loc := res.Locations[0].PhysicalLocation
+	if res.RuleID != "docmgr.vocabulary/unknown_value" || res.Level != "warning" ||
+		loc.ArtifactLocation.URI != "ttmp/MEN-1/design/01-routes.md" || loc.Region == nil || loc.Region.StartLine != 6 {
+		t.Fatalf("unexpected first result: %+v", res)
+	}
+	if strings.Contains(res.Message.Text, base) || res.Properties["ticket"] != "MEN-1" {
+		t.Fatalf("expected relative message and ticket property: %+v", res)
+	}
+	if run.Results[2].Level != "note" || len(run.Results[2].Locations) != 0 {
+		t.Fatalf("expected location-less note, got %+v", run.Results[2])
+	}
+}
+
+func TestRenderJUnitHasCasePerTicket(t *testing.T) {
+	findings := []Finding{
+		{Ticket: "MEN-1", Stage: "docmgr.doctor", Symptom: "stale_code", Severity: core.SeverityWarning, Message: "changed", Path: "a.md"},
+		{Ticket: "MEN-1", Stage: "docmgr.doctor", Symptom: "missing_index", Severity: core.SeverityError, Message: "no index", Path: "b"},
+		{Ticket: "", Stage: "docmgr.doctor", Symptom: "no_vocabulary", Severity: core.SeverityInfo, Message: "no vocabulary"},
+	}
+	data, err := Render(FormatJUnit, findings, Options{Tickets: []string{"MEN-1", "MEN-2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var suites junitSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatalf("invalid JUnit XML: %v", err)
+	}
+	if suites.Tests != 3 || suites.Failures != 1 {
+		t.Fatalf("unexpected totals: %s", data)
+	}
+	cases := suites.Suites[0].Cases
+	if cases[0].Name != "MEN-1" || cases[0].Failure == nil || cases[0].Failure.Type != "error" ||
+		cases[0].Failure.Message != "1 error(s), 1 warning(s)" {
+		t.Fatalf("unexpected MEN-1 case: %+v", cases[0])
+	}
+	if cases[1].Name != "MEN-2" || cases[1].Failure != nil {
+		t.Fatalf("expected passing MEN-2 case: %+v", cases[1])
+	}
+	if cases[2].Name != workspaceCase || cases[2].Failure != nil || !strings.Contains(cases[2].SystemOut, "no vocabulary") {
+		t.Fatalf("expected info-only workspace case: %+v", cases[2])
+	}
+}
+
+func TestRenderCheckstyleGroupsByFile(t *testing.T) {
+	findings := []Finding{
+		{Stage: "docmgr.frontmatter.parse", Symptom: "schema_violation", Severity: core.SeverityError, Message: "Title missing", Path: "/repo/a.md", Line: 1, Column: 1},
+		{Stage: "docmgr.doctor", Symptom: "stale_code", Severity: core.SeverityInfo, Message: "changed", Path: "/repo/b.md"},
+		{Stage: "docmgr.vocabulary", Symptom: "unknown_value", Severity: core.SeverityWarning, Message: "bogus", Path: "/repo/a.md", Line: 6, Column: 7},
+	}
+	data, err := Render(FormatCheckstyle, findings, Options{BaseDir: "/repo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rep checkstyleReport
+	if err := xml.Unmarshal(data, &rep); err != nil {
+		t.Fatalf("invalid checkstyle XML: %v", err)
+	}
+	if len(rep.Files) != 2 || rep.Files[0].Name != "a.md" || len(rep.Files[0].Errors) != 2 {
+		t.Fatalf("unexpected files: %s", data)
+	}
+	if e := rep.Files[0].Errors[1]; e.Line != 6 || e.Severity != "warning" || e.Source != "docmgr.vocabulary.unknown_value" {
+		t.Fatalf("unexpected error entry: %+v", e)
+	}
+	if rep.Files[1].Errors[0].Severity != "info" {
+		t.Fatalf("expected info severity: %+v", rep.Files[1].Errors[0])
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat(" SARIF "); err != nil || f != FormatSARIF {
+		t.Fatalf("ParseFormat(SARIF) = %q, %v", f, err)
+	}
+	if _, err := ParseFormat("html"); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
@@ -0,0 +1,137 @@
+package report
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "docmgr"
+	toolURI      = "https://github.com/go-go-golems/docmgr"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// renderSARIF writes a single-run SARIF log; each distinct stage/symptom pair
+// becomes a rule of the docmgr driver.
+func renderSARIF(findings []Finding, opts Options) ([]byte, error) {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           toolName,
+			InformationURI: toolURI,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	ruleIndex := map[string]int{}
+	for _, f := range findings {
+		id := f.RuleID()
+		idx, ok := ruleIndex[id]
+		if !ok {
+			idx = len(run.Tool.Driver.Rules)
+			ruleIndex[id] = idx
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               id,
+				Name:             string(f.Symptom),
+				ShortDescription: sarifMessage{Text: string(f.Symptom) + " (" + string(f.Stage) + ")"},
+			})
+		}
+		res := sarifResult{
+			RuleID:    id,
+			RuleIndex: idx,
+			Level:     sarifLevel(f.Severity),
+			Message:   sarifMessage{Text: f.text(opts.BaseDir)},
+		}
+		if f.Path != "" {
+			loc := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: sarifURI(opts.BaseDir, f.Path)}}
+			if f.Line > 0 {
+				loc.Region = &sarifRegion{StartLine: f.Line, StartColumn: f.Column}
+			}
+			res.Locations = []sarifLocation{{PhysicalLocation: loc}}
+		}
+		if f.Ticket != "" {
+			res.Properties = map[string]string{"ticket": f.Ticket}
+		}
+		run.Results = append(run.Results, res)
+	}
+	return json.MarshalIndent(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}, "", "  ")
+}
+
+func sarifLevel(sev core.Severity) string {
+	if sev == core.SeverityError {
+		return "error"
+	}
+	if sev == core.SeverityWarning {
+		return "warning"
+	}
+	return "note"
+}
+
+// sarifURI returns a base-relative URI, or a file:// URI for paths outside base.
+func sarifURI(base, path string) string {
+	rel := relPath(base, path)
+	if filepath.IsAbs(filepath.FromSlash(rel)) {
+		return "file://" + rel
+	}
+	return rel
+}
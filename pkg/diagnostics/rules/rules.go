@@ -2,6 +2,7 @@ package rules
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	"github.com/go-go-golems/docmgr/pkg/diagnostics/core"
@@ -12,6 +13,8 @@ type RuleResult struct {
 	Headline string
 	Body     string
 	Severity core.Severity
+	// Location is the "path:line:col" the finding points at, when known.
+	Location string `json:"Location,omitempty"`
 	Actions  []Action
 }
 
@@ -28,9 +31,41 @@ type Rule interface {
 	Render(context.Context, *core.Taxonomy) (*RuleResult, error)
 }
 
+// Fixer is the machine-applicable counterpart of a rule's Actions: it edits
+// the file a matching taxonomy points at. Fix receives the file's current
+// content (possibly already changed by other fixes) so several fixes to one
+// file compose, and returns nil when there is nothing to change.
+type Fixer interface {
+	// Name identifies the fixer for selection (doctor --fix --only <name>).
+	Name() string
+	Match(*core.Taxonomy) bool
+	Fix(ctx context.Context, t *core.Taxonomy, file string, content []byte) (*Fix, error)
+}
+
+// Fix is the result of one fixer application.
+type Fix struct {
+	Content []byte
+	Summary string
+	// Backup asks for the original file to be kept as <file>.bak.
+	Backup bool
+}
+
+// NoFixError reports a finding a fixer matched but cannot fix safely.
+type NoFixError struct {
+	Reason string
+}
+
+func (e *NoFixError) Error() string { return e.Reason }
+
+// NoFix returns a NoFixError with a formatted reason.
+func NoFix(format string, args ...any) error {
+	return &NoFixError{Reason: fmt.Sprintf(format, args...)}
+}
+
 // Registry stores rules and renders them by score.
 type Registry struct {
-	rules []Rule
+	rules  []Rule
+	fixers []Fixer
 }
 
 func NewRegistry() *Registry {
@@ -45,6 +80,19 @@ func (r *Registry) Register(rule Rule) {
 	r.rules = append(r.rules, rule)
 }
 
+// RegisterFixer adds a fixer to the registry.
+func (r *Registry) RegisterFixer(fixer Fixer) {
+	if fixer == nil {
+		return
+	}
+	r.fixers = append(r.fixers, fixer)
+}
+
+// Fixers returns the registered fixers in registration order.
+func (r *Registry) Fixers() []Fixer {
+	return append([]Fixer(nil), r.fixers...)
+}
+
 // RenderAll runs all matching rules, sorted by score (desc).
 func (r *Registry) RenderAll(ctx context.Context, t *core.Taxonomy) ([]*RuleResult, error) {
 	type scored struct {
@@ -9,6 +9,7 @@
 //   - docmgr-cli-guide.md: Complete CLI reference
 //   - docmgr-http-api.md: HTTP API server reference
 //   - docmgr-web-ui.md: Embedded web UI guide
+//   - docmgr-lsp.md: Language server for editors (docmgr lsp)
 //   - path-anchors.md: Anchored RelatedFiles paths (repo://, ws://, docs://, abs://)
 //   - templates-and-guidelines.md: Document templates and guidelines
 //   - docmgr-ci-automation.md: CI/CD integration guide
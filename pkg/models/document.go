@@ -67,19 +67,34 @@ import (
 //	# API Design
 //	...content...
 type Document struct {
-	Title           string       `yaml:"Title" json:"title"`
-	Ticket          string       `yaml:"Ticket" json:"ticket"`
-	Status          string       `yaml:"Status" json:"status"`
-	Topics          []string     `yaml:"Topics" json:"topics"`
-	DocType         string       `yaml:"DocType" json:"docType"`
-	Intent          string       `yaml:"Intent" json:"intent"`
-	Owners          []string     `yaml:"Owners" json:"owners"`
-	RelatedFiles    RelatedFiles `yaml:"RelatedFiles" json:"relatedFiles"`
-	ExternalSources []string     `yaml:"ExternalSources" json:"externalSources"`
-	Summary         string       `yaml:"Summary" json:"summary"`
-	LastUpdated     time.Time    `yaml:"LastUpdated" json:"lastUpdated"`
-	WhatFor         string       `yaml:"WhatFor" json:"whatFor"`
-	WhenToUse       string       `yaml:"WhenToUse" json:"whenToUse"`
+	Title           string          `yaml:"Title" json:"title"`
+	Ticket          string          `yaml:"Ticket" json:"ticket"`
+	Status          string          `yaml:"Status" json:"status"`
+	Topics          []string        `yaml:"Topics" json:"topics"`
+	DocType         string          `yaml:"DocType" json:"docType"`
+	Intent          string          `yaml:"Intent" json:"intent"`
+	Owners          []string        `yaml:"Owners" json:"owners"`
+	RelatedFiles    RelatedFiles    `yaml:"RelatedFiles" json:"relatedFiles"`
+	ExternalSources ExternalSources `yaml:"ExternalSources" json:"externalSources"`
+	Summary         string          `yaml:"Summary" json:"summary"`
+	LastUpdated     time.Time       `yaml:"LastUpdated" json:"lastUpdated"`
+	WhatFor         string          `yaml:"WhatFor" json:"whatFor"`
+	WhenToUse       string          `yaml:"WhenToUse" json:"whenToUse"`
+
+	// Ticket-to-ticket relations, set on a ticket's index.md (see TicketLinkKind).
+	DependsOn  []string `yaml:"DependsOn,omitempty" json:"dependsOn,omitempty"`
+	Blocks     []string `yaml:"Blocks,omitempty" json:"blocks,omitempty"`
+	Supersedes []string `yaml:"Supersedes,omitempty" json:"supersedes,omitempty"`
+	RelatesTo  []string `yaml:"RelatesTo,omitempty" json:"relatesTo,omitempty"`
+
+	// Extra holds frontmatter keys that are not built-in fields (custom fields
+	// declared in the workspace schema, or anything else a team added). They are
+	// preserved when the document is rewritten; see FieldSchema.
+	Extra map[string]any `yaml:",inline" json:"extra,omitempty"`
+
+	// Positions locates each frontmatter field in the source file. Only set
+	// when the document was parsed from a file (not for index-backed results).
+	Positions FrontmatterPositions `yaml:"-" json:"-"`
 }
 
 // Validate checks that the document has all required fields populated.
@@ -172,14 +187,153 @@ type VocabItem struct {
 	Description string `yaml:"description" json:"description"`
 }
 
-// ExternalSource represents metadata about an imported source
+// ExternalSource represents metadata about an imported source.
+//
+// Type is the kind of source: "local" for a plain file copy, "html" or "pdf"
+// for imports whose text was extracted to markdown, "url" for other fetched
+// content, or whatever a legacy string entry was prefixed with. Path is where
+// the source came from (a local path), URL its origin on the web, LocalCopy the
+// ticket-relative path of the copy under sources/, and SHA the sha256 of the
+// source bytes when they were last fetched.
 type ExternalSource struct {
-	Type        string    `yaml:"type" json:"type"`
-	Path        string    `yaml:"path" json:"path"`
-	Repo        string    `yaml:"repo,omitempty" json:"repo,omitempty"`
-	LastFetched time.Time `yaml:"lastFetched" json:"lastFetched"`
-	SHA         string    `yaml:"sha,omitempty" json:"sha,omitempty"`
-	URL         string    `yaml:"url,omitempty" json:"url,omitempty"`
+	Type        string    `yaml:"Type,omitempty" json:"type"`
+	Path        string    `yaml:"Path,omitempty" json:"path"`
+	Repo        string    `yaml:"Repo,omitempty" json:"repo,omitempty"`
+	URL         string    `yaml:"URL,omitempty" json:"url,omitempty"`
+	LocalCopy   string    `yaml:"LocalCopy,omitempty" json:"localCopy,omitempty"`
+	SHA         string    `yaml:"SHA,omitempty" json:"sha,omitempty"`
+	LastFetched time.Time `yaml:"LastFetched,omitempty" json:"lastFetched,omitempty"`
+}
+
+// ParseExternalSource converts a legacy string entry into an ExternalSource:
+// "https://host/page" → {Type: url, URL: ...}, "local:spec.pdf" →
+// {Type: local, Path: spec.pdf}, anything else → {Path: s}.
+func ParseExternalSource(s string) ExternalSource {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "://") {
+		return ExternalSource{Type: "url", URL: s}
+	}
+	if typ, rest, ok := strings.Cut(s, ":"); ok && isSourceTypeWord(typ) && rest != "" {
+		return ExternalSource{Type: typ, Path: rest}
+	}
+	return ExternalSource{Path: s}
+}
+
+func isSourceTypeWord(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+		case i > 0 && (r == '-' || (r >= '0' && r <= '9')):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the source the way legacy string entries looked: the URL when
+// there is one, otherwise "type:path" (or just the path).
+func (es ExternalSource) String() string {
+	if es.URL != "" {
+		return es.URL
+	}
+	if es.Type != "" && es.Path != "" {
+		return es.Type + ":" + es.Path
+	}
+	if es.Path != "" {
+		return es.Path
+	}
+	return es.LocalCopy
+}
+
+// UnmarshalYAML accepts a legacy scalar string (see ParseExternalSource) or a
+// mapping with case-insensitive keys (Type/type, Path/path, LastFetched/lastFetched, ...).
+func (es *ExternalSource) UnmarshalYAML(value *yaml.Node) error {
+	*es = ExternalSource{}
+	if value == nil {
+		return nil
+	}
+	switch value.Kind {
+	case yaml.ScalarNode:
+		*es = ParseExternalSource(value.Value)
+		return nil
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			k := strings.ToLower(strings.TrimSpace(value.Content[i].Value))
+			v := value.Content[i+1]
+			switch k {
+			case "type":
+				es.Type = strings.TrimSpace(v.Value)
+			case "path":
+				es.Path = strings.TrimSpace(v.Value)
+			case "repo":
+				es.Repo = strings.TrimSpace(v.Value)
+			case "url":
+				es.URL = strings.TrimSpace(v.Value)
+			case "localcopy":
+				es.LocalCopy = strings.TrimSpace(v.Value)
+			case "sha":
+				es.SHA = strings.TrimSpace(v.Value)
+			case "lastfetched":
+				var t time.Time
+				if err := v.Decode(&t); err == nil {
+					es.LastFetched = t
+				}
+			}
+		}
+		return nil
+	case yaml.DocumentNode, yaml.SequenceNode, yaml.AliasNode:
+		// unsupported for this type; treat as empty
+		return nil
+	default:
+		return nil
+	}
+}
+
+// ExternalSources is a list of ExternalSource entries that decodes from either
+// legacy scalar strings or structured mappings:
+//
+//	# Legacy format (still supported):
+//	ExternalSources:
+//	  - local:spec.pdf
+//	  - https://example.com/rfc
+//
+//	# Current format:
+//	ExternalSources:
+//	  - Type: pdf
+//	    Path: /home/me/Downloads/spec.pdf
+//	    LocalCopy: sources/local/spec.pdf
+//	    SHA: 9f86d08...
+//	    LastFetched: 2026-01-15T10:00:00Z
+//
+// Like RelatedFiles, it always marshals in the structured format.
+type ExternalSources []ExternalSource
+
+func (ess *ExternalSources) UnmarshalYAML(value *yaml.Node) error {
+	if value == nil || value.Kind != yaml.SequenceNode {
+		// Treat non-sequence as empty
+		*ess = nil
+		return nil
+	}
+	out := make([]ExternalSource, 0, len(value.Content))
+	for _, n := range value.Content {
+		var es ExternalSource
+		if err := es.UnmarshalYAML(n); err != nil {
+			continue
+		}
+		if es != (ExternalSource{}) {
+			out = append(out, es)
+		}
+	}
+	*ess = out
+	return nil
+}
+
+func (ess ExternalSources) MarshalYAML() (interface{}, error) {
+	return []ExternalSource(ess), nil
 }
 
 // TicketWorkspace represents a ticket's documentation workspace.
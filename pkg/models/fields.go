@@ -0,0 +1,301 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType is the value type of a custom frontmatter field.
+type FieldType string
+
+const (
+	FieldTypeString FieldType = "string"
+	FieldTypeList   FieldType = "list"
+	FieldTypeInt    FieldType = "int"
+	FieldTypeBool   FieldType = "bool"
+	FieldTypeDate   FieldType = "date"
+	FieldTypeEnum   FieldType = "enum"
+)
+
+// FieldSchema declares custom frontmatter fields on top of the built-in
+// Document fields.
+//
+// Undeclared extra keys are still preserved when documents are rewritten and
+// still indexed; the schema adds typing (for `docmgr meta update`), allowed
+// values and required-ness (checked by `docmgr doctor`).
+//
+// Example schema file (referenced as `schema:` in .ttmp.yaml):
+//
+//	fields:
+//	  - name: Reviewers
+//	    type: list
+//	    description: People who must sign off
+//	  - name: Priority
+//	    type: enum
+//	    values: [P0, P1, P2, P3]
+//	  - name: Epic
+//	    type: string
+//	    required: true
+//	    docTypes: [index]
+//	  - name: DueDate
+//	    type: date
+type FieldSchema struct {
+	Fields []FieldDef `yaml:"fields" json:"fields"`
+}
+
+// FieldDef declares one custom frontmatter field.
+type FieldDef struct {
+	Name        string    `yaml:"name" json:"name"`
+	Type        FieldType `yaml:"type" json:"type"`
+	Description string    `yaml:"description,omitempty" json:"description,omitempty"`
+	// Values restricts enum values (and list elements, when set on a list).
+	Values []string `yaml:"values,omitempty" json:"values,omitempty"`
+	// Required makes doctor report documents missing the field.
+	Required bool `yaml:"required,omitempty" json:"required,omitempty"`
+	// DocTypes limits Required to these doc types (all doc types when empty).
+	DocTypes []string `yaml:"docTypes,omitempty" json:"docTypes,omitempty"`
+}
+
+// builtinFieldNames are the Document fields a schema cannot redeclare.
+var builtinFieldNames = []string{
+	"Title", "Ticket", "Status", "Topics", "DocType", "Intent", "Owners",
+	"RelatedFiles", "ExternalSources", "Summary", "LastUpdated", "WhatFor", "WhenToUse",
+	"DependsOn", "Blocks", "Supersedes", "RelatesTo",
+}
+
+// IsBuiltinField reports whether name (case-insensitive) is a built-in Document field.
+func IsBuiltinField(name string) bool {
+	for _, b := range builtinFieldNames {
+		if strings.EqualFold(b, strings.TrimSpace(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the schema itself: names are set, unique and not built-in,
+// types are known and enums declare their values.
+func (s *FieldSchema) Validate() error {
+	if s == nil {
+		return nil
+	}
+	seen := map[string]struct{}{}
+	for i, f := range s.Fields {
+		name := strings.TrimSpace(f.Name)
+		if name == "" {
+			return fmt.Errorf("fields[%d]: missing name", i)
+		}
+		if IsBuiltinField(name) {
+			return fmt.Errorf("field %q: built-in fields cannot be redeclared", name)
+		}
+		key := strings.ToLower(name)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("field %q: declared more than once", name)
+		}
+		seen[key] = struct{}{}
+		switch f.Type {
+		case FieldTypeString, FieldTypeList, FieldTypeInt, FieldTypeBool, FieldTypeDate:
+		case FieldTypeEnum:
+			if len(f.Values) == 0 {
+				return fmt.Errorf("field %q: enum fields need values", name)
+			}
+		case "":
+			return fmt.Errorf("field %q: missing type", name)
+		default:
+			return fmt.Errorf("field %q: unknown type %q (valid: string, list, int, bool, date, enum)", name, f.Type)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the declaration for name (case-insensitive).
+func (s *FieldSchema) Lookup(name string) (FieldDef, bool) {
+	if s == nil {
+		return FieldDef{}, false
+	}
+	for _, f := range s.Fields {
+		if strings.EqualFold(f.Name, strings.TrimSpace(name)) {
+			return f, true
+		}
+	}
+	return FieldDef{}, false
+}
+
+// AppliesTo reports whether a Required field is required for docType.
+func (f FieldDef) AppliesTo(docType string) bool {
+	if len(f.DocTypes) == 0 {
+		return true
+	}
+	for _, dt := range f.DocTypes {
+		if strings.EqualFold(dt, docType) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseValue converts a command-line value into the typed frontmatter value for
+// this field. Lists are comma-separated, like Topics in `docmgr meta update`.
+func (f FieldDef) ParseValue(raw string) (any, error) {
+	raw = strings.TrimSpace(raw)
+	if f.Type == FieldTypeList {
+		items := []string{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		for _, item := range items {
+			if err := f.checkScalar(item); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	if err := f.checkScalar(raw); err != nil {
+		return nil, err
+	}
+	switch f.Type {
+	case FieldTypeInt:
+		n, _ := strconv.Atoi(raw)
+		return n, nil
+	case FieldTypeBool:
+		b, _ := strconv.ParseBool(raw)
+		return b, nil
+	case FieldTypeString, FieldTypeList, FieldTypeDate, FieldTypeEnum:
+		return raw, nil
+	default:
+		return raw, nil
+	}
+}
+
+// CheckValue validates a frontmatter value (as decoded from YAML or hydrated
+// from the index) against the declaration.
+func (f FieldDef) CheckValue(v any) error {
+	// A scalar is accepted for list fields as a one-element list.
+	values, isList := FieldValueStrings(v)
+	if isList && f.Type != FieldTypeList {
+		return fmt.Errorf("%s must be a single %s value, not a list", f.Name, f.Type)
+	}
+	for _, s := range values {
+		if err := f.checkScalar(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f FieldDef) checkScalar(s string) error {
+	switch f.Type {
+	case FieldTypeInt:
+		if _, err := strconv.Atoi(s); err != nil {
+			return fmt.Errorf("%s must be an integer, got %q", f.Name, s)
+		}
+	case FieldTypeBool:
+		if _, err := strconv.ParseBool(s); err != nil {
+			return fmt.Errorf("%s must be true or false, got %q", f.Name, s)
+		}
+	case FieldTypeDate:
+		if _, err := ParseFieldDate(s); err != nil {
+			return fmt.Errorf("%s must be a date (YYYY-MM-DD or RFC3339), got %q", f.Name, s)
+		}
+	case FieldTypeString, FieldTypeList, FieldTypeEnum:
+	}
+	if len(f.Values) > 0 && (f.Type == FieldTypeEnum || f.Type == FieldTypeList) {
+		for _, allowed := range f.Values {
+			if allowed == s {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s value %q is not one of: %s", f.Name, s, strings.Join(f.Values, ", "))
+	}
+	return nil
+}
+
+// ParseFieldDate accepts YYYY-MM-DD or RFC3339 dates.
+func ParseFieldDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// FieldValueStrings renders a custom field value as strings: one per list
+// element, or a single string for scalars. Nested mappings are JSON-encoded.
+// Empty values (nil, "") yield no strings.
+func FieldValueStrings(v any) ([]string, bool) {
+	switch x := v.(type) {
+	case nil:
+		return nil, false
+	case []string:
+		return x, true
+	case []any:
+		out := make([]string, 0, len(x))
+		for _, item := range x {
+			if s := fieldScalarString(item); s != "" {
+				out = append(out, s)
+			}
+		}
+		return out, true
+	default:
+		if s := fieldScalarString(x); s != "" {
+			return []string{s}, false
+		}
+		return nil, false
+	}
+}
+
+func fieldScalarString(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(x)
+	case time.Time:
+		return FormatFieldDate(x)
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case map[string]any:
+		b, err := json.Marshal(x)
+		if err != nil {
+			return fmt.Sprint(x)
+		}
+		return string(b)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+// FormatFieldDate renders a date-only timestamp as YYYY-MM-DD and anything
+// else as RFC3339, matching how the value was most likely written.
+func FormatFieldDate(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
+
+// ExtraFieldNames returns the document's custom field names, sorted.
+func (d *Document) ExtraFieldNames() []string {
+	names := make([]string, 0, len(d.Extra))
+	for k := range d.Extra {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExtraField returns a custom field value by name (case-insensitive).
+func (d *Document) ExtraField(name string) (string, any, bool) {
+	for k, v := range d.Extra {
+		if strings.EqualFold(k, name) {
+			return k, v, true
+		}
+	}
+	return "", nil, false
+}
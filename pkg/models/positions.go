@@ -0,0 +1,62 @@
+package models
+
+import "strings"
+
+// Position is a 1-based line and column in a document file. The zero value
+// means the position is unknown.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// IsZero reports whether the position is unknown.
+func (p Position) IsZero() bool {
+	return p.Line == 0
+}
+
+// FieldPosition locates one frontmatter field.
+type FieldPosition struct {
+	Key   Position `json:"key"`
+	Value Position `json:"value"`
+	// Items locates each element of a list value, in order (for RelatedFiles
+	// and ExternalSources entries, the first key of the entry).
+	Items []Position `json:"items,omitempty"`
+}
+
+// FrontmatterPositions maps frontmatter keys, as written in the file, to
+// their positions. It is filled by the frontmatter parser.
+type FrontmatterPositions map[string]FieldPosition
+
+// Field returns the positions of a field; the name matches case-insensitively
+// when there is no exact match.
+func (p FrontmatterPositions) Field(name string) (FieldPosition, bool) {
+	if fp, ok := p[name]; ok {
+		return fp, true
+	}
+	for k, fp := range p {
+		if strings.EqualFold(k, name) {
+			return fp, true
+		}
+	}
+	return FieldPosition{}, false
+}
+
+// Value returns the position of a field's value, or the zero Position when
+// the field is absent.
+func (p FrontmatterPositions) Value(name string) Position {
+	fp, _ := p.Field(name)
+	return fp.Value
+}
+
+// Item returns the position of list element i of a field, falling back to the
+// field's value when the element is unknown.
+func (p FrontmatterPositions) Item(name string, i int) Position {
+	fp, ok := p.Field(name)
+	if !ok {
+		return Position{}
+	}
+	if i >= 0 && i < len(fp.Items) {
+		return fp.Items[i]
+	}
+	return fp.Value
+}
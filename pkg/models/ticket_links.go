@@ -0,0 +1,145 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TicketLinkKind names a ticket-to-ticket relation stored in a ticket's
+// index.md frontmatter.
+//
+//	---
+//	Ticket: MEN-4300
+//	DependsOn: [MEN-4242]
+//	Supersedes: [MEN-3900]
+//	---
+type TicketLinkKind string
+
+const (
+	// TicketLinkDependsOn: this ticket cannot finish before the target.
+	TicketLinkDependsOn TicketLinkKind = "DependsOn"
+	// TicketLinkBlocks: the target cannot finish before this ticket (inverse of DependsOn).
+	TicketLinkBlocks TicketLinkKind = "Blocks"
+	// TicketLinkSupersedes: this ticket replaces the target.
+	TicketLinkSupersedes TicketLinkKind = "Supersedes"
+	// TicketLinkRelatesTo: informational link without ordering.
+	TicketLinkRelatesTo TicketLinkKind = "RelatesTo"
+)
+
+// TicketLinkKinds lists all relation kinds in frontmatter order.
+var TicketLinkKinds = []TicketLinkKind{
+	TicketLinkDependsOn,
+	TicketLinkBlocks,
+	TicketLinkSupersedes,
+	TicketLinkRelatesTo,
+}
+
+// ParseTicketLinkKind accepts a kind as written in frontmatter ("DependsOn")
+// or as a flag name ("depends-on"), case-insensitively.
+func ParseTicketLinkKind(s string) (TicketLinkKind, error) {
+	key := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), "-", ""))
+	for _, k := range TicketLinkKinds {
+		if strings.ToLower(string(k)) == key {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown ticket link kind %q (expected depends-on, blocks, supersedes or relates-to)", s)
+}
+
+// FlagName returns the kebab-case form used by CLI flags ("depends-on").
+func (k TicketLinkKind) FlagName() string {
+	switch k {
+	case TicketLinkDependsOn:
+		return "depends-on"
+	case TicketLinkBlocks:
+		return "blocks"
+	case TicketLinkSupersedes:
+		return "supersedes"
+	case TicketLinkRelatesTo:
+		return "relates-to"
+	}
+	return strings.ToLower(string(k))
+}
+
+// TicketLink is one relation from a document's ticket to Target.
+type TicketLink struct {
+	Kind   TicketLinkKind `json:"kind"`
+	Target string         `json:"target"`
+}
+
+// TicketLinks returns the targets of kind, as stored in frontmatter.
+func (d *Document) TicketLinks(kind TicketLinkKind) []string {
+	if p := d.ticketLinksField(kind); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// AllTicketLinks returns every relation on the document, in TicketLinkKinds order.
+func (d *Document) AllTicketLinks() []TicketLink {
+	var out []TicketLink
+	for _, k := range TicketLinkKinds {
+		for _, target := range d.TicketLinks(k) {
+			target = strings.TrimSpace(target)
+			if target == "" {
+				continue
+			}
+			out = append(out, TicketLink{Kind: k, Target: target})
+		}
+	}
+	return out
+}
+
+// AddTicketLink appends target to kind unless already present (case-insensitive).
+// It reports whether the document changed.
+func (d *Document) AddTicketLink(kind TicketLinkKind, target string) bool {
+	p := d.ticketLinksField(kind)
+	target = strings.TrimSpace(target)
+	if p == nil || target == "" {
+		return false
+	}
+	for _, existing := range *p {
+		if strings.EqualFold(strings.TrimSpace(existing), target) {
+			return false
+		}
+	}
+	*p = append(*p, target)
+	return true
+}
+
+// RemoveTicketLink removes target from kind (case-insensitive). It reports
+// whether the document changed.
+func (d *Document) RemoveTicketLink(kind TicketLinkKind, target string) bool {
+	p := d.ticketLinksField(kind)
+	if p == nil {
+		return false
+	}
+	kept := (*p)[:0]
+	removed := false
+	for _, existing := range *p {
+		if strings.EqualFold(strings.TrimSpace(existing), strings.TrimSpace(target)) {
+			removed = true
+			continue
+		}
+		kept = append(kept, existing)
+	}
+	if len(kept) == 0 {
+		kept = nil
+	}
+	*p = kept
+	return removed
+}
+
+func (d *Document) ticketLinksField(kind TicketLinkKind) *[]string {
+	switch kind {
+	case TicketLinkDependsOn:
+		return &d.DependsOn
+	case TicketLinkBlocks:
+		return &d.Blocks
+	case TicketLinkSupersedes:
+		return &d.Supersedes
+	case TicketLinkRelatesTo:
+		return &d.RelatesTo
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ import { BrowserRouter, Navigate, Route, Routes } from 'react-router-dom'
 
 import { store } from './app/store'
 import { ToastHost } from './components/ToastHost'
+import { WorkspaceEvents } from './components/WorkspaceEvents'
 import { DocViewerPage } from './features/doc/DocViewerPage'
 import { FileViewerPage } from './features/file/FileViewerPage'
 import { SearchPage } from './features/search/SearchPage'
@@ -22,6 +23,7 @@ function App() {
     <Provider store={store}>
       <BrowserRouter>
         <ToastHost />
+        <WorkspaceEvents />
         <Routes>
           <Route path="/" element={<Navigate to="/workspace" replace />} />
           <Route path="/search" element={<SearchPage />} />
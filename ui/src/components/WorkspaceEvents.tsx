@@ -0,0 +1,56 @@
+import { useEffect } from 'react'
+
+import { useAppDispatch } from '../app/hooks'
+import { docmgrApi } from '../services/docmgrApi'
+import type { WorkspaceEvent } from '../services/docmgrApi'
+
+const eventTypes: WorkspaceEvent['type'][] = [
+  'doc.updated',
+  'doc.removed',
+  'ticket.created',
+  'task.checked',
+  'tasks.updated',
+  'changelog.appended',
+  'index.refreshed',
+]
+
+// WorkspaceEvents subscribes to /api/v1/events and invalidates the cached
+// queries an event affects, so open tabs follow edits without polling.
+export function WorkspaceEvents() {
+  const dispatch = useAppDispatch()
+
+  useEffect(() => {
+    if (typeof EventSource === 'undefined') return
+    const source = new EventSource('/api/v1/events')
+
+    const onEvent = (msg: MessageEvent<string>) => {
+      let ev: WorkspaceEvent
+      try {
+        ev = JSON.parse(msg.data) as WorkspaceEvent
+      } catch {
+        return
+      }
+      if (ev.type === 'index.refreshed') {
+        dispatch(docmgrApi.util.invalidateTags(['Workspace', 'Search', 'Ticket', 'Doc', 'Doctor']))
+        return
+      }
+      dispatch(
+        docmgrApi.util.invalidateTags([
+          'Workspace',
+          'Search',
+          'Doctor',
+          ev.ticket ? { type: 'Ticket', id: ev.ticket } : 'Ticket',
+          ...(ev.path ? [{ type: 'Doc' as const, id: ev.path }] : []),
+        ]),
+      )
+    }
+
+    for (const t of eventTypes) source.addEventListener(t, onEvent)
+    return () => {
+      for (const t of eventTypes) source.removeEventListener(t, onEvent)
+      source.close()
+    }
+  }, [dispatch])
+
+  return null
+}
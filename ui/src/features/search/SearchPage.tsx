@@ -272,6 +272,7 @@ export function SearchPage() {
       status: filters.status,
       file: filters.file,
       dir: filters.dir,
+      q: filters.where,
       orderBy: effectiveOrderBySafe,
       reverse: mode === 'reverse',
       includeArchived: filters.includeArchived,
@@ -376,6 +377,13 @@ export function SearchPage() {
         onRemove: () => dispatch(setFilter({ key: 'dir', value: '' })),
       })
     }
+    if (mode !== 'files' && filters.where.trim() !== '') {
+      chips.push({
+        key: 'where',
+        label: `where:${filters.where.trim()}`,
+        onRemove: () => dispatch(setFilter({ key: 'where', value: '' })),
+      })
+    }
     return chips
   }, [dispatch, filters, mode, query])
 
@@ -401,7 +409,8 @@ export function SearchPage() {
       filters.docType.trim() !== '' ||
       filters.status.trim() !== '' ||
       filters.file.trim() !== '' ||
-      filters.dir.trim() !== ''
+      filters.dir.trim() !== '' ||
+      filters.where.trim() !== ''
 
     if (!hasIntent) return
 
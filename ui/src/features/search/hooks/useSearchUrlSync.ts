@@ -63,6 +63,7 @@ export function useSearchUrlSync({
     dispatch(setFilter({ key: 'status', value: (params.get('status') || '').trim() }))
     dispatch(setFilter({ key: 'file', value: (params.get('file') || '').trim() }))
     dispatch(setFilter({ key: 'dir', value: (params.get('dir') || '').trim() }))
+    dispatch(setFilter({ key: 'where', value: (params.get('where') || '').trim() }))
     dispatch(setFilter({ key: 'orderBy', value: (params.get('orderBy') || '').trim() || 'rank' }))
     dispatch(
       setFilter({
@@ -105,6 +106,7 @@ export function useSearchUrlSync({
       if (filters.status.trim() !== '') params.set('status', filters.status.trim())
       if (filters.file.trim() !== '') params.set('file', filters.file.trim())
       if (filters.dir.trim() !== '') params.set('dir', filters.dir.trim())
+      if (filters.where.trim() !== '') params.set('where', filters.where.trim())
       if (filters.orderBy.trim() !== '' && filters.orderBy.trim() !== 'rank') params.set('orderBy', filters.orderBy.trim())
       if (filters.includeArchived !== true) params.set('includeArchived', String(filters.includeArchived))
       if (filters.includeScripts !== true) params.set('includeScripts', String(filters.includeScripts))
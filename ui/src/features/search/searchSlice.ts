@@ -10,6 +10,7 @@ export type SearchFilters = {
   status: string
   file: string
   dir: string
+  where: string
   orderBy: string
   includeArchived: boolean
   includeScripts: boolean
@@ -32,6 +33,7 @@ const initialState: SearchState = {
     status: '',
     file: '',
     dir: '',
+    where: '',
     orderBy: 'rank',
     includeArchived: true,
     includeScripts: true,
@@ -66,6 +68,7 @@ const searchSlice = createSlice({
       state.filters.status = ''
       state.filters.file = ''
       state.filters.dir = ''
+      state.filters.where = ''
       state.filters.orderBy = 'rank'
       state.filters.includeArchived = true
       state.filters.includeScripts = true
@@ -100,6 +100,15 @@ export function SearchFiltersFields({
           <option value="last_updated">Last updated</option>
         </select>
       </div>
+      <div className="col-12">
+        <label className="form-label small mb-1">Where</label>
+        <input
+          className="form-control form-control-sm font-monospace"
+          placeholder="e.g. status:active AND NOT owner:bob AND updated>7d"
+          value={filters.where}
+          onChange={(e) => onFilterChange('where', e.target.value)}
+        />
+      </div>
     </>
   )
 }
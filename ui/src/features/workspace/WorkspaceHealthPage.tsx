@@ -1,19 +1,19 @@
-import { useMemo } from 'react'
+import { useMemo, useState } from 'react'
 import { Link, useSearchParams } from 'react-router-dom'
 
 import { ApiErrorAlert } from '../../components/ApiErrorAlert'
 import { EmptyState } from '../../components/EmptyState'
 import { LoadingSpinner } from '../../components/LoadingSpinner'
 import { DiagnosticList } from '../search/components/DiagnosticList'
-import type { DiagnosticTaxonomy, DoctorFinding } from '../../services/docmgrApi'
-import { useGetWorkspaceDoctorQuery } from '../../services/docmgrApi'
+import type { DiagnosticTaxonomy, DoctorFinding, DoctorFixResponse } from '../../services/docmgrApi'
+import { useFixWorkspaceDoctorMutation, useGetWorkspaceDoctorQuery } from '../../services/docmgrApi'
 
 function findingToTaxonomy(f: DoctorFinding): DiagnosticTaxonomy {
   return {
     Tool: 'doctor',
     Stage: f.issue,
     Symptom: f.message,
-    Path: f.path,
+    Path: f.line ? `${f.path}:${f.line}:${f.column ?? 0}` : f.path,
     Severity: f.severity,
     Context: f.ticket ? { Ticket: f.ticket } : undefined,
   }
@@ -30,6 +30,80 @@ function statusBadgeVariant(status: string): string {
   }
 }
 
+// DoctorFixesCard previews the machine-applicable doctor fixes as unified
+// diffs and applies them (POST /workspace/doctor/fix).
+function DoctorFixesCard({ ticket }: { ticket: string }) {
+  const [fixDoctor, { error, isLoading }] = useFixWorkspaceDoctorMutation()
+  const [result, setResult] = useState<DoctorFixResponse | null>(null)
+
+  async function run(dryRun: boolean) {
+    try {
+      setResult(await fixDoctor({ ticket: ticket || undefined, dryRun }).unwrap())
+    } catch {
+      // surfaced via error
+    }
+  }
+
+  return (
+    <div className="card">
+      <div className="card-header fw-semibold d-flex justify-content-between align-items-center">
+        <span>
+          Fixes
+          {result && !result.dryRun ? <span className="text-muted small ms-2">applied</span> : null}
+        </span>
+        <span className="d-flex gap-2">
+          <button className="btn btn-sm btn-outline-secondary" disabled={isLoading} onClick={() => void run(true)}>
+            Preview fixes
+          </button>
+          <button
+            className="btn btn-sm btn-primary"
+            disabled={isLoading || !result || !result.dryRun || result.files.length === 0}
+            onClick={() => void run(false)}
+          >
+            Apply fixes
+          </button>
+        </span>
+      </div>
+      <div className="card-body vstack gap-3">
+        {error ? <ApiErrorAlert title="Fix run failed" error={error} /> : null}
+        {isLoading ? <LoadingSpinner /> : null}
+        {!result ? (
+          <div className="text-muted small">Preview the changes doctor can make automatically before applying them.</div>
+        ) : result.files.length === 0 ? (
+          <EmptyState title="Nothing to fix">No fixer applies to the current findings.</EmptyState>
+        ) : (
+          result.files.map((f) => (
+            <div key={f.path}>
+              <div className="d-flex flex-wrap gap-2 align-items-center mb-1">
+                <span className="font-monospace small">{f.path}</span>
+                {f.fixers.map((name) => (
+                  <span key={name} className="badge text-bg-light text-dark">
+                    {name}
+                  </span>
+                ))}
+                {f.backup ? <span className="text-muted small">(.bak kept)</span> : null}
+              </div>
+              <pre className="small bg-light border rounded p-2 mb-0">{f.diff}</pre>
+            </div>
+          ))
+        )}
+        {result && result.skipped.length > 0 ? (
+          <div>
+            <div className="fw-semibold small mb-1">Needs manual attention</div>
+            <ul className="small mb-0">
+              {result.skipped.map((sk, i) => (
+                <li key={`${sk.path}-${sk.fixer}-${i}`}>
+                  <span className="font-monospace">{sk.path}</span> ({sk.fixer}): {sk.reason}
+                </li>
+              ))}
+            </ul>
+          </div>
+        ) : null}
+      </div>
+    </div>
+  )
+}
+
 export function WorkspaceHealthPage() {
   const [searchParams, setSearchParams] = useSearchParams()
   const ticket = (searchParams.get('ticket') ?? '').trim()
@@ -148,6 +222,8 @@ export function WorkspaceHealthPage() {
           </div>
         </div>
       ) : null}
+
+      {data ? <DoctorFixesCard key={ticket} ticket={ticket} /> : null}
     </div>
   )
 }
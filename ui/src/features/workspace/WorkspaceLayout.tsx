@@ -3,7 +3,13 @@ import { NavLink, Outlet } from 'react-router-dom'
 import { PageHeader } from '../../components/PageHeader'
 import { useToast } from '../toast/useToast'
 import { timeAgo } from '../../lib/time'
-import { useGetWorkspaceStatusQuery, useGetWorkspaceSummaryQuery, useRefreshIndexMutation } from '../../services/docmgrApi'
+import {
+  useGetViewsQuery,
+  useGetWorkspaceStatusQuery,
+  useGetWorkspaceSummaryQuery,
+  useRefreshIndexMutation,
+  type SavedView,
+} from '../../services/docmgrApi'
 
 function ShellNav() {
   const navLink = (to: string, label: string) => (
@@ -30,6 +36,50 @@ function ShellNav() {
   )
 }
 
+function savedViewSearchURL(v: SavedView): string {
+  const params = new URLSearchParams()
+  const set = (key: string, value: string | undefined) => {
+    if ((value ?? '').trim() !== '') params.set(key, (value ?? '').trim())
+  }
+  if ((v.file ?? '').trim() !== '' || (v.dir ?? '').trim() !== '') params.set('mode', 'reverse')
+  set('q', v.query)
+  set('ticket', v.ticket)
+  set('topics', (v.topics ?? []).join(','))
+  set('docType', v.docType)
+  set('status', v.status)
+  set('file', v.file)
+  set('dir', v.dir)
+  set('where', v.where)
+  set('orderBy', v.orderBy)
+  const qs = params.toString()
+  return qs === '' ? '/search' : `/search?${qs}`
+}
+
+function SavedViewsCard({ skip }: { skip: boolean }) {
+  const { data } = useGetViewsQuery(undefined, { skip })
+  const views = data?.views ?? []
+  if (views.length === 0) return null
+
+  return (
+    <div className="card mt-3">
+      <div className="card-header fw-semibold">Saved views</div>
+      <div className="list-group list-group-flush">
+        {views.map((v) => (
+          <NavLink
+            key={v.name}
+            className="list-group-item list-group-item-action"
+            to={savedViewSearchURL(v)}
+            title={v.where ?? ''}
+          >
+            <div className="fw-semibold">{v.name}</div>
+            {v.description ? <div className="small text-muted">{v.description}</div> : null}
+          </NavLink>
+        ))}
+      </div>
+    </div>
+  )
+}
+
 export function WorkspaceLayout() {
   const toast = useToast()
   const { data: wsStatus, isError: wsError, refetch } = useGetWorkspaceStatusQuery()
@@ -74,6 +124,7 @@ export function WorkspaceLayout() {
       <div className="row g-3">
         <div className="col-12 col-lg-3">
           <ShellNav />
+          <SavedViewsCard skip={wsError} />
           <div className="card mt-3">
             <div className="card-header fw-semibold">Quick stats</div>
             <div className="card-body">
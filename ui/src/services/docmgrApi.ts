@@ -132,6 +132,25 @@ export type WorkspaceTopicsResponse = {
   results: WorkspaceTopicListItem[]
 }
 
+export type SavedView = {
+  name: string
+  description?: string
+  query?: string
+  where?: string
+  ticket?: string
+  topics?: string[]
+  docType?: string
+  status?: string
+  file?: string
+  dir?: string
+  orderBy?: string
+}
+
+export type ViewsResponse = {
+  path: string
+  views: SavedView[]
+}
+
 export type WorkspaceTopicDetailResponse = {
   topic: string
   stats: WorkspaceSummaryStats
@@ -172,6 +191,7 @@ export type SearchDocsQueryEcho = {
   status: string
   file: string
   dir: string
+  q: string
   externalSource: string
   since: string
   until: string
@@ -209,6 +229,8 @@ export type SearchDocsArgs = {
   status?: string
   file?: string
   dir?: string
+  // q is a query expression, e.g. `status:active AND topic:api AND NOT owner:bob`.
+  q?: string
   orderBy?: string
   reverse?: boolean
   includeArchived?: boolean
@@ -237,6 +259,17 @@ export type SearchFilesArgs = {
   limit?: number
 }
 
+export type ExternalSource = {
+  type: string
+  path: string
+  repo?: string
+  url?: string
+  // Ticket-relative copy under sources/ and the sha256 of its bytes at import time.
+  localCopy?: string
+  sha?: string
+  lastFetched?: string
+}
+
 export type DocumentMeta = {
   title: string
   ticket: string
@@ -246,7 +279,7 @@ export type DocumentMeta = {
   intent: string
   owners: string[]
   relatedFiles: RelatedFile[]
-  externalSources: string[]
+  externalSources: ExternalSource[]
   summary: string
   lastUpdated: string
   whatFor: string
@@ -316,9 +349,19 @@ export type TicketDocsResponse = {
   nextCursor: string
 }
 
-export type TicketTasksItem = {
+// Inline task attributes (`@alice due:2026-11-01 !high #api` at the end of a
+// task line); omitted when unset.
+export type TaskAttrs = {
+  assignees?: string[]
+  due?: string
+  priority?: 'high' | 'medium' | 'low'
+  tags?: string[]
+}
+
+export type TicketTasksItem = TaskAttrs & {
   id: number
   checked: boolean
+  // Task text without the stable-ID marker and inline attributes.
   text: string
   // Stable short ID stored as an invisible marker in tasks.md; present for
   // tasks created via `docmgr task add` or migrated via `docmgr task migrate`.
@@ -338,6 +381,54 @@ export type TicketTasksResponse = {
   sections: TicketTasksSection[]
 }
 
+// One task from GET /api/v1/tasks (tasks of all tickets, from the index).
+export type TaskBoardItem = TaskAttrs & {
+  ticket: string
+  ticketTitle: string
+  ticketStatus: string
+  // Stable ID when present, else the 1-based position; pass to checkTicketTasks.
+  ref: string
+  id: number
+  stableId?: string
+  section: string
+  checked: boolean
+  text: string
+  line: number
+  path: string
+}
+
+export type TaskBoardArgs = {
+  ticket?: string
+  checked?: boolean
+  status?: string
+  topics?: string[]
+  owners?: string[]
+  section?: string
+  q?: string
+  assignee?: string
+  priority?: string
+  includeArchived?: boolean
+}
+
+export type TaskBoardResponse = {
+  query: {
+    ticket: string
+    checked: string
+    status: string
+    topics: string[] | null
+    owners: string[] | null
+    section: string
+    q: string
+    assignee: string
+    priority: string
+    includeArchived: boolean
+  }
+  stats: { total: number; done: number }
+  // Section headings in order of first appearance (board columns).
+  sections: string[]
+  results: TaskBoardItem[]
+}
+
 export type TicketGraphResponse = {
   ticket: string
   direction: 'TD' | 'LR'
@@ -345,6 +436,35 @@ export type TicketGraphResponse = {
   stats: { nodes: number; edges: number }
 }
 
+export type WorkspaceGraphNode = {
+  id: string
+  kind: 'ticket' | 'doc' | 'file'
+  label: string
+  ticket?: string
+  status?: string
+  docType?: string
+  path?: string
+  missing?: boolean
+}
+
+export type WorkspaceGraphEdge = {
+  from: string
+  to: string
+  kind: string
+  label?: string
+  weight?: number
+}
+
+export type WorkspaceGraphResponse = {
+  direction: 'TD' | 'LR'
+  format: 'mermaid' | 'dot' | 'json'
+  mermaid?: string
+  dot?: string
+  nodes: WorkspaceGraphNode[]
+  edges: WorkspaceGraphEdge[]
+  stats: { nodes: number; edges: number }
+}
+
 export type DocMetaUpdateResponse = {
   path: string
   field: string
@@ -361,11 +481,16 @@ export type DocRelateResponse = {
   status: string
 }
 
+export type ChangelogKind = 'added' | 'changed' | 'fixed' | 'removed' | 'decision'
+
 export type ChangelogEntry = {
   date: string
+  kind?: ChangelogKind
   title: string
   heading: string
   body: string
+  tasks?: { id: string; text?: string }[]
+  files?: { path: string; note?: string }[]
 }
 
 export type TicketChangelogResponse = {
@@ -381,6 +506,9 @@ export type DoctorFinding = {
   severity: string
   message: string
   path: string
+  // Frontmatter position of the finding, when known (1-based).
+  line?: number
+  column?: number
 }
 
 export type DoctorRollupItem = {
@@ -404,10 +532,53 @@ export type WorkspaceDoctorResponse = {
   findings: DoctorFinding[]
 }
 
+export type DoctorFixFile = {
+  ticket: string
+  path: string
+  fixers: string[]
+  changes: Array<{ fixer: string; summary: string }>
+  diff: string
+  backup: boolean
+}
+
+export type DoctorFixSkip = {
+  ticket: string
+  path: string
+  fixer: string
+  reason: string
+}
+
+export type DoctorFixResponse = {
+  ticket: string
+  dryRun: boolean
+  fixers: string[]
+  files: DoctorFixFile[]
+  skipped: DoctorFixSkip[]
+}
+
+export type WorkspaceEventType =
+  | 'doc.updated'
+  | 'doc.removed'
+  | 'ticket.created'
+  | 'task.checked'
+  | 'tasks.updated'
+  | 'changelog.appended'
+  | 'index.refreshed'
+
+// Pushed by GET /api/v1/events (Server-Sent Events); see WorkspaceEvents.
+export type WorkspaceEvent = {
+  id: number
+  type: WorkspaceEventType
+  ticket?: string
+  path?: string
+  at: string
+  data?: Record<string, unknown>
+}
+
 export const docmgrApi = createApi({
   reducerPath: 'docmgrApi',
   baseQuery: fetchBaseQuery({ baseUrl: '/api/v1' }),
-  tagTypes: ['Workspace', 'Search', 'Ticket', 'Doc', 'Doctor'],
+  tagTypes: ['Workspace', 'Search', 'Ticket', 'Doc', 'Doctor', 'Views'],
   endpoints: (builder) => ({
     getWorkspaceStatus: builder.query<WorkspaceStatus, void>({
       query: () => '/workspace/status',
@@ -415,7 +586,7 @@ export const docmgrApi = createApi({
     }),
     refreshIndex: builder.mutation<RefreshIndexResponse, void>({
       query: () => ({ url: '/index/refresh', method: 'POST' }),
-      invalidatesTags: ['Workspace', 'Search'],
+      invalidatesTags: ['Workspace', 'Search', 'Views'],
     }),
     getWorkspaceSummary: builder.query<WorkspaceSummaryResponse, void>({
       query: () => '/workspace/summary',
@@ -512,6 +683,10 @@ export const docmgrApi = createApi({
       }),
       providesTags: ['Workspace'],
     }),
+    getViews: builder.query<ViewsResponse, void>({
+      query: () => '/views',
+      providesTags: ['Views'],
+    }),
     searchDocs: builder.query<SearchDocsResponse, SearchDocsArgs>({
       query: (args) => ({
         url: '/search/docs',
@@ -523,6 +698,7 @@ export const docmgrApi = createApi({
           status: args.status ?? '',
           file: args.file ?? '',
           dir: args.dir ?? '',
+          q: args.q ?? '',
           orderBy: args.orderBy ?? '',
           reverse: args.reverse ?? false,
           includeArchived: args.includeArchived ?? true,
@@ -692,19 +868,38 @@ export const docmgrApi = createApi({
       invalidatesTags: (_r, _e, args) => [{ type: 'Ticket', id: args.ticket }, 'Workspace'],
     }),
 
+    getTaskBoard: builder.query<TaskBoardResponse, TaskBoardArgs>({
+      query: (args) => ({
+        url: '/tasks',
+        params: {
+          ticket: args.ticket ?? '',
+          checked: args.checked == null ? '' : String(args.checked),
+          status: args.status ?? '',
+          topics: (args.topics ?? []).join(','),
+          owners: (args.owners ?? []).join(','),
+          section: args.section ?? '',
+          q: args.q ?? '',
+          assignee: args.assignee ?? '',
+          priority: args.priority ?? '',
+          includeArchived: args.includeArchived ?? false,
+        },
+      }),
+      providesTags: ['Workspace'],
+    }),
+
     getTicketChangelog: builder.query<TicketChangelogResponse, { ticket: string }>({
       query: (args) => ({ url: '/tickets/changelog', params: { ticket: args.ticket } }),
       providesTags: (_r, _e, args) => [{ type: 'Ticket', id: args.ticket }],
     }),
 
     appendTicketChangelog: builder.mutation<
-      { ok: boolean; ticket: string; path: string; date: string },
-      { ticket: string; title?: string; entry: string }
+      { ok: boolean; ticket: string; path: string; date: string; kind: string },
+      { ticket: string; title?: string; entry: string; kind?: ChangelogKind }
     >({
       query: (args) => ({
         url: '/tickets/changelog',
         method: 'POST',
-        body: { ticket: args.ticket, title: args.title ?? '', entry: args.entry },
+        body: { ticket: args.ticket, title: args.title ?? '', entry: args.entry, kind: args.kind ?? '' },
       }),
       invalidatesTags: (_r, _e, args) => [{ type: 'Ticket', id: args.ticket }, 'Workspace'],
     }),
@@ -720,6 +915,15 @@ export const docmgrApi = createApi({
       providesTags: ['Doctor'],
     }),
 
+    fixWorkspaceDoctor: builder.mutation<DoctorFixResponse, { ticket?: string; only?: string[]; dryRun?: boolean }>({
+      query: (args) => ({
+        url: '/workspace/doctor/fix',
+        method: 'POST',
+        body: { ticket: args.ticket ?? '', only: args.only ?? [], dryRun: args.dryRun ?? false },
+      }),
+      invalidatesTags: (_r, _e, args) => (args.dryRun ? [] : ['Doctor', 'Workspace', 'Search']),
+    }),
+
     getTicketGraph: builder.query<
       TicketGraphResponse,
       {
@@ -742,6 +946,30 @@ export const docmgrApi = createApi({
       }),
       providesTags: (_r, _e, args) => [{ type: 'Ticket', id: args.ticket }],
     }),
+
+    getWorkspaceGraph: builder.query<
+      WorkspaceGraphResponse,
+      {
+        topics?: string[]
+        status?: string[]
+        includeDocs?: boolean
+        format?: 'mermaid' | 'dot' | 'json'
+        direction?: 'TD' | 'LR'
+      }
+    >({
+      query: (args) => ({
+        url: '/tickets/graph',
+        params: {
+          all: true,
+          topics: (args.topics ?? []).join(','),
+          status: (args.status ?? []).join(','),
+          includeDocs: args.includeDocs ?? false,
+          format: args.format ?? 'mermaid',
+          direction: args.direction ?? 'TD',
+        },
+      }),
+      providesTags: ['Workspace'],
+    }),
   }),
 })
 
@@ -753,6 +981,7 @@ export const {
   useGetWorkspaceRecentQuery,
   useGetWorkspaceTopicsQuery,
   useGetWorkspaceTopicQuery,
+  useGetViewsQuery,
   useRefreshIndexMutation,
   useLazySearchDocsQuery,
   useLazySearchFilesQuery,
@@ -765,8 +994,11 @@ export const {
   useGetTicketTasksQuery,
   useCheckTicketTasksMutation,
   useAddTicketTaskMutation,
+  useGetTaskBoardQuery,
   useGetTicketChangelogQuery,
   useAppendTicketChangelogMutation,
   useGetWorkspaceDoctorQuery,
+  useFixWorkspaceDoctorMutation,
   useGetTicketGraphQuery,
+  useGetWorkspaceGraphQuery,
 } = docmgrApi